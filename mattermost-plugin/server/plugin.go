@@ -34,6 +34,7 @@ const (
 	sharedBoardsName          = "enablepublicsharedboards"
 	notifyFreqCardSecondsKey  = "notify_freq_card_seconds"
 	notifyFreqBoardSecondsKey = "notify_freq_board_seconds"
+	insertBlocksBatchSizeKey  = "insert_blocks_batch_size"
 )
 
 type BoardsEmbed struct {
@@ -226,6 +227,7 @@ func (p *Plugin) createBoardsConfig(mmconfig mmModel.Config, baseURL string, ser
 		FeatureFlags:             featureFlags,
 		NotifyFreqCardSeconds:    getPluginSettingInt(mmconfig, notifyFreqCardSecondsKey, 120),
 		NotifyFreqBoardSeconds:   getPluginSettingInt(mmconfig, notifyFreqBoardSecondsKey, 86400),
+		InsertBlocksBatchSize:    getPluginSettingInt(mmconfig, insertBlocksBatchSizeKey, 1000),
 	}
 }
 
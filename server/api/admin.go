@@ -2,12 +2,14 @@ package api
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/store"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -16,40 +18,123 @@ type AdminSetPasswordData struct {
 	Password string `json:"password"`
 }
 
+type AdminReadOnlyData struct {
+	ReadOnly bool `json:"readOnly"`
+}
+
+type AdminImpersonateData struct {
+	Reason string `json:"reason"`
+	// ReadOnly defaults to true
+	ReadOnly *bool `json:"readOnly"`
+}
+
+// impersonationAdminID identifies the admin in impersonation sessions. Admin
+// APIs are only served on the local socket, which doesn't authenticate a user.
+const impersonationAdminID = "local-admin"
+
 func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	username := vars["username"]
 
-	requestBody, err := ioutil.ReadAll(r.Body)
+	var requestData AdminSetPasswordData
+	if !a.decodeJSONBody(w, r, &requestData) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetPassword", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("username", username)
+
+	if !strings.Contains(requestData.Password, "") {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "password is required", nil)
+		return
+	}
+
+	err := a.app.UpdateUserPassword(username, requestData.Password)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	var requestData AdminSetPasswordData
-	err = json.Unmarshal(requestBody, &requestData)
+	a.logger.Debug("AdminSetPassword, username: %s", mlog.String("username", username))
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAdminSetReadOnly(w http.ResponseWriter, r *http.Request) {
+	var requestData AdminReadOnlyData
+	if !a.decodeJSONBody(w, r, &requestData) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminSetReadOnly", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("readOnly", requestData.ReadOnly)
+
+	a.app.SetReadOnly(requestData.ReadOnly)
+
+	a.logger.Info("AdminSetReadOnly", mlog.Bool("readOnly", requestData.ReadOnly))
+
+	data, err := json.Marshal(requestData)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "adminSetPassword", audit.Fail)
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userID"]
+
+	var requestData AdminImpersonateData
+	if !a.decodeJSONBody(w, r, &requestData) {
+		return
+	}
+	readOnly := requestData.ReadOnly == nil || *requestData.ReadOnly
+
+	auditRec := a.makeAuditRecord(r, "adminImpersonate", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
-	auditRec.AddMeta("username", username)
+	auditRec.AddMeta("userID", userID)
+	auditRec.AddMeta(audit.KeyImpersonatedBy, impersonationAdminID)
+	auditRec.AddMeta("reason", requestData.Reason)
+	auditRec.AddMeta("readOnly", readOnly)
 
-	if !strings.Contains(requestData.Password, "") {
-		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "password is required", err)
+	if strings.TrimSpace(requestData.Reason) == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "reason is required", nil)
 		return
 	}
 
-	err = a.app.UpdateUserPassword(username, requestData.Password)
+	session, err := a.app.ImpersonateUser(userID, impersonationAdminID, requestData.Reason, readOnly)
+	if errors.Is(err, app.ErrImpersonationDisabled) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "user not found", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("AdminSetPassword, username: %s", mlog.String("username", username))
+	a.logger.Info("AdminImpersonate",
+		mlog.String("userID", userID),
+		mlog.String("sessionID", session.ID),
+		mlog.Bool("readOnly", readOnly),
+	)
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	data, err := json.Marshal(session)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("sessionID", session.ID)
 	auditRec.Success()
 }
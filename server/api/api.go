@@ -2,10 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"runtime/debug"
 	"strconv"
@@ -17,6 +19,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -27,11 +30,19 @@ const (
 	HeaderRequestedWithXML = "XMLHttpRequest"
 	SingleUser             = "single-user"
 	UploadFormFileKey      = "file"
+	HeaderCardCountWarning = "X-Card-Count-Warning"
+
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
 )
 
 const (
 	ErrorNoWorkspaceCode    = 1000
 	ErrorNoWorkspaceMessage = "No workspace"
+	ErrorReadOnlyCode       = 1001
+	ErrorReadOnlyMessage    = "The server is in read-only mode for maintenance"
+	ErrorInvalidJSONMessage = "invalid JSON"
 )
 
 type PermissionError struct {
@@ -52,6 +63,7 @@ type API struct {
 	MattermostAuth  bool
 	logger          *mlog.Logger
 	audit           *audit.Audit
+	rateLimiter     *rateLimiter
 }
 
 func NewAPI(app *app.App, singleUserToken string, authService string, logger *mlog.Logger, audit *audit.Audit) *API {
@@ -61,13 +73,26 @@ func NewAPI(app *app.App, singleUserToken string, authService string, logger *ml
 		authService:     authService,
 		logger:          logger,
 		audit:           audit,
+		rateLimiter:     newRateLimiter(),
+	}
+}
+
+// singleUserID returns the ID of the user of the single user mode, which is
+// the configured one if set, so that its changes are attributed to it.
+func (a *API) singleUserID() string {
+	if id := a.app.GetConfig().SingleUserID; id != "" {
+		return id
 	}
+	return SingleUser
 }
 
 func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv1 := r.PathPrefix("/api/v1").Subrouter()
 	apiv1.Use(a.panicHandler)
+	apiv1.Use(a.rateLimit)
 	apiv1.Use(a.requireCSRFToken)
+	apiv1.Use(a.rejectWritesWhenReadOnly)
+	apiv1.Use(a.limitRequestBody)
 
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks", a.sessionRequired(a.handleGetBlocks)).Methods("GET")
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks", a.sessionRequired(a.handlePostBlocks)).Methods("POST")
@@ -76,11 +101,42 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks/{blockID}/subtree", a.attachSession(a.handleGetSubTree, false)).Methods("GET")
 
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/sharing", a.sessionRequired(a.handleGetBoardsSharingStatus)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/export", a.sessionRequired(a.handleExportBoards)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/stats", a.attachSession(a.handleGetBoardStats, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/properties/{propertyID}/options/{optionID}/usage", a.attachSession(a.handleGetPropertyOptionUsage, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/export.pdf", a.attachSession(a.handleExportBoardPDF, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/export.csv", a.attachSession(a.handleExportBoardCSV, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/activity", a.sessionRequired(a.handleGetBoardActivity)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/activity.csv", a.sessionRequired(a.handleExportBoardActivity)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/presence", a.sessionRequired(a.handleGetBoardPresence)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/notifications/settings", a.sessionRequired(a.handleGetNotificationSettings)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/notifications/settings", a.sessionRequired(a.handleUpdateNotificationSettings)).Methods("PUT")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/blocks/{blockID}/mute", a.sessionRequired(a.handleMuteCard)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/blocks/{blockID}/unmute", a.sessionRequired(a.handleUnmuteCard)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/acknowledge", a.sessionRequired(a.handleAcknowledgeBoard)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/blocks/clear", a.sessionRequired(a.handleClearBoard)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/acknowledgments", a.sessionRequired(a.handleGetBoardAcknowledgments)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/move", a.sessionRequired(a.handleMoveCards)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/views/{viewID}/order", a.sessionRequired(a.handleSetViewCardOrder)).Methods("PUT")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/attachments", a.sessionRequired(a.handleAttachFile)).Methods("POST").Name(routeAttachFile)
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/attachments", a.attachSession(a.handleGetAttachments, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments", a.attachSession(a.handleGetCommentThreads, false)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/replies", a.sessionRequired(a.handleReplyToComment)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/resolve", a.sessionRequired(a.handleResolveCommentThread)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/unresolve", a.sessionRequired(a.handleUnresolveCommentThread)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/apikeys", a.sessionRequired(a.handleCreateBoardAPIKey)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/apikeys", a.sessionRequired(a.handleGetBoardAPIKeys)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/boards/{boardID}/apikeys/{keyID}", a.sessionRequired(a.handleDeleteBoardAPIKey)).Methods("DELETE")
+
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks/export", a.sessionRequired(a.handleExport)).Methods("GET")
 	apiv1.HandleFunc("/workspaces/{workspaceID}/blocks/import", a.sessionRequired(a.handleImport)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/import/markdown", a.sessionRequired(a.handleImportMarkdown)).Methods("POST")
 
 	apiv1.HandleFunc("/workspaces/{workspaceID}/sharing/{rootID}", a.sessionRequired(a.handlePostSharing)).Methods("POST")
 	apiv1.HandleFunc("/workspaces/{workspaceID}/sharing/{rootID}", a.sessionRequired(a.handleGetSharing)).Methods("GET")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/sharing/{rootID}", a.sessionRequired(a.handlePatchSharing)).Methods("PATCH")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/sharing/{rootID}/regenerate", a.sessionRequired(a.handleRegenerateSharingToken)).Methods("POST")
 
 	apiv1.HandleFunc("/workspaces/{workspaceID}", a.sessionRequired(a.handleGetWorkspace)).Methods("GET")
 	apiv1.HandleFunc("/workspaces/{workspaceID}/regenerate_signup_token", a.sessionRequired(a.handlePostWorkspaceRegenerateSignupToken)).Methods("POST")
@@ -88,21 +144,24 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 	// User APIs
 	apiv1.HandleFunc("/users/me", a.sessionRequired(a.handleGetMe)).Methods("GET")
+	apiv1.HandleFunc("/users/me/assigned", a.sessionRequired(a.handleGetAssignedCards)).Methods("GET")
 	apiv1.HandleFunc("/users/{userID}", a.sessionRequired(a.handleGetUser)).Methods("GET")
 	apiv1.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.handleChangePassword)).Methods("POST")
 
-	apiv1.HandleFunc("/login", a.handleLogin).Methods("POST")
-	apiv1.HandleFunc("/logout", a.sessionRequired(a.handleLogout)).Methods("POST")
+	apiv1.HandleFunc("/login", a.handleLogin).Methods("POST").Name(routeLogin)
+	apiv1.HandleFunc("/logout", a.sessionRequired(a.handleLogout)).Methods("POST").Name(routeLogout)
 	apiv1.HandleFunc("/register", a.handleRegister).Methods("POST")
 	apiv1.HandleFunc("/clientConfig", a.getClientConfig).Methods("GET")
+	apiv1.HandleFunc("/server/info", a.attachSession(a.handleGetServerInfo, false)).Methods("GET")
 
-	apiv1.HandleFunc("/workspaces/{workspaceID}/{rootID}/files", a.sessionRequired(a.handleUploadFile)).Methods("POST")
+	apiv1.HandleFunc("/workspaces/{workspaceID}/{rootID}/files", a.sessionRequired(a.handleUploadFile)).Methods("POST").Name(routeUploadFile)
 
 	apiv1.HandleFunc("/workspaces", a.sessionRequired(a.handleGetUserWorkspaces)).Methods("GET")
 
 	// Get Files API
 
 	files := r.PathPrefix("/files").Subrouter()
+	files.Use(a.readTokenRateLimit)
 	files.HandleFunc("/workspaces/{workspaceID}/{rootID}/{filename}", a.attachSession(a.handleServeFile, false)).Methods("GET")
 
 	// Subscriptions
@@ -113,6 +172,8 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
 	r.HandleFunc("/api/v1/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v1/admin/readonly", a.adminRequired(a.handleAdminSetReadOnly)).Methods("POST")
+	r.HandleFunc("/api/v1/admin/users/{userID}/impersonate", a.adminRequired(a.handleAdminImpersonate)).Methods("POST")
 }
 
 func (a *API) panicHandler(next http.Handler) http.Handler {
@@ -144,6 +205,119 @@ func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 	})
 }
 
+// rejectWritesWhenReadOnly fails the requests that can modify data while the
+// server is in read-only mode. Users can still log in and out.
+func (a *API) rejectWritesWhenReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.app.IsReadOnly() && !isReadOnlyAllowed(r) {
+			a.errorResponseWithCode(w, r.URL.Path, http.StatusServiceUnavailable, ErrorReadOnlyCode, ErrorReadOnlyMessage, nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Names of the routes the middlewares treat differently. The names don't
+// depend on the route prefix the API is mounted under.
+const (
+	routeLogin      = "login"
+	routeLogout     = "logout"
+	routeUploadFile = "uploadFile"
+	routeAttachFile = "attachFile"
+)
+
+// uploadRoutes are the routes that receive files, their bodies are limited by
+// MaxFileSize instead of MaxRequestBodySize.
+var uploadRoutes = map[string]bool{
+	routeUploadFile: true,
+	routeAttachFile: true,
+}
+
+// multipartOverhead is the room left for the multipart framing and other form
+// fields of an upload, on top of the file itself.
+const multipartOverhead = 1024 * 1024
+
+// limitRequestBody fails the requests that announce a body larger than the
+// configured limit, and caps the reads of the others.
+func (a *API) limitRequestBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := a.requestBodyLimit(r)
+		if limit > 0 {
+			if r.ContentLength > limit {
+				a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "request body too large", nil)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestBodyLimit returns the maximum body size for the request's route, or
+// 0 if it isn't limited.
+func (a *API) requestBodyLimit(r *http.Request) int64 {
+	config := a.app.GetConfig()
+	if uploadRoutes[routeName(r)] {
+		if config.MaxFileSize <= 0 {
+			return 0
+		}
+		return config.MaxFileSize + multipartOverhead
+	}
+	return config.MaxRequestBodySize
+}
+
+// isErrRequestBodyTooLarge returns true if err was returned by a body capped
+// by limitRequestBody after reading past its limit.
+func isErrRequestBodyTooLarge(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "http: request body too large")
+}
+
+// readBodyErrorResponse writes the error response for a failure to read the
+// request body.
+func (a *API) readBodyErrorResponse(w http.ResponseWriter, api string, err error) {
+	if isErrRequestBodyTooLarge(err) {
+		a.errorResponse(w, api, http.StatusRequestEntityTooLarge, "request body too large", err)
+		return
+	}
+	a.errorResponse(w, api, http.StatusInternalServerError, "", err)
+}
+
+// decodeJSONBody reads the request body into v. If the body can't be read or
+// isn't valid JSON, it writes the error response and returns false.
+func (a *API) decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.readBodyErrorResponse(w, r.URL.Path, err)
+		return false
+	}
+
+	if err = json.Unmarshal(requestBody, v); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, ErrorInvalidJSONMessage, err)
+		return false
+	}
+	return true
+}
+
+func isReadOnlyAllowed(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	name := routeName(r)
+	return name == routeLogin || name == routeLogout
+}
+
+// routeName returns the name of the route matched by the request, or an empty
+// string if it doesn't have one.
+func routeName(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		return route.GetName()
+	}
+	return ""
+}
+
 func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	clientConfig := a.app.GetClientConfig()
 
@@ -155,6 +329,38 @@ func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	jsonBytesResponse(w, http.StatusOK, configData)
 }
 
+func (a *API) handleGetServerInfo(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/server/info getServerInfo
+	//
+	// Returns the version of the server, and the optional features enabled on it to authenticated users
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ServerInfo"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	session, _ := r.Context().Value(sessionContextKey).(*model.Session)
+	// in single user mode, the requests without the token get a session too
+	authenticated := session != nil && (len(a.singleUserToken) == 0 || session.Token == a.singleUserToken)
+
+	info := a.app.GetServerInfo(authenticated)
+
+	data, err := json.Marshal(info)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
 func (a *API) checkCSRFToken(r *http.Request) bool {
 	token := r.Header.Get(HeaderRequestedWith)
 	return token == HeaderRequestedWithXML
@@ -177,6 +383,21 @@ func (a *API) hasValidReadTokenForBlock(r *http.Request, container store.Contain
 	return isValid
 }
 
+func (a *API) hasBoardAPIKeyForBlock(r *http.Request, container store.Container, blockID string) bool {
+	key, ok := r.Context().Value(boardAPIKeyContextKey).(*model.BoardAPIKey)
+	if !ok {
+		return false
+	}
+
+	allowed, err := a.app.BoardAPIKeyAllowsBlock(container, key, blockID)
+	if err != nil {
+		a.logger.Error("BoardAPIKeyAllowsBlock ERROR", mlog.Err(err))
+		return false
+	}
+
+	return allowed
+}
+
 func (a *API) getContainerAllowingReadTokenForBlock(r *http.Request, blockID string) (*store.Container, error) {
 	ctx := r.Context()
 	session, _ := ctx.Value(sessionContextKey).(*model.Session)
@@ -206,6 +427,11 @@ func (a *API) getContainerAllowingReadTokenForBlock(r *http.Request, blockID str
 			return &container, nil
 		}
 
+		// No session, but has a board API key for the block
+		if len(blockID) > 0 && a.hasBoardAPIKeyForBlock(r, container, blockID) {
+			return &container, nil
+		}
+
 		return nil, PermissionError{"access denied to workspace"}
 	}
 
@@ -224,6 +450,11 @@ func (a *API) getContainerAllowingReadTokenForBlock(r *http.Request, blockID str
 		return &container, nil
 	}
 
+	// No session, but has a board API key for the block
+	if len(blockID) > 0 && a.hasBoardAPIKeyForBlock(r, container, blockID) {
+		return &container, nil
+	}
+
 	return nil, PermissionError{"access denied to workspace"}
 }
 
@@ -252,18 +483,42 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   type: string
 	// - name: type
 	//   in: query
-	//   description: Type of blocks to return, omit to specify all types
+	//   description: Comma separated list of the types of blocks to return, omit to specify all types
+	//   required: false
+	//   type: string
+	// - name: expand
+	//   in: query
+	//   description: Comma separated list of expansions. "relations" resolves the cards referenced by relation properties, "actors" returns the blocks along with the users that created or modified them, which are only resolved for a session
+	//   required: false
+	//   type: string
+	// - name: sort_by
+	//   in: query
+	//   description: ID of a property of the board in parent_id to sort the cards by, or __title for their title. The cards without a value come last, followed by the other blocks
 	//   required: false
 	//   type: string
+	// - name: sort_order
+	//   in: query
+	//   description: Order of the sort, asc or desc. Defaults to asc
+	//   required: false
+	//   type: string
+	// - name: include_archived
+	//   in: query
+	//   description: Set to true to include the archived boards when listing the boards of the workspace
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, a BlocksWithActors object if the actors are expanded
 	//     schema:
 	//       type: array
 	//       items:
 	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid sort, or sort without parent_id
+	//   '404':
+	//     description: board to sort by not found
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -272,20 +527,33 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	parentID := query.Get("parent_id")
 	blockType := query.Get("type")
+	blockTypes := splitListOption(blockType)
 	all := query.Get("all")
 	blockID := query.Get("block_id")
+	sortBy := query.Get("sort_by")
+	sortOrder := query.Get("sort_order")
 	container, err := a.getContainerAllowingReadTokenForBlock(r, blockID)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
+	if sortOrder != "" && sortOrder != "asc" && sortOrder != "desc" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid sort_order", nil)
+		return
+	}
+	if sortBy != "" && (parentID == "" || all != "" || blockID != "") {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "sort_by requires parent_id", nil)
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "getBlocks", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("parentID", parentID)
 	auditRec.AddMeta("blockType", blockType)
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("sortBy", sortBy)
 
 	var blocks []model.Block
 	var block *model.Block
@@ -306,13 +574,51 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 			blocks = append(blocks, *block)
 		}
 	default:
-		blocks, err = a.app.GetBlocks(*container, parentID, blockType)
+		blocks, err = a.app.GetBlocks(*container, parentID, blockTypes)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		if parentID == "" && len(blockTypes) > 0 && query.Get("include_archived") != "true" {
+			blocks = withoutArchivedBoards(blocks)
+		}
+	}
+
+	blocks, err = a.filterRestrictedBlocks(r, *container, blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if sortBy != "" {
+		err = a.app.SortCardsByProperty(*container, parentID, blocks, sortBy, sortOrder == "desc")
+		if store.IsErrNotFound(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+			return
+		}
+		if errors.Is(err, model.ErrInvalidProperty) || errors.Is(err, model.ErrInvalidPropSchema) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
 	}
 
+	userID, restricted := a.restrictionUserID(r)
+	if err = a.app.ComputeFormulas(*container, blocks, userID, restricted); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if hasExpandOption(query, "relations") {
+		if err = a.app.ExpandRelations(*container, blocks, "", userID, restricted); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetBlocks",
 		mlog.String("parentID", parentID),
 		mlog.String("blockType", blockType),
@@ -320,7 +626,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		mlog.Int("block_count", len(blocks)),
 	)
 
-	json, err := json.Marshal(blocks)
+	json, err := a.marshalBlocks(r, blocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -332,6 +638,100 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+// withoutArchivedBoards returns the blocks that are not archived boards.
+func withoutArchivedBoards(blocks []model.Block) []model.Block {
+	result := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if !block.IsArchived() {
+			result = append(result, block)
+		}
+	}
+	return result
+}
+
+// splitListOption returns the non-empty values of a comma separated query
+// parameter.
+func splitListOption(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// hasExpandOption returns true if the comma separated expand query parameter
+// includes option.
+func hasExpandOption(query url.Values, option string) bool {
+	for _, value := range strings.Split(query.Get("expand"), ",") {
+		if strings.TrimSpace(value) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalBlocks encodes the blocks of a response, along with the users that
+// created or modified them if the actors are expanded. The users are only
+// resolved for a session, read tokens and board API keys get the user IDs
+// of the blocks only.
+func (a *API) marshalBlocks(r *http.Request, blocks []model.Block) ([]byte, error) {
+	if blocks == nil {
+		blocks = []model.Block{}
+	}
+	if !hasExpandOption(r.URL.Query(), "actors") {
+		return json.Marshal(blocks)
+	}
+
+	actors := []*model.UserSummary{}
+	if _, ok := r.Context().Value(sessionContextKey).(*model.Session); ok {
+		var err error
+		if actors, err = a.app.GetBlockActors(blocks); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(model.BlocksWithActors{Blocks: blocks, Actors: actors})
+}
+
+// restrictionUserID returns the ID of the user the card restrictions are
+// checked for, which is empty for a read token or board API key. The
+// restrictions don't apply in single user mode.
+func (a *API) restrictionUserID(r *http.Request) (string, bool) {
+	session, ok := r.Context().Value(sessionContextKey).(*model.Session)
+	if !ok {
+		return "", true
+	}
+	if len(a.singleUserToken) > 0 {
+		return "", false
+	}
+	return session.UserID, true
+}
+
+// filterRestrictedBlocks removes the restricted cards the caller can't see
+// from blocks, along with the blocks in them.
+func (a *API) filterRestrictedBlocks(r *http.Request, container store.Container, blocks []model.Block) ([]model.Block, error) {
+	userID, restricted := a.restrictionUserID(r)
+	if !restricted || len(blocks) == 0 {
+		return blocks, nil
+	}
+	return a.app.FilterRestrictedBlocks(container, blocks, userID)
+}
+
+// checkBlockVisible returns ErrBlockRestricted if the block is, or is in, a
+// restricted card the caller can't see.
+func (a *API) checkBlockVisible(r *http.Request, container store.Container, blockID string) error {
+	userID, restricted := a.restrictionUserID(r)
+	if !restricted {
+		return nil
+	}
+	return a.app.CheckBlockVisible(container, blockID, userID)
+}
+
+// stampModificationMetadata sets the modifier and the update time of the
+// blocks from the session and the server clock, ignoring the values sent by
+// the client. The creation time is left to the store, which sets it from
+// the server clock for new blocks and keeps it for the existing ones.
 func stampModificationMetadata(r *http.Request, blocks []model.Block, auditRec *audit.Record) {
 	ctx := r.Context()
 	session := ctx.Value(sessionContextKey).(*model.Session)
@@ -384,6 +784,26 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	//       items:
 	//         $ref: '#/definitions/Block'
 	//       type: array
+	//     headers:
+	//       X-Card-Count-Warning:
+	//         type: string
+	//         description: comma separated IDs of the boards past the card count warning threshold
+	//   '400':
+	//     description: rejected by the insert validation webhook, too many blocks in the request, a block or view type not allowed on its board, a default sort on a property the board lacks, a block missing a field required by its type, a block of unknown type, or a card property value longer than its limit
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '403':
+	//     description: the parent of a block is a restricted card, or is in one, the caller can't see
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board of a block not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: board block limit exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -395,17 +815,8 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var blocks []model.Block
-
-	err = json.Unmarshal(requestBody, &blocks)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &blocks) {
 		return
 	}
 
@@ -435,12 +846,47 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "postBlocks", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
+	// blocks can't be added to a restricted card the caller can't see, the
+	// parents posted along with the blocks are new and need no check
+	checked := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		checked[block.ID] = true
+	}
+	for _, block := range blocks {
+		if block.ParentID == "" || checked[block.ParentID] {
+			continue
+		}
+		checked[block.ParentID] = true
+		if err = a.checkBlockVisible(r, *container, block.ParentID); err != nil {
+			a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+			return
+		}
+	}
+
 	stampModificationMetadata(r, blocks, auditRec)
 
 	ctx := r.Context()
 	session := ctx.Value(sessionContextKey).(*model.Session)
 
 	newBlocks, err := a.app.InsertBlocks(*container, blocks, session.UserID, true)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if isBadRequestBlockError(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if app.IsErrBlockLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, err.Error(), err)
+		return
+	}
+	var batchErr app.ErrInsertBatchFailed
+	if errors.As(err, &batchErr) {
+		message := fmt.Sprintf("%d of %d blocks were inserted before the failure", batchErr.Inserted, batchErr.Total)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, message, err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -454,6 +900,16 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the blocks are inserted already, failing to check the card count
+	// doesn't fail the request
+	boardIDs, err := a.app.GetBoardsOverCardCountWarning(*container, newBlocks)
+	if err != nil {
+		a.logger.Warn("POST Blocks card count warning", mlog.Err(err))
+	}
+	if len(boardIDs) > 0 {
+		w.Header().Set(HeaderCardCountWarning, strings.Join(boardIDs, ","))
+	}
+
 	jsonBytesResponse(w, http.StatusOK, json)
 
 	auditRec.AddMeta("blockCount", len(blocks))
@@ -537,15 +993,18 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "getMe", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	if session.UserID == SingleUser {
+	if len(a.singleUserToken) > 0 {
 		now := utils.GetMillis()
 		user = &model.User{
-			ID:       SingleUser,
+			ID:       a.singleUserID(),
 			Username: SingleUser,
 			Email:    SingleUser,
 			CreateAt: now,
 			UpdateAt: now,
 		}
+		if name := a.app.GetConfig().SingleUserName; name != "" {
+			user.Username = name
+		}
 	} else {
 		user, err = a.app.GetUser(session.UserID)
 		if err != nil {
@@ -566,30 +1025,43 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation DELETE /api/v1/workspaces/{workspaceID}/blocks/{blockID} deleteBlock
+func (a *API) handleGetAssignedCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/users/me/assigned getAssignedCards
 	//
-	// Deletes a block
+	// Returns the cards assigned to the currently logged-in user across the boards of their workspaces, due first
 	//
 	// ---
 	// produces:
 	// - application/json
 	// parameters:
-	// - name: workspaceID
-	//   in: path
-	//   description: Workspace ID
-	//   required: true
-	//   type: string
-	// - name: blockID
-	//   in: path
-	//   description: ID of block to delete
-	//   required: true
+	// - name: status
+	//   in: query
+	//   description: ID or value of the option of the first select property of the card's board
+	//   required: false
 	//   type: string
+	// - name: due_after
+	//   in: query
+	//   description: Keep the cards due at or after this time, in milliseconds
+	//   required: false
+	//   type: integer
+	// - name: due_before
+	//   in: query
+	//   description: Keep the cards due at or before this time, in milliseconds
+	//   required: false
+	//   type: integer
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AssignedCard"
+	//   '400':
+	//     description: invalid due date
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -597,29 +1069,131 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	session := ctx.Value(sessionContextKey).(*model.Session)
-	userID := session.UserID
 
-	vars := mux.Vars(r)
-	blockID := vars["blockID"]
+	query := r.URL.Query()
+	opts := model.AssignedCardsOptions{Status: query.Get("status")}
+	var err error
+	if dueAfter := query.Get("due_after"); dueAfter != "" {
+		opts.DueAfter, err = strconv.ParseInt(dueAfter, 10, 64)
+		if err != nil || opts.DueAfter < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid due_after timestamp", err)
+			return
+		}
+	}
+	if dueBefore := query.Get("due_before"); dueBefore != "" {
+		opts.DueBefore, err = strconv.ParseInt(dueBefore, 10, 64)
+		if err != nil || opts.DueBefore < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid due_before timestamp", err)
+			return
+		}
+	}
 
-	container, err := a.getContainer(r)
+	auditRec := a.makeAuditRecord(r, "getAssignedCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+
+	workspaceIDs := []string{"0"}
+	if a.MattermostAuth {
+		userWorkspaces, err := a.app.GetUserWorkspaces(session.UserID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		workspaceIDs = make([]string, 0, len(userWorkspaces))
+		for _, workspace := range userWorkspaces {
+			workspaceIDs = append(workspaceIDs, workspace.ID)
+		}
+	}
+
+	cards, err := a.app.GetAssignedCards(workspaceIDs, session.UserID, opts)
 	if err != nil {
-		a.noContainerErrorResponse(w, r.URL.Path, err)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("blockID", blockID)
+	a.logger.Debug("GetAssignedCards",
+		mlog.String("userID", session.UserID),
+		mlog.Int("card_count", len(cards)),
+	)
 
-	err = a.app.DeleteBlock(*container, blockID, userID)
+	data, err := json.Marshal(cards)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE Block", mlog.String("blockID", blockID))
-	jsonStringResponse(w, http.StatusOK, "{}")
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardCount", len(cards))
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /api/v1/workspaces/{workspaceID}/blocks/{blockID} deleteBlock
+	//
+	// Deletes a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of block to delete
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/DeleteBlockResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	blockID := vars["blockID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("blockID", blockID)
+
+	if err = a.checkBlockVisible(r, *container, blockID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	deletedIDs, err := a.app.DeleteBlock(*container, blockID, userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(DeleteBlockResponse{DeletedBlockIDs: deletedIDs})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("DELETE Block", mlog.String("blockID", blockID))
+	jsonBytesResponse(w, http.StatusOK, data)
 
 	auditRec.Success()
 }
@@ -654,6 +1228,12 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: block or its new board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -672,16 +1252,8 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var patch *model.BlockPatch
-	err = json.Unmarshal(requestBody, &patch)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &patch) {
 		return
 	}
 
@@ -689,14 +1261,33 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("blockID", blockID)
 
-	err = a.app.PatchBlock(*container, blockID, patch, userID)
+	if err = a.checkBlockVisible(r, *container, blockID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	block, err := a.app.PatchBlock(*container, blockID, patch, userID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if isBadRequestBlockError(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(block)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
 	a.logger.Debug("PATCH Block", mlog.String("blockID", blockID))
-	jsonStringResponse(w, http.StatusOK, "{}")
+	jsonBytesResponse(w, http.StatusOK, data)
 
 	auditRec.Success()
 }
@@ -726,6 +1317,10 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
+	//   '404':
+	//     description: block or its new board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -741,16 +1336,8 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var patches *model.BlockPatchBatch
-	err = json.Unmarshal(requestBody, &patches)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &patches) {
 		return
 	}
 
@@ -760,7 +1347,22 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
 	}
 
+	for _, blockID := range patches.BlockIDs {
+		if err = a.checkBlockVisible(r, *container, blockID); err != nil {
+			a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+			return
+		}
+	}
+
 	err = a.app.PatchBlocks(*container, patches, userID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if isBadRequestBlockError(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -798,11 +1400,27 @@ func (a *API) handleGetSubTree(w http.ResponseWriter, r *http.Request) {
 	//   type: integer
 	//   minimum: 2
 	//   maximum: 3
+	// - name: order
+	//   in: query
+	//   description: Sorts the blocks by creation time, "asc" or "desc". Unsorted if omitted.
+	//   required: false
+	//   type: string
+	// - name: limit_per_level
+	//   in: query
+	//   description: Maximum number of children returned for each block. All of them if omitted.
+	//   required: false
+	//   type: integer
+	//   minimum: 1
+	// - name: expand
+	//   in: query
+	//   description: Comma separated list of expansions. "relations" resolves the cards referenced by relation properties, "actors" returns the blocks along with the users that created or modified them, which are only resolved for a session
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, a BlocksWithActors object if the actors are expanded
 	//     schema:
 	//       type: array
 	//       items:
@@ -833,22 +1451,65 @@ func (a *API) handleGetSubTree(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	opts := model.QuerySubtreeOptions{}
+	switch order := query.Get("order"); order {
+	case "":
+	case "asc", "desc":
+		opts.OrderByCreateAt = true
+		opts.Descending = order == "desc"
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid order", nil)
+		return
+	}
+
+	if limit := query.Get("limit_per_level"); limit != "" {
+		opts.LimitPerLevel, err = strconv.ParseUint(limit, 10, 64)
+		if err != nil || opts.LimitPerLevel == 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit_per_level", err)
+			return
+		}
+	}
+
 	auditRec := a.makeAuditRecord(r, "getSubTree", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("blockID", blockID)
 
-	blocks, err := a.app.GetSubTree(*container, blockID, int(levels))
+	blocks, err := a.app.GetSubTree(*container, blockID, int(levels), opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	blocks, err = a.filterRestrictedBlocks(r, *container, blocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
+	userID, restricted := a.restrictionUserID(r)
+	if err = a.app.ComputeFormulas(*container, blocks, userID, restricted); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if hasExpandOption(query, "relations") {
+		// with a read token, only the titles of the shared board are visible
+		restrictRootID := ""
+		if _, ok := r.Context().Value(sessionContextKey).(*model.Session); !ok {
+			restrictRootID = blockID
+		}
+		if err = a.app.ExpandRelations(*container, blocks, restrictRootID, userID, restricted); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
 	a.logger.Debug("GetSubTree",
 		mlog.Int64("levels", levels),
 		mlog.String("blockID", blockID),
 		mlog.Int("block_count", len(blocks)),
 	)
-	json, err := json.Marshal(blocks)
+	json, err := a.marshalBlocks(r, blocks)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -860,10 +1521,10 @@ func (a *API) handleGetSubTree(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
-func (a *API) handleExport(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /api/v1/workspaces/{workspaceID}/blocks/export exportBlocks
+func (a *API) handleGetBoardStats(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/stats getBoardStats
 	//
-	// Returns all blocks
+	// Returns a summary of the cards of a board
 	//
 	// ---
 	// produces:
@@ -874,101 +1535,77 @@ func (a *API) handleExport(w http.ResponseWriter, r *http.Request) {
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: group_property_id
+	//   in: query
+	//   description: ID of the select property to group cards by, omit to use the first select property
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       type: array
-	//       items:
-	//         "$ref": "#/definitions/Block"
+	//       "$ref": "#/definitions/BoardStats"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	query := r.URL.Query()
-	rootID := query.Get("root_id")
-	container, err := a.getContainer(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	groupPropertyID := r.URL.Query().Get("group_property_id")
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "export", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "getBoardStats", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("rootID", rootID)
+	auditRec.AddMeta("boardID", boardID)
 
-	var blocks []model.Block
-	if rootID == "" {
-		blocks, err = a.app.GetAllBlocks(*container)
-	} else {
-		blocks, err = a.app.GetBlocksWithRootID(*container, rootID)
+	stats, err := a.app.GetBoardStats(*container, boardID, groupPropertyID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
 	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("raw blocks", mlog.Int("block_count", len(blocks)))
-	auditRec.AddMeta("rawCount", len(blocks))
-
-	blocks = filterOrphanBlocks(blocks)
-
-	a.logger.Debug("EXPORT filtered blocks", mlog.Int("block_count", len(blocks)))
-	auditRec.AddMeta("filteredCount", len(blocks))
+	a.logger.Debug("GetBoardStats",
+		mlog.String("boardID", boardID),
+		mlog.Int("card_count", stats.CardCount),
+	)
 
-	json, err := json.Marshal(blocks)
+	data, err := json.Marshal(stats)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, json)
+	jsonBytesResponse(w, http.StatusOK, data)
 
+	auditRec.AddMeta("cardCount", stats.CardCount)
 	auditRec.Success()
 }
 
-func filterOrphanBlocks(blocks []model.Block) (ret []model.Block) {
-	queue := make([]model.Block, 0)
-	childrenOfBlockWithID := make(map[string]*[]model.Block)
-
-	// Build the trees from nodes
-	for _, block := range blocks {
-		if len(block.ParentID) == 0 {
-			// Queue root blocks to process first
-			queue = append(queue, block)
-		} else {
-			siblings := childrenOfBlockWithID[block.ParentID]
-			if siblings != nil {
-				*siblings = append(*siblings, block)
-			} else {
-				siblings := []model.Block{block}
-				childrenOfBlockWithID[block.ParentID] = &siblings
-			}
-		}
-	}
-
-	// Map the trees to an array, which skips orphaned nodes
-	blocks = make([]model.Block, 0)
-	for len(queue) > 0 {
-		block := queue[0]
-		queue = queue[1:] // dequeue
-		blocks = append(blocks, block)
-		children := childrenOfBlockWithID[block.ID]
-		if children != nil {
-			queue = append(queue, (*children)...)
-		}
-	}
-
-	return blocks
-}
-
-func (a *API) handleImport(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /api/v1/workspaces/{workspaceID}/blocks/import importBlocks
+func (a *API) handleGetPropertyOptionUsage(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/properties/{propertyID}/options/{optionID}/usage getPropertyOptionUsage
 	//
-	// Import blocks
+	// Returns the number of cards of a board set to an option of a select property
 	//
 	// ---
 	// produces:
@@ -979,72 +1616,2722 @@ func (a *API) handleImport(w http.ResponseWriter, r *http.Request) {
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
-	// - name: Body
-	//   in: body
-	//   description: array of blocks to import
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
 	//   required: true
-	//   schema:
-	//     type: array
-	//     items:
-	//       "$ref": "#/definitions/Block"
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: ID of the select or multi-select property
+	//   required: true
+	//   type: string
+	// - name: optionID
+	//   in: path
+	//   description: ID of the option
+	//   required: true
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/PropertyOptionUsage"
+	//   '404':
+	//     description: board, property or option not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	container, err := a.getContainer(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	optionID := vars["optionID"]
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
+	auditRec := a.makeAuditRecord(r, "getPropertyOptionUsage", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+	auditRec.AddMeta("optionID", optionID)
+
+	usage, err := a.app.GetPropertyOptionUsage(*container, boardID, propertyID, optionID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	var blocks []model.Block
+	a.logger.Debug("GetPropertyOptionUsage",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", propertyID),
+		mlog.String("optionID", optionID),
+		mlog.Int("card_count", usage.CardCount),
+	)
 
-	err = json.Unmarshal(requestBody, &blocks)
+	data, err := json.Marshal(usage)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "import", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	jsonBytesResponse(w, http.StatusOK, data)
 
-	stampModificationMetadata(r, blocks, auditRec)
+	auditRec.AddMeta("cardCount", usage.CardCount)
+	auditRec.Success()
+}
 
-	ctx := r.Context()
+func (a *API) handleExportBoardPDF(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/export.pdf exportBoardPDF
+	//
+	// Exports a view of a board to PDF
+	//
+	// ---
+	// produces:
+	// - application/pdf
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: view_id
+	//   in: query
+	//   description: ID of the view to export, omit to export the first view of the board
+	//   required: false
+	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Read token for shared boards
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board or view not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '501':
+	//     description: PDF export is not configured
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	viewID := r.URL.Query().Get("view_id")
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoardPDF", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	userID, restricted := a.restrictionUserID(r)
+	pdf, err := a.app.ExportBoardViewPDF(*container, boardID, viewID, userID, restricted)
+	if errors.Is(err, app.ErrPDFExportDisabled) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board or view not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pdf\"", boardID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdf)
+
+	auditRec.Success()
+}
+
+func (a *API) handleExportBoardCSV(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/export.csv exportBoardCSV
+	//
+	// Exports the cards of a board as CSV, with their name and the properties
+	// visible in a view, in the order of its columns. The rows are streamed in
+	// the order the cards were created
+	//
+	// ---
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: view_id
+	//   in: query
+	//   description: ID of the view whose columns to export, omit to export all the properties of the board
+	//   required: false
+	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Read token for shared boards
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board or view not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	viewID := r.URL.Query().Get("view_id")
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoardCSV", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.csv\"", boardID))
+
+	// once rows are streamed the response can't be changed, so the errors
+	// after that are only logged
+	userID, restricted := a.restrictionUserID(r)
+	out := &writeTracker{w: w}
+	err = a.app.ExportBoardViewCSV(*container, boardID, viewID, userID, restricted, out)
+	if err != nil && out.written {
+		a.logger.Error("exportBoardCSV: export interrupted", mlog.String("boardID", boardID), mlog.Err(err))
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board or view not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleExportBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/export exportBoards
+	//
+	// Exports the blocks of a selection of boards in a single response,
+	// streamed board by board. The boards that can't be found are skipped
+	// and reported
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the boards to export
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardsExportRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardsExport"
+	//   '400':
+	//     description: no board to export
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var request model.BoardsExportRequest
+	if !a.decodeJSONBody(w, r, &request) {
+		return
+	}
+	if len(request.BoardIDs) == 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "no board to export", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardCount", len(request.BoardIDs))
+
+	w.Header().Set("Content-Type", "application/json")
+
+	// once blocks are streamed the response can't be changed, so the errors
+	// after that are only logged
+	userID, restricted := a.restrictionUserID(r)
+	out := &writeTracker{w: w}
+	err = a.app.ExportBoards(*container, request.BoardIDs, userID, restricted, out)
+	if err != nil && out.written {
+		a.logger.Error("exportBoards: export interrupted", mlog.Err(err))
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleExportBoardActivity(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/activity.csv exportBoardActivity
+	//
+	// Exports the changes made to the blocks of a board as CSV, with the
+	// timestamp, user ID, username, block title, action and changed fields
+	// of each change
+	//
+	// ---
+	// produces:
+	// - text/csv
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Export the changes made at or after this timestamp, in milliseconds
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: Export the changes made at or before this timestamp, in milliseconds
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid time range
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	from, to, ok := a.activityRange(w, r)
+	if !ok {
+		return
+	}
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoardActivity", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("from", from)
+	auditRec.AddMeta("to", to)
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-activity.csv\"", boardID))
+
+	// once rows are streamed the response can't be changed, so the errors
+	// after that are only logged
+	userID, restricted := a.restrictionUserID(r)
+	out := &writeTracker{w: w}
+	err = a.app.ExportBoardActivity(*container, boardID, userID, restricted, from, to, out)
+	if err != nil && out.written {
+		a.logger.Error("exportBoardActivity: export interrupted", mlog.String("boardID", boardID), mlog.Err(err))
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardActivity(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/activity getBoardActivity
+	//
+	// Returns the changes made to the blocks of a board, with the previous
+	// and new values of the changed titles and card properties
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Return the changes made at or after this timestamp, in milliseconds
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: Return the changes made at or before this timestamp, in milliseconds
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/ActivityEntry"
+	//   '400':
+	//     description: invalid time range
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	from, to, ok := a.activityRange(w, r)
+	if !ok {
+		return
+	}
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardActivity", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("from", from)
+	auditRec.AddMeta("to", to)
+
+	userID, restricted := a.restrictionUserID(r)
+	activity, err := a.app.GetBoardActivity(*container, boardID, userID, restricted, from, to)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(activity)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("entryCount", len(activity))
+	auditRec.Success()
+}
+
+// activityRange reads the from and to timestamps of an activity request. It
+// fails the request and returns false if they aren't a valid range.
+func (a *API) activityRange(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	query := r.URL.Query()
+
+	var from, to int64
+	var err error
+	if fromParam := query.Get("from"); fromParam != "" {
+		from, err = strconv.ParseInt(fromParam, 10, 64)
+		if err != nil || from < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid from timestamp", err)
+			return 0, 0, false
+		}
+	}
+	if toParam := query.Get("to"); toParam != "" {
+		to, err = strconv.ParseInt(toParam, 10, 64)
+		if err != nil || to < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid to timestamp", err)
+			return 0, 0, false
+		}
+	}
+	if to > 0 && from > to {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "from is after to", nil)
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// writeTracker is a writer recording whether anything was written to it.
+type writeTracker struct {
+	w       io.Writer
+	written bool
+}
+
+func (t *writeTracker) Write(p []byte) (int, error) {
+	t.written = true
+	return t.w.Write(p)
+}
+
+// Flush sends the buffered data to the client, so streamed exports show
+// progress and aren't timed out.
+func (t *writeTracker) Flush() {
+	if flusher, ok := t.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (a *API) handleGetBoardPresence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/presence getBoardPresence
+	//
+	// Returns the users currently viewing a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardPresence"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardPresence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	presence, err := a.app.GetBoardPresence(*container, boardID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(presence)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("userCount", len(presence.UserIDs))
+	auditRec.Success()
+}
+
+func (a *API) handleGetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/notifications/settings getNotificationSettings
+	//
+	// Returns how the current user is notified of the changes to the cards they follow on a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/NotificationSettings"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getNotificationSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	settings, err := a.app.GetNotificationSettings(*container, boardID, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /api/v1/workspaces/{workspaceID}/boards/{boardID}/notifications/settings updateNotificationSettings
+	//
+	// Sets how the current user is notified of the changes to the cards they follow on a board:
+	// instantly, in an hourly or a daily digest, or not at all
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: notification settings, only the mode is used
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/NotificationSettings"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/NotificationSettings"
+	//   '400':
+	//     description: invalid mode
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var settings model.NotificationSettings
+	if !a.decodeJSONBody(w, r, &settings) {
+		return
+	}
+	settings.BoardID = boardID
+	settings.UserID = session.UserID
+
+	auditRec := a.makeAuditRecord(r, "updateNotificationSettings", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("mode", settings.Mode)
+
+	updated, err := a.app.UpdateNotificationSettings(*container, &settings)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	var errInvalid model.ErrInvalidNotificationSettings
+	if errors.As(err, &errInvalid) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleMuteCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/blocks/{blockID}/mute muteCard
+	//
+	// Mutes the notifications of the changes to a card for the current user, even if following its board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the card
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardMute"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["blockID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "muteCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	mute, err := a.app.MuteCard(*container, boardID, cardID, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "card not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(mute)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleUnmuteCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/blocks/{blockID}/unmute unmuteCard
+	//
+	// Restores the notifications of the changes to a card for the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: ID of the card
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["blockID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "unmuteCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	err = a.app.UnmuteCard(*container, boardID, cardID, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "card not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	auditRec.Success()
+}
+
+func (a *API) handleAcknowledgeBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/acknowledge acknowledgeBoard
+	//
+	// Records that the current user has reviewed the board as of its last activity. Any later activity requires a new acknowledgment
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAcknowledgment"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "acknowledgeBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	ack, err := a.app.AcknowledgeBoard(*container, boardID, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(ack)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardLastActivityAt", ack.BoardLastActivityAt)
+	auditRec.Success()
+}
+
+func (a *API) handleClearBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/blocks/clear clearBoard
+	//
+	// Deletes all the cards and content blocks of a board in one transaction, keeping the board, its properties, sharing and views
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: clear_views
+	//   in: query
+	//   description: Set to true to delete the views of the board too
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ClearBoardResult"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	clearViews := r.URL.Query().Get("clear_views") == "true"
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "clearBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("clearViews", clearViews)
+
+	result, err := a.app.ClearBoard(*container, boardID, clearViews, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("ClearBoard",
+		mlog.String("boardID", boardID),
+		mlog.Int("deleted_count", result.DeletedCount),
+	)
+	auditRec.AddMeta("deletedCount", result.DeletedCount)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardAcknowledgments(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/acknowledgments getBoardAcknowledgments
+	//
+	// Returns who has acknowledged the board since its last activity, and which workspace users haven't
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAcknowledgments"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAcknowledgments", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	acks, err := a.app.GetBoardAcknowledgments(*container, boardID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(acks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("acknowledgedCount", len(acks.Acknowledged))
+	auditRec.Success()
+}
+
+func (a *API) handleMoveCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/move moveCards
+	//
+	// Sets the value of a select property on several cards of a board at once
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards to move and their new property value
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MoveCardsRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid property or value
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '403':
+	//     description: access to one of the cards is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board or card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var move model.MoveCardsRequest
+	if !a.decodeJSONBody(w, r, &move) {
+		return
+	}
+	if len(move.CardIDs) == 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "no cards to move", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", move.PropertyID)
+	auditRec.AddMeta("cardCount", len(move.CardIDs))
+
+	for _, cardID := range move.CardIDs {
+		if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+			a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	cards, err := a.app.MoveCards(*container, boardID, move, session.UserID)
+	if errors.Is(err, model.ErrInvalidProperty) || errors.Is(err, model.ErrInvalidPropertyValue) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("MoveCards",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", move.PropertyID),
+		mlog.Int("card_count", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetViewCardOrder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /api/v1/workspaces/{workspaceID}/boards/{boardID}/views/{viewID}/order setViewCardOrder
+	//
+	// Sets the manual order of the cards of the given groups of a view, the order of the other groups is kept
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the ordered card IDs of the groups to order
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ViewCardOrder"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid card order
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '403':
+	//     description: access to one of the ordered cards is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: view not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	viewID := vars["viewID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var order model.ViewCardOrder
+	if !a.decodeJSONBody(w, r, &order) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setViewCardOrder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	for _, cardIDs := range order.CardOrderByGroup {
+		for _, cardID := range cardIDs {
+			if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+				a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+				return
+			}
+		}
+	}
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	view, err := a.app.SetViewCardOrder(*container, boardID, viewID, order, session.UserID)
+	var eico model.ErrInvalidCardOrder
+	if errors.As(err, &eico) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "view not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("SetViewCardOrder",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+	)
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleExport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/blocks/export exportBlocks
+	//
+	// Returns all blocks
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: root_id
+	//   in: query
+	//   description: ID of the board to export, all boards if empty
+	//   required: false
+	//   type: string
+	// - name: include_comments
+	//   in: query
+	//   description: Whether to export the comments of the cards, defaults to true
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	query := r.URL.Query()
+	rootID := query.Get("root_id")
+	includeComments := query.Get("include_comments") != "false"
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "export", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("rootID", rootID)
+	auditRec.AddMeta("includeComments", includeComments)
+
+	var blocks []model.Block
+	if rootID == "" {
+		blocks, err = a.app.GetAllBlocks(*container)
+	} else {
+		blocks, err = a.app.GetBlocksWithRootID(*container, rootID)
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.logger.Debug("raw blocks", mlog.Int("block_count", len(blocks)))
+	auditRec.AddMeta("rawCount", len(blocks))
+
+	if !includeComments {
+		blocks = filterCommentBlocks(blocks)
+	}
+
+	// the blocks deeper in a hidden card are dropped as orphans
+	blocks, err = a.filterRestrictedBlocks(r, *container, blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	blocks = filterOrphanBlocks(blocks)
+
+	a.logger.Debug("EXPORT filtered blocks", mlog.Int("block_count", len(blocks)))
+	auditRec.AddMeta("filteredCount", len(blocks))
+
+	json, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, json)
+
+	auditRec.Success()
+}
+
+// filterCommentBlocks returns the blocks that aren't comments.
+func filterCommentBlocks(blocks []model.Block) []model.Block {
+	ret := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type != model.TypeComment {
+			ret = append(ret, block)
+		}
+	}
+	return ret
+}
+
+func filterOrphanBlocks(blocks []model.Block) (ret []model.Block) {
+	queue := make([]model.Block, 0)
+	childrenOfBlockWithID := make(map[string]*[]model.Block)
+
+	// Build the trees from nodes
+	for _, block := range blocks {
+		if len(block.ParentID) == 0 {
+			// Queue root blocks to process first
+			queue = append(queue, block)
+		} else {
+			siblings := childrenOfBlockWithID[block.ParentID]
+			if siblings != nil {
+				*siblings = append(*siblings, block)
+			} else {
+				siblings := []model.Block{block}
+				childrenOfBlockWithID[block.ParentID] = &siblings
+			}
+		}
+	}
+
+	// Map the trees to an array, which skips orphaned nodes
+	blocks = make([]model.Block, 0)
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:] // dequeue
+		blocks = append(blocks, block)
+		children := childrenOfBlockWithID[block.ID]
+		if children != nil {
+			queue = append(queue, (*children)...)
+		}
+	}
+
+	return blocks
+}
+
+func (a *API) handleImport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/blocks/import importBlocks
+	//
+	// Import blocks
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: skip_invalid
+	//   in: query
+	//   description: Import the valid blocks and report the invalid ones instead of failing
+	//   required: false
+	//   type: boolean
+	// - name: Body
+	//   in: body
+	//   description: array of blocks to import
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       "$ref": "#/definitions/Block"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the import report if skip_invalid is set or if blocks of unknown types were imported. The legacy block types are renamed with the configured import_block_type_mappings first
+	//     schema:
+	//       "$ref": "#/definitions/ImportResult"
+	//   '400':
+	//     description: rejected by the insert validation webhook, too many blocks in the request, a block or view type not allowed on its board, a default sort on a property the board lacks, a block missing a field required by its type, a block of unknown type, or a card property value longer than its limit
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board of a block not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: board block limit exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.readBodyErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	skipInvalid := r.URL.Query().Get("skip_invalid") == "true"
+
+	var blocks []model.Block
+	var skipped []model.SkippedBlock
+
+	if skipInvalid {
+		blocks, skipped, err = model.ValidBlocksFromJSON(requestBody)
+	} else {
+		err = json.Unmarshal(requestBody, &blocks)
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, ErrorInvalidJSONMessage, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "import", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("skipInvalid", skipInvalid)
+
+	unknownTypes := a.app.RemapImportedBlockTypes(blocks)
+	if len(unknownTypes) > 0 {
+		auditRec.AddMeta("unknownTypes", unknownTypes)
+	}
+
+	stampModificationMetadata(r, blocks, auditRec)
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	_, err = a.app.InsertBlocks(*container, model.GenerateBlockIDs(blocks, a.logger), session.UserID, false)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if isBadRequestBlockError(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if app.IsErrBlockLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, err.Error(), err)
+		return
+	}
+	var batchErr app.ErrInsertBatchFailed
+	if errors.As(err, &batchErr) {
+		message := fmt.Sprintf("%d of %d blocks were inserted before the failure", batchErr.Inserted, batchErr.Total)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if skipInvalid || len(unknownTypes) > 0 {
+		if skipped == nil {
+			skipped = []model.SkippedBlock{}
+		}
+		result := model.ImportResult{ImportedCount: len(blocks), Skipped: skipped, UnknownTypes: unknownTypes}
+		data, err := json.Marshal(result)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		jsonBytesResponse(w, http.StatusOK, data)
+	} else {
+		jsonStringResponse(w, http.StatusOK, "{}")
+	}
+
+	a.logger.Debug("IMPORT Blocks",
+		mlog.Int("block_count", len(blocks)),
+		mlog.Int("skipped_count", len(skipped)),
+	)
+	auditRec.AddMeta("blockCount", len(blocks))
+	auditRec.AddMeta("skippedCount", len(skipped))
+	auditRec.Success()
+}
+
+func (a *API) handleImportMarkdown(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/import/markdown importMarkdown
+	//
+	// Creates a board from a Markdown outline. Top-level list items become cards, and nested
+	// items their checkboxes and text. Headings group the cards with a Section property, and
+	// the keys of the front matter are select properties with comma separated options.
+	//
+	// ---
+	// consumes:
+	// - text/markdown
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: Markdown document to import
+	//   required: true
+	//   schema:
+	//     type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the sections of the document that were left out
+	//     schema:
+	//       "$ref": "#/definitions/MarkdownImportResult"
+	//   '400':
+	//     description: no cards in the document, rejected by the insert validation webhook, or a card property value longer than its limit
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board of a block not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: board block limit exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.readBodyErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	blocks, skipped := model.BlocksFromMarkdown(string(requestBody))
+	cardCount := 0
+	for _, block := range blocks {
+		if block.Type == model.TypeCard {
+			cardCount++
+		}
+	}
+	if cardCount == 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "no list items to import as cards", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "importMarkdown", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	stampModificationMetadata(r, blocks, nil)
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	blocks, err = a.app.InsertBlocks(*container, blocks, session.UserID, false)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if isBadRequestBlockError(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if app.IsErrBlockLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, err.Error(), err)
+		return
+	}
+	var batchErr app.ErrInsertBatchFailed
+	if errors.As(err, &batchErr) {
+		message := fmt.Sprintf("%d of %d blocks were inserted before the failure", batchErr.Inserted, batchErr.Total)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	result := model.MarkdownImportResult{
+		BoardID:   blocks[0].ID,
+		CardCount: cardCount,
+		Skipped:   skipped,
+	}
+	if result.Skipped == nil {
+		result.Skipped = []model.SkippedSection{}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("IMPORT Markdown",
+		mlog.String("boardID", result.BoardID),
+		mlog.Int("card_count", cardCount),
+		mlog.Int("skipped_count", len(skipped)),
+	)
+	auditRec.AddMeta("boardID", result.BoardID)
+	auditRec.AddMeta("cardCount", cardCount)
+	auditRec.AddMeta("skippedCount", len(skipped))
+	auditRec.Success()
+}
+
+// Sharing
+
+func (a *API) handleGetBoardsSharingStatus(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/sharing getBoardsSharingStatus
+	//
+	// Returns whether sharing is enabled for each of a list of boards,
+	// without their access tokens. Boards that aren't in the workspace
+	// are left out
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: ids
+	//   in: query
+	//   description: Comma separated IDs of the boards
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//       additionalProperties:
+	//         "$ref": "#/definitions/SharingStatus"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardIDs := []string{}
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			boardIDs = append(boardIDs, id)
+		}
+	}
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardsSharingStatus", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardCount", len(boardIDs))
+
+	statuses, err := a.app.GetBoardsSharingStatus(*container, boardIDs)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
+func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/sharing/{rootID} getSharing
+	//
+	// Returns sharing information for a root block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: rootID
+	//   in: path
+	//   description: ID of the root block
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	rootID := vars["rootID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getSharing", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("rootID", rootID)
+
+	sharing, err := a.app.GetSharing(*container, rootID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	sharingData, err := json.Marshal(sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, sharingData)
+
+	if sharing == nil {
+		sharing = &model.Sharing{}
+	}
+	a.logger.Debug("GET sharing",
+		mlog.String("rootID", rootID),
+		mlog.String("shareID", sharing.ID),
+		mlog.Bool("enabled", sharing.Enabled),
+	)
+	auditRec.AddMeta("shareID", sharing.ID)
+	auditRec.AddMeta("enabled", sharing.Enabled)
+	auditRec.Success()
+}
+
+func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/sharing/{rootID} postSharing
+	//
+	// Sets sharing information for a root block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: rootID
+	//   in: path
+	//   description: ID of the root block
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: sharing information for a root block
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Sharing"
+	// - name: expand
+	//   in: query
+	//   description: Set to "board" to return a SharingWithBoard object, with the root block and its last activity
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, the sharing as stored, or a SharingWithBoard object if the board is expanded
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var sharing model.Sharing
+	if !a.decodeJSONBody(w, r, &sharing) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "postSharing", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("shareID", sharing.ID)
+	auditRec.AddMeta("enabled", sharing.Enabled)
+
+	// Stamp ModifiedBy
+	ctx := r.Context()
 	session := ctx.Value(sessionContextKey).(*model.Session)
-	_, err = a.app.InsertBlocks(*container, model.GenerateBlockIDs(blocks, a.logger), session.UserID, false)
+	userID := session.UserID
+	if userID == SingleUser {
+		userID = ""
+	}
+	sharing.ModifiedBy = userID
+
+	stored, err := a.app.UpsertSharing(*container, sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := a.marshalSharing(r, *container, stored)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
+	auditRec.Success()
+}
+
+// marshalSharing returns the JSON of a sharing, along with its root block if
+// requested with expand=board.
+func (a *API) marshalSharing(r *http.Request, container store.Container, sharing *model.Sharing) ([]byte, error) {
+	if !hasExpandOption(r.URL.Query(), "board") {
+		return json.Marshal(sharing)
+	}
+
+	board, err := a.app.GetBoardWithLastActivity(container, sharing.ID)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(model.SharingWithBoard{Sharing: sharing, Board: board})
+}
+
+func (a *API) handlePatchSharing(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /api/v1/workspaces/{workspaceID}/sharing/{rootID} patchSharing
+	//
+	// Partially updates the sharing information of a root block, keeping the
+	// fields that aren't set in the patch
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: rootID
+	//   in: path
+	//   description: ID of the root block
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the sharing fields to update
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SharingPatch"
+	// - name: expand
+	//   in: query
+	//   description: Set to "board" to return a SharingWithBoard object, with the root block and its last activity
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, the sharing as stored, or a SharingWithBoard object if the board is expanded
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   '400':
+	//     description: invalid patch
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	rootID := vars["rootID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var patch model.SharingPatch
+	if !a.decodeJSONBody(w, r, &patch) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchSharing", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("rootID", rootID)
+	if patch.Enabled != nil {
+		auditRec.AddMeta("enabled", *patch.Enabled)
+	}
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+	if userID == SingleUser {
+		userID = ""
+	}
+
+	sharing, err := a.app.PatchSharing(*container, rootID, &patch, userID)
+	var errInvalid model.ErrInvalidSharingPatch
+	if errors.As(err, &errInvalid) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	sharingData, err := a.marshalSharing(r, *container, sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, sharingData)
+
+	a.logger.Debug("PATCH sharing", mlog.String("rootID", rootID))
+	auditRec.Success()
+}
+
+func (a *API) handleRegenerateSharingToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/sharing/{rootID}/regenerate regenerateSharingToken
+	//
+	// Regenerates the access token of a shared root block, keeping sharing enabled
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: rootID
+	//   in: path
+	//   description: ID of the root block
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Sharing"
+	//   '404':
+	//     description: sharing not enabled
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	rootID := vars["rootID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "regenerateSharingToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("rootID", rootID)
+
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+	if userID == SingleUser {
+		userID = ""
+	}
+
+	sharing, err := a.app.RegenerateSharingToken(*container, rootID, userID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "sharing not enabled", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	sharingData, err := json.Marshal(sharing)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, sharingData)
+
+	a.logger.Debug("REGENERATE sharing token", mlog.String("rootID", rootID))
+	auditRec.Success()
+}
+
+// Board API keys
+
+func (a *API) handleCreateBoardAPIKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/apikeys createBoardAPIKey
+	//
+	// Creates a read-only API key for a board. The token is only returned in this response
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the key to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardAPIKeyRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAPIKey"
+	//   '400':
+	//     description: invalid request
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	var request model.BoardAPIKeyRequest
+	if !a.decodeJSONBody(w, r, &request) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardAPIKey", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("name", request.Name)
+
+	if strings.TrimSpace(request.Name) == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "name is required", nil)
+		return
+	}
+
+	session := r.Context().Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+	if userID == SingleUser {
+		userID = ""
+	}
+
+	key, err := a.app.CreateBoardAPIKey(*container, boardID, request.Name, userID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "board not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	a.logger.Debug("CreateBoardAPIKey", mlog.String("boardID", boardID), mlog.String("keyID", key.ID))
+	auditRec.AddMeta("keyID", key.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardAPIKeys(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/apikeys getBoardAPIKeys
+	//
+	// Returns the API keys of a board, without their tokens
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardAPIKey"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAPIKeys", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	keys, err := a.app.GetBoardAPIKeys(*container, boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardAPIKey(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /api/v1/workspaces/{workspaceID}/boards/{boardID}/apikeys/{keyID} deleteBoardAPIKey
+	//
+	// Revokes an API key of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: keyID
+	//   in: path
+	//   description: ID of the key to revoke
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: key not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	keyID := vars["keyID"]
+
+	container, err := a.getContainer(r)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardAPIKey", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("keyID", keyID)
+
+	err = a.app.DeleteBoardAPIKey(*container, boardID, keyID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "key not found", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+
+	a.logger.Debug("DeleteBoardAPIKey", mlog.String("boardID", boardID), mlog.String("keyID", keyID))
+	auditRec.Success()
+}
+
+// Workspace
+
+func (a *API) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID} getWorkspace
+	//
+	// Returns information of the root workspace
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: with_stats
+	//   in: query
+	//   description: Set to "true" to include the usage statistics of the workspace
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Workspace"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	var workspace *model.Workspace
+	var err error
+
+	if a.MattermostAuth {
+		vars := mux.Vars(r)
+		workspaceID := vars["workspaceID"]
+
+		ctx := r.Context()
+		session := ctx.Value(sessionContextKey).(*model.Session)
+		if !a.app.DoesUserHaveWorkspaceAccess(session.UserID, workspaceID) {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "user does not have workspace access", nil)
+			return
+		}
+
+		workspace, err = a.app.GetWorkspace(workspaceID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		}
+		if workspace == nil {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid workspace", nil)
+			return
+		}
+	} else {
+		workspace, err = a.app.GetRootWorkspace()
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getWorkspace", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("resultWorkspaceID", workspace.ID)
+
+	if r.URL.Query().Get("with_stats") == "true" {
+		workspace.Stats, err = a.app.GetWorkspaceStats(workspace.ID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	workspaceData, err := json.Marshal(workspace)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, workspaceData)
+	auditRec.Success()
+}
+
+func (a *API) handlePostWorkspaceRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/regenerate_signup_token regenerateSignupToken
+	//
+	// Regenerates the signup token for the root workspace
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	workspace, err := a.app.GetRootWorkspace()
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "regenerateSignupToken", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	workspace.SignupToken = utils.NewID(utils.IDTypeToken)
+
+	err = a.app.UpsertWorkspaceSignupToken(*workspace)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// File upload
+
+func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /workspaces/{workspaceID}/{rootID}/{fileID} getFile
+	//
+	// Returns the contents of an uploaded file
+	//
+	// ---
+	// produces:
+	// - application/json
+	// - image/jpg
+	// - image/png
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: rootID
+	//   in: path
+	//   description: ID of the root block
+	//   required: true
+	//   type: string
+	// - name: fileID
+	//   in: path
+	//   description: ID of the file
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	workspaceID := vars["workspaceID"]
+	rootID := vars["rootID"]
+	filename := vars["filename"]
+
+	// Caller must have access to the root block's container
+	_, err := a.getContainerAllowingReadTokenForBlock(r, rootID)
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec := a.makeAuditRecord(r, "getFile", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("rootID", rootID)
+	auditRec.AddMeta("filename", filename)
 
-	a.logger.Debug("IMPORT Blocks", mlog.Int("block_count", len(blocks)))
-	auditRec.AddMeta("blockCount", len(blocks))
+	contentType := "image/jpg"
+
+	fileExtension := strings.ToLower(filepath.Ext(filename))
+	if fileExtension == "png" {
+		contentType = "image/png"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+
+	fileReader, err := a.app.GetFileReader(workspaceID, rootID, filename)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	defer fileReader.Close()
+	http.ServeContent(w, r, filename, time.Now(), fileReader)
 	auditRec.Success()
 }
 
-// Sharing
+// DeleteBlockResponse is the response to a block delete
+// swagger:model
+type DeleteBlockResponse struct {
+	// The IDs of the deleted blocks
+	// required: true
+	DeletedBlockIDs []string `json:"deletedBlockIds"`
+}
 
-func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /api/v1/workspaces/{workspaceID}/sharing/{rootID} getSharing
+// FileUploadResponse is the response to a file upload
+// swagger:model
+type FileUploadResponse struct {
+	// The FileID to retrieve the uploaded file
+	// required: true
+	FileID string `json:"fileId"`
+
+	// Name of the file when it was uploaded
+	// required: true
+	Filename string `json:"filename"`
+
+	// Size of the stored file in bytes
+	// required: true
+	Size int64 `json:"size"`
+
+	// MIME type of the file, derived from its extension
+	// required: false
+	ContentType string `json:"contentType"`
+
+	// Upload time
+	// required: true
+	UploadedAt int64 `json:"uploadedAt"`
+}
+
+func FileUploadResponseFromJSON(data io.Reader) (*FileUploadResponse, error) {
+	var fileUploadResponse FileUploadResponse
+
+	if err := json.NewDecoder(data).Decode(&fileUploadResponse); err != nil {
+		return nil, err
+	}
+	return &fileUploadResponse, nil
+}
+
+func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/{rootID}/files uploadFile
 	//
-	// Returns sharing information for a root block
+	// Upload a binary file, attached to a root block
 	//
 	// ---
+	// consumes:
+	// - multipart/form-data
 	// produces:
 	// - application/json
 	// parameters:
@@ -1058,64 +4345,104 @@ func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
 	//   description: ID of the root block
 	//   required: true
 	//   type: string
+	// - name: uploaded file
+	//   in: formData
+	//   type: file
+	//   description: The file to upload
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/Sharing"
+	//       "$ref": "#/definitions/FileUploadResponse"
+	//   '400':
+	//     description: file type not allowed on the board
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	vars := mux.Vars(r)
+	workspaceID := vars["workspaceID"]
 	rootID := vars["rootID"]
 
-	container, err := a.getContainer(r)
+	// Caller must have access to the root block's container
+	container, err := a.getContainerAllowingReadTokenForBlock(r, rootID)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "getSharing", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if isErrRequestBodyTooLarge(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "file exceeds maximum size", err)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(w, "%v", err)
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "uploadFile", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("rootID", rootID)
+	auditRec.AddMeta("filename", handle.Filename)
 
-	sharing, err := a.app.GetSharing(*container, rootID)
+	if a.isFileTooLarge(handle.Size) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "file exceeds maximum size", nil)
+		return
+	}
+
+	err = a.app.CheckFileAllowed(*container, rootID, handle.Filename)
+	if app.IsErrFileTypeNotAllowed(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	sharingData, err := json.Marshal(sharing)
+	fileInfo, err := a.app.SaveFile(file, workspaceID, rootID, handle.Filename)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, sharingData)
-
-	if sharing == nil {
-		sharing = &model.Sharing{}
-	}
-	a.logger.Debug("GET sharing",
-		mlog.String("rootID", rootID),
-		mlog.String("shareID", sharing.ID),
-		mlog.Bool("enabled", sharing.Enabled),
+	a.logger.Debug("uploadFile",
+		mlog.String("filename", handle.Filename),
+		mlog.String("fileID", fileInfo.ID),
 	)
-	auditRec.AddMeta("shareID", sharing.ID)
-	auditRec.AddMeta("enabled", sharing.Enabled)
+	data, err := json.Marshal(FileUploadResponse{
+		FileID:      fileInfo.ID,
+		Filename:    fileInfo.Filename,
+		Size:        fileInfo.Size,
+		ContentType: fileInfo.ContentType,
+		UploadedAt:  fileInfo.CreateAt,
+	})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("fileID", fileInfo.ID)
 	auditRec.Success()
 }
 
-func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /api/v1/workspaces/{workspaceID}/sharing/{rootID} postSharing
+func (a *API) handleAttachFile(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/attachments attachFile
 	//
-	// Sets sharing information for a root block
+	// Upload a binary file and attach it to a card as a new image block
 	//
 	// ---
+	// consumes:
+	// - multipart/form-data
 	// produces:
 	// - application/json
 	// parameters:
@@ -1124,79 +4451,132 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
-	// - name: rootID
+	// - name: boardID
 	//   in: path
-	//   description: ID of the root block
+	//   description: Board ID
 	//   required: true
 	//   type: string
-	// - name: Body
-	//   in: body
-	//   description: sharing information for a root block
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
 	//   required: true
-	//   schema:
-	//     "$ref": "#/definitions/Sharing"
+	//   type: string
+	// - name: uploaded file
+	//   in: formData
+	//   type: file
+	//   description: The file to attach
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '400':
+	//     description: rejected by the insert validation webhook, or file or block type not allowed on the board
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: file or board block limit exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+
 	container, err := a.getContainer(r)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if isErrRequestBodyTooLarge(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "file exceeds maximum size", err)
+		return
+	}
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "missing file", err)
 		return
 	}
+	defer file.Close()
 
-	var sharing model.Sharing
+	auditRec := a.makeAuditRecord(r, "attachFile", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("filename", handle.Filename)
 
-	err = json.Unmarshal(requestBody, &sharing)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "postSharing", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("shareID", sharing.ID)
-	auditRec.AddMeta("enabled", sharing.Enabled)
+	if a.isFileTooLarge(handle.Size) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "file exceeds maximum size", nil)
+		return
+	}
 
-	// Stamp ModifiedBy
-	ctx := r.Context()
-	session := ctx.Value(sessionContextKey).(*model.Session)
-	userID := session.UserID
-	if userID == SingleUser {
-		userID = ""
+	block, err := a.app.AttachFileToCard(*container, boardID, cardID, file, handle.Filename, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "card not found", err)
+		return
+	}
+	if isBadRequestBlockError(err) || app.IsErrFileTypeNotAllowed(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if app.IsErrBlockLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, err.Error(), err)
+		return
+	}
+	var batchErr app.ErrInsertBatchFailed
+	if errors.As(err, &batchErr) {
+		message := fmt.Sprintf("%d of %d blocks were inserted before the failure", batchErr.Inserted, batchErr.Total)
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, message, err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
 	}
-	sharing.ModifiedBy = userID
 
-	err = a.app.UpsertSharing(*container, sharing)
+	a.logger.Debug("attachFile",
+		mlog.String("filename", handle.Filename),
+		mlog.String("blockID", block.ID),
+	)
+	data, err := json.Marshal(block)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	jsonBytesResponse(w, http.StatusOK, data)
 
-	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
+	auditRec.AddMeta("blockID", block.ID)
 	auditRec.Success()
 }
 
-// Workspace
-
-func (a *API) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /api/v1/workspaces/{workspaceID} getWorkspace
+func (a *API) handleGetAttachments(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/attachments getAttachments
 	//
-	// Returns information of the root workspace
+	// Returns the metadata of the files attached to a card
 	//
 	// ---
 	// produces:
@@ -1207,66 +4587,89 @@ func (a *API) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/Workspace"
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AttachmentInfo"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
-	//       "$ref": "#/definitions/ErrorResponse"
-
-	var workspace *model.Workspace
-	var err error
-
-	if a.MattermostAuth {
-		vars := mux.Vars(r)
-		workspaceID := vars["workspaceID"]
-
-		ctx := r.Context()
-		session := ctx.Value(sessionContextKey).(*model.Session)
-		if !a.app.DoesUserHaveWorkspaceAccess(session.UserID, workspaceID) {
-			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "user does not have workspace access", nil)
-			return
-		}
-
-		workspace, err = a.app.GetWorkspace(workspaceID)
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		}
-		if workspace == nil {
-			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid workspace", nil)
-			return
-		}
-	} else {
-		workspace, err = a.app.GetRootWorkspace()
-		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-			return
-		}
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
+	if err != nil {
+		a.noContainerErrorResponse(w, r.URL.Path, err)
+		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "getWorkspace", audit.Fail)
+	auditRec := a.makeAuditRecord(r, "getAttachments", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("resultWorkspaceID", workspace.ID)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
 
-	workspaceData, err := json.Marshal(workspace)
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
+	}
+
+	attachments, err := a.app.GetCardAttachments(*container, boardID, cardID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "card not found", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonBytesResponse(w, http.StatusOK, workspaceData)
+	a.logger.Debug("GetAttachments",
+		mlog.String("cardID", cardID),
+		mlog.Int("attachment_count", len(attachments)),
+	)
+
+	data, err := json.Marshal(attachments)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("attachmentCount", len(attachments))
 	auditRec.Success()
 }
 
-func (a *API) handlePostWorkspaceRegenerateSignupToken(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /api/v1/workspaces/{workspaceID}/regenerate_signup_token regenerateSignupToken
+func (a *API) handleGetCommentThreads(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments getCommentThreads
 	//
-	// Regenerates the signup token for the root workspace
+	// Returns the comments of a card grouped in threads, oldest first
 	//
 	// ---
 	// produces:
@@ -1277,136 +4680,279 @@ func (a *API) handlePostWorkspaceRegenerateSignupToken(w http.ResponseWriter, r
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: include_resolved
+	//   in: query
+	//   description: If true, also list the resolved threads
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CommentThread"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: card not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	workspace, err := a.app.GetRootWorkspace()
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	includeResolved := r.URL.Query().Get("include_resolved") == "true"
+
+	container, err := a.getContainerAllowingReadTokenForBlock(r, boardID)
 	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "regenerateSignupToken", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec := a.makeAuditRecord(r, "getCommentThreads", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
 
-	workspace.SignupToken = utils.NewID(utils.IDTypeToken)
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
+	}
 
-	err = a.app.UpsertWorkspaceSignupToken(*workspace)
+	threads, err := a.app.GetCommentThreads(*container, boardID, cardID, includeResolved)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "card not found", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	jsonStringResponse(w, http.StatusOK, "{}")
+	a.logger.Debug("GetCommentThreads",
+		mlog.String("cardID", cardID),
+		mlog.Int("thread_count", len(threads)),
+	)
+
+	data, err := json.Marshal(threads)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("threadCount", len(threads))
 	auditRec.Success()
 }
 
-// File upload
-
-func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET /workspaces/{workspaceID}/{rootID}/{fileID} getFile
+func (a *API) handleReplyToComment(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/replies replyToComment
 	//
-	// Returns the contents of an uploaded file
+	// Adds a reply to the thread of a comment. Replies to a reply are added to the thread it belongs to
 	//
 	// ---
 	// produces:
 	// - application/json
-	// - image/jpg
-	// - image/png
 	// parameters:
 	// - name: workspaceID
 	//   in: path
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
-	// - name: rootID
+	// - name: boardID
 	//   in: path
-	//   description: ID of the root block
+	//   description: Board ID
 	//   required: true
 	//   type: string
-	// - name: fileID
+	// - name: cardID
 	//   in: path
-	//   description: ID of the file
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of the comment to reply to
 	//   required: true
 	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the reply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CommentReply"
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: empty reply, or rejected by the insert validation webhook
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: comment not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: board block limit exceeded
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
 	vars := mux.Vars(r)
-	workspaceID := vars["workspaceID"]
-	rootID := vars["rootID"]
-	filename := vars["filename"]
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	commentID := vars["commentID"]
 
-	// Caller must have access to the root block's container
-	_, err := a.getContainerAllowingReadTokenForBlock(r, rootID)
+	container, err := a.getContainer(r)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	auditRec := a.makeAuditRecord(r, "getFile", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelRead, auditRec)
-	auditRec.AddMeta("rootID", rootID)
-	auditRec.AddMeta("filename", filename)
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.readBodyErrorResponse(w, r.URL.Path, err)
+		return
+	}
 
-	contentType := "image/jpg"
+	var reply model.CommentReply
+	if err = json.Unmarshal(requestBody, &reply); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, ErrorInvalidJSONMessage, err)
+		return
+	}
 
-	fileExtension := strings.ToLower(filepath.Ext(filename))
-	if fileExtension == "png" {
-		contentType = "image/png"
+	auditRec := a.makeAuditRecord(r, "replyToComment", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("commentID", commentID)
+
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
+		return
 	}
 
-	w.Header().Set("Content-Type", contentType)
+	block, err := a.app.ReplyToComment(*container, boardID, cardID, commentID, reply.Text, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "comment not found", err)
+		return
+	}
+	if errors.Is(err, model.ErrEmptyComment) || webhook.IsErrInsertRejected(err) || app.IsErrBlockTypeNotAllowed(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if app.IsErrBlockLimitExceeded(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
 
-	fileReader, err := a.app.GetFileReader(workspaceID, rootID, filename)
+	data, err := json.Marshal(block)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
-	defer fileReader.Close()
-	http.ServeContent(w, r, filename, time.Now(), fileReader)
-	auditRec.Success()
-}
 
-// FileUploadResponse is the response to a file upload
-// swagger:model
-type FileUploadResponse struct {
-	// The FileID to retrieve the uploaded file
-	// required: true
-	FileID string `json:"fileId"`
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("blockID", block.ID)
+	auditRec.Success()
 }
 
-func FileUploadResponseFromJSON(data io.Reader) (*FileUploadResponse, error) {
-	var fileUploadResponse FileUploadResponse
+func (a *API) handleResolveCommentThread(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/resolve resolveCommentThread
+	//
+	// Flags the thread of a comment as resolved
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: workspaceID
+	//   in: path
+	//   description: Workspace ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of a comment of the thread
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: comment not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 
-	if err := json.NewDecoder(data).Decode(&fileUploadResponse); err != nil {
-		return nil, err
-	}
-	return &fileUploadResponse, nil
+	a.setCommentThreadResolved(w, r, true)
 }
 
-func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /api/v1/workspaces/{workspaceID}/{rootID}/files uploadFile
+func (a *API) handleUnresolveCommentThread(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /api/v1/workspaces/{workspaceID}/boards/{boardID}/cards/{cardID}/comments/{commentID}/unresolve unresolveCommentThread
 	//
-	// Upload a binary file, attached to a root block
+	// Clears the resolved flag of the thread of a comment
 	//
 	// ---
-	// consumes:
-	// - multipart/form-data
 	// produces:
 	// - application/json
 	// parameters:
@@ -1415,61 +4961,85 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	//   description: Workspace ID
 	//   required: true
 	//   type: string
-	// - name: rootID
+	// - name: boardID
 	//   in: path
-	//   description: ID of the root block
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: commentID
+	//   in: path
+	//   description: ID of a comment of the thread
 	//   required: true
 	//   type: string
-	// - name: uploaded file
-	//   in: formData
-	//   type: file
-	//   description: The file to upload
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
 	//     description: success
 	//     schema:
-	//       "$ref": "#/definitions/FileUploadResponse"
+	//       "$ref": "#/definitions/Block"
+	//   '403':
+	//     description: access to the card is restricted
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: comment not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
+	a.setCommentThreadResolved(w, r, false)
+}
+
+// setCommentThreadResolved sets the resolved flag of the thread of the
+// comment in the request. The read-only sessions are rejected before, so any
+// member that can edit the card can resolve its threads.
+func (a *API) setCommentThreadResolved(w http.ResponseWriter, r *http.Request, resolved bool) {
+	ctx := r.Context()
+	session := ctx.Value(sessionContextKey).(*model.Session)
+
 	vars := mux.Vars(r)
-	workspaceID := vars["workspaceID"]
-	rootID := vars["rootID"]
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	commentID := vars["commentID"]
 
-	// Caller must have access to the root block's container
-	_, err := a.getContainerAllowingReadTokenForBlock(r, rootID)
+	container, err := a.getContainer(r)
 	if err != nil {
 		a.noContainerErrorResponse(w, r.URL.Path, err)
 		return
 	}
 
-	file, handle, err := r.FormFile(UploadFormFileKey)
-	if err != nil {
-		fmt.Fprintf(w, "%v", err)
+	auditRec := a.makeAuditRecord(r, "setCommentThreadResolved", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("commentID", commentID)
+	auditRec.AddMeta("resolved", resolved)
+
+	if err = a.checkBlockVisible(r, *container, cardID); err != nil {
+		a.restrictedBlockErrorResponse(w, r.URL.Path, err)
 		return
 	}
-	defer file.Close()
-
-	auditRec := a.makeAuditRecord(r, "uploadFile", audit.Fail)
-	defer a.audit.LogRecord(audit.LevelModify, auditRec)
-	auditRec.AddMeta("rootID", rootID)
-	auditRec.AddMeta("filename", handle.Filename)
 
-	fileID, err := a.app.SaveFile(file, workspaceID, rootID, handle.Filename)
+	block, err := a.app.SetCommentThreadResolved(*container, boardID, cardID, commentID, resolved, session.UserID)
+	if store.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "comment not found", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("uploadFile",
-		mlog.String("filename", handle.Filename),
-		mlog.String("fileID", fileID),
-	)
-	data, err := json.Marshal(FileUploadResponse{FileID: fileID})
+	data, err := json.Marshal(block)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1477,10 +5047,16 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 
 	jsonBytesResponse(w, http.StatusOK, data)
 
-	auditRec.AddMeta("fileID", fileID)
 	auditRec.Success()
 }
 
+// isFileTooLarge returns true if a file of the given size exceeds the
+// configured MaxFileSize.
+func (a *API) isFileTooLarge(size int64) bool {
+	maxFileSize := a.app.GetConfig().MaxFileSize
+	return maxFileSize > 0 && size > maxFileSize
+}
+
 func (a *API) getWorkspaceUsers(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /api/v1/workspaces/{workspaceID}/users getWorkspaceUsers
 	//
@@ -1580,17 +5156,8 @@ func (a *API) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var sub model.Subscription
-
-	err = json.Unmarshal(requestBody, &sub)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &sub) {
 		return
 	}
 
@@ -1732,6 +5299,11 @@ func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 	//   description: Subscriber ID
 	//   required: true
 	//   type: string
+	// - name: include_muted
+	//   in: query
+	//   description: If true, also list the muted cards the subscriber isn't subscribed to
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -1767,7 +5339,9 @@ func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subs, err := a.app.GetSubscriptions(*container, subscriberID)
+	includeMuted := r.URL.Query().Get("include_muted") == "true"
+
+	subs, err := a.app.GetSubscriptions(*container, subscriberID, includeMuted)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1829,6 +5403,28 @@ func (a *API) noContainerErrorResponse(w http.ResponseWriter, api string, source
 	a.errorResponseWithCode(w, api, http.StatusBadRequest, ErrorNoWorkspaceCode, ErrorNoWorkspaceMessage, sourceError)
 }
 
+// isBadRequestBlockError returns true if the blocks sent to be inserted or
+// patched were rejected, rather than failing to be saved.
+func isBadRequestBlockError(err error) bool {
+	return webhook.IsErrInsertRejected(err) || app.IsErrTooManyBlocks(err) ||
+		app.IsErrBlockTypeNotAllowed(err) || app.IsErrViewTypeNotAllowed(err) ||
+		errors.Is(err, model.ErrUnknownViewType) || errors.Is(err, model.ErrUnknownBlockType) || errors.Is(err, model.ErrInvalidDefaultSort) ||
+		model.IsErrMissingBlockField(err) || model.IsErrInvalidBlockType(err) ||
+		app.IsErrPropertyValueTooLong(err) || errors.Is(err, model.ErrInvalidFormula) ||
+		errors.Is(err, model.ErrBlockNotPinnable) || errors.Is(err, model.ErrBlockNotRestrictable) ||
+		errors.Is(err, model.ErrInvalidRelation)
+}
+
+// restrictedBlockErrorResponse responds with 403 to an ErrBlockRestricted,
+// and with 500 to other errors.
+func (a *API) restrictedBlockErrorResponse(w http.ResponseWriter, api string, sourceError error) {
+	if errors.Is(sourceError, model.ErrBlockRestricted) {
+		a.errorResponse(w, api, http.StatusForbidden, sourceError.Error(), sourceError)
+		return
+	}
+	a.errorResponse(w, api, http.StatusInternalServerError, "", sourceError)
+}
+
 func jsonStringResponse(w http.ResponseWriter, code int, message string) { //nolint:unparam
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -12,9 +12,11 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 	ctx := r.Context()
 	var sessionID string
 	var userID string
+	var impersonatedBy string
 	if session, ok := ctx.Value(sessionContextKey).(*model.Session); ok {
 		sessionID = session.ID
 		userID = session.UserID
+		impersonatedBy = session.ImpersonatedBy()
 	}
 
 	workspaceID := "unknown"
@@ -33,6 +35,12 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 		IPAddress: r.RemoteAddr,
 		Meta:      []audit.Meta{{K: audit.KeyWorkspaceID, V: workspaceID}},
 	}
+	if impersonatedBy != "" {
+		rec.AddMeta(audit.KeyImpersonatedBy, impersonatedBy)
+	}
+	if key, ok := ctx.Value(boardAPIKeyContextKey).(*model.BoardAPIKey); ok {
+		rec.AddMeta(audit.KeyBoardAPIKeyID, key.ID)
+	}
 
 	return rec
 }
@@ -3,17 +3,19 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net"
 	"net/http"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -159,7 +161,7 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/LoginResponse"
 	//   '401':
-	//     description: invalid login
+	//     description: invalid credentials, whether the user doesn't exist or the password is wrong
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 	//   '500':
@@ -173,16 +175,8 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var loginData LoginRequest
-	err = json.Unmarshal(requestBody, &loginData)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &loginData) {
 		return
 	}
 
@@ -193,8 +187,12 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 
 	if loginData.Type == "normal" {
 		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
+		if errors.Is(err, app.ErrInvalidCredentials) {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, err.Error(), err)
+			return
+		}
 		if err != nil {
-			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "incorrect login", err)
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
 		json, err := json.Marshal(LoginResponse{Token: token})
@@ -203,6 +201,7 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		a.setSessionCookie(w, token, int(a.app.GetConfig().SessionExpireTime))
 		jsonBytesResponse(w, http.StatusOK, json)
 		auditRec.Success()
 		return
@@ -250,10 +249,38 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 
 	auditRec.AddMeta("sessionID", session.ID)
 
+	a.setSessionCookie(w, "", -1)
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
 
+// setSessionCookie sets the session cookie with the attributes from the
+// configuration. A negative maxAge deletes the cookie.
+func (a *API) setSessionCookie(w http.ResponseWriter, token string, maxAge int) {
+	config := a.app.GetConfig()
+	sameSite, err := config.SessionCookieSameSiteMode()
+	if err != nil {
+		a.logger.Error("Invalid session cookie configuration, not setting the cookie", mlog.Err(err))
+		return
+	}
+
+	path := config.SessionCookiePath
+	if path == "" {
+		path = "/"
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     auth.SessionCookieToken,
+		Value:    token,
+		Path:     path,
+		Domain:   config.SessionCookieDomain,
+		MaxAge:   maxAge,
+		Secure:   config.SecureCookie,
+		HttpOnly: config.SessionCookieHTTPOnly,
+		SameSite: sameSite,
+	})
+}
+
 func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /api/v1/register register
 	//
@@ -274,6 +301,10 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//   '401':
 	//     description: invalid registration token
+	//   '403':
+	//     description: email domain not allowed
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   '500':
 	//     description: internal error
 	//     schema:
@@ -285,16 +316,8 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var registerData RegisterRequest
-	err = json.Unmarshal(requestBody, &registerData)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &registerData) {
 		return
 	}
 	registerData.Email = strings.TrimSpace(registerData.Email)
@@ -325,16 +348,21 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err = registerData.IsValid(); err != nil {
+	if err := registerData.IsValid(); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
+	if !a.app.IsEmailDomainAllowed(registerData.Email) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "registration is not allowed for this email domain", nil)
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "register", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
 
-	err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	err := a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
@@ -387,19 +415,12 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["userID"]
 
-	requestBody, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
-		return
-	}
-
 	var requestData ChangePasswordRequest
-	if err = json.Unmarshal(requestBody, &requestData); err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if !a.decodeJSONBody(w, r, &requestData) {
 		return
 	}
 
-	if err = requestData.IsValid(); err != nil {
+	if err := requestData.IsValid(); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -407,7 +428,7 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "changePassword", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 
-	if err = a.app.ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
+	if err := a.app.ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -435,7 +456,7 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			session := &model.Session{
 				ID:          SingleUser,
 				Token:       token,
-				UserID:      SingleUser,
+				UserID:      a.singleUserID(),
 				AuthService: a.authService,
 				Props:       map[string]interface{}{},
 				CreateAt:    now,
@@ -470,6 +491,18 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 				return
 			}
 
+			// Board API keys only authenticate to the read endpoints that
+			// don't require a session, for the blocks of their board
+			if key := a.getBoardAPIKey(token); key != nil {
+				if !isReadOnlyAllowed(r) {
+					a.errorResponse(w, r.URL.Path, http.StatusForbidden, "board API keys are read-only", nil)
+					return
+				}
+				ctx := context.WithValue(r.Context(), boardAPIKeyContextKey, key)
+				handler(w, r.WithContext(ctx))
+				return
+			}
+
 			handler(w, r)
 			return
 		}
@@ -485,11 +518,32 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if session.IsReadOnly() && !isReadOnlyAllowed(r) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "session is read-only", nil)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), sessionContextKey, session)
 		handler(w, r.WithContext(ctx))
 	}
 }
 
+// getBoardAPIKey returns the board API key with the token, or nil if there
+// isn't one.
+func (a *API) getBoardAPIKey(token string) *model.BoardAPIKey {
+	if token == "" {
+		return nil
+	}
+	key, err := a.app.GetBoardAPIKeyByToken(token)
+	if err != nil {
+		if !store.IsErrNotFound(err) {
+			a.logger.Error("GetBoardAPIKeyByToken ERROR", mlog.Err(err))
+		}
+		return nil
+	}
+	return key
+}
+
 func (a *API) adminRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Currently, admin APIs require local unix connections
@@ -11,6 +11,7 @@ type contextKey int
 const (
 	httpConnContextKey contextKey = iota
 	sessionContextKey
+	boardAPIKeyContextKey
 )
 
 // SetContextConn stores the connection in the request context.
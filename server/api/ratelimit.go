@@ -0,0 +1,191 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// rateLimitWindow is the length in milliseconds of the windows the requests
+// are counted in.
+const rateLimitWindow = 60 * 1000
+
+// rateLimiter counts the requests of each client in fixed one minute
+// windows.
+type rateLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*rateLimitCount
+	lastSweep int64
+}
+
+type rateLimitCount struct {
+	start int64
+	count int
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		windows: map[string]*rateLimitCount{},
+	}
+}
+
+// allow records a request of the client at now. It returns the number of
+// requests left in the current window, the time the window resets at in
+// milliseconds, and false if the client is over the limit.
+func (l *rateLimiter) allow(key string, limit int, now int64) (int, int64, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now-l.lastSweep >= rateLimitWindow {
+		for k, window := range l.windows {
+			if now-window.start >= rateLimitWindow {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	window, ok := l.windows[key]
+	if !ok || now-window.start >= rateLimitWindow {
+		window = &rateLimitCount{start: now}
+		l.windows[key] = window
+	}
+	reset := window.start + rateLimitWindow
+
+	if window.count >= limit {
+		return 0, reset, false
+	}
+	window.count++
+	return limit - window.count, reset, true
+}
+
+// rateLimit fails the requests of the clients past the configured number of
+// requests per minute, and tells every client its limit in the response
+// headers. Anonymous requests with a valid read token are limited by the read
+// token limit instead when it is set.
+func (a *API) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the keys are only resolved when a limit is set, as resolving
+		// them reads the session or the sharing of the request
+		config := a.app.GetConfig()
+		if config.ReadTokenRateLimitPerMinute > 0 {
+			if key, limit := a.readTokenRateLimitKey(r); key != "" && limit > 0 {
+				if a.limitRequest(w, r, key, limit) {
+					next.ServeHTTP(w, r)
+				}
+				return
+			}
+		}
+
+		if config.RateLimitPerMinute <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if a.limitRequest(w, r, a.rateLimitKey(r), config.RateLimitPerMinute) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// readTokenRateLimit fails the anonymous requests with a read token past the
+// configured number of requests per minute, leaving the other requests
+// unlimited.
+func (a *API) readTokenRateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config := a.app.GetConfig()
+		if config.ReadTokenRateLimitPerMinute <= 0 && config.RateLimitPerMinute <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, limit := a.readTokenRateLimitKey(r)
+		if key == "" || a.limitRequest(w, r, key, limit) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// limitRequest counts the request against the limit of the client identified
+// by key, and sets the rate limit headers. It fails the request and returns
+// false if the client is over the limit. Limits of 0 or less are disabled.
+func (a *API) limitRequest(w http.ResponseWriter, r *http.Request, key string, limit int) bool {
+	if limit <= 0 {
+		return true
+	}
+
+	now := utils.GetMillis()
+	remaining, reset, ok := a.rateLimiter.allow(key, limit, now)
+
+	w.Header().Set(HeaderRateLimitLimit, strconv.Itoa(limit))
+	w.Header().Set(HeaderRateLimitRemaining, strconv.Itoa(remaining))
+	w.Header().Set(HeaderRateLimitReset, strconv.FormatInt(reset/1000, 10))
+
+	if !ok {
+		retryAfter := (reset - now + 999) / 1000
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+		a.errorResponse(w, r.URL.Path, http.StatusTooManyRequests, "too many requests", nil)
+		return false
+	}
+	return true
+}
+
+// rateLimitKey identifies the client of a request: the user of its session,
+// or its address if it has no valid session, so that made up tokens don't
+// get a limit of their own.
+func (a *API) rateLimitKey(r *http.Request) string {
+	if a.MattermostAuth {
+		if userID := r.Header.Get("Mattermost-User-Id"); userID != "" {
+			return "user:" + userID
+		}
+	}
+	if token, _ := auth.ParseAuthTokenFromRequest(r); token != "" {
+		if len(a.singleUserToken) > 0 {
+			if token == a.singleUserToken {
+				return "user:" + a.singleUserID()
+			}
+		} else if session, err := a.app.GetSession(token); err == nil && session != nil {
+			return "user:" + session.UserID
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+// readTokenRateLimitKey identifies the anonymous requests with a read token
+// by their address and the board the token shares, so that scraping a shared
+// board doesn't throttle the others, and returns the read token limit. The
+// requests with a token that doesn't share a board are identified by their
+// address only, with the rate limit of any request. It returns an empty key
+// for the other requests.
+func (a *API) readTokenRateLimitKey(r *http.Request) (string, int) {
+	readToken := r.URL.Query().Get("read_token")
+	if readToken == "" {
+		return "", 0
+	}
+	key := a.rateLimitKey(r)
+	if !strings.HasPrefix(key, "addr:") {
+		return "", 0
+	}
+
+	workspaceID := mux.Vars(r)["workspaceID"]
+	if workspaceID == "" {
+		workspaceID = "0"
+	}
+	boardID, err := a.app.GetSharedRootID(store.Container{WorkspaceID: workspaceID}, readToken)
+	if err != nil || boardID == "" {
+		return key, a.app.GetConfig().RateLimitPerMinute
+	}
+	return "readtoken:" + strings.TrimPrefix(key, "addr:") + ":" + boardID, a.app.GetConfig().ReadTokenRateLimitPerMinute
+}
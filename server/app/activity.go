@@ -0,0 +1,282 @@
+package app
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+const (
+	// activityExportPageSize is the number of history records read at a
+	// time when exporting the activity of a board.
+	activityExportPageSize = 1000
+
+	activityCreated = "created"
+	activityUpdated = "updated"
+	activityDeleted = "deleted"
+)
+
+var activityExportHeader = []string{"timestamp", "userId", "username", "blockTitle", "action", "changedFields", "changes"}
+
+// ExportBoardActivity writes the activity of a board, as returned by
+// GetBoardActivity, as CSV. The history is read a page at a time and flushed
+// to w after each page, so that large ranges are streamed.
+func (a *App) ExportBoardActivity(c store.Container, boardID, userID string, restricted bool, from, to int64, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := false
+	return a.forEachBoardActivityPage(c, boardID, userID, restricted, from, to, func(entries []model.ActivityEntry) error {
+		if !header {
+			if err := writer.Write(activityExportHeader); err != nil {
+				return err
+			}
+			header = true
+		}
+
+		for _, entry := range entries {
+			err := writer.Write([]string{
+				time.Unix(0, entry.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339Nano),
+				entry.UserID,
+				entry.Username,
+				entry.BlockTitle,
+				entry.Action,
+				strings.Join(entry.ChangedFields, ";"),
+				strings.Join(entry.Changes, ";"),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		flushWriter(w)
+		return nil
+	})
+}
+
+// GetBoardActivity returns the changes made to the blocks of a board, one
+// entry for each record of the block history updated between from and to.
+// Changes of titles and card properties are described with their previous
+// and new values. Both bounds are inclusive timestamps in milliseconds, zero
+// for no bound. If restricted is true, the changes of the restricted cards
+// userID can't see, and of the blocks in them, are left out.
+func (a *App) GetBoardActivity(c store.Container, boardID, userID string, restricted bool, from, to int64) ([]model.ActivityEntry, error) {
+	activity := []model.ActivityEntry{}
+	err := a.forEachBoardActivityPage(c, boardID, userID, restricted, from, to, func(entries []model.ActivityEntry) error {
+		activity = append(activity, entries...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// forEachBoardActivityPage calls fn with the activity of the board, as
+// returned by GetBoardActivity, a page of the history at a time. fn is
+// called at least once if the board exists.
+func (a *App) forEachBoardActivityPage(c store.Container, boardID, userID string, restricted bool, from, to int64, fn func([]model.ActivityEntry) error) error {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return store.NewErrNotFound(boardID)
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	opts := model.QueryBlockHistoryOptions{Limit: activityExportPageSize}
+	if from > 0 {
+		opts.AfterUpdateAt = from - 1
+	}
+	if to > 0 {
+		opts.BeforeUpdateAt = to + 1
+	}
+
+	previous := map[string]*model.Block{}
+	usernames := map[string]string{}
+	hidden := map[string]bool{}
+	for {
+		history, err := a.store.GetBoardHistory(c, boardID, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := a.resolveUsernames(history, usernames); err != nil {
+			return err
+		}
+
+		entries := make([]model.ActivityEntry, 0, len(history))
+		for i := range history {
+			block := &history[i]
+			if restricted {
+				isHidden, err := a.isHiddenHistoryRecord(c, block, userID, hidden)
+				if err != nil {
+					return err
+				}
+				if isHidden {
+					continue
+				}
+			}
+
+			prev, seen := previous[block.ID]
+			if !seen && from > 0 {
+				prev, err = a.previousBlockVersion(c, block)
+				if err != nil {
+					return err
+				}
+			}
+			previous[block.ID] = block
+
+			action, changed := activityAction(prev, block)
+			entries = append(entries, model.ActivityEntry{
+				Timestamp:     block.UpdateAt,
+				UserID:        block.ModifiedBy,
+				Username:      usernames[block.ModifiedBy],
+				BlockID:       block.ID,
+				BlockTitle:    block.Title,
+				Action:        action,
+				ChangedFields: changed,
+				Changes:       a.activityChanges(schema, prev, block, action),
+			})
+		}
+
+		if err := fn(entries); err != nil {
+			return err
+		}
+
+		if len(history) < activityExportPageSize {
+			return nil
+		}
+		opts.Offset += activityExportPageSize
+	}
+}
+
+// previousBlockVersion returns the version of a block before the history
+// record, or nil if the record is its first.
+func (a *App) previousBlockVersion(c store.Container, block *model.Block) (*model.Block, error) {
+	opts := model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: block.UpdateAt,
+		Limit:          1,
+		Descending:     true,
+	}
+	history, err := a.store.GetBlockHistory(c, block.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+	return &history[0], nil
+}
+
+// resolveUsernames adds the usernames of the modifiers of the blocks that
+// aren't in usernames yet.
+func (a *App) resolveUsernames(blocks []model.Block, usernames map[string]string) error {
+	userIDs := []string{}
+	for _, block := range blocks {
+		if _, ok := usernames[block.ModifiedBy]; ok || block.ModifiedBy == "" {
+			continue
+		}
+		usernames[block.ModifiedBy] = ""
+		userIDs = append(userIDs, block.ModifiedBy)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := a.store.GetUsersByIDs(userIDs)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	return nil
+}
+
+// activityAction returns the action a history record is for, and the
+// fields changed since the previous version of the block.
+func activityAction(prev, block *model.Block) (string, []string) {
+	switch {
+	case block.DeleteAt != 0:
+		return activityDeleted, []string{}
+	case prev == nil:
+		return activityCreated, []string{}
+	}
+
+	changed := []string{}
+	if prev.Title != block.Title {
+		changed = append(changed, "title")
+	}
+	if prev.ParentID != block.ParentID {
+		changed = append(changed, "parentId")
+	}
+	if prev.Type != block.Type {
+		changed = append(changed, "type")
+	}
+
+	fields := []string{}
+	for key, value := range block.Fields {
+		if !reflect.DeepEqual(prev.Fields[key], value) {
+			fields = append(fields, "fields."+key)
+		}
+	}
+	for key := range prev.Fields {
+		if _, ok := block.Fields[key]; !ok {
+			fields = append(fields, "fields."+key)
+		}
+	}
+	sort.Strings(fields)
+
+	return activityUpdated, append(changed, fields...)
+}
+
+// activityChanges describes the changes of the title and of the card
+// properties of an updated block since its previous version, as
+// "name: old → new", with the properties in the order of the schema.
+func (a *App) activityChanges(schema model.PropSchema, prev, block *model.Block, action string) []string {
+	changes := []string{}
+	if action != activityUpdated {
+		return changes
+	}
+
+	if prev.Title != block.Title {
+		changes = append(changes, formatActivityChange("title", prev.Title, block.Title))
+	}
+
+	prevValues, _ := prev.Fields["properties"].(map[string]interface{})
+	values, _ := block.Fields["properties"].(map[string]interface{})
+	props := make([]model.PropDef, 0, len(schema))
+	for _, prop := range schema {
+		if !reflect.DeepEqual(prevValues[prop.ID], values[prop.ID]) {
+			props = append(props, prop)
+		}
+	}
+	sort.Slice(props, func(i, j int) bool {
+		return props[i].Index < props[j].Index
+	})
+	for _, prop := range props {
+		oldValue := a.exportValue(prop, prevValues[prop.ID])
+		newValue := a.exportValue(prop, values[prop.ID])
+		if oldValue != newValue {
+			changes = append(changes, formatActivityChange(prop.Name, oldValue, newValue))
+		}
+	}
+	return changes
+}
+
+func formatActivityChange(name, oldValue, newValue string) string {
+	return name + ": " + oldValue + " → " + newValue
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityAction(t *testing.T) {
+	prev := &model.Block{
+		ID:       "card",
+		ParentID: "board",
+		Type:     model.TypeCard,
+		Title:    "Card",
+		Fields:   map[string]interface{}{"icon": "", "properties": map[string]interface{}{"a": "1"}, "removed": true},
+	}
+
+	t.Run("created", func(t *testing.T) {
+		action, changed := activityAction(nil, prev)
+		require.Equal(t, activityCreated, action)
+		require.Empty(t, changed)
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		block := &model.Block{
+			ID:       "card",
+			ParentID: "other-board",
+			Type:     model.TypeCard,
+			Title:    "Renamed",
+			Fields:   map[string]interface{}{"icon": "", "properties": map[string]interface{}{"a": "2"}},
+		}
+		action, changed := activityAction(prev, block)
+		require.Equal(t, activityUpdated, action)
+		require.Equal(t, []string{"title", "parentId", "fields.properties", "fields.removed"}, changed)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		block := *prev
+		block.DeleteAt = 1
+		action, changed := activityAction(prev, &block)
+		require.Equal(t, activityDeleted, action)
+		require.Empty(t, changed)
+	})
+}
+
+func TestActivityChanges(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Block{
+		ID:   "board",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "Todo"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+				map[string]interface{}{"id": "notes", "name": "Notes", "type": "text"},
+			},
+		},
+	}
+	schema, err := model.ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	prev := &model.Block{
+		ID:     "card",
+		Type:   model.TypeCard,
+		Title:  "Card",
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "todo", "notes": "same"}},
+	}
+
+	t.Run("updated", func(t *testing.T) {
+		block := &model.Block{
+			ID:     "card",
+			Type:   model.TypeCard,
+			Title:  "Renamed",
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "done", "notes": "same", "removed": "x"}},
+		}
+		changes := th.App.activityChanges(schema, prev, block, activityUpdated)
+		require.Equal(t, []string{"title: Card → Renamed", "Status: TODO → DONE"}, changes)
+	})
+
+	t.Run("property cleared", func(t *testing.T) {
+		block := &model.Block{
+			ID:     "card",
+			Type:   model.TypeCard,
+			Title:  "Card",
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "todo"}},
+		}
+		changes := th.App.activityChanges(schema, prev, block, activityUpdated)
+		require.Equal(t, []string{"Notes: same → "}, changes)
+	})
+
+	t.Run("created", func(t *testing.T) {
+		require.Empty(t, th.App.activityChanges(schema, nil, prev, activityCreated))
+	})
+}
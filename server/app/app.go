@@ -1,6 +1,8 @@
 package app
 
 import (
+	"sync/atomic"
+
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/metrics"
@@ -15,41 +17,73 @@ import (
 )
 
 type Services struct {
-	Auth          *auth.Auth
-	Store         store.Store
-	FilesBackend  filestore.FileBackend
-	Webhook       *webhook.Client
-	Metrics       *metrics.Metrics
-	Notifications *notify.Service
-	Logger        *mlog.Logger
+	Auth         *auth.Auth
+	Store        store.Store
+	FilesBackend filestore.FileBackend
+	// SecondaryFilesBackend is the backend the files operations fail over
+	// to, nil to only use FilesBackend.
+	SecondaryFilesBackend filestore.FileBackend
+	Webhook               *webhook.Client
+	Metrics               *metrics.Metrics
+	Notifications         *notify.Service
+	Logger                *mlog.Logger
 }
 
 type App struct {
-	config        *config.Configuration
-	store         store.Store
-	auth          *auth.Auth
-	wsAdapter     ws.Adapter
-	filesBackend  filestore.FileBackend
-	webhook       *webhook.Client
-	metrics       *metrics.Metrics
-	notifications *notify.Service
-	logger        *mlog.Logger
+	readOnly int32 // accessed atomically
+
+	config                *config.Configuration
+	store                 store.Store
+	auth                  *auth.Auth
+	wsAdapter             ws.Adapter
+	filesBackend          filestore.FileBackend
+	secondaryFilesBackend filestore.FileBackend
+	webhook               *webhook.Client
+	metrics               *metrics.Metrics
+	notifications         *notify.Service
+	logger                *mlog.Logger
+
+	storageUsage    *storageUsageCache
+	changeDebouncer *changeDebouncer
 }
 
 func (a *App) SetConfig(config *config.Configuration) {
 	a.config = config
 }
 
+func (a *App) GetConfig() *config.Configuration {
+	return a.config
+}
+
+// IsReadOnly returns true if the server is in read-only maintenance mode.
+func (a *App) IsReadOnly() bool {
+	return atomic.LoadInt32(&a.readOnly) == 1
+}
+
+// SetReadOnly turns the read-only maintenance mode on or off.
+func (a *App) SetReadOnly(readOnly bool) {
+	var value int32
+	if readOnly {
+		value = 1
+	}
+	atomic.StoreInt32(&a.readOnly, value)
+}
+
 func New(config *config.Configuration, wsAdapter ws.Adapter, services Services) *App {
-	return &App{
-		config:        config,
-		store:         services.Store,
-		auth:          services.Auth,
-		wsAdapter:     wsAdapter,
-		filesBackend:  services.FilesBackend,
-		webhook:       services.Webhook,
-		metrics:       services.Metrics,
-		notifications: services.Notifications,
-		logger:        services.Logger,
+	app := &App{
+		config:                config,
+		store:                 services.Store,
+		auth:                  services.Auth,
+		wsAdapter:             wsAdapter,
+		filesBackend:          services.FilesBackend,
+		secondaryFilesBackend: services.SecondaryFilesBackend,
+		webhook:               services.Webhook,
+		metrics:               services.Metrics,
+		notifications:         services.Notifications,
+		logger:                services.Logger,
+		storageUsage:          newStorageUsageCache(),
+		changeDebouncer:       newChangeDebouncer(),
 	}
+	app.SetReadOnly(config.ReadOnlyMode)
+	return app
 }
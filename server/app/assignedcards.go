@@ -0,0 +1,108 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// GetAssignedCards returns the cards of the boards of the workspaces with a
+// person property set to the user, due first. The cards without a due date
+// come last, and templates and the cards the user can't see are left out.
+func (a *App) GetAssignedCards(workspaceIDs []string, userID string, opts model.AssignedCardsOptions) ([]model.AssignedCard, error) {
+	assigned := []model.AssignedCard{}
+	for _, workspaceID := range workspaceIDs {
+		c := store.Container{WorkspaceID: workspaceID}
+
+		boards, err := a.store.GetBlocksWithType(c, model.TypeBoard)
+		if err != nil {
+			return nil, err
+		}
+
+		// the cards of all the boards are read at once, rather than board
+		// by board
+		cards, err := a.store.GetBlocksWithType(c, model.TypeCard)
+		if err != nil {
+			return nil, err
+		}
+		cardsByBoard := map[string][]model.Block{}
+		for _, card := range cards {
+			cardsByBoard[card.ParentID] = append(cardsByBoard[card.ParentID], card)
+		}
+
+		for i := range boards {
+			board := &boards[i]
+			if isTemplate, _ := board.Fields["isTemplate"].(bool); isTemplate {
+				continue
+			}
+			schema, err := model.ParsePropertySchema(board)
+			if err != nil {
+				continue
+			}
+
+			statusPropID := firstPropertyID(schema, propTypeSelect)
+			datePropID := firstPropertyID(schema, propTypeDate)
+			for _, card := range cardsByBoard[board.ID] {
+				if !card.IsVisibleTo(userID) || !isAssignedTo(card, schema, userID) {
+					continue
+				}
+
+				props, _ := card.Fields["properties"].(map[string]interface{})
+				optionID, _ := props[statusPropID].(string)
+				item := model.AssignedCard{
+					Card:        card,
+					WorkspaceID: workspaceID,
+					BoardID:     board.ID,
+					BoardTitle:  board.Title,
+					Status:      schema[statusPropID].Options[optionID].Value,
+				}
+				if date, ok := props[datePropID].(string); ok {
+					if _, to, err := model.ParseDateRange(date); err == nil {
+						item.DueDate = to
+					}
+				}
+
+				if matchesAssignedCardsOptions(item, optionID, opts) {
+					assigned = append(assigned, item)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(assigned, func(i, j int) bool {
+		if assigned[i].DueDate == 0 || assigned[j].DueDate == 0 {
+			return assigned[j].DueDate == 0 && assigned[i].DueDate != 0
+		}
+		return assigned[i].DueDate < assigned[j].DueDate
+	})
+	return assigned, nil
+}
+
+// isAssignedTo returns true if a person property of the card is set to the
+// user.
+func isAssignedTo(card model.Block, schema model.PropSchema, userID string) bool {
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	for propID, value := range props {
+		if schema[propID].Type == propTypePerson && value == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAssignedCardsOptions returns true if the card passes the filters,
+// optionID being the ID of its status option.
+func matchesAssignedCardsOptions(item model.AssignedCard, optionID string, opts model.AssignedCardsOptions) bool {
+	if opts.Status != "" && optionID != opts.Status && !strings.EqualFold(item.Status, opts.Status) {
+		return false
+	}
+	if opts.DueAfter > 0 && item.DueDate < opts.DueAfter {
+		return false
+	}
+	if opts.DueBefore > 0 && (item.DueDate == 0 || item.DueDate > opts.DueBefore) {
+		return false
+	}
+	return true
+}
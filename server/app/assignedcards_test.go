@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestGetAssignedCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	boards := []model.Block{
+		{
+			ID:    "board-id",
+			Type:  model.TypeBoard,
+			Title: "Roadmap",
+			Fields: map[string]interface{}{
+				"cardProperties": []interface{}{
+					map[string]interface{}{
+						"id":   "status",
+						"type": "select",
+						"options": []interface{}{
+							map[string]interface{}{"id": "todo", "value": "To Do"},
+							map[string]interface{}{"id": "done", "value": "Done"},
+						},
+					},
+					map[string]interface{}{"id": "owner", "type": "person"},
+					map[string]interface{}{"id": "due", "type": "date"},
+				},
+			},
+		},
+		{
+			ID:     "template-id",
+			Type:   model.TypeBoard,
+			Fields: map[string]interface{}{"isTemplate": true},
+		},
+	}
+
+	cards := []model.Block{
+		{ID: "card-1", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": "user-id", "due": `{"from":2000}`},
+		}},
+		{ID: "card-2", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done", "owner": "user-id"},
+		}},
+		{ID: "card-3", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": "user-id", "due": `{"from":1000,"to":1500}`},
+		}},
+		{ID: "card-4", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": "other-user-id"},
+		}},
+		{ID: "card-5", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties":            map[string]interface{}{"owner": "user-id"},
+			model.RestrictedToField: []interface{}{"other-user-id"},
+		}},
+	}
+
+	expectBlocks := func() {
+		th.Store.EXPECT().GetBlocksWithType(gomock.Eq(container), gomock.Eq(model.TypeBoard)).Return(boards, nil)
+		th.Store.EXPECT().GetBlocksWithType(gomock.Eq(container), gomock.Eq(model.TypeCard)).Return(cards, nil)
+	}
+
+	t.Run("all assigned cards, due first", func(t *testing.T) {
+		expectBlocks()
+
+		assigned, err := th.App.GetAssignedCards([]string{"0"}, "user-id", model.AssignedCardsOptions{})
+		require.NoError(t, err)
+		require.Len(t, assigned, 3)
+		require.Equal(t, "card-3", assigned[0].Card.ID)
+		require.Equal(t, int64(1500), assigned[0].DueDate)
+		require.Equal(t, "card-1", assigned[1].Card.ID)
+		require.Equal(t, "card-2", assigned[2].Card.ID)
+		require.Equal(t, "Done", assigned[2].Status)
+		require.Equal(t, "Roadmap", assigned[2].BoardTitle)
+		require.Equal(t, "0", assigned[2].WorkspaceID)
+	})
+
+	t.Run("filter by status", func(t *testing.T) {
+		expectBlocks()
+
+		assigned, err := th.App.GetAssignedCards([]string{"0"}, "user-id", model.AssignedCardsOptions{Status: "to do"})
+		require.NoError(t, err)
+		require.Len(t, assigned, 2)
+
+		expectBlocks()
+
+		assigned, err = th.App.GetAssignedCards([]string{"0"}, "user-id", model.AssignedCardsOptions{Status: "done"})
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		require.Equal(t, "card-2", assigned[0].Card.ID)
+	})
+
+	t.Run("filter by due date", func(t *testing.T) {
+		expectBlocks()
+
+		assigned, err := th.App.GetAssignedCards([]string{"0"}, "user-id", model.AssignedCardsOptions{DueAfter: 1600, DueBefore: 3000})
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		require.Equal(t, "card-1", assigned[0].Card.ID)
+	})
+}
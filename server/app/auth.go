@@ -1,6 +1,10 @@
 package app
 
 import (
+	"database/sql"
+	"strings"
+	"sync"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -65,35 +69,48 @@ func (a *App) GetUser(id string) (*model.User, error) {
 	return user, nil
 }
 
+// ErrInvalidCredentials is returned by Login whether the user doesn't exist
+// or the password is wrong, so failed logins don't reveal which users exist.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+var (
+	dummyPasswordHashOnce sync.Once
+	dummyPasswordHash     string
+)
+
+// getDummyPasswordHash returns the hash the password is compared with when
+// no user matches a login, so it takes as long as for an existing user.
+func getDummyPasswordHash() string {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash = auth.HashPassword(utils.NewID(utils.IDTypeNone))
+	})
+	return dummyPasswordHash
+}
+
 // Login create a new user session if the authentication data is valid.
 func (a *App) Login(username, email, password, mfaToken string) (string, error) {
 	var user *model.User
+	var err error
 	if username != "" {
-		var err error
 		user, err = a.store.GetUserByUsername(username)
-		if err != nil {
-			a.metrics.IncrementLoginFailCount(1)
-			return "", errors.Wrap(err, "invalid username or password")
-		}
 	}
-
-	if user == nil && email != "" {
-		var err error
+	if err == nil && user == nil && email != "" {
 		user, err = a.store.GetUserByEmail(email)
-		if err != nil {
-			a.metrics.IncrementLoginFailCount(1)
-			return "", errors.Wrap(err, "invalid username or password")
-		}
 	}
-	if user == nil {
-		a.metrics.IncrementLoginFailCount(1)
-		return "", errors.New("invalid username or password")
+	if errors.Is(err, sql.ErrNoRows) || store.IsErrNotFound(err) {
+		a.logger.Debug("No user found for login", mlog.Err(err))
+		user = nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "unable to get user for login")
 	}
 
-	if !auth.ComparePassword(user.Password, password) {
+	passwordHash := getDummyPasswordHash()
+	if user != nil {
+		passwordHash = user.Password
+	}
+	if !auth.ComparePassword(passwordHash, password) || user == nil {
 		a.metrics.IncrementLoginFailCount(1)
-		a.logger.Debug("Invalid password for user", mlog.String("userID", user.ID))
-		return "", errors.New("invalid username or password")
+		return "", ErrInvalidCredentials
 	}
 
 	authService := user.AuthService
@@ -108,7 +125,7 @@ func (a *App) Login(username, email, password, mfaToken string) (string, error)
 		AuthService: authService,
 		Props:       map[string]interface{}{},
 	}
-	err := a.store.CreateSession(&session)
+	err = a.store.CreateSession(&session)
 	if err != nil {
 		return "", errors.Wrap(err, "unable to create session")
 	}
@@ -131,6 +148,28 @@ func (a *App) Logout(sessionID string) error {
 	return nil
 }
 
+// IsEmailDomainAllowed returns true if users can register with the email,
+// that is if its domain is one of the configured AllowedEmailDomains or if
+// none are configured.
+func (a *App) IsEmailDomainAllowed(email string) bool {
+	if len(a.config.AllowedEmailDomains) == 0 {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+
+	for _, allowed := range a.config.AllowedEmailDomains {
+		if strings.EqualFold(domain, strings.TrimPrefix(strings.TrimSpace(allowed), "@")) {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterUser creates a new user if the provided data is valid.
 func (a *App) RegisterUser(username, email, password string) error {
 	var user *model.User
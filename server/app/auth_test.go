@@ -1,8 +1,10 @@
 package app
 
 import (
+	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
@@ -39,8 +41,8 @@ func TestLogin(t *testing.T) {
 		{"success, using email", "", "testEmail", "testPassword", "", false},
 	}
 
-	th.Store.EXPECT().GetUserByUsername("badUsername").Return(nil, errors.New("Bad Username"))
-	th.Store.EXPECT().GetUserByEmail("badEmail").Return(nil, errors.New("Bad Email"))
+	th.Store.EXPECT().GetUserByUsername("badUsername").Return(nil, nil)
+	th.Store.EXPECT().GetUserByEmail("badEmail").Return(nil, sql.ErrNoRows)
 	th.Store.EXPECT().GetUserByUsername("testUsername").Return(mockUser, nil).Times(2)
 	th.Store.EXPECT().GetUserByEmail("testEmail").Return(mockUser, nil)
 	th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil).Times(2)
@@ -56,6 +58,44 @@ func TestLogin(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("fail, store error", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("storeError").Return(nil, errors.New("connection refused"))
+		_, err := th.App.Login("storeError", "", "testPassword", "")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestLoginFailures(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetUserByUsername("badUsername").Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetUserByUsername("testUsername").Return(mockUser, nil).AnyTimes()
+
+	login := func(username, password string) (time.Duration, error) {
+		start := time.Now()
+		_, err := th.App.Login(username, "", password, "")
+		return time.Since(start), err
+	}
+
+	// hashes the dummy password outside of the measures
+	_, _ = login("badUsername", "badPassword")
+
+	unknownUserTime, unknownUserErr := login("badUsername", "badPassword")
+	badPasswordTime, badPasswordErr := login("testUsername", "badPassword")
+
+	t.Run("same error", func(t *testing.T) {
+		require.Equal(t, ErrInvalidCredentials, unknownUserErr)
+		require.Equal(t, ErrInvalidCredentials, badPasswordErr)
+	})
+
+	t.Run("similar timing", func(t *testing.T) {
+		// both compare a password hash, which dominates the time spent
+		require.Greater(t, int64(unknownUserTime), int64(badPasswordTime/4))
+		require.Greater(t, int64(badPasswordTime), int64(unknownUserTime/4))
+	})
 }
 
 func TestGetUser(t *testing.T) {
@@ -125,6 +165,36 @@ func TestRegisterUser(t *testing.T) {
 	}
 }
 
+func TestIsEmailDomainAllowed(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("all domains allowed by default", func(t *testing.T) {
+		require.True(t, th.App.IsEmailDomainAllowed("user@example.com"))
+	})
+
+	th.App.config.AllowedEmailDomains = []string{"example.com", "@Example.org"}
+
+	testcases := []struct {
+		email   string
+		allowed bool
+	}{
+		{"user@example.com", true},
+		{"user@EXAMPLE.COM", true},
+		{"user@example.org", true},
+		{"user@sub.example.com", false},
+		{"user@example.com.evil.net", false},
+		{"user@other.com", false},
+		{"no-domain", false},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.email, func(t *testing.T) {
+			require.Equal(t, test.allowed, th.App.IsEmailDomainAllowed(test.email))
+		})
+	}
+}
+
 func TestUpdateUserPassword(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -1,31 +1,184 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/notify/notifymentions"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (a *App) GetBlocks(c store.Container, parentID string, blockType string) ([]model.Block, error) {
-	if blockType != "" && parentID != "" {
-		return a.store.GetBlocksWithParentAndType(c, parentID, blockType)
+// maxIDConflictRetries is the number of times a block gets a new ID when its
+// insert conflicts with a concurrently inserted block.
+const maxIDConflictRetries = 3
+
+// ErrBlockLimitExceeded is returned when inserting blocks would push a board
+// past the configured maximum number of blocks.
+type ErrBlockLimitExceeded struct {
+	RootID string
+	Limit  int
+}
+
+func (e ErrBlockLimitExceeded) Error() string {
+	return fmt.Sprintf("board %s cannot contain more than %d blocks", e.RootID, e.Limit)
+}
+
+// IsErrBlockLimitExceeded returns true if `err` is or wraps an ErrBlockLimitExceeded.
+func IsErrBlockLimitExceeded(err error) bool {
+	var eble ErrBlockLimitExceeded
+	return errors.As(err, &eble)
+}
+
+// ErrTooManyBlocks is returned when a single insert contains more blocks than
+// the configured maximum.
+type ErrTooManyBlocks struct {
+	Count int
+	Limit int
+}
+
+func (e ErrTooManyBlocks) Error() string {
+	return fmt.Sprintf("cannot insert %d blocks at once, the limit is %d", e.Count, e.Limit)
+}
+
+// IsErrTooManyBlocks returns true if `err` is or wraps an ErrTooManyBlocks.
+func IsErrTooManyBlocks(err error) bool {
+	var etmb ErrTooManyBlocks
+	return errors.As(err, &etmb)
+}
+
+// ErrBlockTypeNotAllowed is returned when adding a block of a type that isn't
+// in the allowed block types of its board.
+type ErrBlockTypeNotAllowed struct {
+	RootID string
+	Type   model.BlockType
+}
+
+func (e ErrBlockTypeNotAllowed) Error() string {
+	return fmt.Sprintf("blocks of type %s are not allowed on board %s", e.Type, e.RootID)
+}
+
+// IsErrBlockTypeNotAllowed returns true if `err` is or wraps an ErrBlockTypeNotAllowed.
+func IsErrBlockTypeNotAllowed(err error) bool {
+	var ebtna ErrBlockTypeNotAllowed
+	return errors.As(err, &ebtna)
+}
+
+// ErrViewTypeNotAllowed is returned when adding a view of a type that isn't
+// in the allowed view types of its board.
+type ErrViewTypeNotAllowed struct {
+	RootID   string
+	ViewType string
+}
+
+func (e ErrViewTypeNotAllowed) Error() string {
+	return fmt.Sprintf("views of type %s are not allowed on board %s", e.ViewType, e.RootID)
+}
+
+// IsErrViewTypeNotAllowed returns true if `err` is or wraps an ErrViewTypeNotAllowed.
+func IsErrViewTypeNotAllowed(err error) bool {
+	var evtna ErrViewTypeNotAllowed
+	return errors.As(err, &evtna)
+}
+
+// ErrInsertBatchFailed is returned when a batch of an insert fails. The
+// failed batch is rolled back, while the batches before it stay inserted.
+type ErrInsertBatchFailed struct {
+	Inserted int
+	Total    int
+	Err      error
+}
+
+func (e ErrInsertBatchFailed) Error() string {
+	return fmt.Sprintf("insert failed after %d of %d blocks: %v", e.Inserted, e.Total, e.Err)
+}
+
+func (e ErrInsertBatchFailed) Unwrap() error {
+	return e.Err
+}
+
+// GetBlocks returns the children of parentID, or the blocks of the
+// workspace if it's empty, that are of any of blockTypes. An empty
+// blockTypes matches all types.
+func (a *App) GetBlocks(c store.Container, parentID string, blockTypes []string) ([]model.Block, error) {
+	if len(blockTypes) != 0 && parentID == "" {
+		blocks, err := a.store.GetBlocksWithTypes(c, blockTypes)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.setBoardsLastActivity(c, blocks); err != nil {
+			return nil, err
+		}
+		return blocks, nil
 	}
 
-	if blockType != "" {
-		return a.store.GetBlocksWithType(c, blockType)
+	var blocks []model.Block
+	var err error
+	if len(blockTypes) != 0 {
+		blocks, err = a.store.GetBlocksWithParentAndTypes(c, parentID, blockTypes)
+	} else {
+		blocks, err = a.store.GetBlocksWithParent(c, parentID)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return a.store.GetBlocksWithParent(c, parentID)
+	// children of a card are its comments and contents; pinned ones go first
+	model.SortPinnedFirst(blocks)
+	return blocks, nil
+}
+
+// setBoardsLastActivity sets LastActivityAt on the boards in blocks, with a
+// single query for all of them.
+func (a *App) setBoardsLastActivity(c store.Container, blocks []model.Block) error {
+	boardIDs := []string{}
+	for _, block := range blocks {
+		if block.Type == model.TypeBoard {
+			boardIDs = append(boardIDs, block.ID)
+		}
+	}
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	lastActivities, err := a.store.GetLastActivityByRootIDs(c, boardIDs)
+	if err != nil {
+		return err
+	}
+	for i := range blocks {
+		if blocks[i].Type == model.TypeBoard {
+			blocks[i].LastActivityAt = lastActivities[blocks[i].ID]
+		}
+	}
+	return nil
 }
 
 func (a *App) GetBlockWithID(c store.Container, blockID string) (*model.Block, error) {
 	return a.store.GetBlock(c, blockID)
 }
 
+// GetBoardWithLastActivity returns a root block, with its last activity set
+// if it is a board, or nil if it doesn't exist.
+func (a *App) GetBoardWithLastActivity(c store.Container, boardID string) (*model.Block, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil || board == nil {
+		return nil, err
+	}
+
+	boards := []model.Block{*board}
+	if err := a.setBoardsLastActivity(c, boards); err != nil {
+		return nil, err
+	}
+	return &boards[0], nil
+}
+
 func (a *App) GetBlocksWithRootID(c store.Container, rootID string) ([]model.Block, error) {
 	return a.store.GetBlocksWithRootID(c, rootID)
 }
@@ -38,36 +191,86 @@ func (a *App) GetParentID(c store.Container, blockID string) (string, error) {
 	return a.store.GetParentID(c, blockID)
 }
 
-func (a *App) PatchBlock(c store.Container, blockID string, blockPatch *model.BlockPatch, modifiedByID string) error {
+// PatchBlock applies the patch to a block and returns the updated block.
+func (a *App) PatchBlock(c store.Container, blockID string, blockPatch *model.BlockPatch, modifiedByID string) (*model.Block, error) {
 	oldBlock, err := a.store.GetBlock(c, blockID)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if oldBlock == nil {
+		return nil, store.NewErrNotFound(blockID)
+	}
+
+	if err = checkPinnable(oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = checkRestrictable(oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = a.checkRelations(c, oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = a.checkPatchedBlockType(c, oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = checkPatchedDefaultSort(oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = checkPatchedFormulas(oldBlock, blockPatch); err != nil {
+		return nil, err
+	}
+	if err = a.checkPatchedPropertyValueLengths(c, oldBlock, blockPatch); err != nil {
+		return nil, err
 	}
 
 	err = a.store.PatchBlock(c, blockID, blockPatch, modifiedByID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	a.metrics.IncrementBlocksPatched(1)
 	block, err := a.store.GetBlock(c, blockID)
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	a.wsAdapter.BroadcastBlockChange(c.WorkspaceID, *block)
+	a.broadcastBlockChange(c, *block, nil)
 	go func() {
-		a.webhook.NotifyUpdate(*block)
+		a.notifyWebhookUpdate(c, *block, nil)
 		a.notifyBlockChanged(notify.Update, c, block, oldBlock, modifiedByID)
 	}()
-	return nil
+	return block, nil
 }
 
 func (a *App) PatchBlocks(c store.Container, blockPatches *model.BlockPatchBatch, modifiedByID string) error {
 	oldBlocks := make([]model.Block, 0, len(blockPatches.BlockIDs))
-	for _, blockID := range blockPatches.BlockIDs {
+	for i, blockID := range blockPatches.BlockIDs {
 		oldBlock, err := a.store.GetBlock(c, blockID)
 		if err != nil {
-			return nil
+			return err
+		}
+		if oldBlock == nil {
+			return store.NewErrNotFound(blockID)
+		}
+		if err = checkPinnable(oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = checkRestrictable(oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = a.checkRelations(c, oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = a.checkPatchedBlockType(c, oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = checkPatchedDefaultSort(oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = checkPatchedFormulas(oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		if err = a.checkPatchedPropertyValueLengths(c, oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
 		}
 		oldBlocks = append(oldBlocks, *oldBlock)
 	}
@@ -78,28 +281,47 @@ func (a *App) PatchBlocks(c store.Container, blockPatches *model.BlockPatchBatch
 	}
 
 	a.metrics.IncrementBlocksPatched(len(oldBlocks))
-	for i, blockID := range blockPatches.BlockIDs {
+	debounces := map[string]time.Duration{}
+	newBlocks := make([]model.Block, 0, len(blockPatches.BlockIDs))
+	for _, blockID := range blockPatches.BlockIDs {
 		newBlock, err := a.store.GetBlock(c, blockID)
 		if err != nil {
 			return nil
 		}
-		a.wsAdapter.BroadcastBlockChange(c.WorkspaceID, *newBlock)
-		go func(currentIndex int) {
-			a.webhook.NotifyUpdate(*newBlock)
-			a.notifyBlockChanged(notify.Update, c, newBlock, &oldBlocks[currentIndex], modifiedByID)
-		}(i)
+		a.broadcastBlockChange(c, *newBlock, debounces)
+		newBlocks = append(newBlocks, *newBlock)
 	}
 
+	go func() {
+		webhookDebounces := map[string]time.Duration{}
+		for i := range newBlocks {
+			a.notifyWebhookUpdate(c, newBlocks[i], webhookDebounces)
+			a.notifyBlockChanged(notify.Update, c, &newBlocks[i], &oldBlocks[i], modifiedByID)
+		}
+	}()
+
+	return nil
+}
+
+// checkPinnable returns ErrBlockNotPinnable if the patch pins a block that
+// can't be pinned.
+func checkPinnable(block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil {
+		return nil
+	}
+	if pinned, _ := blockPatch.UpdatedFields[model.PinnedField].(bool); pinned && !block.IsPinnable() {
+		return model.ErrBlockNotPinnable
+	}
 	return nil
 }
 
 func (a *App) InsertBlock(c store.Container, block model.Block, modifiedByID string) error {
 	err := a.store.InsertBlock(c, &block, modifiedByID)
 	if err == nil {
-		a.wsAdapter.BroadcastBlockChange(c.WorkspaceID, block)
+		a.broadcastBlockChange(c, block, nil)
 		a.metrics.IncrementBlocksInserted(1)
 		go func() {
-			a.webhook.NotifyUpdate(block)
+			a.notifyWebhookUpdate(c, block, nil)
 			a.notifyBlockChanged(notify.Add, c, &block, nil, modifiedByID)
 		}()
 	}
@@ -107,80 +329,457 @@ func (a *App) InsertBlock(c store.Container, block model.Block, modifiedByID str
 }
 
 func (a *App) InsertBlocks(c store.Container, blocks []model.Block, modifiedByID string, allowNotifications bool) ([]model.Block, error) {
-	needsNotify := make([]model.Block, 0, len(blocks))
-	for i := range blocks {
-		err := a.store.InsertBlock(c, &blocks[i], modifiedByID)
-		if err != nil {
-			return nil, err
+	if a.config.MaxBlocksPerInsert > 0 && len(blocks) > a.config.MaxBlocksPerInsert {
+		return nil, ErrTooManyBlocks{Count: len(blocks), Limit: a.config.MaxBlocksPerInsert}
+	}
+
+	if err := a.checkBlockLimit(c, blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkAllowedBlockTypes(c, blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkBlockFields(blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkPropertyValueLengths(c, blocks); err != nil {
+		return nil, err
+	}
+
+	if err := checkFormulas(blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.applyDefaultViewSort(c, blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.resolveCommentMentions(c, blocks); err != nil {
+		return nil, err
+	}
+
+	if err := a.webhook.ValidateInsert(c.WorkspaceID, blocks); err != nil {
+		return nil, err
+	}
+
+	batchSize := a.config.InsertBlocksBatchSize
+	if batchSize <= 0 {
+		batchSize = len(blocks)
+	}
+
+	inserted := 0
+	var err error
+	debounces := map[string]time.Duration{}
+	for inserted < len(blocks) {
+		end := inserted + batchSize
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		if err = a.insertBlockBatch(c, blocks, inserted, end, modifiedByID); err != nil {
+			break
 		}
-		blocks[i].WorkspaceID = c.WorkspaceID
-		needsNotify = append(needsNotify, blocks[i])
 
-		a.wsAdapter.BroadcastBlockChange(c.WorkspaceID, blocks[i])
-		a.metrics.IncrementBlocksInserted(1)
+		for i := inserted; i < end; i++ {
+			blocks[i].WorkspaceID = c.WorkspaceID
+			a.broadcastBlockChange(c, blocks[i], debounces)
+		}
+		a.metrics.IncrementBlocksInserted(end - inserted)
+		inserted = end
 	}
 
+	// copied after all inserts, as an ID conflict can update earlier blocks
+	needsNotify := make([]model.Block, inserted)
+	copy(needsNotify, blocks[:inserted])
+
 	go func() {
+		webhookDebounces := map[string]time.Duration{}
 		for _, b := range needsNotify {
 			block := b
-			a.webhook.NotifyUpdate(block)
+			a.notifyWebhookUpdate(c, block, webhookDebounces)
 			if allowNotifications {
 				a.notifyBlockChanged(notify.Add, c, &block, nil, modifiedByID)
 			}
 		}
 	}()
 
+	if err != nil {
+		if inserted == 0 {
+			return nil, err
+		}
+		return nil, ErrInsertBatchFailed{Inserted: inserted, Total: len(blocks), Err: err}
+	}
 	return blocks, nil
 }
 
-func (a *App) GetSubTree(c store.Container, blockID string, levels int) ([]model.Block, error) {
+// insertBlockBatch inserts blocks[start:end] in a single transaction. If a
+// block ID conflicts with a concurrently inserted block, the transaction is
+// rolled back and the batch is inserted again block by block, regenerating the
+// conflicting IDs.
+func (a *App) insertBlockBatch(c store.Container, blocks []model.Block, start, end int, modifiedByID string) error {
+	err := a.store.InsertBlocks(c, blocks[start:end], modifiedByID)
+	if !store.IsErrConflict(err) {
+		return err
+	}
+
+	for i := start; i < end; i++ {
+		if err := a.insertBlockRegeneratingID(c, blocks, i, modifiedByID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertBlockRegeneratingID inserts blocks[i]. If another block with the same
+// ID was inserted concurrently, the block gets a new ID and the insert is
+// retried, updating the references to the old ID in the whole batch. Blocks
+// of the batch that were already inserted and referenced the old ID are
+// saved again.
+func (a *App) insertBlockRegeneratingID(c store.Container, blocks []model.Block, i int, modifiedByID string) error {
+	for retries := 0; ; retries++ {
+		err := a.store.InsertBlock(c, &blocks[i], modifiedByID)
+		if !store.IsErrConflict(err) || retries >= maxIDConflictRetries {
+			return err
+		}
+
+		oldID := blocks[i].ID
+		newID := utils.NewID(model.BlockType2IDType(blocks[i].Type))
+		a.logger.Warn("Block ID conflict on insert, regenerating ID",
+			mlog.String("oldID", oldID),
+			mlog.String("newID", newID),
+		)
+		blocks[i].ID = newID
+
+		for j := range blocks {
+			if !blocks[j].ReplaceReferences(oldID, newID) || j >= i {
+				continue
+			}
+			if err := a.store.InsertBlock(c, &blocks[j], modifiedByID); err != nil {
+				return err
+			}
+			a.broadcastBlockChange(c, blocks[j], nil)
+		}
+	}
+}
+
+// checkBlockLimit verifies that inserting the blocks keeps every board they
+// belong to within the configured MaxBlocksPerBoard. The blocks that already
+// exist in the board are updated rather than added, so they are only looked
+// up, and left out of the count, when the board would otherwise exceed the
+// limit.
+func (a *App) checkBlockLimit(c store.Container, blocks []model.Block) error {
+	if a.config.MaxBlocksPerBoard <= 0 {
+		return nil
+	}
+
+	incoming := make(map[string]map[string]bool)
+	for _, block := range blocks {
+		if incoming[block.RootID] == nil {
+			incoming[block.RootID] = map[string]bool{}
+		}
+		incoming[block.RootID][block.ID] = true
+	}
+
+	for rootID, blockIDs := range incoming {
+		existing, err := a.store.GetBlockCountWithRootID(c, rootID)
+		if err != nil {
+			return err
+		}
+		added := int64(len(blockIDs))
+		if existing+added <= int64(a.config.MaxBlocksPerBoard) {
+			continue
+		}
+
+		for blockID := range blockIDs {
+			block, err := a.store.GetBlock(c, blockID)
+			if err != nil {
+				return err
+			}
+			if block != nil && block.RootID == rootID {
+				added--
+			}
+		}
+		if existing+added > int64(a.config.MaxBlocksPerBoard) {
+			return ErrBlockLimitExceeded{RootID: rootID, Limit: a.config.MaxBlocksPerBoard}
+		}
+	}
+
+	return nil
+}
+
+// RemapImportedBlockTypes renames the legacy types of imported blocks with the
+// configured mappings, and returns the types that are still unknown.
+func (a *App) RemapImportedBlockTypes(blocks []model.Block) []string {
+	mappings, err := a.config.ImportBlockTypeMappingsByVersion()
+	if err != nil {
+		a.logger.Warn("Invalid block type mappings, the block types are not remapped", mlog.Err(err))
+	}
+	return model.RemapBlockTypes(blocks, mappings)
+}
+
+// checkBlockFields returns an ErrMissingBlockField if a block lacks a field
+// required by its type. Blocks of unknown types fail with an
+// ErrInvalidBlockType if RejectUnknownBlockTypes is set, and are logged
+// otherwise.
+func (a *App) checkBlockFields(blocks []model.Block) error {
+	for _, block := range blocks {
+		if _, err := model.BlockTypeFromString(string(block.Type)); err != nil {
+			if a.config.RejectUnknownBlockTypes {
+				return fmt.Errorf("block %s: %w", block.ID, &model.ErrInvalidBlockType{Type: string(block.Type)})
+			}
+			a.logger.Warn("Inserting block of unknown type",
+				mlog.String("blockID", block.ID),
+				mlog.String("type", string(block.Type)),
+			)
+		}
+		if err := block.CheckRequiredFields(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkAllowedBlockTypes returns a not found error if the board of any of
+// the blocks doesn't exist, ErrBlockTypeNotAllowed if a block is of a type its
+// board doesn't allow, or ErrViewTypeNotAllowed if it is a view of a type its
+// board doesn't allow. Boards inserted along with the blocks are checked with
+// their new allowed types, which must be known types.
+func (a *App) checkAllowedBlockTypes(c store.Container, blocks []model.Block) error {
+	boards := map[string]*model.Block{}
+	for i := range blocks {
+		if blocks[i].ID == blocks[i].RootID {
+			if err := blocks[i].CheckAllowedBlockTypes(); err != nil {
+				return err
+			}
+			if err := blocks[i].CheckAllowedViewTypes(); err != nil {
+				return err
+			}
+			boards[blocks[i].ID] = &blocks[i]
+		}
+	}
+
+	for _, block := range blocks {
+		if block.ID == block.RootID {
+			continue
+		}
+
+		board, ok := boards[block.RootID]
+		if !ok {
+			var err error
+			if board, err = a.store.GetBlock(c, block.RootID); err != nil {
+				return err
+			}
+			boards[block.RootID] = board
+		}
+
+		if board == nil {
+			return store.NewErrNotFound(block.RootID)
+		}
+		if !board.AllowsBlockType(block.Type) {
+			return ErrBlockTypeNotAllowed{RootID: block.RootID, Type: block.Type}
+		}
+		if block.Type == model.TypeView && !board.AllowsViewType(block.ViewType()) {
+			return ErrViewTypeNotAllowed{RootID: block.RootID, ViewType: block.ViewType()}
+		}
+	}
+	return nil
+}
+
+// checkPatchedBlockType returns ErrBlockTypeNotAllowed or
+// ErrViewTypeNotAllowed if the patch changes the type, view type or board of
+// a block to a type its board doesn't allow, and ErrUnknownBlockType or
+// ErrUnknownViewType if it allows unknown block or view types on a board.
+func (a *App) checkPatchedBlockType(c store.Container, block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil {
+		return nil
+	}
+
+	if block.ID == block.RootID {
+		if allowed, ok := blockPatch.UpdatedFields[model.AllowedBlockTypesField]; ok {
+			board := model.Block{Fields: map[string]interface{}{model.AllowedBlockTypesField: allowed}}
+			if err := board.CheckAllowedBlockTypes(); err != nil {
+				return err
+			}
+		}
+		if allowed, ok := blockPatch.UpdatedFields[model.AllowedViewTypesField]; ok {
+			board := model.Block{Fields: map[string]interface{}{model.AllowedViewTypesField: allowed}}
+			if err := board.CheckAllowedViewTypes(); err != nil {
+				return err
+			}
+		}
+	}
+
+	viewType, patchesViewType := blockPatch.UpdatedFields[model.ViewTypeField]
+	if blockPatch.Type == nil && blockPatch.RootID == nil && !patchesViewType {
+		return nil
+	}
+
+	patched := model.Block{ID: block.ID, RootID: block.RootID, Type: block.Type, Fields: map[string]interface{}{}}
+	if blockPatch.RootID != nil {
+		patched.RootID = *blockPatch.RootID
+	}
+	if blockPatch.Type != nil {
+		patched.Type = *blockPatch.Type
+	}
+	patched.Fields[model.ViewTypeField] = block.Fields[model.ViewTypeField]
+	if patchesViewType {
+		patched.Fields[model.ViewTypeField] = viewType
+	}
+	return a.checkAllowedBlockTypes(c, []model.Block{patched})
+}
+
+// resolveCommentMentions stores the IDs of the workspace users @mentioned in
+// comment blocks, so clients can render mentions without resolving usernames.
+// Mentions of unknown usernames are left as plain text.
+func (a *App) resolveCommentMentions(c store.Container, blocks []model.Block) error {
+	var userIDsByName map[string]string
+
+	for i := range blocks {
+		if blocks[i].Type != model.TypeComment {
+			continue
+		}
+
+		mentions := notifymentions.ExtractMentions(&blocks[i])
+		if len(mentions) == 0 {
+			continue
+		}
+
+		if userIDsByName == nil {
+			users, err := a.store.GetUsersByWorkspace(c.WorkspaceID)
+			if err != nil {
+				return err
+			}
+			userIDsByName = make(map[string]string, len(users))
+			for _, user := range users {
+				userIDsByName[user.Username] = user.ID
+			}
+		}
+
+		mentionedUserIDs := make([]string, 0, len(mentions))
+		for username := range mentions {
+			if userID, ok := userIDsByName[username]; ok {
+				mentionedUserIDs = append(mentionedUserIDs, userID)
+			}
+		}
+		if len(mentionedUserIDs) == 0 {
+			continue
+		}
+		sort.Strings(mentionedUserIDs)
+
+		if blocks[i].Fields == nil {
+			blocks[i].Fields = make(map[string]interface{})
+		}
+		blocks[i].Fields[model.MentionedUserIDsField] = mentionedUserIDs
+	}
+
+	return nil
+}
+
+// GetSubTree returns the blocks within 2 or 3 levels of blockID, ordered and
+// limited by opts.
+func (a *App) GetSubTree(c store.Container, blockID string, levels int, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	var blocks []model.Block
+	var err error
+
 	// Only 2 or 3 levels are supported for now
 	if levels >= 3 {
-		return a.store.GetSubTree3(c, blockID, model.QuerySubtreeOptions{})
+		blocks, err = a.store.GetSubTree3(c, blockID, opts)
+	} else {
+		blocks, err = a.store.GetSubTree2(c, blockID, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.setBoardsLastActivity(c, blocks); err != nil {
+		return nil, err
 	}
-	return a.store.GetSubTree2(c, blockID, model.QuerySubtreeOptions{})
+	return blocks, nil
 }
 
 func (a *App) GetAllBlocks(c store.Container) ([]model.Block, error) {
 	return a.store.GetAllBlocks(c)
 }
 
-func (a *App) DeleteBlock(c store.Container, blockID string, modifiedBy string) error {
+// DeleteBlock deletes a block and returns the IDs of the deleted blocks, so
+// that clients can drop them from their state. Deletes don't cascade, so the
+// list is the block itself, or empty if it doesn't exist.
+func (a *App) DeleteBlock(c store.Container, blockID string, modifiedBy string) ([]string, error) {
 	block, err := a.store.GetBlock(c, blockID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if block == nil {
 		// deleting non-existing block not considered an error
-		return nil
+		return []string{}, nil
 	}
 
 	err = a.store.DeleteBlock(c, blockID, modifiedBy)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if block.Type == model.TypeImage {
-		fileName, fileIDExists := block.Fields["fileId"]
-		if fileName, fileIDIsString := fileName.(string); fileIDExists && fileIDIsString {
-			filePath := filepath.Join(block.WorkspaceID, block.RootID, fileName)
-			err = a.filesBackend.RemoveFile(filePath)
-
-			if err != nil {
-				a.logger.Error("Error deleting image file",
-					mlog.String("FilePath", filePath),
-					mlog.Err(err))
-			}
-		}
-	}
+	a.removeBlockFile(block)
 
 	a.wsAdapter.BroadcastBlockDelete(c.WorkspaceID, blockID, block.ParentID)
 	a.metrics.IncrementBlocksDeleted(1)
 	go func() {
 		a.notifyBlockChanged(notify.Delete, c, block, block, modifiedBy)
 	}()
-	return nil
+	return []string{blockID}, nil
+}
+
+// ClearBoard deletes all the cards and content blocks of a board in one
+// transaction, keeping the board along with its properties and sharing. The
+// views are kept too unless clearViews is true.
+func (a *App) ClearBoard(c store.Container, boardID string, clearViews bool, modifiedBy string) (*model.ClearBoardResult, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return nil, store.NewErrNotFound(boardID)
+	}
+
+	keepTypes := []string{model.TypeView}
+	if clearViews {
+		keepTypes = nil
+	}
+	deleted, err := a.store.DeleteBoardBlocks(c, boardID, keepTypes, modifiedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deleted {
+		a.removeBlockFile(&deleted[i])
+		a.wsAdapter.BroadcastBlockDelete(c.WorkspaceID, deleted[i].ID, deleted[i].ParentID)
+	}
+	a.metrics.IncrementBlocksDeleted(len(deleted))
+	go func() {
+		for i := range deleted {
+			a.notifyBlockChanged(notify.Delete, c, &deleted[i], &deleted[i], modifiedBy)
+		}
+	}()
+	return &model.ClearBoardResult{BoardID: boardID, DeletedCount: len(deleted)}, nil
+}
+
+// removeBlockFile removes the file of a deleted image block.
+func (a *App) removeBlockFile(block *model.Block) {
+	if block.Type != model.TypeImage {
+		return
+	}
+	fileName, fileIDExists := block.Fields["fileId"]
+	if fileName, fileIDIsString := fileName.(string); fileIDExists && fileIDIsString {
+		filePath := filepath.Join(block.WorkspaceID, block.RootID, fileName)
+		if err := a.removeFile(filePath); err != nil {
+			a.logger.Error("Error deleting image file",
+				mlog.String("FilePath", filePath),
+				mlog.Err(err))
+		}
+	}
 }
 
 func (a *App) GetBlockCountsByType() (map[string]int64, error) {
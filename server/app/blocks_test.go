@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -63,6 +64,73 @@ func TestInsertBlock(t *testing.T) {
 	})
 }
 
+func TestDeleteBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("returns the deleted block", func(t *testing.T) {
+		block := &model.Block{ID: "card-id", ParentID: "board-id", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(block, nil)
+		th.Store.EXPECT().DeleteBlock(gomock.Eq(container), gomock.Eq("card-id"), gomock.Eq("user-id-1")).Return(nil)
+
+		deletedIDs, err := th.App.DeleteBlock(container, "card-id", "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"card-id"}, deletedIDs)
+	})
+
+	t.Run("block doesn't exist", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing-id")).Return(nil, nil)
+
+		deletedIDs, err := th.App.DeleteBlock(container, "missing-id", "user-id-1")
+		require.NoError(t, err)
+		require.Empty(t, deletedIDs)
+	})
+}
+
+func TestClearBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+
+	t.Run("keeps the views", func(t *testing.T) {
+		deleted := []model.Block{
+			{ID: "card-id", ParentID: "board-id", RootID: "board-id", Type: model.TypeCard},
+			{ID: "text-id", ParentID: "card-id", RootID: "board-id", Type: model.TypeText},
+		}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().DeleteBoardBlocks(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq([]string{model.TypeView}), gomock.Eq("user-id-1")).Return(deleted, nil)
+
+		result, err := th.App.ClearBoard(container, "board-id", false, "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, &model.ClearBoardResult{BoardID: "board-id", DeletedCount: 2}, result)
+	})
+
+	t.Run("clears the views", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().DeleteBoardBlocks(gomock.Eq(container), gomock.Eq("board-id"), gomock.Nil(), gomock.Eq("user-id-1")).Return([]model.Block{}, nil)
+
+		result, err := th.App.ClearBoard(container, "board-id", true, "user-id-1")
+		require.NoError(t, err)
+		require.Zero(t, result.DeletedCount)
+	})
+
+	t.Run("not a board", func(t *testing.T) {
+		card := &model.Block{ID: "card-id", ParentID: "board-id", RootID: "board-id", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+
+		_, err := th.App.ClearBoard(container, "card-id", false, "user-id-1")
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
+
 func TestPatchBlocks(t *testing.T) {
 	th, tearDown := SetupTestHelper(t)
 	defer tearDown()
@@ -84,3 +152,549 @@ func TestPatchBlocks(t *testing.T) {
 		require.Error(t, err, "error")
 	})
 }
+
+func TestInsertBlocksResolvesCommentMentions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	t.Run("known and unknown mentions", func(t *testing.T) {
+		blocks := []model.Block{{ID: "comment-id", RootID: "board-id", Type: model.TypeComment, Title: "Hi @user1 and @nobody"}}
+		users := []*model.User{{ID: "user-id-1", Username: "user1"}, {ID: "user-id-2", Username: "user2"}}
+		th.Store.EXPECT().GetUsersByWorkspace("0").Return(users, nil)
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		result, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, []string{"user-id-1"}, result[0].Fields[model.MentionedUserIDsField])
+		require.Equal(t, "Hi @user1 and @nobody", result[0].Title)
+	})
+
+	t.Run("no mentions skips user lookup", func(t *testing.T) {
+		blocks := []model.Block{{ID: "comment-id", RootID: "board-id", Type: model.TypeComment, Title: "Hi"}}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		result, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+		require.NotContains(t, result[0].Fields, model.MentionedUserIDsField)
+	})
+}
+
+func TestInsertBlocksBlockLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+	th.App.config.MaxBlocksPerBoard = 3
+
+	t.Run("within limit", func(t *testing.T) {
+		blocks := []model.Block{{ID: "block-id-1", RootID: "board-id", Type: model.TypeCard}}
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(int64(2), nil)
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("exceeds limit", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "block-id-1", RootID: "board-id", Type: model.TypeCard},
+			{ID: "block-id-2", RootID: "board-id", Type: model.TypeCard},
+		}
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(int64(2), nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("block-id-1")).Return(nil, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("block-id-2")).Return(nil, nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.Error(t, err)
+		require.True(t, IsErrBlockLimitExceeded(err))
+	})
+
+	t.Run("updates at the limit", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "block-id-1", RootID: "board-id", Type: model.TypeCard},
+			{ID: "block-id-2", RootID: "board-id", Type: model.TypeCard},
+		}
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(int64(3), nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("block-id-1")).Return(&blocks[0], nil).AnyTimes()
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("block-id-2")).Return(&blocks[1], nil).AnyTimes()
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+}
+
+func TestInsertBlocksIDConflict(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	// newBatch returns a card with a text block that the card references
+	newBatch := func() []model.Block {
+		return []model.Block{
+			{
+				ID:     "card-id",
+				RootID: "board-id",
+				Type:   model.TypeCard,
+				Fields: map[string]interface{}{"contentOrder": []interface{}{"text-id"}},
+			},
+			{ID: "text-id", ParentID: "card-id", RootID: "board-id", Type: model.TypeText},
+		}
+	}
+
+	t.Run("conflict on a referenced block", func(t *testing.T) {
+		blocks := newBatch()
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).
+			Return(st.NewErrConflict("text-id"))
+		th.Store.EXPECT().InsertBlock(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).DoAndReturn(
+			func(_ st.Container, block *model.Block, _ string) error {
+				if block.ID == "text-id" {
+					return st.NewErrConflict(block.ID)
+				}
+				return nil
+			},
+		).Times(4)
+
+		newBlocks, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+		require.Equal(t, "card-id", newBlocks[0].ID)
+		require.NotEqual(t, "text-id", newBlocks[1].ID)
+		require.Equal(t, []interface{}{newBlocks[1].ID}, newBlocks[0].Fields["contentOrder"])
+	})
+
+	t.Run("conflict retries exhausted", func(t *testing.T) {
+		blocks := newBatch()
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).
+			Return(st.NewErrConflict("card-id"))
+		th.Store.EXPECT().InsertBlock(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).
+			Return(st.NewErrConflict("card-id")).Times(maxIDConflictRetries + 1)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, st.IsErrConflict(err))
+	})
+}
+
+func TestGetBlocksBoardLastActivity(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	boards := []model.Block{
+		{ID: "board-id-1", RootID: "board-id-1", Type: model.TypeBoard},
+		{ID: "board-id-2", RootID: "board-id-2", Type: model.TypeBoard},
+	}
+	th.Store.EXPECT().GetBlocksWithTypes(gomock.Eq(container), gomock.Eq([]string{model.TypeBoard})).Return(boards, nil)
+	th.Store.EXPECT().GetLastActivityByRootIDs(gomock.Eq(container), gomock.Eq([]string{"board-id-1", "board-id-2"})).
+		Return(map[string]int64{"board-id-1": 100}, nil)
+
+	blocks, err := th.App.GetBlocks(container, "", []string{model.TypeBoard})
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.EqualValues(t, 100, blocks[0].LastActivityAt)
+	require.Zero(t, blocks[1].LastActivityAt)
+}
+
+func TestPatchBlockPinned(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.PinnedField: true}}
+
+	t.Run("pin a card", func(t *testing.T) {
+		card := &model.Block{ID: "card-id", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+
+		_, err := th.App.PatchBlock(container, "card-id", patch, "user-id-1")
+		require.ErrorIs(t, err, model.ErrBlockNotPinnable)
+	})
+
+	t.Run("pin a comment", func(t *testing.T) {
+		comment := &model.Block{ID: "comment-id", Type: model.TypeComment}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("comment-id")).Return(comment, nil).Times(2)
+		th.Store.EXPECT().PatchBlock(gomock.Eq(container), gomock.Eq("comment-id"), gomock.Eq(patch), gomock.Eq("user-id-1")).Return(nil)
+
+		block, err := th.App.PatchBlock(container, "comment-id", patch, "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, "comment-id", block.ID)
+	})
+}
+
+func TestInsertBlocksBatches(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+	th.App.config.InsertBlocksBatchSize = 2
+	th.App.config.MaxBlocksPerInsert = 4
+
+	newBlocks := func(count int) []model.Block {
+		blocks := make([]model.Block, count)
+		for i := range blocks {
+			blocks[i] = model.Block{ID: fmt.Sprintf("block-id-%d", i), RootID: "board-id", Type: model.TypeCard}
+		}
+		return blocks
+	}
+
+	t.Run("inserted in batches", func(t *testing.T) {
+		batchSizes := []int{}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).DoAndReturn(
+			func(_ st.Container, blocks []model.Block, _ string) error {
+				batchSizes = append(batchSizes, len(blocks))
+				return nil
+			},
+		).Times(2)
+
+		result, err := th.App.InsertBlocks(container, newBlocks(3), "user-id-1", false)
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, []int{2, 1}, batchSizes)
+	})
+
+	t.Run("failed batch", func(t *testing.T) {
+		gomock.InOrder(
+			th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil),
+			th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(blockError{"error"}),
+		)
+
+		_, err := th.App.InsertBlocks(container, newBlocks(4), "user-id-1", false)
+		var batchErr ErrInsertBatchFailed
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, 2, batchErr.Inserted)
+		require.Equal(t, 4, batchErr.Total)
+	})
+
+	t.Run("too many blocks", func(t *testing.T) {
+		_, err := th.App.InsertBlocks(container, newBlocks(5), "user-id-1", false)
+		require.True(t, IsErrTooManyBlocks(err))
+	})
+}
+
+func TestBlocksWithMissingBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing-board-id")).Return(nil, nil).AnyTimes()
+
+	t.Run("insert into a missing board", func(t *testing.T) {
+		blocks := []model.Block{{ID: "card-id", RootID: "missing-board-id", Type: model.TypeCard}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, st.IsErrNotFound(err))
+	})
+
+	t.Run("insert along with the board", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "missing-board-id", RootID: "missing-board-id", Type: model.TypeBoard},
+			{ID: "card-id", RootID: "missing-board-id", Type: model.TypeCard},
+		}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("patch a missing block", func(t *testing.T) {
+		title := "New title"
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing-card-id")).Return(nil, nil).Times(2)
+
+		_, err := th.App.PatchBlock(container, "missing-card-id", &model.BlockPatch{Title: &title}, "user-id-1")
+		require.True(t, st.IsErrNotFound(err))
+
+		err = th.App.PatchBlocks(container, &model.BlockPatchBatch{
+			BlockIDs:     []string{"missing-card-id"},
+			BlockPatches: []model.BlockPatch{{Title: &title}},
+		}, "user-id-1")
+		require.True(t, st.IsErrNotFound(err))
+	})
+
+	t.Run("move a card to a missing board", func(t *testing.T) {
+		card := &model.Block{ID: "card-id", RootID: "board-id", Type: model.TypeCard}
+		rootID := "missing-board-id"
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+
+		_, err := th.App.PatchBlock(container, "card-id", &model.BlockPatch{RootID: &rootID}, "user-id-1")
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
+
+func TestInsertBlocksAllowedBlockTypes(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{model.AllowedBlockTypesField: []interface{}{"card", "text"}},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	t.Run("allowed types", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "card-id", RootID: "board-id", Type: model.TypeCard},
+			{ID: "text-id", RootID: "board-id", Type: model.TypeText},
+		}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("type not allowed", func(t *testing.T) {
+		blocks := []model.Block{{ID: "image-id", RootID: "board-id", Type: model.TypeImage}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, IsErrBlockTypeNotAllowed(err))
+	})
+
+	t.Run("board inserted with the blocks", func(t *testing.T) {
+		blocks := []model.Block{
+			{
+				ID:     "new-board-id",
+				RootID: "new-board-id",
+				Type:   model.TypeBoard,
+				Fields: map[string]interface{}{model.AllowedBlockTypesField: []interface{}{"card"}},
+			},
+			{ID: "image-id", RootID: "new-board-id", Type: model.TypeImage},
+		}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, IsErrBlockTypeNotAllowed(err))
+	})
+
+	t.Run("patch to a type not allowed", func(t *testing.T) {
+		text := &model.Block{ID: "text-id", RootID: "board-id", Type: model.TypeText}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("text-id")).Return(text, nil)
+
+		imageType := model.BlockType(model.TypeImage)
+		_, err := th.App.PatchBlock(container, "text-id", &model.BlockPatch{Type: &imageType}, "user-id-1")
+		require.True(t, IsErrBlockTypeNotAllowed(err))
+	})
+}
+
+func TestInsertBlocksRequiredFields(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	t.Run("required field missing", func(t *testing.T) {
+		blocks := []model.Block{{ID: "image-id", RootID: "board-id", Type: model.TypeImage}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.Equal(t, model.ErrMissingBlockField{BlockID: "image-id", Field: "fileId"}, err)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		blocks := []model.Block{{ID: "block-id", RootID: "board-id", Type: "timeline"}}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown type rejected", func(t *testing.T) {
+		th.App.config.RejectUnknownBlockTypes = true
+		defer func() { th.App.config.RejectUnknownBlockTypes = false }()
+		blocks := []model.Block{{ID: "block-id", RootID: "board-id", Type: "timeline"}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, model.IsErrInvalidBlockType(err))
+	})
+}
+
+func TestRemapImportedBlockTypes(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.ImportBlockTypeMappings = map[string]map[string]string{"1": {"rule": "divider"}}
+	defer func() { th.App.config.ImportBlockTypeMappings = nil }()
+
+	blocks := []model.Block{
+		{ID: "rule-id", Schema: 1, Type: "rule"},
+		{ID: "timeline-id", Schema: 1, Type: "timeline"},
+	}
+	unknown := th.App.RemapImportedBlockTypes(blocks)
+	require.Equal(t, model.BlockType(model.TypeDivider), blocks[0].Type)
+	require.Equal(t, []string{"timeline"}, unknown)
+
+	t.Run("invalid schema version", func(t *testing.T) {
+		th.App.config.ImportBlockTypeMappings = map[string]map[string]string{"v1": {"rule": "divider"}}
+		blocks := []model.Block{{ID: "rule-id", Schema: 1, Type: "rule"}}
+		require.Equal(t, []string{"rule"}, th.App.RemapImportedBlockTypes(blocks))
+	})
+}
+
+func TestInsertBlocksDefaultViewSort(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties":       []interface{}{map[string]interface{}{"id": "priority", "type": "select"}},
+			model.DefaultSortField: map[string]interface{}{"propertyId": "priority", "reversed": true},
+		},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	t.Run("view without sort", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{model.ViewTypeField: "table"}},
+		}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		inserted, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"propertyId": "priority", "reversed": true},
+		}, inserted[0].Fields[model.ViewSortOptionsField])
+	})
+
+	t.Run("view with a sort", func(t *testing.T) {
+		sortOptions := []interface{}{map[string]interface{}{"propertyId": model.TitlePropertyID, "reversed": false}}
+		blocks := []model.Block{{
+			ID:     "view-id",
+			RootID: "board-id",
+			Type:   model.TypeView,
+			Fields: map[string]interface{}{model.ViewTypeField: "table", model.ViewSortOptionsField: sortOptions},
+		}}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		inserted, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+		require.Equal(t, sortOptions, inserted[0].Fields[model.ViewSortOptionsField])
+	})
+
+	t.Run("board inserted with an unknown default sort", func(t *testing.T) {
+		blocks := []model.Block{{
+			ID:     "new-board-id",
+			RootID: "new-board-id",
+			Type:   model.TypeBoard,
+			Fields: map[string]interface{}{model.DefaultSortField: map[string]interface{}{"propertyId": "priority"}},
+		}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.ErrorIs(t, err, model.ErrInvalidDefaultSort)
+	})
+
+	t.Run("patch to an unknown default sort", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			model.DefaultSortField: map[string]interface{}{"propertyId": "estimate"},
+		}}
+		_, err := th.App.PatchBlock(container, "board-id", patch, "user-id-1")
+		require.ErrorIs(t, err, model.ErrInvalidDefaultSort)
+	})
+}
+
+func TestInsertBlocksAllowedViewTypes(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{model.AllowedViewTypesField: []interface{}{"table"}},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	t.Run("allowed view type", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{model.ViewTypeField: "table"}},
+		}
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("view type not allowed", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{model.ViewTypeField: "gallery"}},
+		}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, IsErrViewTypeNotAllowed(err))
+	})
+
+	t.Run("board view type by default", func(t *testing.T) {
+		blocks := []model.Block{{ID: "view-id", RootID: "board-id", Type: model.TypeView}}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.True(t, IsErrViewTypeNotAllowed(err))
+	})
+
+	t.Run("unknown allowed view type", func(t *testing.T) {
+		blocks := []model.Block{
+			{
+				ID:     "new-board-id",
+				RootID: "new-board-id",
+				Type:   model.TypeBoard,
+				Fields: map[string]interface{}{model.AllowedViewTypesField: []interface{}{"timeline"}},
+			},
+		}
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.ErrorIs(t, err, model.ErrUnknownViewType)
+	})
+
+	t.Run("patch to a view type not allowed", func(t *testing.T) {
+		view := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{model.ViewTypeField: "table"}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(view, nil)
+
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.ViewTypeField: "calendar"}}
+		_, err := th.App.PatchBlock(container, "view-id", patch, "user-id-1")
+		require.True(t, IsErrViewTypeNotAllowed(err))
+	})
+
+	t.Run("patch to an unknown allowed view type", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.AllowedViewTypesField: []interface{}{"timeline"}}}
+		_, err := th.App.PatchBlock(container, "board-id", patch, "user-id-1")
+		require.ErrorIs(t, err, model.ErrUnknownViewType)
+	})
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// AcknowledgeBoard records that a user has reviewed a board as of its last
+// activity. The acknowledgment is outdated by any later activity.
+func (a *App) AcknowledgeBoard(c store.Container, boardID, userID string) (*model.BoardAcknowledgment, error) {
+	if _, err := a.getBoard(c, boardID); err != nil {
+		return nil, err
+	}
+
+	lastActivityAt, err := a.store.GetLastActivityWithRootID(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ack := &model.BoardAcknowledgment{
+		BoardID:             boardID,
+		UserID:              userID,
+		BoardLastActivityAt: lastActivityAt,
+	}
+	if err := a.store.UpsertBoardAcknowledgment(c, ack); err != nil {
+		return nil, err
+	}
+	return ack, nil
+}
+
+// GetBoardAcknowledgments returns the acknowledgments of a board since its
+// last activity, and the workspace users who haven't acknowledged it since.
+func (a *App) GetBoardAcknowledgments(c store.Container, boardID string) (*model.BoardAcknowledgments, error) {
+	if _, err := a.getBoard(c, boardID); err != nil {
+		return nil, err
+	}
+
+	lastActivityAt, err := a.store.GetLastActivityWithRootID(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := a.store.GetBoardAcknowledgments(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := a.store.GetUsersByWorkspace(c.WorkspaceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	result := &model.BoardAcknowledgments{
+		BoardID:        boardID,
+		LastActivityAt: lastActivityAt,
+		Acknowledged:   []*model.BoardAcknowledgment{},
+		PendingUserIDs: []string{},
+	}
+	acknowledged := map[string]bool{}
+	for _, ack := range acks {
+		if ack.IsCurrent(lastActivityAt) {
+			result.Acknowledged = append(result.Acknowledged, ack)
+			acknowledged[ack.UserID] = true
+		}
+	}
+	for _, user := range users {
+		if !acknowledged[user.ID] && !user.IsBot && user.DeleteAt == 0 {
+			result.PendingUserIDs = append(result.PendingUserIDs, user.ID)
+		}
+	}
+	sort.Strings(result.PendingUserIDs)
+	return result, nil
+}
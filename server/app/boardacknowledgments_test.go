@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestGetBoardAcknowledgments(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{ID: "board-id", RootID: "board-id", Type: model.TypeBoard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetLastActivityWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(int64(200), nil).AnyTimes()
+
+	t.Run("acknowledge as of the last activity", func(t *testing.T) {
+		th.Store.EXPECT().UpsertBoardAcknowledgment(gomock.Eq(container), gomock.Any()).Return(nil)
+
+		ack, err := th.App.AcknowledgeBoard(container, "board-id", "user-1")
+		require.NoError(t, err)
+		require.Equal(t, "user-1", ack.UserID)
+		require.Equal(t, int64(200), ack.BoardLastActivityAt)
+	})
+
+	t.Run("outdated acknowledgments are pending", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardAcknowledgments(gomock.Eq(container), gomock.Eq("board-id")).Return([]*model.BoardAcknowledgment{
+			{BoardID: "board-id", UserID: "user-1", BoardLastActivityAt: 200},
+			{BoardID: "board-id", UserID: "user-2", BoardLastActivityAt: 100},
+		}, nil)
+		th.Store.EXPECT().GetUsersByWorkspace("0").Return([]*model.User{
+			{ID: "user-3"},
+			{ID: "user-2"},
+			{ID: "user-1"},
+			{ID: "bot", IsBot: true},
+			{ID: "deleted", DeleteAt: 1},
+		}, nil)
+
+		acks, err := th.App.GetBoardAcknowledgments(container, "board-id")
+		require.NoError(t, err)
+		require.Equal(t, int64(200), acks.LastActivityAt)
+		require.Len(t, acks.Acknowledged, 1)
+		require.Equal(t, "user-1", acks.Acknowledged[0].UserID)
+		require.Equal(t, []string{"user-2", "user-3"}, acks.PendingUserIDs)
+	})
+}
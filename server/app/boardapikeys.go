@@ -0,0 +1,67 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// CreateBoardAPIKey creates a read-only API key for a board. The returned
+// key is the only one with its token set.
+func (a *App) CreateBoardAPIKey(c store.Container, boardID, name, userID string) (*model.BoardAPIKey, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return nil, store.NewErrNotFound(boardID)
+	}
+
+	key := &model.BoardAPIKey{
+		ID:        utils.NewID(utils.IDTypeNone),
+		BoardID:   boardID,
+		Name:      name,
+		Token:     utils.NewID(utils.IDTypeToken),
+		CreatedBy: userID,
+		CreateAt:  utils.GetMillis(),
+	}
+	if err := a.store.CreateBoardAPIKey(c, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetBoardAPIKeys returns the API keys of a board, without their tokens.
+func (a *App) GetBoardAPIKeys(c store.Container, boardID string) ([]*model.BoardAPIKey, error) {
+	keys, err := a.store.GetBoardAPIKeys(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		key.Token = ""
+	}
+	return keys, nil
+}
+
+// DeleteBoardAPIKey revokes an API key of a board.
+func (a *App) DeleteBoardAPIKey(c store.Container, boardID, keyID string) error {
+	return a.store.DeleteBoardAPIKey(c, boardID, keyID)
+}
+
+// GetBoardAPIKeyByToken returns the board API key with the token.
+func (a *App) GetBoardAPIKeyByToken(token string) (*model.BoardAPIKey, error) {
+	return a.store.GetBoardAPIKeyByToken(token)
+}
+
+// BoardAPIKeyAllowsBlock returns true if the block is in the board of the
+// API key.
+func (a *App) BoardAPIKeyAllowsBlock(c store.Container, key *model.BoardAPIKey, blockID string) (bool, error) {
+	if key.WorkspaceID != c.WorkspaceID {
+		return false, nil
+	}
+	rootID, err := a.store.GetRootID(c, blockID)
+	if err != nil {
+		return false, err
+	}
+	return rootID == key.BoardID, nil
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBoardAPIKey(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("creates a key with a token", func(t *testing.T) {
+		board := &model.Block{ID: "board-id", Type: model.TypeBoard}
+		th.Store.EXPECT().GetBlock(container, "board-id").Return(board, nil)
+		th.Store.EXPECT().CreateBoardAPIKey(container, gomock.Any()).Return(nil)
+
+		key, err := th.App.CreateBoardAPIKey(container, "board-id", "dashboard", "user-id")
+		require.NoError(t, err)
+		require.NotEmpty(t, key.ID)
+		require.NotEmpty(t, key.Token)
+		require.Equal(t, "board-id", key.BoardID)
+		require.Equal(t, "dashboard", key.Name)
+		require.Equal(t, "user-id", key.CreatedBy)
+	})
+
+	t.Run("not a board", func(t *testing.T) {
+		card := &model.Block{ID: "card-id", Type: model.TypeCard}
+		th.Store.EXPECT().GetBlock(container, "card-id").Return(card, nil)
+
+		_, err := th.App.CreateBoardAPIKey(container, "card-id", "dashboard", "user-id")
+		require.True(t, store.IsErrNotFound(err))
+	})
+}
+
+func TestGetBoardAPIKeys(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	keys := []*model.BoardAPIKey{{ID: "key-id", BoardID: "board-id", Token: "token"}}
+	th.Store.EXPECT().GetBoardAPIKeys(container, "board-id").Return(keys, nil)
+
+	result, err := th.App.GetBoardAPIKeys(container, "board-id")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "key-id", result[0].ID)
+	require.Empty(t, result[0].Token)
+}
+
+func TestBoardAPIKeyAllowsBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+	key := &model.BoardAPIKey{ID: "key-id", BoardID: "board-id", WorkspaceID: "0"}
+
+	t.Run("block of the board", func(t *testing.T) {
+		th.Store.EXPECT().GetRootID(container, "card-id").Return("board-id", nil)
+
+		allowed, err := th.App.BoardAPIKeyAllowsBlock(container, key, "card-id")
+		require.NoError(t, err)
+		require.True(t, allowed)
+	})
+
+	t.Run("block of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetRootID(container, "other-card-id").Return("other-board-id", nil)
+
+		allowed, err := th.App.BoardAPIKeyAllowsBlock(container, key, "other-card-id")
+		require.NoError(t, err)
+		require.False(t, allowed)
+	})
+
+	t.Run("another workspace", func(t *testing.T) {
+		allowed, err := th.App.BoardAPIKeyAllowsBlock(store.Container{WorkspaceID: "other"}, key, "card-id")
+		require.NoError(t, err)
+		require.False(t, allowed)
+	})
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// minBoardArchiveNoticeDays is the minimum number of days a board is given
+// notice of its archival for.
+const minBoardArchiveNoticeDays = 1
+
+// ArchiveInactiveBoards archives the boards of all the workspaces that have
+// had no activity for the configured number of days. A board is first given
+// notice of its archival, which is notified like any other change of the
+// board, and is archived if it is still inactive the configured number of
+// notice days later, one day at least. Templates and the boards opted out
+// are left alone.
+func (a *App) ArchiveInactiveBoards() error {
+	if a.config.BoardArchiveAfterDays <= 0 {
+		return nil
+	}
+
+	noticeDays := a.config.BoardArchiveNoticeDays
+	if noticeDays < minBoardArchiveNoticeDays {
+		noticeDays = minBoardArchiveNoticeDays
+	}
+
+	now := utils.GetMillis()
+	inactiveBefore := now - daysToMillis(a.config.BoardArchiveAfterDays)
+	noticedBefore := now - daysToMillis(noticeDays)
+	before := inactiveBefore
+	if noticedBefore > before {
+		before = noticedBefore
+	}
+
+	boards, err := a.store.GetInactiveBoards(before)
+	if err != nil {
+		return err
+	}
+
+	for i := range boards {
+		board := &boards[i]
+		if board.IsTemplate() || board.IsArchived() || board.AutoArchiveDisabled() {
+			continue
+		}
+
+		patch := &model.BlockPatch{}
+		switch {
+		case isNoticedForArchival(board) && board.UpdateAt < noticedBefore:
+			patch.UpdatedFields = map[string]interface{}{model.ArchivedAtField: now}
+			patch.DeletedFields = []string{model.ArchiveNoticeAtField}
+		case !isNoticedForArchival(board) && board.LastActivityAt < inactiveBefore:
+			patch.UpdatedFields = map[string]interface{}{model.ArchiveNoticeAtField: now}
+		default:
+			continue
+		}
+
+		c := store.Container{WorkspaceID: board.WorkspaceID}
+		if _, err := a.PatchBlock(c, board.ID, patch, model.SystemUserID); err != nil {
+			a.logger.Error("Cannot archive inactive board",
+				mlog.String("workspaceID", board.WorkspaceID),
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+// isNoticedForArchival returns true if the board was given notice of its
+// archival and has had no activity since, the notice being the last change
+// of the board.
+func isNoticedForArchival(board *model.Block) bool {
+	return board.ArchiveNoticeAt() > 0 &&
+		board.ModifiedBy == model.SystemUserID &&
+		board.LastActivityAt <= board.UpdateAt
+}
+
+func daysToMillis(days int) int64 {
+	return int64(days) * int64(24*time.Hour/time.Millisecond)
+}
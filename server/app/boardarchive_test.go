@@ -0,0 +1,115 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestArchiveInactiveBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	day := daysToMillis(1)
+	now := utils.GetMillis()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.BoardArchiveAfterDays = 0
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+	})
+
+	th.App.config.BoardArchiveAfterDays = 30
+	th.App.config.BoardArchiveNoticeDays = 7
+	defer func() { th.App.config.BoardArchiveAfterDays = 0 }()
+
+	patchedBoard := func(board *model.Block, patch *model.BlockPatch) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq(board.ID)).Return(board, nil).Times(2)
+		th.Store.EXPECT().PatchBlock(gomock.Eq(container), gomock.Eq(board.ID), gomock.Any(), gomock.Eq(model.SystemUserID)).
+			DoAndReturn(func(_ st.Container, _ string, p *model.BlockPatch, _ string) error {
+				*patch = *p
+				return nil
+			})
+	}
+
+	t.Run("give notice", func(t *testing.T) {
+		board := model.Block{ID: "board-id", WorkspaceID: "0", Type: model.TypeBoard, UpdateAt: now - 40*day, LastActivityAt: now - 40*day}
+		th.Store.EXPECT().GetInactiveBoards(gomock.Any()).Return([]model.Block{board}, nil)
+		var patch model.BlockPatch
+		patchedBoard(&board, &patch)
+
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+		require.Contains(t, patch.UpdatedFields, model.ArchiveNoticeAtField)
+		require.NotContains(t, patch.UpdatedFields, model.ArchivedAtField)
+	})
+
+	t.Run("archive after notice", func(t *testing.T) {
+		board := model.Block{
+			ID:             "board-id",
+			WorkspaceID:    "0",
+			Type:           model.TypeBoard,
+			Fields:         map[string]interface{}{model.ArchiveNoticeAtField: float64(now - 8*day)},
+			ModifiedBy:     model.SystemUserID,
+			UpdateAt:       now - 8*day,
+			LastActivityAt: now - 8*day,
+		}
+		th.Store.EXPECT().GetInactiveBoards(gomock.Any()).Return([]model.Block{board}, nil)
+		var patch model.BlockPatch
+		patchedBoard(&board, &patch)
+
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+		require.Contains(t, patch.UpdatedFields, model.ArchivedAtField)
+		require.Equal(t, []string{model.ArchiveNoticeAtField}, patch.DeletedFields)
+	})
+
+	t.Run("notice not expired", func(t *testing.T) {
+		board := model.Block{
+			ID:             "board-id",
+			WorkspaceID:    "0",
+			Type:           model.TypeBoard,
+			Fields:         map[string]interface{}{model.ArchiveNoticeAtField: float64(now - 2*day)},
+			ModifiedBy:     model.SystemUserID,
+			UpdateAt:       now - 2*day,
+			LastActivityAt: now - 2*day,
+		}
+		th.Store.EXPECT().GetInactiveBoards(gomock.Any()).Return([]model.Block{board}, nil)
+
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+	})
+
+	t.Run("notice of at least a day", func(t *testing.T) {
+		th.App.config.BoardArchiveNoticeDays = 0
+		defer func() { th.App.config.BoardArchiveNoticeDays = 7 }()
+
+		board := model.Block{
+			ID:             "board-id",
+			WorkspaceID:    "0",
+			Type:           model.TypeBoard,
+			Fields:         map[string]interface{}{model.ArchiveNoticeAtField: float64(now - day/2)},
+			ModifiedBy:     model.SystemUserID,
+			UpdateAt:       now - day/2,
+			LastActivityAt: now - 40*day,
+		}
+		th.Store.EXPECT().GetInactiveBoards(gomock.Any()).Return([]model.Block{board}, nil)
+
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+	})
+
+	t.Run("skipped boards", func(t *testing.T) {
+		boards := []model.Block{
+			{ID: "template-id", Type: model.TypeBoard, Fields: map[string]interface{}{"isTemplate": true}, LastActivityAt: now - 40*day},
+			{ID: "archived-id", Type: model.TypeBoard, Fields: map[string]interface{}{model.ArchivedAtField: float64(now - day)}, LastActivityAt: now - 40*day},
+			{ID: "opted-out-id", Type: model.TypeBoard, Fields: map[string]interface{}{model.AutoArchiveDisabledField: true}, LastActivityAt: now - 40*day},
+		}
+		th.Store.EXPECT().GetInactiveBoards(gomock.Any()).Return(boards, nil)
+
+		require.NoError(t, th.App.ArchiveInactiveBoards())
+	})
+}
@@ -0,0 +1,322 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	propTypeSelect      = "select"
+	propTypeMultiSelect = "multiSelect"
+	propTypePerson      = "person"
+	propTypeDate        = "date"
+	propTypeNumber      = "number"
+	propTypeCreatedTime = "createdTime"
+	propTypeUpdatedTime = "updatedTime"
+)
+
+// getBoard returns the board block with the specified ID, or a not found
+// error if there is no such block or it isn't a board.
+func (a *App) getBoard(c store.Container, boardID string) (*model.Block, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return nil, store.NewErrNotFound(boardID)
+	}
+	return board, nil
+}
+
+// getCard returns the card block with the specified ID, or a not found
+// error if there is no such card in the board.
+func (a *App) getCard(c store.Container, boardID, cardID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(c, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || card.Type != model.TypeCard || card.RootID != boardID {
+		return nil, store.NewErrNotFound(cardID)
+	}
+	return card, nil
+}
+
+// GetBoardStats summarizes the cards of a board. Cards are grouped by the
+// select property groupPropertyID, or by the first select property of the
+// board if it is empty.
+func (a *App) GetBoardStats(c store.Container, boardID string, groupPropertyID string) (*model.BoardStats, error) {
+	board, err := a.getBoard(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupPropertyID == "" {
+		groupPropertyID = defaultGroupPropertyID(schema)
+	}
+
+	opts := model.CardStatsOptions{
+		GroupPropertyID: groupPropertyID,
+		Now:             utils.GetMillis(),
+	}
+	for propID, prop := range schema {
+		switch prop.Type {
+		case propTypePerson:
+			opts.PersonPropertyIDs = append(opts.PersonPropertyIDs, propID)
+		case propTypeDate:
+			opts.DatePropertyIDs = append(opts.DatePropertyIDs, propID)
+		}
+	}
+	sort.Strings(opts.PersonPropertyIDs)
+	sort.Strings(opts.DatePropertyIDs)
+
+	stats, err := a.store.GetBoardCardStats(c, boardID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lastActivity, err := a.store.GetLastActivityWithRootID(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	stats.LastActivityAt = lastActivity
+
+	return stats, nil
+}
+
+// GetPropertyOptionUsage counts the cards of a board set to an option of a
+// select or multi-select property.
+func (a *App) GetPropertyOptionUsage(c store.Container, boardID, propertyID, optionID string) (*model.PropertyOptionUsage, error) {
+	board, err := a.getBoard(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	prop, ok := schema[propertyID]
+	if !ok || (prop.Type != propTypeSelect && prop.Type != propTypeMultiSelect) {
+		return nil, store.NewErrNotFound(propertyID)
+	}
+	if _, ok := prop.Options[optionID]; !ok {
+		return nil, store.NewErrNotFound(optionID)
+	}
+
+	cards, err := a.store.GetBlocksWithParentAndType(c, boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &model.PropertyOptionUsage{
+		BoardID:    boardID,
+		PropertyID: propertyID,
+		OptionID:   optionID,
+	}
+	for _, card := range cards {
+		props, _ := card.Fields["properties"].(map[string]interface{})
+		switch value := props[propertyID].(type) {
+		case string:
+			if value == optionID {
+				usage.CardCount++
+			}
+		case []interface{}:
+			for _, v := range value {
+				if v == optionID {
+					usage.CardCount++
+					break
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// GetBoardPresence returns the users currently viewing a board.
+func (a *App) GetBoardPresence(c store.Container, boardID string) (*model.BoardPresence, error) {
+	if _, err := a.getBoard(c, boardID); err != nil {
+		return nil, err
+	}
+
+	return &model.BoardPresence{
+		BoardID: boardID,
+		UserIDs: a.wsAdapter.GetBoardPresence(boardID),
+	}, nil
+}
+
+// GetBoardsOverCardCountWarning returns the IDs of the boards of the cards in
+// blocks that contain more cards than the configured CardCountWarningThreshold.
+// Boards with fewer blocks than the threshold can't be over it, so the cards
+// are only counted for the others.
+func (a *App) GetBoardsOverCardCountWarning(c store.Container, blocks []model.Block) ([]string, error) {
+	threshold := int64(a.config.CardCountWarningThreshold)
+	if threshold <= 0 {
+		return nil, nil
+	}
+
+	rootIDs := make(map[string]bool)
+	for _, block := range blocks {
+		if block.Type == model.TypeCard {
+			rootIDs[block.RootID] = true
+		}
+	}
+
+	boardIDs := []string{}
+	for rootID := range rootIDs {
+		blockCount, err := a.store.GetBlockCountWithRootID(c, rootID)
+		if err != nil {
+			return nil, err
+		}
+		if blockCount <= threshold {
+			continue
+		}
+
+		cardCount, err := a.store.GetBlockCountWithRootIDAndType(c, rootID, model.TypeCard)
+		if err != nil {
+			return nil, err
+		}
+		if cardCount > threshold {
+			boardIDs = append(boardIDs, rootID)
+		}
+	}
+	sort.Strings(boardIDs)
+
+	return boardIDs, nil
+}
+
+// MoveCards sets the value of a select property on the cards of a board,
+// in a single transaction, and returns the updated cards.
+func (a *App) MoveCards(c store.Container, boardID string, move model.MoveCardsRequest, modifiedByID string) ([]model.Block, error) {
+	board, err := a.getBoard(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	prop, ok := schema[move.PropertyID]
+	if !ok || prop.Type != propTypeSelect {
+		return nil, model.ErrInvalidProperty
+	}
+	if _, ok := prop.Options[move.Value]; !ok && move.Value != "" {
+		return nil, model.ErrInvalidPropertyValue
+	}
+
+	patches := &model.BlockPatchBatch{}
+	seen := make(map[string]bool, len(move.CardIDs))
+	for _, cardID := range move.CardIDs {
+		if seen[cardID] {
+			continue
+		}
+		seen[cardID] = true
+
+		card, err := a.getCard(c, boardID, cardID)
+		if err != nil {
+			return nil, err
+		}
+
+		// properties is patched as a whole, so keep the other values
+		props := map[string]interface{}{}
+		if oldProps, ok := card.Fields["properties"].(map[string]interface{}); ok {
+			for id, value := range oldProps {
+				props[id] = value
+			}
+		}
+		if move.Value == "" {
+			delete(props, move.PropertyID)
+		} else {
+			props[move.PropertyID] = move.Value
+		}
+
+		patches.BlockIDs = append(patches.BlockIDs, cardID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"properties": props},
+		})
+	}
+
+	if err := a.PatchBlocks(c, patches, modifiedByID); err != nil {
+		return nil, err
+	}
+
+	cards := make([]model.Block, 0, len(patches.BlockIDs))
+	for _, cardID := range patches.BlockIDs {
+		card, err := a.store.GetBlock(c, cardID)
+		if err != nil {
+			return nil, err
+		}
+		if card != nil {
+			cards = append(cards, *card)
+		}
+	}
+	return cards, nil
+}
+
+// SetViewCardOrder stores the manual order of the cards of the given groups
+// on a view of the board, keeping the order of the other groups, and returns
+// the updated view.
+func (a *App) SetViewCardOrder(c store.Container, boardID, viewID string, order model.ViewCardOrder, modifiedByID string) (*model.Block, error) {
+	view, err := a.store.GetBlock(c, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.Type != model.TypeView || view.RootID != boardID {
+		return nil, store.NewErrNotFound(viewID)
+	}
+
+	cards, err := a.store.GetBlocksWithParentAndType(c, boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cardIDs := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		cardIDs[card.ID] = true
+	}
+	if err = order.Validate(cardIDs); err != nil {
+		return nil, err
+	}
+
+	merged, cardOrder := order.Merge(view.Fields)
+	patch := &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			model.ViewGroupCardOrderField: merged.CardOrderByGroup,
+			model.ViewCardOrderField:      cardOrder,
+		},
+	}
+	return a.PatchBlock(c, viewID, patch, modifiedByID)
+}
+
+// defaultGroupPropertyID returns the ID of the first select property of the
+// schema, or an empty string if there is none.
+func defaultGroupPropertyID(schema model.PropSchema) string {
+	return firstPropertyID(schema, propTypeSelect)
+}
+
+// firstPropertyID returns the ID of the first property of the type in the
+// schema, or an empty string if there is none.
+func firstPropertyID(schema model.PropSchema, propType string) string {
+	props := make([]model.PropDef, 0, len(schema))
+	for _, prop := range schema {
+		if prop.Type == propType {
+			props = append(props, prop)
+		}
+	}
+	if len(props) == 0 {
+		return ""
+	}
+
+	sort.Slice(props, func(i, j int) bool {
+		return props[i].Index < props[j].Index
+	})
+	return props[0].ID
+}
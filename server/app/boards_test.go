@@ -0,0 +1,275 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestGetBoardStats(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "assignee", "type": "person"},
+				map[string]interface{}{
+					"id":   "status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+				map[string]interface{}{"id": "due", "type": "date"},
+			},
+		},
+	}
+
+	card := model.Block{ID: "card-1", Type: model.TypeCard}
+
+	t.Run("summarizes cards", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBoardCardStats(gomock.Eq(container), gomock.Eq("board-id"), gomock.Any()).DoAndReturn(
+			func(_ st.Container, boardID string, opts model.CardStatsOptions) (*model.BoardStats, error) {
+				require.Equal(t, "status", opts.GroupPropertyID)
+				require.Equal(t, []string{"assignee"}, opts.PersonPropertyIDs)
+				require.Equal(t, []string{"due"}, opts.DatePropertyIDs)
+				require.NotZero(t, opts.Now)
+				return &model.BoardStats{
+					BoardID:             boardID,
+					CardCount:           3,
+					GroupPropertyID:     opts.GroupPropertyID,
+					CardsByGroup:        map[string]int{"todo": 2, "": 1},
+					UnassignedCardCount: 2,
+					OverdueCardCount:    1,
+				}, nil
+			})
+		th.Store.EXPECT().GetLastActivityWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(int64(1234), nil)
+
+		stats, err := th.App.GetBoardStats(container, "board-id", "")
+		require.NoError(t, err)
+		require.Equal(t, 3, stats.CardCount)
+		require.Equal(t, "status", stats.GroupPropertyID)
+		require.Equal(t, map[string]int{"todo": 2, "": 1}, stats.CardsByGroup)
+		require.Equal(t, 2, stats.UnassignedCardCount)
+		require.Equal(t, 1, stats.OverdueCardCount)
+		require.Equal(t, int64(1234), stats.LastActivityAt)
+	})
+
+	t.Run("not a board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-1")).Return(&card, nil)
+
+		_, err := th.App.GetBoardStats(container, "card-1", "")
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
+
+func TestGetPropertyOptionUsage(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+				map[string]interface{}{
+					"id":   "tags",
+					"type": "multiSelect",
+					"options": []interface{}{
+						map[string]interface{}{"id": "bug", "value": "Bug"},
+						map[string]interface{}{"id": "ui", "value": "UI"},
+					},
+				},
+				map[string]interface{}{"id": "notes", "type": "text"},
+			},
+		},
+	}
+
+	cards := []model.Block{
+		{ID: "card-1", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "tags": []interface{}{"bug", "ui"}},
+		}},
+		{ID: "card-2", Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "tags": []interface{}{"ui"}},
+		}},
+		{ID: "card-3", Type: model.TypeCard, Fields: map[string]interface{}{}},
+	}
+
+	t.Run("select option", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard)).Return(cards, nil)
+
+		usage, err := th.App.GetPropertyOptionUsage(container, "board-id", "status", "todo")
+		require.NoError(t, err)
+		require.Equal(t, 2, usage.CardCount)
+		require.Equal(t, "todo", usage.OptionID)
+	})
+
+	t.Run("multi-select option", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard)).Return(cards, nil)
+
+		usage, err := th.App.GetPropertyOptionUsage(container, "board-id", "tags", "bug")
+		require.NoError(t, err)
+		require.Equal(t, 1, usage.CardCount)
+	})
+
+	t.Run("unused option", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard)).Return(cards, nil)
+
+		usage, err := th.App.GetPropertyOptionUsage(container, "board-id", "status", "done")
+		require.NoError(t, err)
+		require.Equal(t, 0, usage.CardCount)
+	})
+
+	t.Run("not a select property", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+
+		_, err := th.App.GetPropertyOptionUsage(container, "board-id", "notes", "todo")
+		require.True(t, st.IsErrNotFound(err))
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+
+		_, err := th.App.GetPropertyOptionUsage(container, "board-id", "status", "unknown")
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
+
+func TestMoveCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "assignee", "type": "person"},
+				map[string]interface{}{
+					"id":   "status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+			},
+		},
+	}
+	card := &model.Block{ID: "card-1", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+		"properties": map[string]interface{}{"status": "todo", "assignee": "user-id-1"},
+	}}
+
+	t.Run("not a select property", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+
+		move := model.MoveCardsRequest{CardIDs: []string{"card-1"}, PropertyID: "assignee", Value: "user-id-2"}
+		_, err := th.App.MoveCards(container, "board-id", move, "user-id-1")
+		require.ErrorIs(t, err, model.ErrInvalidProperty)
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+
+		move := model.MoveCardsRequest{CardIDs: []string{"card-1"}, PropertyID: "status", Value: "doing"}
+		_, err := th.App.MoveCards(container, "board-id", move, "user-id-1")
+		require.ErrorIs(t, err, model.ErrInvalidPropertyValue)
+	})
+
+	t.Run("card of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-2")).
+			Return(&model.Block{ID: "card-2", RootID: "board-id-2", Type: model.TypeCard}, nil)
+
+		move := model.MoveCardsRequest{CardIDs: []string{"card-2"}, PropertyID: "status", Value: "done"}
+		_, err := th.App.MoveCards(container, "board-id", move, "user-id-1")
+		require.True(t, st.IsErrNotFound(err))
+	})
+
+	t.Run("moves cards", func(t *testing.T) {
+		// for the move, the relation check of the patch and the change
+		// debounce of the broadcast
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).Times(3)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-1")).Return(card, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlocks(gomock.Eq(container), gomock.Eq(&model.BlockPatchBatch{
+			BlockIDs: []string{"card-1"},
+			BlockPatches: []model.BlockPatch{{UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "done", "assignee": "user-id-1"},
+			}}},
+		}), gomock.Eq("user-id-1")).Return(nil)
+
+		move := model.MoveCardsRequest{CardIDs: []string{"card-1", "card-1"}, PropertyID: "status", Value: "done"}
+		cards, err := th.App.MoveCards(container, "board-id", move, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+	})
+}
+
+func TestGetBoardsOverCardCountWarning(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	th.App.config.CardCountWarningThreshold = 10
+
+	blocks := []model.Block{
+		{ID: "card-1", RootID: "board-1", Type: model.TypeCard},
+		{ID: "card-2", RootID: "board-2", Type: model.TypeCard},
+		{ID: "card-3", RootID: "board-3", Type: model.TypeCard},
+		{ID: "text-1", RootID: "board-4", Type: model.TypeText},
+	}
+
+	t.Run("counts cards of boards past the threshold only", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-1")).Return(int64(5), nil)
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-2")).Return(int64(20), nil)
+		th.Store.EXPECT().GetBlockCountWithRootIDAndType(gomock.Eq(container), gomock.Eq("board-2"), gomock.Eq(model.TypeCard)).Return(int64(8), nil)
+		th.Store.EXPECT().GetBlockCountWithRootID(gomock.Eq(container), gomock.Eq("board-3")).Return(int64(30), nil)
+		th.Store.EXPECT().GetBlockCountWithRootIDAndType(gomock.Eq(container), gomock.Eq("board-3"), gomock.Eq(model.TypeCard)).Return(int64(11), nil)
+
+		boardIDs, err := th.App.GetBoardsOverCardCountWarning(container, blocks)
+		require.NoError(t, err)
+		require.Equal(t, []string{"board-3"}, boardIDs)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.CardCountWarningThreshold = 0
+
+		boardIDs, err := th.App.GetBoardsOverCardCountWarning(container, blocks)
+		require.NoError(t, err)
+		require.Empty(t, boardIDs)
+	})
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// cardSortKey is the value of the property a card is sorted by.
+type cardSortKey struct {
+	missing bool
+	isText  bool
+	text    string
+	number  float64
+}
+
+func textSortKey(s string) cardSortKey {
+	if s == "" {
+		return cardSortKey{missing: true}
+	}
+	return cardSortKey{isText: true, text: strings.ToLower(s)}
+}
+
+func (k cardSortKey) less(other cardSortKey, descending bool) bool {
+	if k.missing || other.missing {
+		return !k.missing && other.missing
+	}
+	if descending {
+		k, other = other, k
+	}
+	if k.isText {
+		return k.text < other.text
+	}
+	return k.number < other.number
+}
+
+// SortCardsByProperty sorts the cards in blocks by the value of a property of
+// the board, or by title for TitlePropertyID. Select options sort in the order
+// they are defined in, and cards without a value come last in either order.
+// The other blocks follow the cards in their original order.
+func (a *App) SortCardsByProperty(c store.Container, boardID string, blocks []model.Block, propertyID string, descending bool) error {
+	prop := model.PropDef{ID: propertyID}
+	if propertyID != model.TitlePropertyID {
+		board, err := a.getBoard(c, boardID)
+		if err != nil {
+			return err
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if prop, ok = schema[propertyID]; !ok {
+			return fmt.Errorf("%w: %s", model.ErrInvalidProperty, propertyID)
+		}
+	}
+
+	usernames := map[string]string{}
+	keys := make([]cardSortKey, len(blocks))
+	for i := range blocks {
+		if blocks[i].Type == model.TypeCard {
+			keys[i] = a.cardSortKey(blocks[i], prop, usernames)
+		}
+	}
+
+	order := make([]int, len(blocks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		bi, bj := blocks[order[i]], blocks[order[j]]
+		if bi.Type != model.TypeCard || bj.Type != model.TypeCard {
+			return bi.Type == model.TypeCard && bj.Type != model.TypeCard
+		}
+		return keys[order[i]].less(keys[order[j]], descending)
+	})
+
+	sorted := make([]model.Block, len(blocks))
+	for i, index := range order {
+		sorted[i] = blocks[index]
+	}
+	copy(blocks, sorted)
+	return nil
+}
+
+// cardSortKey returns the value of the property of the card to sort by.
+// Usernames of person properties are cached in usernames.
+func (a *App) cardSortKey(card model.Block, prop model.PropDef, usernames map[string]string) cardSortKey {
+	switch {
+	case prop.ID == model.TitlePropertyID:
+		return textSortKey(card.Title)
+	case prop.Type == propTypeCreatedTime:
+		return cardSortKey{number: float64(card.CreateAt)}
+	case prop.Type == propTypeUpdatedTime:
+		return cardSortKey{number: float64(card.UpdateAt)}
+	}
+
+	props, _ := card.Fields["properties"].(map[string]interface{})
+	value := props[prop.ID]
+	if value == nil || value == "" {
+		return cardSortKey{missing: true}
+	}
+
+	switch prop.Type {
+	case propTypeSelect, propTypeMultiSelect:
+		// multi selects sort by their first option
+		optionIDs, ok := value.([]interface{})
+		if !ok {
+			optionIDs = []interface{}{value}
+		}
+		index := -1
+		for _, id := range optionIDs {
+			optionID, _ := id.(string)
+			if option, ok := prop.Options[optionID]; ok && (index < 0 || option.Index < index) {
+				index = option.Index
+			}
+		}
+		if index < 0 {
+			return cardSortKey{missing: true}
+		}
+		return cardSortKey{number: float64(index)}
+
+	case propTypeNumber:
+		number, err := strconv.ParseFloat(fmt.Sprintf("%v", value), 64)
+		if err != nil {
+			return cardSortKey{missing: true}
+		}
+		return cardSortKey{number: number}
+
+	case propTypeDate:
+		date, _ := value.(string)
+		from, _, err := model.ParseDateRange(date)
+		if err != nil {
+			return cardSortKey{missing: true}
+		}
+		return cardSortKey{number: float64(from)}
+
+	case propTypePerson:
+		userID, _ := value.(string)
+		username, ok := usernames[userID]
+		if !ok {
+			username = userID
+			if user, err := a.store.GetUserByID(userID); err == nil && user != nil {
+				username = user.Username
+			}
+			usernames[userID] = username
+		}
+		return textSortKey(username)
+	}
+	return textSortKey(fmt.Sprintf("%v", value))
+}
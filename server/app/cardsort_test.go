@@ -0,0 +1,94 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestSortCardsByProperty(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "priority",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "high", "value": "High"},
+						map[string]interface{}{"id": "low", "value": "Low"},
+					},
+				},
+				map[string]interface{}{"id": "estimate", "type": "number"},
+			},
+		},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+
+	card := func(id string, properties map[string]interface{}) model.Block {
+		return model.Block{
+			ID:       id,
+			RootID:   "board-id",
+			ParentID: "board-id",
+			Type:     model.TypeCard,
+			Title:    id,
+			Fields:   map[string]interface{}{"properties": properties},
+		}
+	}
+	blockIDs := func(blocks []model.Block) []string {
+		ids := []string{}
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+	blocks := func() []model.Block {
+		return []model.Block{
+			card("none", map[string]interface{}{}),
+			{ID: "view", RootID: "board-id", ParentID: "board-id", Type: model.TypeView},
+			card("low", map[string]interface{}{"priority": "low", "estimate": "3"}),
+			card("high", map[string]interface{}{"priority": "high", "estimate": "12"}),
+		}
+	}
+
+	t.Run("select in the order of the options", func(t *testing.T) {
+		sorted := blocks()
+		require.NoError(t, th.App.SortCardsByProperty(container, "board-id", sorted, "priority", false))
+		require.Equal(t, []string{"high", "low", "none", "view"}, blockIDs(sorted))
+	})
+
+	t.Run("missing values last in descending order", func(t *testing.T) {
+		sorted := blocks()
+		require.NoError(t, th.App.SortCardsByProperty(container, "board-id", sorted, "priority", true))
+		require.Equal(t, []string{"low", "high", "none", "view"}, blockIDs(sorted))
+	})
+
+	t.Run("numbers", func(t *testing.T) {
+		sorted := blocks()
+		require.NoError(t, th.App.SortCardsByProperty(container, "board-id", sorted, "estimate", false))
+		require.Equal(t, []string{"low", "high", "none", "view"}, blockIDs(sorted))
+	})
+
+	t.Run("title", func(t *testing.T) {
+		sorted := blocks()
+		require.NoError(t, th.App.SortCardsByProperty(container, "board-id", sorted, model.TitlePropertyID, true))
+		require.Equal(t, []string{"none", "low", "high", "view"}, blockIDs(sorted))
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		err := th.App.SortCardsByProperty(container, "board-id", blocks(), "status", false)
+		require.ErrorIs(t, err, model.ErrInvalidProperty)
+	})
+}
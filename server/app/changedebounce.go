@@ -0,0 +1,125 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	dispatcherWebsocket = "websocket"
+	dispatcherWebhook   = "webhook"
+)
+
+type boardChangeKey struct {
+	dispatcher  string
+	workspaceID string
+	boardID     string
+}
+
+type pendingBoardChange struct {
+	change  model.BoardChange
+	changed map[string]bool
+}
+
+// changeDebouncer collects the block changes of the boards with a change
+// debounce, to notify them as a single board change per dispatcher.
+type changeDebouncer struct {
+	mu      sync.Mutex
+	pending map[boardChangeKey]*pendingBoardChange
+}
+
+func newChangeDebouncer() *changeDebouncer {
+	return &changeDebouncer{pending: map[boardChangeKey]*pendingBoardChange{}}
+}
+
+// add records the change of a block. The first change of a board schedules
+// flush to be called with all the changes collected once delay has passed.
+func (cd *changeDebouncer) add(key boardChangeKey, blockID string, delay time.Duration, flush func(model.BoardChange)) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+
+	pending, ok := cd.pending[key]
+	if !ok {
+		pending = &pendingBoardChange{
+			change:  model.BoardChange{BoardID: key.boardID},
+			changed: map[string]bool{},
+		}
+		cd.pending[key] = pending
+
+		time.AfterFunc(delay, func() {
+			cd.mu.Lock()
+			delete(cd.pending, key)
+			cd.mu.Unlock()
+			flush(pending.change)
+		})
+	}
+
+	if !pending.changed[blockID] {
+		pending.changed[blockID] = true
+		pending.change.BlockIDs = append(pending.change.BlockIDs, blockID)
+	}
+}
+
+// boardChangeDebounce returns the change debounce of the board of block.
+// The debounces of the boards already looked up are kept in debounces if it
+// isn't nil. Changes of blocks whose board can't be loaded aren't debounced.
+func (a *App) boardChangeDebounce(c store.Container, block model.Block, debounces map[string]time.Duration) time.Duration {
+	if block.RootID == "" {
+		return 0
+	}
+	if block.ID == block.RootID {
+		return block.ChangeDebounce()
+	}
+	if debounce, ok := debounces[block.RootID]; ok {
+		return debounce
+	}
+
+	var debounce time.Duration
+	board, err := a.store.GetBlock(c, block.RootID)
+	if err != nil {
+		a.logger.Warn("Cannot load board for change debounce", mlog.String("boardID", block.RootID), mlog.Err(err))
+	} else if board != nil {
+		debounce = board.ChangeDebounce()
+	}
+	if debounces != nil {
+		debounces[block.RootID] = debounce
+	}
+	return debounce
+}
+
+// broadcastBlockChange sends the change of block to the websocket clients,
+// right away or as part of the debounced changes of its board.
+func (a *App) broadcastBlockChange(c store.Container, block model.Block, debounces map[string]time.Duration) {
+	delay := a.boardChangeDebounce(c, block, debounces)
+	if delay <= 0 {
+		a.wsAdapter.BroadcastBlockChange(c.WorkspaceID, block)
+		return
+	}
+
+	key := boardChangeKey{dispatcher: dispatcherWebsocket, workspaceID: c.WorkspaceID, boardID: block.RootID}
+	a.changeDebouncer.add(key, block.ID, delay, func(change model.BoardChange) {
+		a.wsAdapter.BroadcastBoardChange(c.WorkspaceID, change)
+	})
+}
+
+// notifyWebhookUpdate calls the update webhooks with block, right away or as
+// part of the debounced changes of its board.
+func (a *App) notifyWebhookUpdate(c store.Container, block model.Block, debounces map[string]time.Duration) {
+	if len(a.config.WebhookUpdate) == 0 {
+		return
+	}
+
+	delay := a.boardChangeDebounce(c, block, debounces)
+	if delay <= 0 {
+		a.webhook.NotifyUpdate(block)
+		return
+	}
+
+	key := boardChangeKey{dispatcher: dispatcherWebhook, workspaceID: c.WorkspaceID, boardID: block.RootID}
+	a.changeDebouncer.add(key, block.ID, delay, a.webhook.NotifyBoardChange)
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestChangeDebouncer(t *testing.T) {
+	debouncer := newChangeDebouncer()
+	flushed := make(chan model.BoardChange, 2)
+	flush := func(change model.BoardChange) { flushed <- change }
+
+	key := boardChangeKey{dispatcher: dispatcherWebsocket, workspaceID: "0", boardID: "board-id"}
+	debouncer.add(key, "card-1", 20*time.Millisecond, flush)
+	debouncer.add(key, "card-2", 20*time.Millisecond, flush)
+	debouncer.add(key, "card-1", 20*time.Millisecond, flush)
+
+	change := <-flushed
+	require.Equal(t, model.BoardChange{BoardID: "board-id", BlockIDs: []string{"card-1", "card-2"}}, change)
+
+	// changes after the flush start a new debounce
+	debouncer.add(key, "card-3", time.Millisecond, flush)
+	change = <-flushed
+	require.Equal(t, []string{"card-3"}, change.BlockIDs)
+}
+
+func TestInsertBlocksWebhookDebounce(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var mu sync.Mutex
+	payloads := []map[string]interface{}{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer ts.Close()
+	th.App.config.WebhookUpdate = []string{ts.URL}
+	defer func() { th.App.config.WebhookUpdate = nil }()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{model.ChangeDebounceField: float64(50)},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+	blocks := []model.Block{
+		{ID: "card-1", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard},
+		{ID: "card-2", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard},
+	}
+	_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(payloads) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, payloads, 1)
+	require.Equal(t, "board-id", payloads[0]["boardId"])
+	require.Equal(t, []interface{}{"card-1", "card-2"}, payloads[0]["blockIds"])
+}
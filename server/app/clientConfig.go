@@ -1,9 +1,13 @@
 package app
 
 import (
+	"strings"
+
 	"github.com/mattermost/focalboard/server/model"
 )
 
+const filesDriverS3 = "amazons3"
+
 func (a *App) GetClientConfig() *model.ClientConfig {
 	return &model.ClientConfig{
 		Telemetry:                a.config.Telemetry,
@@ -12,3 +16,33 @@ func (a *App) GetClientConfig() *model.ClientConfig {
 		FeatureFlags:             a.config.FeatureFlags,
 	}
 }
+
+// GetServerInfo returns the version of the server, and the optional features
+// enabled on it if includeFeatures is true, as they describe its configuration.
+func (a *App) GetServerInfo(includeFeatures bool) *model.ServerInfo {
+	info := &model.ServerInfo{
+		Version:     model.CurrentVersion,
+		BuildNumber: model.BuildNumber,
+		BuildHash:   model.BuildHash,
+		BuildDate:   model.BuildDate,
+		Edition:     model.Edition,
+	}
+	if !includeFeatures {
+		return info
+	}
+
+	filesS3 := a.config.FilesDriver == filesDriverS3
+	info.Features = map[string]bool{
+		"filesS3":                  filesS3,
+		"filesS3SSE":               filesS3 && a.config.FilesS3Config.SSE,
+		"webhooks":                 len(a.config.WebhookUpdate) > 0,
+		"insertValidationWebhooks": len(a.config.WebhookInsertValidation) > 0,
+		"pdfExport":                len(strings.Fields(a.config.PDFConverter)) > 0,
+		"publicSharedBoards":       a.config.EnablePublicSharedBoards,
+		"rateLimit":                a.config.RateLimitPerMinute > 0,
+		"readOnlyMode":             a.IsReadOnly(),
+		"historyRetention":         a.HistoryRetention().Enabled(),
+	}
+	info.HistoryRetention = a.HistoryRetention()
+	return info
+}
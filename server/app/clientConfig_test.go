@@ -3,6 +3,7 @@ package app
 import (
 	"testing"
 
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/require"
 )
@@ -28,3 +29,33 @@ func TestGetClientConfig(t *testing.T) {
 		require.Equal(t, 2, len(clientConfig.FeatureFlags))
 	})
 }
+
+func TestGetServerInfo(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	newConfiguration := config.Configuration{}
+	newConfiguration.FilesDriver = "amazons3"
+	newConfiguration.FilesS3Config.SSE = true
+	newConfiguration.PDFConverter = "wkhtmltopdf - -"
+	th.App.SetConfig(&newConfiguration)
+
+	t.Run("without features", func(t *testing.T) {
+		info := th.App.GetServerInfo(false)
+		require.Equal(t, model.CurrentVersion, info.Version)
+		require.Nil(t, info.Features)
+		require.Nil(t, info.HistoryRetention)
+	})
+
+	t.Run("with features", func(t *testing.T) {
+		info := th.App.GetServerInfo(true)
+		require.Equal(t, model.CurrentVersion, info.Version)
+		require.True(t, info.Features["filesS3"])
+		require.True(t, info.Features["filesS3SSE"])
+		require.True(t, info.Features["pdfExport"])
+		require.False(t, info.Features["webhooks"])
+		require.False(t, info.Features["publicSharedBoards"])
+		require.False(t, info.Features["historyRetention"])
+		require.Equal(t, &model.HistoryRetention{}, info.HistoryRetention)
+	})
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetCommentThreads returns the comments of a card grouped in threads, oldest
+// first. The replies whose parent comment no longer exists start their own
+// thread. The resolved threads are left out unless includeResolved is true.
+func (a *App) GetCommentThreads(c store.Container, boardID, cardID string, includeResolved bool) ([]model.CommentThread, error) {
+	if _, err := a.getCard(c, boardID, cardID); err != nil {
+		return nil, err
+	}
+
+	comments, err := a.store.GetBlocksWithParentAndType(c, cardID, model.TypeComment)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreateAt < comments[j].CreateAt
+	})
+
+	exists := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		exists[comment.ID] = true
+	}
+
+	threads := []model.CommentThread{}
+	index := map[string]int{}
+	for _, comment := range comments {
+		parentID := comment.ParentCommentID()
+		if parentID == "" || !exists[parentID] {
+			index[comment.ID] = len(threads)
+			threads = append(threads, model.CommentThread{Comment: comment, Replies: []model.Block{}})
+		}
+	}
+	for _, comment := range comments {
+		parentID := comment.ParentCommentID()
+		if i, ok := index[parentID]; ok && comment.ID != threads[i].Comment.ID {
+			threads[i].Replies = append(threads[i].Replies, comment)
+		}
+	}
+
+	if includeResolved {
+		return threads, nil
+	}
+	unresolved := make([]model.CommentThread, 0, len(threads))
+	for _, thread := range threads {
+		if !thread.Comment.IsResolved() {
+			unresolved = append(unresolved, thread)
+		}
+	}
+	return unresolved, nil
+}
+
+// ReplyToComment adds a comment to the card replying to the thread of
+// commentID. Replies to a reply are added to the thread it belongs to.
+func (a *App) ReplyToComment(c store.Container, boardID, cardID, commentID, text, modifiedByID string) (*model.Block, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, model.ErrEmptyComment
+	}
+
+	comment, err := a.getComment(c, boardID, cardID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	threadID := comment.ID
+	if parentID := comment.ParentCommentID(); parentID != "" {
+		threadID = parentID
+	}
+
+	now := utils.GetMillis()
+	reply := model.Block{
+		ID:       utils.NewID(model.BlockType2IDType(model.TypeComment)),
+		ParentID: cardID,
+		RootID:   boardID,
+		Schema:   1,
+		Type:     model.TypeComment,
+		Title:    text,
+		Fields:   map[string]interface{}{model.ParentCommentIDField: threadID},
+		CreateAt: now,
+		UpdateAt: now,
+	}
+
+	blocks, err := a.InsertBlocks(c, []model.Block{reply}, modifiedByID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &blocks[0], nil
+}
+
+// SetCommentThreadResolved flags the thread of commentID as resolved, or
+// clears the flag, and returns the comment starting the thread.
+func (a *App) SetCommentThreadResolved(c store.Container, boardID, cardID, commentID string, resolved bool, modifiedByID string) (*model.Block, error) {
+	comment, err := a.getComment(c, boardID, cardID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parentID := comment.ParentCommentID(); parentID != "" {
+		if comment, err = a.getComment(c, boardID, cardID, parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	patch := &model.BlockPatch{}
+	if resolved {
+		patch.UpdatedFields = map[string]interface{}{model.ResolvedField: true}
+	} else {
+		patch.DeletedFields = []string{model.ResolvedField}
+	}
+	return a.PatchBlock(c, comment.ID, patch, modifiedByID)
+}
+
+// getComment returns the comment with the specified ID, or a not found error
+// if there is no such comment in the card.
+func (a *App) getComment(c store.Container, boardID, cardID, commentID string) (*model.Block, error) {
+	comment, err := a.store.GetBlock(c, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment == nil || comment.Type != model.TypeComment || comment.ParentID != cardID || comment.RootID != boardID {
+		return nil, store.NewErrNotFound(commentID)
+	}
+	return comment, nil
+}
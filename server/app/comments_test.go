@@ -0,0 +1,85 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestGetCommentThreads(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	card := &model.Block{ID: "card-id", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	comments := []model.Block{
+		{ID: "reply-2", Type: model.TypeComment, CreateAt: 4, Fields: map[string]interface{}{model.ParentCommentIDField: "comment-1"}},
+		{ID: "comment-1", Type: model.TypeComment, CreateAt: 1, Fields: map[string]interface{}{}},
+		{ID: "reply-1", Type: model.TypeComment, CreateAt: 3, Fields: map[string]interface{}{model.ParentCommentIDField: "comment-1"}},
+		{ID: "comment-2", Type: model.TypeComment, CreateAt: 2, Fields: map[string]interface{}{model.ResolvedField: true}},
+		{ID: "orphan", Type: model.TypeComment, CreateAt: 5, Fields: map[string]interface{}{model.ParentCommentIDField: "deleted"}},
+	}
+
+	t.Run("include resolved threads", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("card-id"), gomock.Eq(model.TypeComment)).Return(comments, nil)
+
+		threads, err := th.App.GetCommentThreads(container, "board-id", "card-id", true)
+		require.NoError(t, err)
+		require.Len(t, threads, 3)
+		require.Equal(t, "comment-1", threads[0].Comment.ID)
+		require.Len(t, threads[0].Replies, 2)
+		require.Equal(t, "reply-1", threads[0].Replies[0].ID)
+		require.Equal(t, "reply-2", threads[0].Replies[1].ID)
+		require.Equal(t, "comment-2", threads[1].Comment.ID)
+		require.Empty(t, threads[1].Replies)
+		require.Equal(t, "orphan", threads[2].Comment.ID)
+	})
+
+	t.Run("hide resolved threads", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("card-id"), gomock.Eq(model.TypeComment)).Return(comments, nil)
+
+		threads, err := th.App.GetCommentThreads(container, "board-id", "card-id", false)
+		require.NoError(t, err)
+		require.Len(t, threads, 2)
+		require.Equal(t, "comment-1", threads[0].Comment.ID)
+		require.Equal(t, "orphan", threads[1].Comment.ID)
+	})
+
+	t.Run("card not in board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil)
+
+		_, err := th.App.GetCommentThreads(container, "other-board-id", "card-id", false)
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
+
+func TestReplyToComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("empty text", func(t *testing.T) {
+		_, err := th.App.ReplyToComment(container, "board-id", "card-id", "comment-id", "  ", "user-id")
+		require.ErrorIs(t, err, model.ErrEmptyComment)
+	})
+
+	t.Run("not a comment", func(t *testing.T) {
+		text := &model.Block{ID: "text-id", RootID: "board-id", ParentID: "card-id", Type: model.TypeText}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("text-id")).Return(text, nil)
+
+		_, err := th.App.ReplyToComment(container, "board-id", "card-id", "text-id", "reply", "user-id")
+		require.True(t, st.IsErrNotFound(err))
+	})
+}
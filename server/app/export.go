@@ -0,0 +1,481 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+const (
+	// pdfConverterTimeout is the maximum time given to the PDF converter
+	// to render a board.
+	pdfConverterTimeout = 60 * time.Second
+
+	// csvExportPageSize is the number of cards read at a time when
+	// exporting a board to CSV.
+	csvExportPageSize = 1000
+)
+
+// ErrPDFExportDisabled is returned when there is no PDF converter configured.
+var ErrPDFExportDisabled = errors.New("PDF export is not configured")
+
+// exportColumn is a group of cards of a kanban view.
+type exportColumn struct {
+	Name  string
+	Cards []exportCard
+}
+
+type exportCard struct {
+	Title  string
+	Values []string
+}
+
+type exportPage struct {
+	Title      string
+	ViewTitle  string
+	Kanban     bool
+	Properties []string
+	Cards      []exportCard
+	Columns    []exportColumn
+}
+
+var exportTemplate = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px; text-align: left; vertical-align: top; }
+.columns { display: flex; }
+.column { flex: 1; margin-right: 8px; }
+.card { border: 1px solid #ccc; margin-bottom: 4px; padding: 4px; }
+.card div { color: #555; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>{{.ViewTitle}}</h2>
+{{if .Kanban}}<div class="columns">
+{{range .Columns}}<div class="column">
+<h3>{{.Name}} ({{len .Cards}})</h3>
+{{range .Cards}}<div class="card"><strong>{{.Title}}</strong>{{range .Values}}{{if .}}<div>{{.}}</div>{{end}}{{end}}</div>
+{{end}}</div>
+{{end}}</div>
+{{else}}<table>
+<tr><th>Name</th>{{range .Properties}}<th>{{.}}</th>{{end}}</tr>
+{{range .Cards}}<tr><td>{{.Title}}</td>{{range .Values}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// ExportBoardViewPDF renders a view of a board with its cards to PDF. The
+// first view of the board is used if viewID is empty. If restricted is true,
+// the restricted cards userID can't see are left out.
+func (a *App) ExportBoardViewPDF(c store.Container, boardID, viewID, userID string, restricted bool) ([]byte, error) {
+	if len(strings.Fields(a.config.PDFConverter)) == 0 {
+		return nil, ErrPDFExportDisabled
+	}
+
+	html, err := a.ExportBoardViewHTML(c, boardID, viewID, userID, restricted)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.convertHTMLToPDF(html)
+}
+
+// ExportBoardViewHTML renders a view of a board with its cards to a
+// standalone HTML page, as a table or as kanban columns depending on the
+// type of the view. If restricted is true, the restricted cards userID can't
+// see are left out.
+func (a *App) ExportBoardViewHTML(c store.Container, boardID, viewID, userID string, restricted bool) ([]byte, error) {
+	board, err := a.getBoard(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := a.getExportView(c, boardID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithParentAndType(c, boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		cards = withoutHiddenCards(cards, userID)
+	}
+	sortExportCards(cards, view)
+
+	props := exportProperties(schema, view)
+	page := exportPage{
+		Title:     board.Title,
+		ViewTitle: view.Title,
+	}
+	for _, prop := range props {
+		page.Properties = append(page.Properties, prop.Name)
+	}
+
+	groupBy, _ := view.Fields["groupById"].(string)
+	groupProp, hasGroup := schema[groupBy]
+	page.Kanban = view.ViewType() == model.ViewTypeBoard && hasGroup && groupProp.Type == propTypeSelect
+
+	var columns map[string]*exportColumn
+	if page.Kanban {
+		columns = make(map[string]*exportColumn)
+	}
+
+	for i := range cards {
+		card := exportCard{Title: cards[i].Title}
+		values, _ := cards[i].Fields["properties"].(map[string]interface{})
+		for _, prop := range props {
+			card.Values = append(card.Values, a.exportValue(prop, values[prop.ID]))
+		}
+
+		if !page.Kanban {
+			page.Cards = append(page.Cards, card)
+			continue
+		}
+
+		option, _ := values[groupBy].(string)
+		if _, ok := groupProp.Options[option]; !ok {
+			option = ""
+		}
+		column, ok := columns[option]
+		if !ok {
+			column = &exportColumn{}
+			columns[option] = column
+		}
+		column.Cards = append(column.Cards, card)
+	}
+
+	if page.Kanban {
+		page.Columns = exportColumns(groupProp, columns)
+	}
+
+	var buf bytes.Buffer
+	if err := exportTemplate.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportBoardViewCSV writes the cards of a board as CSV, with their title
+// and the properties visible in a view, in the order of its columns. All the
+// properties of the board are written if viewID is empty. The cards are read
+// a page at a time in creation order and flushed to w after each page, so
+// that large boards are streamed. If restricted is true, the restricted cards
+// userID can't see are left out.
+func (a *App) ExportBoardViewCSV(c store.Container, boardID, viewID, userID string, restricted bool, w io.Writer) error {
+	board, err := a.getBoard(c, boardID)
+	if err != nil {
+		return err
+	}
+
+	var view *model.Block
+	if viewID != "" {
+		if view, err = a.getExportView(c, boardID, viewID); err != nil {
+			return err
+		}
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	props := exportProperties(schema, view)
+	if view != nil && view.ViewType() == model.ViewTypeCalendar {
+		dateID, _ := view.Fields["dateDisplayPropertyId"].(string)
+		if dateProp, ok := schema[dateID]; ok && !containsProperty(props, dateID) {
+			props = append(props, dateProp)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	header := []string{"Name"}
+	for _, prop := range props {
+		header = append(header, prop.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	opts := model.QueryPageOptions{Limit: csvExportPageSize}
+	for {
+		cards, err := a.store.GetBlocksWithParentAndTypePage(c, boardID, model.TypeCard, opts)
+		if err != nil {
+			return err
+		}
+
+		for i := range cards {
+			if restricted && isHiddenCard(&cards[i], userID) {
+				continue
+			}
+
+			values, _ := cards[i].Fields["properties"].(map[string]interface{})
+			row := []string{cards[i].Title}
+			for _, prop := range props {
+				row = append(row, a.exportValue(prop, values[prop.ID]))
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		flushWriter(w)
+
+		if len(cards) < csvExportPageSize {
+			return nil
+		}
+		last := cards[len(cards)-1]
+		opts.AfterCreateAt, opts.AfterID = last.CreateAt, last.ID
+	}
+}
+
+// ExportBoards writes the blocks of the boards as the JSON of a
+// model.BoardsExport. The blocks are flushed to w after each board, so that
+// large selections are streamed. The boards that can't be found are left out
+// and reported in the skipped boards. If restricted is true, the restricted
+// cards userID can't see are left out too, along with their contents.
+func (a *App) ExportBoards(c store.Container, boardIDs []string, userID string, restricted bool, w io.Writer) error {
+	if _, err := io.WriteString(w, `{"blocks":[`); err != nil {
+		return err
+	}
+
+	skipped := []model.SkippedBoard{}
+	exported := map[string]bool{}
+	first := true
+	for _, boardID := range boardIDs {
+		if exported[boardID] {
+			continue
+		}
+		exported[boardID] = true
+
+		if _, err := a.getBoard(c, boardID); store.IsErrNotFound(err) {
+			skipped = append(skipped, model.SkippedBoard{BoardID: boardID, Reason: "board not found"})
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		blocks, err := a.store.GetBlocksWithRootID(c, boardID)
+		if err != nil {
+			return err
+		}
+		if restricted {
+			if blocks, err = a.FilterRestrictedBlocks(c, blocks, userID); err != nil {
+				return err
+			}
+		}
+
+		for _, block := range blocks {
+			data, err := json.Marshal(block)
+			if err != nil {
+				return err
+			}
+			if !first {
+				data = append([]byte{','}, data...)
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		flushWriter(w)
+	}
+
+	data, err := json.Marshal(skipped)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `],"skipped":%s}`, data)
+	return err
+}
+
+func containsProperty(props []model.PropDef, propID string) bool {
+	for _, prop := range props {
+		if prop.ID == propID {
+			return true
+		}
+	}
+	return false
+}
+
+// flushWriter sends the data buffered by w to the client, if w supports it.
+func flushWriter(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// getExportView returns the view to export, or the first view of the board
+// if viewID is empty.
+func (a *App) getExportView(c store.Container, boardID, viewID string) (*model.Block, error) {
+	if viewID != "" {
+		view, err := a.store.GetBlock(c, viewID)
+		if err != nil {
+			return nil, err
+		}
+		if view == nil || view.Type != model.TypeView || view.RootID != boardID {
+			return nil, store.NewErrNotFound(viewID)
+		}
+		return view, nil
+	}
+
+	views, err := a.store.GetBlocksWithParentAndType(c, boardID, model.TypeView)
+	if err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, store.NewErrNotFound(boardID)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].CreateAt < views[j].CreateAt
+	})
+	return &views[0], nil
+}
+
+// exportValue returns the displayed value of a card property, or an empty
+// string if the card doesn't have a valid value for it.
+func (a *App) exportValue(prop model.PropDef, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	s, err := prop.GetValue(value, a.store)
+	if err != nil {
+		if str, ok := value.(string); ok && prop.Type != propTypeSelect {
+			return str
+		}
+		return ""
+	}
+	return s
+}
+
+// convertHTMLToPDF runs the configured PDF converter, which reads the HTML
+// page from its standard input and writes the PDF to its standard output.
+func (a *App) convertHTMLToPDF(html []byte) ([]byte, error) {
+	args := strings.Fields(a.config.PDFConverter)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pdfConverterTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec
+	cmd.Stdin = bytes.NewReader(html)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("PDF converter failed: %w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// exportProperties returns the visible properties of the view in the order
+// of its columns, or all the properties of the board in schema order if
+// there is no view or it doesn't list them.
+func exportProperties(schema model.PropSchema, view *model.Block) []model.PropDef {
+	props := []model.PropDef{}
+	if view == nil {
+		return sortedProperties(schema)
+	}
+	if visible, ok := view.Fields["visiblePropertyIds"].([]interface{}); ok {
+		for _, id := range visible {
+			propID, _ := id.(string)
+			if prop, ok := schema[propID]; ok {
+				props = append(props, prop)
+			}
+		}
+		return props
+	}
+	return sortedProperties(schema)
+}
+
+// sortedProperties returns all the properties of the schema in their order.
+func sortedProperties(schema model.PropSchema) []model.PropDef {
+	props := make([]model.PropDef, 0, len(schema))
+	for _, prop := range schema {
+		props = append(props, prop)
+	}
+	sort.Slice(props, func(i, j int) bool {
+		return props[i].Index < props[j].Index
+	})
+	return props
+}
+
+// sortExportCards sorts the cards in the manual order of the view, the cards
+// it doesn't list come last by title.
+func sortExportCards(cards []model.Block, view *model.Block) {
+	position := map[string]int{}
+	if order, ok := view.Fields[model.ViewCardOrderField].([]interface{}); ok {
+		for i, id := range order {
+			if cardID, ok := id.(string); ok {
+				position[cardID] = i + 1
+			}
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		pi, pj := position[cards[i].ID], position[cards[j].ID]
+		if pi != pj {
+			if pi == 0 || pj == 0 {
+				return pj == 0
+			}
+			return pi < pj
+		}
+		return cards[i].Title < cards[j].Title
+	})
+}
+
+// exportColumns returns the kanban columns in the order of the options of the
+// group property, with the cards without a value first.
+func exportColumns(groupProp model.PropDef, columns map[string]*exportColumn) []exportColumn {
+	options := make([]model.PropDefOption, 0, len(groupProp.Options))
+	for _, option := range groupProp.Options {
+		options = append(options, option)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Index < options[j].Index
+	})
+
+	result := []exportColumn{}
+	if column, ok := columns[""]; ok {
+		column.Name = "No " + groupProp.Name
+		result = append(result, *column)
+	}
+	for _, option := range options {
+		column := exportColumn{}
+		if c, ok := columns[option.ID]; ok {
+			column = *c
+		}
+		column.Name = option.Value
+		result = append(result, column)
+	}
+	return result
+}
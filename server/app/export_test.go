@@ -0,0 +1,273 @@
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestExportBoardViewHTML(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:    "board-id",
+		Type:  model.TypeBoard,
+		Title: "Roadmap",
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+				map[string]interface{}{"id": "notes", "name": "Notes", "type": "text"},
+				map[string]interface{}{
+					"id":     "cost",
+					"name":   "Cost",
+					"type":   "number",
+					"format": map[string]interface{}{"decimals": float64(2), "currency": "€"},
+				},
+			},
+		},
+	}
+
+	cards := []model.Block{
+		{ID: "card-1", Type: model.TypeCard, Title: "Beta", Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done", "notes": "<shipped>", "cost": "0.30000000000000004"},
+		}},
+		{ID: "card-2", Type: model.TypeCard, Title: "Alpha", Fields: map[string]interface{}{}},
+	}
+
+	t.Run("table view", func(t *testing.T) {
+		view := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Title: "All", Fields: map[string]interface{}{
+			"viewType":  "table",
+			"cardOrder": []interface{}{"card-1"},
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(view, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard)).Return(cards, nil)
+
+		html, err := th.App.ExportBoardViewHTML(container, "board-id", "view-id", "", false)
+		require.NoError(t, err)
+		require.Contains(t, string(html), "<h1>Roadmap</h1>")
+		require.Contains(t, string(html), "<th>Status</th><th>Notes</th><th>Cost</th>")
+		require.Contains(t, string(html), "<td>Beta</td><td>DONE</td><td>&lt;shipped&gt;</td><td>€0.30</td>")
+		require.Less(t, indexOf(html, "Beta"), indexOf(html, "Alpha"))
+	})
+
+	t.Run("kanban view", func(t *testing.T) {
+		view := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Title: "Board", Fields: map[string]interface{}{
+			"viewType":           "board",
+			"groupById":          "status",
+			"visiblePropertyIds": []interface{}{"notes"},
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeView)).Return([]model.Block{*view}, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndType(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard)).Return(cards, nil)
+
+		html, err := th.App.ExportBoardViewHTML(container, "board-id", "", "", false)
+		require.NoError(t, err)
+		require.Contains(t, string(html), "<h3>No Status (1)</h3>")
+		require.Contains(t, string(html), "<h3>To Do (0)</h3>")
+		require.Contains(t, string(html), "<h3>Done (1)</h3>")
+		require.Less(t, indexOf(html, "No Status"), indexOf(html, "Alpha"))
+		require.Less(t, indexOf(html, "Done (1)"), indexOf(html, "Beta"))
+	})
+
+	t.Run("view of another board", func(t *testing.T) {
+		view := &model.Block{ID: "view-id", RootID: "other-board-id", Type: model.TypeView}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(view, nil)
+
+		_, err := th.App.ExportBoardViewHTML(container, "board-id", "view-id", "", false)
+		require.True(t, st.IsErrNotFound(err))
+	})
+
+	t.Run("PDF export not configured", func(t *testing.T) {
+		_, err := th.App.ExportBoardViewPDF(container, "board-id", "view-id", "", false)
+		require.ErrorIs(t, err, ErrPDFExportDisabled)
+	})
+}
+
+func TestExportBoardViewCSV(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":      "status",
+					"name":    "Status",
+					"type":    "select",
+					"options": []interface{}{map[string]interface{}{"id": "done", "value": "Done"}},
+				},
+				map[string]interface{}{"id": "notes", "name": "Notes", "type": "text"},
+			},
+		},
+	}
+	view := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{
+		"viewType":           "table",
+		"visiblePropertyIds": []interface{}{"status", "notes"},
+	}}
+
+	t.Run("streamed a page at a time", func(t *testing.T) {
+		firstPage := make([]model.Block, csvExportPageSize)
+		for i := range firstPage {
+			firstPage[i] = model.Block{ID: fmt.Sprintf("card-%d", i), Type: model.TypeCard, Title: fmt.Sprintf("Card %d", i), CreateAt: int64(i)}
+		}
+		secondPage := []model.Block{
+			{ID: "last", Type: model.TypeCard, Title: "Last, \"quoted\"", Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": "done", "notes": "line\nbreak"},
+			}},
+			{ID: "hidden", Type: model.TypeCard, Title: "Hidden", Fields: map[string]interface{}{
+				model.RestrictedToField: []interface{}{"other-user-id"},
+			}},
+		}
+
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(view, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndTypePage(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard),
+			gomock.Eq(model.QueryPageOptions{Limit: csvExportPageSize})).Return(firstPage, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndTypePage(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard),
+			gomock.Eq(model.QueryPageOptions{
+				Limit:         csvExportPageSize,
+				AfterCreateAt: csvExportPageSize - 1,
+				AfterID:       fmt.Sprintf("card-%d", csvExportPageSize-1),
+			})).Return(secondPage, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardViewCSV(container, "board-id", "view-id", "user-id", true, &buf)
+		require.NoError(t, err)
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, csvExportPageSize+2)
+		require.Equal(t, []string{"Name", "Status", "Notes"}, rows[0])
+		require.Equal(t, []string{"Card 0", "", ""}, rows[1])
+		require.Equal(t, []string{"Last, \"quoted\"", "DONE", "line\nbreak"}, rows[len(rows)-1])
+	})
+
+	t.Run("calendar view", func(t *testing.T) {
+		calendar := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{
+			"viewType":              "calendar",
+			"visiblePropertyIds":    []interface{}{"notes"},
+			"dateDisplayPropertyId": "status",
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(calendar, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndTypePage(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard),
+			gomock.Any()).Return([]model.Block{}, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardViewCSV(container, "board-id", "view-id", "", false, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "Name,Notes,Status\n", buf.String())
+	})
+
+	t.Run("columns of the view", func(t *testing.T) {
+		reordered := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{
+			"viewType":           "table",
+			"visiblePropertyIds": []interface{}{"notes", "status"},
+		}}
+		hidden := &model.Block{ID: "view-id", RootID: "board-id", Type: model.TypeView, Fields: map[string]interface{}{
+			"viewType":           "table",
+			"visiblePropertyIds": []interface{}{},
+		}}
+
+		for _, tc := range []struct {
+			view     *model.Block
+			expected string
+		}{
+			{reordered, "Name,Notes,Status\n"},
+			{hidden, "Name\n"},
+		} {
+			th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+			th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(tc.view, nil)
+			th.Store.EXPECT().GetBlocksWithParentAndTypePage(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard),
+				gomock.Any()).Return([]model.Block{}, nil)
+
+			var buf bytes.Buffer
+			err := th.App.ExportBoardViewCSV(container, "board-id", "view-id", "", false, &buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, buf.String())
+		}
+	})
+
+	t.Run("all properties without a view", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithParentAndTypePage(gomock.Eq(container), gomock.Eq("board-id"), gomock.Eq(model.TypeCard),
+			gomock.Any()).Return([]model.Block{}, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardViewCSV(container, "board-id", "", "", false, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "Name,Status,Notes\n", buf.String())
+	})
+}
+
+func indexOf(html []byte, s string) int {
+	return strings.Index(string(html), s)
+}
+
+func TestExportBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+	board1 := &model.Block{ID: "board-1", RootID: "board-1", Type: model.TypeBoard}
+	board2 := &model.Block{ID: "board-2", RootID: "board-2", Type: model.TypeBoard}
+	hidden := model.Block{ID: "hidden", RootID: "board-2", ParentID: "board-2", Type: model.TypeCard, Fields: map[string]interface{}{
+		model.RestrictedToField: []interface{}{"other-user-id"},
+	}}
+
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-1")).Return(board1, nil)
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-2")).Return(board2, nil)
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing")).Return(nil, nil)
+	th.Store.EXPECT().GetBlocksWithRootID(gomock.Eq(container), gomock.Eq("board-1")).Return([]model.Block{
+		*board1,
+		{ID: "card", RootID: "board-1", ParentID: "board-1", Type: model.TypeCard},
+	}, nil)
+	th.Store.EXPECT().GetBlocksWithRootID(gomock.Eq(container), gomock.Eq("board-2")).Return([]model.Block{
+		*board2,
+		hidden,
+		{ID: "hidden-text", RootID: "board-2", ParentID: "hidden", Type: model.TypeText},
+	}, nil)
+
+	var buf bytes.Buffer
+	err := th.App.ExportBoards(container, []string{"board-1", "missing", "board-2", "board-1"}, "user-id", true, &buf)
+	require.NoError(t, err)
+
+	var export model.BoardsExport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &export))
+	ids := []string{}
+	for _, block := range export.Blocks {
+		ids = append(ids, block.ID)
+	}
+	require.Equal(t, []string{"board-1", "card", "board-2"}, ids)
+	require.Equal(t, []model.SkippedBoard{{BoardID: "missing", Reason: "board not found"}}, export.Skipped)
+}
@@ -1,11 +1,15 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"path/filepath"
 	"strings"
 
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -13,7 +17,41 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 )
 
-func (a *App) SaveFile(reader io.Reader, workspaceID, rootID, filename string) (string, error) {
+// ErrFileTypeNotAllowed is returned when uploading a file with an extension
+// that isn't in the allowed file extensions of its board.
+type ErrFileTypeNotAllowed struct {
+	RootID    string
+	Extension string
+}
+
+func (e ErrFileTypeNotAllowed) Error() string {
+	return fmt.Sprintf("files of type %s are not allowed on board %s", e.Extension, e.RootID)
+}
+
+// IsErrFileTypeNotAllowed returns true if `err` is or wraps an ErrFileTypeNotAllowed.
+func IsErrFileTypeNotAllowed(err error) bool {
+	var eftna ErrFileTypeNotAllowed
+	return errors.As(err, &eftna)
+}
+
+// CheckFileAllowed returns ErrFileTypeNotAllowed if the board doesn't allow
+// uploading files with the extension of filename.
+func (a *App) CheckFileAllowed(c store.Container, rootID, filename string) error {
+	board, err := a.store.GetBlock(c, rootID)
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(filename)
+	if board != nil && !board.AllowsFileExtension(ext) {
+		return ErrFileTypeNotAllowed{RootID: rootID, Extension: ext}
+	}
+	return nil
+}
+
+// SaveFile stores the contents of an uploaded file under a new file ID,
+// returning the metadata of the stored file.
+func (a *App) SaveFile(reader io.Reader, workspaceID, rootID, filename string) (*model.FileInfo, error) {
 	// NOTE: File extension includes the dot
 	fileExtension := strings.ToLower(filepath.Ext(filename))
 	if fileExtension == ".jpeg" {
@@ -23,19 +61,39 @@ func (a *App) SaveFile(reader io.Reader, workspaceID, rootID, filename string) (
 	createdFilename := fmt.Sprintf(`%s%s`, utils.NewID(utils.IDTypeNone), fileExtension)
 	filePath := filepath.Join(workspaceID, rootID, createdFilename)
 
-	_, appErr := a.filesBackend.WriteFile(reader, filePath)
+	size, appErr := a.filesBackend.WriteFile(reader, filePath)
+	if appErr != nil && a.secondaryFilesBackend != nil {
+		size, appErr = a.writeFileToSecondary(reader, filePath, appErr)
+	}
 	if appErr != nil {
-		return "", fmt.Errorf("unable to store the file in the files storage: %w", appErr)
+		return nil, fmt.Errorf("unable to store the file in the files storage: %w", appErr)
 	}
 
-	return createdFilename, nil
+	return &model.FileInfo{
+		ID:          createdFilename,
+		Filename:    filepath.Base(filename),
+		Size:        size,
+		ContentType: mime.TypeByExtension(fileExtension),
+		CreateAt:    utils.GetMillis(),
+	}, nil
 }
 
+// GetFileReader returns a reader of the file, from the secondary files
+// storage if the primary one fails or doesn't have the file. The files
+// storage serving the file is logged.
 func (a *App) GetFileReader(workspaceID, rootID, filename string) (filestore.ReadCloseSeeker, error) {
 	filePath := filepath.Join(workspaceID, rootID, filename)
 	exists, err := a.filesBackend.FileExists(filePath)
 	if err != nil {
-		return nil, err
+		if a.secondaryFilesBackend == nil {
+			return nil, err
+		}
+		a.logger.Warn("Unable to read the file from the primary files storage, failing over to the secondary one",
+			mlog.String("path", filePath),
+			mlog.Err(err),
+		)
+		a.logger.Info("Serving file", mlog.String("path", filePath), mlog.String("storage", "secondary"))
+		return a.secondaryFilesBackend.Reader(filePath)
 	}
 	// FIXUP: Check the deprecated old location
 	if workspaceID == "0" && !exists {
@@ -60,10 +118,194 @@ func (a *App) GetFileReader(workspaceID, rootID, filename string) (filestore.Rea
 		}
 	}
 
+	if !exists && a.secondaryFilesBackend != nil {
+		if secondaryExists, err2 := a.secondaryFilesBackend.FileExists(filePath); err2 == nil && secondaryExists {
+			a.logger.Info("Serving file", mlog.String("path", filePath), mlog.String("storage", "secondary"))
+			return a.secondaryFilesBackend.Reader(filePath)
+		}
+	}
+
 	reader, err := a.filesBackend.Reader(filePath)
 	if err != nil {
 		return nil, err
 	}
 
+	a.logger.Info("Serving file", mlog.String("path", filePath), mlog.String("storage", "primary"))
 	return reader, nil
 }
+
+// removeFile removes the file from the files storage, and from the secondary
+// one too, as the file may have been stored there on failover.
+func (a *App) removeFile(filePath string) error {
+	err := a.filesBackend.RemoveFile(filePath)
+	if a.secondaryFilesBackend == nil {
+		return err
+	}
+
+	exists, secondaryErr := a.secondaryFilesBackend.FileExists(filePath)
+	if secondaryErr == nil && exists {
+		secondaryErr = a.secondaryFilesBackend.RemoveFile(filePath)
+	}
+	if secondaryErr != nil {
+		a.logger.Error("Error removing file from the secondary files storage",
+			mlog.String("FilePath", filePath),
+			mlog.Err(secondaryErr))
+	}
+	return err
+}
+
+// fileSize returns the size of the file, from the secondary files storage if
+// the primary one fails to get it, as the file may have been stored there on
+// failover.
+func (a *App) fileSize(filePath string) (int64, error) {
+	size, err := a.filesBackend.FileSize(filePath)
+	if err == nil || a.secondaryFilesBackend == nil {
+		return size, err
+	}
+
+	if secondarySize, secondaryErr := a.secondaryFilesBackend.FileSize(filePath); secondaryErr == nil {
+		return secondarySize, nil
+	}
+	return size, err
+}
+
+// writeFileToSecondary stores the file in the secondary files storage after
+// writing it to the primary one failed with primaryErr. The reader is
+// rewound first, so the file can only fail over if it is seekable.
+func (a *App) writeFileToSecondary(reader io.Reader, filePath string, primaryErr error) (int64, error) {
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return 0, primaryErr
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return 0, primaryErr
+	}
+
+	a.logger.Warn("Unable to store the file in the primary files storage, failing over to the secondary one",
+		mlog.String("path", filePath),
+		mlog.Err(primaryErr),
+	)
+	size, err := a.secondaryFilesBackend.WriteFile(reader, filePath)
+	if err != nil {
+		return 0, err
+	}
+	a.logger.Debug("Stored file in the secondary files storage", mlog.String("path", filePath))
+	return size, nil
+}
+
+// AttachFileToCard stores the file, inserts an image block referencing it
+// under the card and appends the block to the content order of the card. Only
+// the content order of the card is patched, so concurrent changes to the card
+// are kept. The stored file is removed again if the blocks can't be saved, so
+// no upload is left orphaned.
+func (a *App) AttachFileToCard(c store.Container, boardID, cardID string, reader io.Reader, filename string, modifiedByID string) (*model.Block, error) {
+	if _, err := a.getCard(c, boardID, cardID); err != nil {
+		return nil, err
+	}
+	if err := a.CheckFileAllowed(c, boardID, filename); err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := a.SaveFile(reader, c.WorkspaceID, boardID, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	block := model.Block{
+		ID:       utils.NewID(model.BlockType2IDType(model.TypeImage)),
+		ParentID: cardID,
+		RootID:   boardID,
+		Schema:   1,
+		Type:     model.TypeImage,
+		Fields: map[string]interface{}{
+			"fileId":   fileInfo.ID,
+			"filename": fileInfo.Filename,
+		},
+		CreateAt: now,
+		UpdateAt: now,
+	}
+	blocks, err := a.InsertBlocks(c, []model.Block{block}, modifiedByID, true)
+	if err != nil {
+		filePath := filepath.Join(c.WorkspaceID, boardID, fileInfo.ID)
+		if removeErr := a.removeFile(filePath); removeErr != nil {
+			a.logger.Error("Error removing attached file",
+				mlog.String("FilePath", filePath),
+				mlog.Err(removeErr))
+		}
+		return nil, err
+	}
+	block = blocks[0]
+
+	if err = a.appendToContentOrder(c, boardID, cardID, block.ID, modifiedByID); err != nil {
+		if _, deleteErr := a.DeleteBlock(c, block.ID, modifiedByID); deleteErr != nil {
+			a.logger.Error("Error deleting attached file block",
+				mlog.String("blockID", block.ID),
+				mlog.Err(deleteErr))
+		}
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// appendToContentOrder patches the content order of the card, reading it
+// right before, to append blockID.
+func (a *App) appendToContentOrder(c store.Container, boardID, cardID, blockID string, modifiedByID string) error {
+	card, err := a.getCard(c, boardID, cardID)
+	if err != nil {
+		return err
+	}
+
+	contentOrder, _ := card.Fields["contentOrder"].([]interface{})
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+		"contentOrder": append(contentOrder, blockID),
+	}}
+	_, err = a.PatchBlock(c, cardID, patch, modifiedByID)
+	return err
+}
+
+// GetCardAttachments returns the metadata of the files attached to a card.
+func (a *App) GetCardAttachments(c store.Container, boardID, cardID string) ([]model.AttachmentInfo, error) {
+	if _, err := a.getCard(c, boardID, cardID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetBlocksWithParentAndType(c, cardID, model.TypeImage)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]model.AttachmentInfo, 0, len(blocks))
+	for _, block := range blocks {
+		fileID, _ := block.Fields["fileId"].(string)
+		if fileID == "" {
+			continue
+		}
+
+		filename, _ := block.Fields["filename"].(string)
+		if filename == "" {
+			filename = fileID
+		}
+
+		filePath := filepath.Join(c.WorkspaceID, boardID, fileID)
+		size, err := a.fileSize(filePath)
+		if err != nil {
+			a.logger.Warn("Cannot get size of attached file",
+				mlog.String("FilePath", filePath),
+				mlog.Err(err))
+		}
+
+		attachments = append(attachments, model.AttachmentInfo{
+			BlockID:     block.ID,
+			FileID:      fileID,
+			Filename:    filename,
+			Size:        size,
+			ContentType: mime.TypeByExtension(filepath.Ext(fileID)),
+			UploadedBy:  block.CreatedBy,
+			UploadedAt:  block.CreateAt,
+		})
+	}
+
+	return attachments, nil
+}
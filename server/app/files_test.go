@@ -2,9 +2,13 @@ package app
 
 import (
 	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
@@ -173,6 +177,38 @@ func TestGetFileReader(t *testing.T) {
 		actual, _ := th.App.GetFileReader(workspaceid, testRootID, testFileName)
 		assert.Equal(t, mockedReadCloseSeek, actual)
 	})
+
+	t.Run("should get file reader from the secondary filestore", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("FileExists", testFilePath).Return(false, nil)
+		mockedSecondaryFileBackend.On("FileExists", testFilePath).Return(true, nil)
+		mockedSecondaryFileBackend.On("Reader", testFilePath).Return(mockedReadCloseSeek, nil)
+
+		actual, err := th.App.GetFileReader("1", testRootID, testFileName)
+		assert.NoError(t, err)
+		assert.Equal(t, mockedReadCloseSeek, actual)
+		mockedFileBackend.AssertNotCalled(t, "Reader", testFilePath)
+	})
+
+	t.Run("should fail over to the secondary filestore when file exists return error", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("FileExists", testFilePath).Return(false, &TestError{})
+		mockedSecondaryFileBackend.On("Reader", testFilePath).Return(mockedReadCloseSeek, nil)
+
+		actual, err := th.App.GetFileReader("1", testRootID, testFileName)
+		assert.NoError(t, err)
+		assert.Equal(t, mockedReadCloseSeek, actual)
+	})
 }
 
 func TestSaveFile(t *testing.T) {
@@ -196,9 +232,12 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
-		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", testRootID, fileName)
-		assert.Equal(t, fileName, actual)
+		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", testRootID, "temp-file-name.txt")
 		assert.Nil(t, err)
+		assert.Equal(t, fileName, actual.ID)
+		assert.Equal(t, "temp-file-name.txt", actual.Filename)
+		assert.Equal(t, int64(10), actual.Size)
+		assert.NotZero(t, actual.CreateAt)
 	})
 
 	t.Run("should save .jpeg file as jpg file to file store using file backend", func(t *testing.T) {
@@ -222,6 +261,7 @@ func TestSaveFile(t *testing.T) {
 		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", "test-root-id", fileName)
 		assert.Nil(t, err)
 		assert.NotNil(t, actual)
+		assert.Equal(t, "image/jpeg", actual.ContentType)
 	})
 
 	t.Run("should return error when fileBackend.WriteFile returns error", func(t *testing.T) {
@@ -244,7 +284,164 @@ func TestSaveFile(t *testing.T) {
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
 		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", "test-root-id", fileName)
-		assert.Equal(t, "", actual)
+		assert.Nil(t, actual)
 		assert.Equal(t, "unable to store the file in the files storage: Mocked File backend error", err.Error())
 	})
+
+	t.Run("should fail over to the secondary filestore when fileBackend.WriteFile returns error", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		reader := strings.NewReader("file contents")
+		mockedFileBackend.On("WriteFile", reader, mock.Anything).Return(
+			func(reader io.Reader, path string) int64 {
+				_, _ = ioutil.ReadAll(reader)
+				return 0
+			},
+			func(reader io.Reader, path string) error {
+				return &TestError{}
+			},
+		)
+		mockedSecondaryFileBackend.On("WriteFile", reader, mock.Anything).Return(
+			func(reader io.Reader, path string) int64 {
+				data, _ := ioutil.ReadAll(reader)
+				assert.Equal(t, "file contents", string(data))
+				return int64(len(data))
+			},
+			func(reader io.Reader, path string) error {
+				return nil
+			},
+		)
+
+		actual, err := th.App.SaveFile(reader, "1", testRootID, "temp-file-name.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(13), actual.Size)
+	})
+
+	t.Run("should not fail over when the file can't be rewound", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		reader := io.LimitReader(strings.NewReader("file contents"), 100)
+		mockedFileBackend.On("WriteFile", reader, mock.Anything).Return(int64(0), &TestError{})
+
+		actual, err := th.App.SaveFile(reader, "1", testRootID, "temp-file-name.txt")
+		assert.Nil(t, actual)
+		assert.Error(t, err)
+		mockedSecondaryFileBackend.AssertNotCalled(t, "WriteFile", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckFileAllowed(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     testRootID,
+		RootID: testRootID,
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{model.AllowedFileExtensionsField: []interface{}{".png"}},
+	}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq(testRootID)).Return(board, nil).AnyTimes()
+
+	t.Run("allowed extension", func(t *testing.T) {
+		assert.NoError(t, th.App.CheckFileAllowed(container, testRootID, "image.PNG"))
+	})
+
+	t.Run("extension not allowed", func(t *testing.T) {
+		err := th.App.CheckFileAllowed(container, testRootID, "script.sh")
+		assert.True(t, IsErrFileTypeNotAllowed(err))
+	})
+}
+
+func TestRemoveFile(t *testing.T) {
+	th, _ := SetupTestHelper(t)
+
+	t.Run("should remove the file from both filestores", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("RemoveFile", testFilePath).Return(nil)
+		mockedSecondaryFileBackend.On("FileExists", testFilePath).Return(true, nil)
+		mockedSecondaryFileBackend.On("RemoveFile", testFilePath).Return(nil)
+
+		assert.NoError(t, th.App.removeFile(testFilePath))
+		mockedFileBackend.AssertCalled(t, "RemoveFile", testFilePath)
+		mockedSecondaryFileBackend.AssertCalled(t, "RemoveFile", testFilePath)
+	})
+
+	t.Run("should skip the secondary filestore without the file", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("RemoveFile", testFilePath).Return(nil)
+		mockedSecondaryFileBackend.On("FileExists", testFilePath).Return(false, nil)
+
+		assert.NoError(t, th.App.removeFile(testFilePath))
+		mockedSecondaryFileBackend.AssertNotCalled(t, "RemoveFile", testFilePath)
+	})
+}
+
+func TestFileSize(t *testing.T) {
+	th, _ := SetupTestHelper(t)
+
+	t.Run("should get the size from the primary filestore", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("FileSize", testFilePath).Return(int64(10), nil)
+
+		size, err := th.App.fileSize(testFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), size)
+		mockedSecondaryFileBackend.AssertNotCalled(t, "FileSize", testFilePath)
+	})
+
+	t.Run("should fall back to the secondary filestore", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		mockedFileBackend.On("FileSize", testFilePath).Return(int64(0), &TestError{})
+		mockedSecondaryFileBackend.On("FileSize", testFilePath).Return(int64(20), nil)
+
+		size, err := th.App.fileSize(testFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(20), size)
+	})
+
+	t.Run("should return the primary error when both filestores miss", func(t *testing.T) {
+		mockedFileBackend := &mocks.FileBackend{}
+		mockedSecondaryFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+		th.App.secondaryFilesBackend = mockedSecondaryFileBackend
+		defer func() { th.App.secondaryFilesBackend = nil }()
+
+		primaryErr := &TestError{}
+		mockedFileBackend.On("FileSize", testFilePath).Return(int64(0), primaryErr)
+		mockedSecondaryFileBackend.On("FileSize", testFilePath).Return(int64(0), &TestError{})
+
+		_, err := th.App.fileSize(testFilePath)
+		assert.Equal(t, primaryErr, err)
+	})
 }
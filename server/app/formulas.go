@@ -0,0 +1,283 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+var errFormulaValue = errors.New("formula value is not a number")
+
+// checkFormulas returns ErrInvalidFormula if the formulas of an inserted
+// board are invalid or circular.
+func checkFormulas(blocks []model.Block) error {
+	for i := range blocks {
+		if blocks[i].Type != model.TypeBoard {
+			continue
+		}
+		if err := blocks[i].CheckFormulas(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPatchedFormulas returns ErrInvalidFormula if the patch sets the
+// properties of a board to formulas that are invalid or circular.
+func checkPatchedFormulas(block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil || block.Type != model.TypeBoard {
+		return nil
+	}
+	cardProperties, ok := blockPatch.UpdatedFields["cardProperties"]
+	if !ok {
+		return nil
+	}
+
+	patched := model.Block{Type: block.Type, Fields: map[string]interface{}{"cardProperties": cardProperties}}
+	return patched.CheckFormulas()
+}
+
+// ComputeFormulas sets Computed on the cards in blocks, evaluating the
+// formula properties of their board. Formulas that can't be evaluated, for
+// instance because a property they reference has no value, are set to nil.
+// If restricted, the children that userID can't see aren't counted.
+func (a *App) ComputeFormulas(c store.Container, blocks []model.Block, userID string, restricted bool) error {
+	schemas := map[string]model.PropSchema{}
+	children := &formulaChildren{app: a, container: c, userID: userID, restricted: restricted}
+	now := utils.GetMillis()
+
+	for i := range blocks {
+		if blocks[i].Type != model.TypeCard {
+			continue
+		}
+
+		schema, ok := schemas[blocks[i].RootID]
+		if !ok {
+			var err error
+			if schema, err = a.getFormulaSchema(c, blocks[i].RootID); err != nil {
+				return err
+			}
+			schemas[blocks[i].RootID] = schema
+		}
+
+		eval := &formulaEvaluator{children: children, card: &blocks[i], schema: schema, now: now}
+		for id, prop := range schema {
+			if prop.Type != model.PropTypeFormula {
+				continue
+			}
+			var computed interface{}
+			value, err := eval.property(prop)
+			switch {
+			case err == nil:
+				computed = value
+			case !errors.Is(err, errFormulaValue) && !errors.Is(err, model.ErrInvalidFormula):
+				return err
+			}
+			if blocks[i].Computed == nil {
+				blocks[i].Computed = map[string]interface{}{}
+			}
+			blocks[i].Computed[id] = computed
+		}
+	}
+	return nil
+}
+
+// getFormulaSchema returns the properties of a board if it has formula
+// properties, or an empty schema otherwise.
+func (a *App) getFormulaSchema(c store.Container, boardID string) (model.PropSchema, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return model.PropSchema{}, nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	for _, prop := range schema {
+		if prop.Type == model.PropTypeFormula {
+			return schema, nil
+		}
+	}
+	return model.PropSchema{}, nil
+}
+
+// formulaChildren loads the children of the cards counted by formulas,
+// once per board rather than once per card.
+type formulaChildren struct {
+	app        *App
+	container  store.Container
+	userID     string
+	restricted bool
+
+	// byBoard holds the children of the cards of each loaded board, by card
+	byBoard map[string]map[string][]model.Block
+}
+
+// of returns the children of card that can be counted.
+func (fc *formulaChildren) of(card *model.Block) ([]model.Block, error) {
+	byCard, ok := fc.byBoard[card.RootID]
+	if !ok {
+		children, err := fc.app.store.GetCardChildrenWithRootID(fc.container, card.RootID)
+		if err != nil {
+			return nil, err
+		}
+		byCard = map[string][]model.Block{}
+		for i := range children {
+			if fc.restricted && isHiddenCard(&children[i], fc.userID) {
+				continue
+			}
+			byCard[children[i].ParentID] = append(byCard[children[i].ParentID], children[i])
+		}
+		if fc.byBoard == nil {
+			fc.byBoard = map[string]map[string][]model.Block{}
+		}
+		fc.byBoard[card.RootID] = byCard
+	}
+	return byCard[card.ID], nil
+}
+
+// formulaEvaluator evaluates the formulas of a card.
+type formulaEvaluator struct {
+	children *formulaChildren
+	card     *model.Block
+	schema   model.PropSchema
+	now      int64
+
+	// evaluating holds the formula properties being evaluated, to stop on
+	// circular references
+	evaluating map[string]bool
+}
+
+// property returns the value of a property of the card as a number.
+func (e *formulaEvaluator) property(prop model.PropDef) (float64, error) {
+	switch prop.Type {
+	case model.PropTypeFormula:
+		if e.evaluating[prop.ID] {
+			return 0, fmt.Errorf("%w: circular reference", model.ErrInvalidFormula)
+		}
+		if e.evaluating == nil {
+			e.evaluating = map[string]bool{}
+		}
+		e.evaluating[prop.ID] = true
+		defer delete(e.evaluating, prop.ID)
+
+		expr, err := model.ParseFormula(prop.Formula)
+		if err != nil {
+			return 0, err
+		}
+		return e.evaluate(expr)
+	case "createdTime":
+		return float64(e.card.CreateAt), nil
+	case "updatedTime":
+		return float64(e.card.UpdateAt), nil
+	}
+
+	props, _ := e.card.Fields["properties"].(map[string]interface{})
+	value, ok := props[prop.ID]
+	if !ok {
+		return 0, errFormulaValue
+	}
+	if prop.Type == "date" {
+		date, ok := value.(string)
+		if !ok {
+			return 0, errFormulaValue
+		}
+		from, _, err := model.ParseDateRange(date)
+		if err != nil {
+			return 0, errFormulaValue
+		}
+		return float64(from), nil
+	}
+
+	number, err := strconv.ParseFloat(fmt.Sprintf("%v", value), 64)
+	if err != nil {
+		return 0, errFormulaValue
+	}
+	return number, nil
+}
+
+func (e *formulaEvaluator) evaluate(expr *model.FormulaExpr) (float64, error) {
+	if expr.Op == model.FormulaNumber {
+		return expr.Number, nil
+	}
+	if expr.Op == model.FormulaCall {
+		return e.call(expr)
+	}
+
+	args := make([]float64, len(expr.Args))
+	for i, arg := range expr.Args {
+		value, err := e.evaluate(arg)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = value
+	}
+
+	switch expr.Op {
+	case model.FormulaNeg:
+		return -args[0], nil
+	case "+":
+		return args[0] + args[1], nil
+	case "-":
+		return args[0] - args[1], nil
+	case "*":
+		return args[0] * args[1], nil
+	case "/":
+		if args[1] == 0 {
+			return 0, errFormulaValue
+		}
+		return args[0] / args[1], nil
+	}
+	return 0, errFormulaValue
+}
+
+func (e *formulaEvaluator) call(expr *model.FormulaExpr) (float64, error) {
+	switch expr.Func {
+	case "prop":
+		prop, ok := e.schema.Property(expr.Args[0].String)
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown property %q", model.ErrInvalidFormula, expr.Args[0].String)
+		}
+		return e.property(prop)
+
+	case "now":
+		return float64(e.now), nil
+
+	case "dateDiff":
+		to, err := e.evaluate(expr.Args[0])
+		if err != nil {
+			return 0, err
+		}
+		from, err := e.evaluate(expr.Args[1])
+		if err != nil {
+			return 0, err
+		}
+		return math.Trunc((to - from) / float64(daysToMillis(1))), nil
+
+	case "countChildren":
+		children, err := e.children.of(e.card)
+		if err != nil {
+			return 0, err
+		}
+		if len(expr.Args) == 0 {
+			return float64(len(children)), nil
+		}
+		count := 0
+		for _, child := range children {
+			if child.Type == model.BlockType(expr.Args[0].String) {
+				count++
+			}
+		}
+		return float64(count), nil
+	}
+	return 0, fmt.Errorf("%w: unknown function %s", model.ErrInvalidFormula, expr.Func)
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestComputeFormulas(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "estimate", "name": "Estimate", "type": "number"},
+				map[string]interface{}{"id": "due", "name": "Due", "type": "date"},
+				map[string]interface{}{"id": "double", "name": "Double", "type": model.PropTypeFormula, "formula": `prop("Estimate") * 2`},
+				map[string]interface{}{"id": "quad", "name": "Quad", "type": model.PropTypeFormula, "formula": `prop("double") + prop("Double")`},
+				map[string]interface{}{"id": "length", "name": "Length", "type": model.PropTypeFormula, "formula": `dateDiff(prop("Due"), 0)`},
+				map[string]interface{}{"id": "tasks", "name": "Tasks", "type": model.PropTypeFormula, "formula": `countChildren("checkbox")`},
+			},
+		},
+	}
+	card := model.Block{
+		ID:     "card-id",
+		RootID: "board-id",
+		Type:   model.TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"estimate": "1.5",
+				"due":      `{"from":259200000}`,
+			},
+		},
+	}
+	children := []model.Block{
+		{ID: "checkbox-1", ParentID: "card-id", Type: model.TypeCheckbox},
+		{ID: "checkbox-2", ParentID: "card-id", Type: model.TypeCheckbox},
+		{ID: "text", ParentID: "card-id", Type: model.TypeText},
+	}
+
+	t.Run("compute the formulas of a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetCardChildrenWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(children, nil)
+
+		blocks := []model.Block{*board, card}
+		require.NoError(t, th.App.ComputeFormulas(container, blocks, "", false))
+		require.Nil(t, blocks[0].Computed)
+		require.Equal(t, map[string]interface{}{
+			"double": 3.0,
+			"quad":   6.0,
+			"length": 3.0,
+			"tasks":  2.0,
+		}, blocks[1].Computed)
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		empty := model.Block{ID: "empty-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetCardChildrenWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(children, nil)
+
+		blocks := []model.Block{empty}
+		require.NoError(t, th.App.ComputeFormulas(container, blocks, "", false))
+		require.Equal(t, map[string]interface{}{
+			"double": nil,
+			"quad":   nil,
+			"length": nil,
+			"tasks":  0.0,
+		}, blocks[0].Computed)
+	})
+
+	t.Run("children loaded once per board", func(t *testing.T) {
+		other := model.Block{ID: "other-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{}}
+		otherChildren := append([]model.Block{
+			{ID: "checkbox-3", ParentID: "other-id", Type: model.TypeCheckbox},
+		}, children...)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetCardChildrenWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(otherChildren, nil).Times(1)
+
+		blocks := []model.Block{card, other}
+		require.NoError(t, th.App.ComputeFormulas(container, blocks, "", false))
+		require.Equal(t, 2.0, blocks[0].Computed["tasks"])
+		require.Equal(t, 1.0, blocks[1].Computed["tasks"])
+	})
+
+	t.Run("restricted children not counted", func(t *testing.T) {
+		count := &model.Block{
+			ID:   "board-id",
+			Type: model.TypeBoard,
+			Fields: map[string]interface{}{
+				"cardProperties": []interface{}{
+					map[string]interface{}{"id": "subcards", "name": "Subcards", "type": model.PropTypeFormula, "formula": `countChildren("card")`},
+				},
+			},
+		}
+		subcards := []model.Block{
+			{ID: "subcard-1", ParentID: "card-id", Type: model.TypeCard},
+			{ID: "subcard-2", ParentID: "card-id", Type: model.TypeCard, Fields: map[string]interface{}{
+				model.RestrictedToField: []interface{}{"user-id-2"},
+			}},
+		}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(count, nil).Times(2)
+		th.Store.EXPECT().GetCardChildrenWithRootID(gomock.Eq(container), gomock.Eq("board-id")).Return(subcards, nil).Times(2)
+
+		blocks := []model.Block{card}
+		require.NoError(t, th.App.ComputeFormulas(container, blocks, "user-id-1", true))
+		require.Equal(t, 1.0, blocks[0].Computed["subcards"])
+
+		blocks = []model.Block{card}
+		require.NoError(t, th.App.ComputeFormulas(container, blocks, "user-id-2", true))
+		require.Equal(t, 2.0, blocks[0].Computed["subcards"])
+	})
+
+	t.Run("patch with circular formulas", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "a", "name": "A", "type": model.PropTypeFormula, "formula": `prop("b")`},
+				map[string]interface{}{"id": "b", "name": "B", "type": model.PropTypeFormula, "formula": `prop("a")`},
+			},
+		}}
+		require.ErrorIs(t, checkPatchedFormulas(board, patch), model.ErrInvalidFormula)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/services/config"
@@ -27,13 +28,17 @@ type TestHelper struct {
 func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	cfg := config.Configuration{}
+	cfg := config.Configuration{
+		// the webhook tests send to local test servers
+		WebhookAllowedHosts: []string{"127.0.0.1"},
+	}
 	store := mockstore.NewMockStore(ctrl)
 	auth := auth.New(&cfg, store)
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
 	sessionToken := "TESTTOKEN"
-	wsserver := ws.NewServer(auth, sessionToken, false, logger)
-	webhook := webhook.NewClient(&cfg, logger)
+	wsserver := ws.NewServer(auth, store, sessionToken, false, logger)
+	webhook, err := webhook.NewClient(&cfg, logger)
+	require.NoError(t, err)
 	metricsService := metrics.NewMetrics(metrics.InstanceInfo{})
 
 	appServices := Services{
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// HistoryRetention returns the effective retention of the history of the
+// blocks, the negative limits of the configuration disabling them.
+func (a *App) HistoryRetention() *model.HistoryRetention {
+	retention := &model.HistoryRetention{}
+	if a.config.BlockHistoryMaxAgeDays > 0 {
+		retention.MaxAgeDays = a.config.BlockHistoryMaxAgeDays
+	}
+	if a.config.BlockHistoryMaxVersions > 0 {
+		retention.MaxVersions = a.config.BlockHistoryMaxVersions
+	}
+	return retention
+}
+
+// PruneBlockHistory deletes the versions of the blocks beyond the retention
+// of their history. The current version of the blocks is always kept.
+func (a *App) PruneBlockHistory() error {
+	retention := a.HistoryRetention()
+	if !retention.Enabled() {
+		return nil
+	}
+
+	var before int64
+	if retention.MaxAgeDays > 0 {
+		before = utils.GetMillis() - daysToMillis(retention.MaxAgeDays)
+	}
+
+	deleted, err := a.store.PruneBlockHistory(before, retention.MaxVersions)
+	if err != nil {
+		return err
+	}
+	a.logger.Debug("Block history pruned", mlog.Int64("deleted", deleted))
+	return nil
+}
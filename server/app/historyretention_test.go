@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func TestPruneBlockHistory(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	defer func() {
+		th.App.config.BlockHistoryMaxAgeDays = 0
+		th.App.config.BlockHistoryMaxVersions = 0
+	}()
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.config.BlockHistoryMaxAgeDays = -1
+		th.App.config.BlockHistoryMaxVersions = 0
+		require.Equal(t, &model.HistoryRetention{}, th.App.HistoryRetention())
+		require.NoError(t, th.App.PruneBlockHistory())
+	})
+
+	t.Run("by number of versions", func(t *testing.T) {
+		th.App.config.BlockHistoryMaxAgeDays = 0
+		th.App.config.BlockHistoryMaxVersions = 10
+		th.Store.EXPECT().PruneBlockHistory(int64(0), 10).Return(int64(3), nil)
+		require.NoError(t, th.App.PruneBlockHistory())
+	})
+
+	t.Run("by age", func(t *testing.T) {
+		th.App.config.BlockHistoryMaxAgeDays = 30
+		th.App.config.BlockHistoryMaxVersions = 0
+		now := utils.GetMillis()
+		th.Store.EXPECT().PruneBlockHistory(gomock.Any(), 0).DoAndReturn(func(before int64, _ int) (int64, error) {
+			require.InDelta(t, now-daysToMillis(30), before, 60000)
+			return 0, nil
+		})
+		require.NoError(t, th.App.PruneBlockHistory())
+		require.Equal(t, &model.HistoryRetention{MaxAgeDays: 30}, th.App.HistoryRetention())
+	})
+}
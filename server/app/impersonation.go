@@ -0,0 +1,62 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// ErrImpersonationDisabled is returned when the impersonation expire time
+// isn't positive, as the sessions would expire as soon as they are created.
+var ErrImpersonationDisabled = errors.New("impersonation_expire_time must be positive to impersonate users")
+
+// ImpersonateUser creates a session acting as the user, for an admin to see
+// what the user sees. The session records the admin and the reason, and
+// expires after the impersonation expire time regardless of activity.
+func (a *App) ImpersonateUser(userID, adminID, reason string, readOnly bool) (*model.Session, error) {
+	if a.config.ImpersonationExpireTime <= 0 {
+		return nil, ErrImpersonationDisabled
+	}
+
+	user, err := a.store.GetUserByID(userID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user == nil) {
+		return nil, store.NewErrNotFound(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	authService := user.AuthService
+	if authService == "" {
+		authService = "native"
+	}
+
+	session := &model.Session{
+		ID:          utils.NewID(utils.IDTypeSession),
+		Token:       utils.NewID(utils.IDTypeToken),
+		UserID:      user.ID,
+		AuthService: authService,
+		Props: map[string]interface{}{
+			model.SessionPropImpersonatedBy:      adminID,
+			model.SessionPropImpersonationReason: reason,
+			model.SessionPropReadOnly:            readOnly,
+			model.SessionPropExpiresAt:           utils.GetMillis() + utils.SecondsToMillis(a.config.ImpersonationExpireTime),
+		},
+	}
+	if err := a.store.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("Impersonation session created",
+		mlog.String("userID", user.ID),
+		mlog.String("impersonatedBy", adminID),
+		mlog.String("sessionID", session.ID),
+		mlog.Bool("readOnly", readOnly),
+	)
+	return session, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonateUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("creates a read-only session for the user", func(t *testing.T) {
+		th.App.config.ImpersonationExpireTime = 60
+		th.Store.EXPECT().GetUserByID(mockUser.ID).Return(mockUser, nil)
+		th.Store.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+		before := utils.GetMillis()
+		session, err := th.App.ImpersonateUser(mockUser.ID, "admin", "support ticket", true)
+		require.NoError(t, err)
+		require.Equal(t, mockUser.ID, session.UserID)
+		require.Equal(t, "native", session.AuthService)
+		require.Equal(t, "admin", session.ImpersonatedBy())
+		require.Equal(t, "support ticket", session.Props[model.SessionPropImpersonationReason])
+		require.True(t, session.IsReadOnly())
+		require.False(t, session.IsExpired(before+utils.SecondsToMillis(59)))
+		require.True(t, session.IsExpired(utils.GetMillis()+utils.SecondsToMillis(60)))
+	})
+
+	t.Run("expire time not positive", func(t *testing.T) {
+		for _, expireTime := range []int64{0, -60} {
+			th.App.config.ImpersonationExpireTime = expireTime
+
+			_, err := th.App.ImpersonateUser(mockUser.ID, "admin", "support ticket", true)
+			require.ErrorIs(t, err, ErrImpersonationDisabled)
+		}
+		th.App.config.ImpersonationExpireTime = 60
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByID("missing").Return(nil, sql.ErrNoRows)
+
+		_, err := th.App.ImpersonateUser("missing", "admin", "support ticket", true)
+		require.True(t, store.IsErrNotFound(err))
+	})
+}
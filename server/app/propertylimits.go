@@ -0,0 +1,116 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// ErrPropertyValueTooLong is returned when a property of a card is set to a
+// value longer than the limit of its property type.
+type ErrPropertyValueTooLong struct {
+	CardID     string
+	PropertyID string
+	Limit      int
+}
+
+func (e ErrPropertyValueTooLong) Error() string {
+	return fmt.Sprintf("value of property %s of card %s is longer than %d characters", e.PropertyID, e.CardID, e.Limit)
+}
+
+// IsErrPropertyValueTooLong returns true if `err` is or wraps an ErrPropertyValueTooLong.
+func IsErrPropertyValueTooLong(err error) bool {
+	var epvtl ErrPropertyValueTooLong
+	return errors.As(err, &epvtl)
+}
+
+// checkPropertyValueLengths returns ErrPropertyValueTooLong if a card in
+// blocks has a property value longer than the limit of its type. Boards
+// inserted along with the cards are used with their new properties.
+func (a *App) checkPropertyValueLengths(c store.Container, blocks []model.Block) error {
+	if len(a.config.PropertyValueMaxLengths) == 0 {
+		return nil
+	}
+
+	boards := map[string]*model.Block{}
+	for i := range blocks {
+		if blocks[i].ID == blocks[i].RootID && blocks[i].Type == model.TypeBoard {
+			boards[blocks[i].ID] = &blocks[i]
+		}
+	}
+
+	for _, card := range blocks {
+		props, ok := card.Fields["properties"].(map[string]interface{})
+		if card.Type != model.TypeCard || !ok {
+			continue
+		}
+
+		board, ok := boards[card.RootID]
+		if !ok {
+			var err error
+			if board, err = a.store.GetBlock(c, card.RootID); err != nil {
+				return err
+			}
+			boards[card.RootID] = board
+		}
+		if err := a.checkCardPropertyLengths(board, card.ID, props); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPatchedPropertyValueLengths returns ErrPropertyValueTooLong if the
+// patch sets a property of a card to a value longer than the limit of its
+// type.
+func (a *App) checkPatchedPropertyValueLengths(c store.Container, block *model.Block, blockPatch *model.BlockPatch) error {
+	if len(a.config.PropertyValueMaxLengths) == 0 || block == nil || blockPatch == nil || block.Type != model.TypeCard {
+		return nil
+	}
+	props, ok := blockPatch.UpdatedFields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	board, err := a.store.GetBlock(c, block.RootID)
+	if err != nil {
+		return err
+	}
+	return a.checkCardPropertyLengths(board, block.ID, props)
+}
+
+func (a *App) checkCardPropertyLengths(board *model.Block, cardID string, props map[string]interface{}) error {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil
+	}
+
+	for propID, value := range props {
+		limit := a.config.PropertyValueMaxLengths[schema[propID].Type]
+		if limit > 0 && propertyValueLength(value) > limit {
+			return ErrPropertyValueTooLong{CardID: cardID, PropertyID: propID, Limit: limit}
+		}
+	}
+	return nil
+}
+
+// propertyValueLength returns the length in characters of a property value,
+// or of its longest element if it is a list.
+func propertyValueLength(value interface{}) int {
+	switch v := value.(type) {
+	case string:
+		return utf8.RuneCountInString(v)
+	case []interface{}:
+		longest := 0
+		for _, item := range v {
+			if length := propertyValueLength(item); length > longest {
+				longest = length
+			}
+		}
+		return longest
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestPropertyValueLengths(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+	board := &model.Block{
+		ID:     "board-id",
+		RootID: "board-id",
+		Type:   model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "notes", "type": "text"},
+				map[string]interface{}{"id": "link", "type": "url"},
+				map[string]interface{}{"id": "tags", "type": "multiSelect"},
+			},
+		},
+	}
+	card := &model.Block{ID: "card-id", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("card-id")).Return(card, nil).AnyTimes()
+
+	th.App.config.PropertyValueMaxLengths = map[string]int{"text": 10, "url": 5}
+	defer func() { th.App.config.PropertyValueMaxLengths = nil }()
+
+	cardWithProperties := func(properties map[string]interface{}) []model.Block {
+		return []model.Block{{
+			ID:       "card-id",
+			RootID:   "board-id",
+			ParentID: "board-id",
+			Type:     model.TypeCard,
+			Fields:   map[string]interface{}{"properties": properties},
+		}}
+	}
+
+	t.Run("values within the limits", func(t *testing.T) {
+		blocks := cardWithProperties(map[string]interface{}{
+			"notes": "ten chars!",
+			"link":  "a.b/c",
+			"tags":  []interface{}{strings.Repeat("x", 100)},
+		})
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("limits count characters", func(t *testing.T) {
+		blocks := cardWithProperties(map[string]interface{}{"notes": "éééééééééé"})
+		th.Store.EXPECT().InsertBlocks(gomock.Eq(container), gomock.Any(), gomock.Eq("user-id-1")).Return(nil)
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("value too long on insert", func(t *testing.T) {
+		blocks := cardWithProperties(map[string]interface{}{"link": "https://example.com"})
+
+		_, err := th.App.InsertBlocks(container, blocks, "user-id-1", false)
+		require.Equal(t, ErrPropertyValueTooLong{CardID: "card-id", PropertyID: "link", Limit: 5}, err)
+	})
+
+	t.Run("board inserted with the card", func(t *testing.T) {
+		newBoard := *board
+		newBoard.ID, newBoard.RootID = "new-board-id", "new-board-id"
+		newCard := cardWithProperties(map[string]interface{}{"notes": strings.Repeat("x", 11)})[0]
+		newCard.RootID, newCard.ParentID = "new-board-id", "new-board-id"
+
+		_, err := th.App.InsertBlocks(container, []model.Block{newBoard, newCard}, "user-id-1", false)
+		require.True(t, IsErrPropertyValueTooLong(err))
+	})
+
+	t.Run("value too long on patch", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"notes": strings.Repeat("x", 11)},
+		}}
+
+		_, err := th.App.PatchBlock(container, "card-id", patch, "user-id-1")
+		require.True(t, IsErrPropertyValueTooLong(err))
+	})
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// checkRelations returns ErrInvalidRelation if the patch sets a relation
+// property of a card to a block that isn't an existing card.
+func (a *App) checkRelations(c store.Container, block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil || block.Type != model.TypeCard {
+		return nil
+	}
+	props, ok := blockPatch.UpdatedFields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	schema, err := a.getRelationSchema(c, block.RootID)
+	if err != nil || len(schema) == 0 {
+		return err
+	}
+
+	for propID, value := range props {
+		if _, ok := schema[propID]; !ok {
+			continue
+		}
+		cardIDs, err := model.RelationCardIDs(value)
+		if err != nil {
+			return model.ErrInvalidRelation
+		}
+		for _, cardID := range cardIDs {
+			card, err := a.store.GetBlock(c, cardID)
+			if err != nil {
+				return err
+			}
+			if card == nil || card.Type != model.TypeCard {
+				return model.ErrInvalidRelation
+			}
+		}
+	}
+	return nil
+}
+
+// ExpandRelations sets Relations on the cards in blocks, resolving the cards
+// referenced by their relation properties. Referenced cards that were deleted
+// are marked as dangling. If rootID isn't empty, only the titles of the cards
+// of that board are included. If restricted is true, the titles of the
+// restricted cards userID can't see aren't included either.
+func (a *App) ExpandRelations(c store.Container, blocks []model.Block, rootID, userID string, restricted bool) error {
+	schemas := map[string]model.PropSchema{}
+	related := map[string]*model.Block{}
+
+	for i := range blocks {
+		if blocks[i].Type != model.TypeCard {
+			continue
+		}
+
+		schema, ok := schemas[blocks[i].RootID]
+		if !ok {
+			var err error
+			if schema, err = a.getRelationSchema(c, blocks[i].RootID); err != nil {
+				return err
+			}
+			schemas[blocks[i].RootID] = schema
+		}
+
+		props, _ := blocks[i].Fields["properties"].(map[string]interface{})
+		for propID := range schema {
+			cardIDs, err := model.RelationCardIDs(props[propID])
+			if err != nil || len(cardIDs) == 0 {
+				continue
+			}
+
+			cards := make([]model.RelatedCard, 0, len(cardIDs))
+			for _, cardID := range cardIDs {
+				card, ok := related[cardID]
+				if !ok {
+					if card, err = a.store.GetBlock(c, cardID); err != nil {
+						return err
+					}
+					related[cardID] = card
+				}
+
+				relatedCard := model.RelatedCard{ID: cardID, Dangling: card == nil || card.Type != model.TypeCard}
+				isHidden := restricted && isHiddenCard(card, userID)
+				if !relatedCard.Dangling && !isHidden && (rootID == "" || card.RootID == rootID) {
+					relatedCard.BoardID = card.RootID
+					relatedCard.Title = card.Title
+				}
+				cards = append(cards, relatedCard)
+			}
+
+			if blocks[i].Relations == nil {
+				blocks[i].Relations = map[string][]model.RelatedCard{}
+			}
+			blocks[i].Relations[propID] = cards
+		}
+	}
+	return nil
+}
+
+// getRelationSchema returns the relation properties of a board, or an empty
+// schema if the board doesn't exist.
+func (a *App) getRelationSchema(c store.Container, boardID string) (model.PropSchema, error) {
+	board, err := a.store.GetBlock(c, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if board == nil || board.Type != model.TypeBoard {
+		return model.PropSchema{}, nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	relations := model.PropSchema{}
+	for id, prop := range schema {
+		if prop.Type == model.PropTypeRelation {
+			relations[id] = prop
+		}
+	}
+	return relations, nil
+}
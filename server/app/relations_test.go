@@ -0,0 +1,116 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+)
+
+func TestRelations(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "0",
+	}
+
+	board := &model.Block{
+		ID:   "board-id",
+		Type: model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "related", "type": "relation"},
+				map[string]interface{}{"id": "notes", "type": "text"},
+			},
+		},
+	}
+	card := &model.Block{ID: "card-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{}}
+	otherCard := &model.Block{ID: "other-card-id", RootID: "other-board-id", Type: model.TypeCard, Title: "Other card"}
+	view := &model.Block{ID: "view-id", RootID: "other-board-id", Type: model.TypeView}
+
+	t.Run("patch with an existing card", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"related": []interface{}{"other-card-id"}, "notes": "view-id"},
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("other-card-id")).Return(otherCard, nil)
+
+		require.NoError(t, th.App.checkRelations(container, card, patch))
+	})
+
+	t.Run("patch with a block that isn't a card", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"related": "view-id"},
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("view-id")).Return(view, nil)
+
+		require.ErrorIs(t, th.App.checkRelations(container, card, patch), model.ErrInvalidRelation)
+	})
+
+	t.Run("patch with a missing card", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"related": "missing-id"},
+		}}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing-id")).Return(nil, nil)
+
+		require.ErrorIs(t, th.App.checkRelations(container, card, patch), model.ErrInvalidRelation)
+	})
+
+	t.Run("expand relations", func(t *testing.T) {
+		blocks := []model.Block{
+			*board,
+			{ID: "card-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"related": []interface{}{"other-card-id", "missing-id"}},
+			}},
+		}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("other-card-id")).Return(otherCard, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("missing-id")).Return(nil, nil)
+
+		require.NoError(t, th.App.ExpandRelations(container, blocks, "", "", false))
+		require.Nil(t, blocks[0].Relations)
+		require.Equal(t, []model.RelatedCard{
+			{ID: "other-card-id", BoardID: "other-board-id", Title: "Other card"},
+			{ID: "missing-id", Dangling: true},
+		}, blocks[1].Relations["related"])
+	})
+
+	t.Run("expand relations restricted to a board", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "card-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"related": "other-card-id"},
+			}},
+		}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("other-card-id")).Return(otherCard, nil)
+
+		require.NoError(t, th.App.ExpandRelations(container, blocks, "board-id", "", false))
+		require.Equal(t, []model.RelatedCard{{ID: "other-card-id"}}, blocks[0].Relations["related"])
+	})
+
+	t.Run("expand relations to a hidden card", func(t *testing.T) {
+		hiddenCard := &model.Block{
+			ID:     "hidden-card-id",
+			RootID: "other-board-id",
+			Type:   model.TypeCard,
+			Title:  "Hidden card",
+			Fields: map[string]interface{}{model.RestrictedToField: []interface{}{"user-1"}},
+		}
+		blocks := []model.Block{
+			{ID: "card-id", RootID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"related": "hidden-card-id"},
+			}},
+		}
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("board-id")).Return(board, nil)
+		th.Store.EXPECT().GetBlock(gomock.Eq(container), gomock.Eq("hidden-card-id")).Return(hiddenCard, nil)
+
+		require.NoError(t, th.App.ExpandRelations(container, blocks, "", "user-2", true))
+		require.Equal(t, []model.RelatedCard{{ID: "hidden-card-id"}}, blocks[0].Relations["related"])
+	})
+}
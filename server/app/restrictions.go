@@ -0,0 +1,124 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// FilterRestrictedBlocks removes from blocks the restricted cards the user
+// can't see, along with the blocks in them. The cards that aren't in blocks
+// are read from the store.
+func (a *App) FilterRestrictedBlocks(c store.Container, blocks []model.Block, userID string) ([]model.Block, error) {
+	byID := make(map[string]*model.Block, len(blocks))
+	for i := range blocks {
+		byID[blocks[i].ID] = &blocks[i]
+	}
+
+	hidden := map[string]bool{}
+	result := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		cardID := block.ID
+		if block.Type != model.TypeCard {
+			cardID = block.ParentID
+		}
+
+		isHidden, ok := hidden[cardID]
+		if !ok {
+			card, found := byID[cardID]
+			if !found {
+				var err error
+				if card, err = a.getBlockIfSet(c, cardID); err != nil {
+					return nil, err
+				}
+			}
+			isHidden = isHiddenCard(card, userID)
+			hidden[cardID] = isHidden
+		}
+
+		if !isHidden {
+			result = append(result, block)
+		}
+	}
+	return result, nil
+}
+
+// CheckBlockVisible returns ErrBlockRestricted if the block is a card the
+// user can't see, or is in one.
+func (a *App) CheckBlockVisible(c store.Container, blockID string, userID string) error {
+	block, err := a.store.GetBlock(c, blockID)
+	if err != nil || block == nil {
+		return err
+	}
+
+	card := block
+	if block.Type != model.TypeCard {
+		if card, err = a.getBlockIfSet(c, block.ParentID); err != nil {
+			return err
+		}
+	}
+
+	if isHiddenCard(card, userID) {
+		return model.ErrBlockRestricted
+	}
+	return nil
+}
+
+func (a *App) getBlockIfSet(c store.Container, blockID string) (*model.Block, error) {
+	if blockID == "" {
+		return nil, nil
+	}
+	return a.store.GetBlock(c, blockID)
+}
+
+// isHiddenHistoryRecord returns true if the history record is of a
+// restricted card userID can't see, or of a block in one. The current
+// version of the card is checked, or the record itself for a deleted card.
+// The cards already checked are kept in hidden.
+func (a *App) isHiddenHistoryRecord(c store.Container, block *model.Block, userID string, hidden map[string]bool) (bool, error) {
+	cardID := block.ID
+	if block.Type != model.TypeCard {
+		cardID = block.ParentID
+	}
+	if isHidden, ok := hidden[cardID]; ok {
+		return isHidden, nil
+	}
+
+	card, err := a.getBlockIfSet(c, cardID)
+	if err != nil {
+		return false, err
+	}
+	if card == nil && block.Type == model.TypeCard {
+		card = block
+	}
+	isHidden := isHiddenCard(card, userID)
+	hidden[cardID] = isHidden
+	return isHidden, nil
+}
+
+// withoutHiddenCards returns the cards userID can see.
+func withoutHiddenCards(cards []model.Block, userID string) []model.Block {
+	visible := make([]model.Block, 0, len(cards))
+	for i := range cards {
+		if !isHiddenCard(&cards[i], userID) {
+			visible = append(visible, cards[i])
+		}
+	}
+	return visible
+}
+
+func isHiddenCard(block *model.Block, userID string) bool {
+	return block != nil && block.Type == model.TypeCard && !block.IsVisibleTo(userID)
+}
+
+// checkRestrictable returns ErrBlockNotRestrictable if the patch restricts
+// the access to a block that isn't a card.
+func checkRestrictable(block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil {
+		return nil
+	}
+	restrictedTo, _ := blockPatch.UpdatedFields[model.RestrictedToField].([]interface{})
+	if len(restrictedTo) > 0 && block.Type != model.TypeCard {
+		return model.ErrBlockNotRestrictable
+	}
+	return nil
+}
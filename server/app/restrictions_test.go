@@ -0,0 +1,88 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRestrictedBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	restricted := map[string]interface{}{model.RestrictedToField: []interface{}{"user-id"}}
+	blocks := []model.Block{
+		{ID: "board-id", Type: model.TypeBoard},
+		{ID: "card-1", ParentID: "board-id", Type: model.TypeCard},
+		{ID: "card-2", ParentID: "board-id", Type: model.TypeCard, Fields: restricted},
+		{ID: "text-1", ParentID: "card-1", Type: model.TypeText},
+		{ID: "text-2", ParentID: "card-2", Type: model.TypeText},
+	}
+
+	t.Run("allowed user", func(t *testing.T) {
+		result, err := th.App.FilterRestrictedBlocks(container, blocks, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, blocks, result)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		result, err := th.App.FilterRestrictedBlocks(container, blocks, "other-user-id")
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, "board-id", result[0].ID)
+		require.Equal(t, "card-1", result[1].ID)
+		require.Equal(t, "text-1", result[2].ID)
+	})
+
+	t.Run("contents of a card not in the blocks", func(t *testing.T) {
+		card := &model.Block{ID: "card-2", ParentID: "board-id", Type: model.TypeCard, Fields: restricted}
+		th.Store.EXPECT().GetBlock(container, "card-2").Return(card, nil)
+
+		result, err := th.App.FilterRestrictedBlocks(container, blocks[4:], "other-user-id")
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+}
+
+func TestCheckBlockVisible(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	card := &model.Block{
+		ID:       "card-id",
+		ParentID: "board-id",
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{model.RestrictedToField: []interface{}{"user-id"}},
+	}
+	text := &model.Block{ID: "text-id", ParentID: "card-id", Type: model.TypeText}
+
+	t.Run("restricted card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(container, "card-id").Return(card, nil).Times(2)
+
+		require.NoError(t, th.App.CheckBlockVisible(container, "card-id", "user-id"))
+		require.ErrorIs(t, th.App.CheckBlockVisible(container, "card-id", "other-user-id"), model.ErrBlockRestricted)
+	})
+
+	t.Run("content of a restricted card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock(container, "text-id").Return(text, nil)
+		th.Store.EXPECT().GetBlock(container, "card-id").Return(card, nil)
+
+		require.ErrorIs(t, th.App.CheckBlockVisible(container, "text-id", "other-user-id"), model.ErrBlockRestricted)
+	})
+
+	t.Run("restrict a block that isn't a card", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.RestrictedToField: []interface{}{"user-id"}}}
+		require.ErrorIs(t, checkRestrictable(text, patch), model.ErrBlockNotRestrictable)
+		require.NoError(t, checkRestrictable(card, patch))
+	})
+}
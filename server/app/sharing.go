@@ -6,6 +6,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 func (a *App) GetSharing(c store.Container, rootID string) (*model.Sharing, error) {
@@ -19,6 +20,72 @@ func (a *App) GetSharing(c store.Container, rootID string) (*model.Sharing, erro
 	return sharing, nil
 }
 
-func (a *App) UpsertSharing(c store.Container, sharing model.Sharing) error {
+// GetSharedRootID returns the ID of the root block shared with readToken,
+// or an empty ID if the token doesn't share any.
+func (a *App) GetSharedRootID(c store.Container, readToken string) (string, error) {
+	sharing, err := a.store.GetEnabledSharingByToken(c, readToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return sharing.ID, nil
+}
+
+// GetBoardsSharingStatus returns whether sharing is enabled for each of the
+// boards of the workspace among boardIDs.
+func (a *App) GetBoardsSharingStatus(c store.Container, boardIDs []string) (map[string]model.SharingStatus, error) {
+	return a.store.GetBoardsSharingStatus(c, boardIDs)
+}
+
+// UpsertSharing sets the sharing of a root block, and returns it as stored.
+// When several admins set it at once, the last write wins and each of them
+// gets the stored state back.
+func (a *App) UpsertSharing(c store.Container, sharing model.Sharing) (*model.Sharing, error) {
 	return a.store.UpsertSharing(c, sharing)
 }
+
+// RegenerateSharingToken replaces the access token of an enabled sharing,
+// invalidating the links that use the old one.
+func (a *App) RegenerateSharingToken(c store.Container, rootID string, modifiedByID string) (*model.Sharing, error) {
+	sharing, err := a.GetSharing(c, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if sharing == nil || !sharing.Enabled {
+		return nil, store.NewErrNotFound(rootID)
+	}
+
+	sharing.Token = utils.NewID(utils.IDTypeToken)
+	sharing.ModifiedBy = modifiedByID
+	sharing.UpdateAt = utils.GetMillis()
+
+	return a.store.UpsertSharing(c, *sharing)
+}
+
+// PatchSharing updates only the fields set in the patch, keeping the others,
+// such as the token, as they are. A new token is generated if the root block
+// wasn't shared before and the patch doesn't set one.
+func (a *App) PatchSharing(c store.Container, rootID string, patch *model.SharingPatch, modifiedByID string) (*model.Sharing, error) {
+	if err := patch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	sharing, err := a.GetSharing(c, rootID)
+	if err != nil {
+		return nil, err
+	}
+	if sharing == nil {
+		sharing = &model.Sharing{
+			ID:    rootID,
+			Token: utils.NewID(utils.IDTypeToken),
+		}
+	}
+
+	sharing = patch.Patch(sharing)
+	sharing.ModifiedBy = modifiedByID
+	sharing.UpdateAt = utils.GetMillis()
+
+	return a.store.UpsertSharing(c, *sharing)
+}
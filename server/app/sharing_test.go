@@ -77,17 +77,59 @@ func TestUpsertSharing(t *testing.T) {
 	}
 
 	t.Run("should success to upsert sharing", func(t *testing.T) {
-		th.Store.EXPECT().UpsertSharing(gomock.Eq(container), gomock.Eq(sharing)).Return(nil)
-		err := th.App.UpsertSharing(container, sharing)
+		th.Store.EXPECT().UpsertSharing(gomock.Eq(container), gomock.Eq(sharing)).Return(&sharing, nil)
+		result, err := th.App.UpsertSharing(container, sharing)
 
 		require.NoError(t, err)
+		require.Equal(t, &sharing, result)
 	})
 
 	t.Run("should fail to upsert a sharing", func(t *testing.T) {
-		th.Store.EXPECT().UpsertSharing(gomock.Eq(container), gomock.Eq(sharing)).Return(errors.New("sharing not found"))
-		err := th.App.UpsertSharing(container, sharing)
+		th.Store.EXPECT().UpsertSharing(gomock.Eq(container), gomock.Eq(sharing)).Return(nil, errors.New("sharing not found"))
+		_, err := th.App.UpsertSharing(container, sharing)
 
 		require.Error(t, err)
 		require.Equal(t, "sharing not found", err.Error())
 	})
 }
+
+func TestPatchSharing(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: utils.NewID(utils.IDTypeWorkspace),
+	}
+	enabled := false
+
+	t.Run("should keep the fields not in the patch", func(t *testing.T) {
+		existing := &model.Sharing{
+			ID:         "test-id",
+			Enabled:    true,
+			Token:      "token",
+			ModifiedBy: "otherid",
+		}
+		th.Store.EXPECT().GetSharing(gomock.Eq(container), gomock.Eq("test-id")).Return(existing, nil)
+		th.Store.EXPECT().UpsertSharing(gomock.Eq(container), gomock.Any()).
+			DoAndReturn(func(_ st.Container, sharing model.Sharing) (*model.Sharing, error) {
+				return &sharing, nil
+			})
+
+		result, err := th.App.PatchSharing(container, "test-id", &model.SharingPatch{Enabled: &enabled}, "user-id")
+		require.NoError(t, err)
+		require.False(t, result.Enabled)
+		require.Equal(t, "token", result.Token)
+		require.Equal(t, "user-id", result.ModifiedBy)
+	})
+
+	t.Run("should fail on an empty patch", func(t *testing.T) {
+		_, err := th.App.PatchSharing(container, "test-id", &model.SharingPatch{}, "user-id")
+		require.ErrorAs(t, err, &model.ErrInvalidSharingPatch{})
+	})
+
+	t.Run("should fail on an empty token", func(t *testing.T) {
+		token := ""
+		_, err := th.App.PatchSharing(container, "test-id", &model.SharingPatch{Token: &token}, "user-id")
+		require.ErrorAs(t, err, &model.ErrInvalidSharingPatch{})
+	})
+}
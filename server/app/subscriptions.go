@@ -30,8 +30,106 @@ func (a *App) DeleteSubscription(c store.Container, blockID string, subscriberID
 	return sub, nil
 }
 
-func (a *App) GetSubscriptions(c store.Container, subscriberID string) ([]*model.Subscription, error) {
-	return a.store.GetSubscriptions(c, subscriberID)
+// GetSubscriptions returns the subscriptions of a subscriber, marking the
+// cards the subscriber muted. If includeMuted is true, the muted cards the
+// subscriber isn't subscribed to are listed too, as muted subscriptions.
+func (a *App) GetSubscriptions(c store.Container, subscriberID string, includeMuted bool) ([]*model.Subscription, error) {
+	subs, err := a.store.GetSubscriptions(c, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+
+	mutes, err := a.store.GetMutedCards(c, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mutes) == 0 {
+		return subs, nil
+	}
+
+	muted := make(map[string]*model.CardMute, len(mutes))
+	for _, mute := range mutes {
+		muted[mute.CardID] = mute
+	}
+	for _, sub := range subs {
+		if _, ok := muted[sub.BlockID]; ok {
+			sub.Muted = true
+			delete(muted, sub.BlockID)
+		}
+	}
+
+	if includeMuted {
+		for _, mute := range mutes {
+			if _, ok := muted[mute.CardID]; !ok {
+				continue
+			}
+			subs = append(subs, &model.Subscription{
+				BlockType:      model.TypeCard,
+				BlockID:        mute.CardID,
+				WorkspaceID:    mute.WorkspaceID,
+				SubscriberType: model.SubTypeUser,
+				SubscriberID:   mute.UserID,
+				CreateAt:       mute.CreateAt,
+				Muted:          true,
+			})
+		}
+	}
+	return subs, nil
+}
+
+// MuteCard stops the notifications of the changes to a card for a user, even
+// if the user follows its board.
+func (a *App) MuteCard(c store.Container, boardID, cardID, userID string) (*model.CardMute, error) {
+	if _, err := a.getCard(c, boardID, cardID); err != nil {
+		return nil, err
+	}
+
+	mute := &model.CardMute{
+		CardID:  cardID,
+		BoardID: boardID,
+		UserID:  userID,
+	}
+	if err := a.store.MuteCard(c, mute); err != nil {
+		return nil, err
+	}
+	return mute, nil
+}
+
+// UnmuteCard restores the notifications of the changes to a card for a user.
+func (a *App) UnmuteCard(c store.Container, boardID, cardID, userID string) error {
+	if _, err := a.getCard(c, boardID, cardID); err != nil {
+		return err
+	}
+	return a.store.UnmuteCard(c, cardID, userID)
+}
+
+// GetNotificationSettings returns the notification settings of a user for a
+// board. Users are notified instantly until they change them.
+func (a *App) GetNotificationSettings(c store.Container, boardID, userID string) (*model.NotificationSettings, error) {
+	if _, err := a.getBoard(c, boardID); err != nil {
+		return nil, err
+	}
+
+	settings, err := a.store.GetNotificationSettings(c, boardID, userID)
+	if store.IsErrNotFound(err) {
+		return &model.NotificationSettings{
+			BoardID:     boardID,
+			WorkspaceID: c.WorkspaceID,
+			UserID:      userID,
+			Mode:        model.NotifyModeInstant,
+		}, nil
+	}
+	return settings, err
+}
+
+// UpdateNotificationSettings sets how a user is notified of the changes to the
+// cards they follow on a board.
+func (a *App) UpdateNotificationSettings(c store.Container, settings *model.NotificationSettings) (*model.NotificationSettings, error) {
+	if _, err := a.getBoard(c, settings.BoardID); err != nil {
+		return nil, err
+	}
+
+	return a.store.UpsertNotificationSettings(c, settings)
 }
 
 func (a *App) notifySubscriptionChanged(c store.Container, subscription *model.Subscription) {
@@ -1,7 +1,45 @@
 package app
 
-import "github.com/mattermost/focalboard/server/model"
+import (
+	"database/sql"
+	"errors"
 
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetWorkspaceUsers returns the users of the workspace, or an empty list if
+// it has none.
 func (a *App) GetWorkspaceUsers(workspaceID string) ([]*model.User, error) {
-	return a.store.GetUsersByWorkspace(workspaceID)
+	users, err := a.store.GetUsersByWorkspace(workspaceID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && users == nil) {
+		return []*model.User{}, nil
+	}
+	return users, err
+}
+
+// GetBlockActors returns the users that created or last modified the blocks,
+// loading each of them once.
+func (a *App) GetBlockActors(blocks []model.Block) ([]*model.UserSummary, error) {
+	seen := map[string]bool{}
+	userIDs := []string{}
+	for _, block := range blocks {
+		for _, userID := range []string{block.CreatedBy, block.ModifiedBy} {
+			if userID == "" || seen[userID] {
+				continue
+			}
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	users, err := a.store.GetUsersByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	actors := make([]*model.UserSummary, 0, len(users))
+	for _, user := range users {
+		actors = append(actors, user.Summary())
+	}
+	return actors, nil
 }
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// applyDefaultViewSort sorts the views inserted without sort options by the
+// default sort of their board. Boards inserted along with the views are
+// checked to sort by one of their properties, and used with their new
+// default sort.
+func (a *App) applyDefaultViewSort(c store.Container, blocks []model.Block) error {
+	boards := map[string]*model.Block{}
+	for i := range blocks {
+		if blocks[i].ID == blocks[i].RootID && blocks[i].Type == model.TypeBoard {
+			if err := blocks[i].CheckDefaultSort(); err != nil {
+				return err
+			}
+			boards[blocks[i].ID] = &blocks[i]
+		}
+	}
+
+	for i := range blocks {
+		view := &blocks[i]
+		if view.Type != model.TypeView || view.HasSortOptions() {
+			continue
+		}
+
+		board, ok := boards[view.RootID]
+		if !ok {
+			var err error
+			if board, err = a.store.GetBlock(c, view.RootID); err != nil {
+				return err
+			}
+			boards[view.RootID] = board
+		}
+		if board == nil {
+			continue
+		}
+
+		// the default sort of a stored board may reference a property
+		// deleted since, in which case the view is left unsorted
+		sortOption, err := board.DefaultSort()
+		if err != nil || sortOption == nil || board.CheckDefaultSort() != nil {
+			continue
+		}
+
+		if view.Fields == nil {
+			view.Fields = map[string]interface{}{}
+		}
+		view.Fields[model.ViewSortOptionsField] = []interface{}{
+			map[string]interface{}{"propertyId": sortOption.PropertyID, "reversed": sortOption.Reversed},
+		}
+	}
+	return nil
+}
+
+// checkPatchedDefaultSort returns ErrInvalidDefaultSort if the patch sets the
+// default sort of a board to a property the patched board doesn't have.
+func checkPatchedDefaultSort(block *model.Block, blockPatch *model.BlockPatch) error {
+	if block == nil || blockPatch == nil || block.Type != model.TypeBoard {
+		return nil
+	}
+	if _, ok := blockPatch.UpdatedFields[model.DefaultSortField]; !ok {
+		return nil
+	}
+
+	patched := model.Block{Type: block.Type, Fields: map[string]interface{}{}}
+	for _, key := range []string{"cardProperties", model.DefaultSortField} {
+		patched.Fields[key] = block.Fields[key]
+		if value, ok := blockPatch.UpdatedFields[key]; ok {
+			patched.Fields[key] = value
+		}
+	}
+	return patched.CheckDefaultSort()
+}
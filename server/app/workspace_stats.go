@@ -0,0 +1,111 @@
+package app
+
+import (
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// storageUsageCacheTTL is how long the storage usage of a workspace is cached,
+// as computing it reads the size of every attached file.
+const storageUsageCacheTTL = 10 * time.Minute
+
+type storageUsage struct {
+	bytes     int64
+	expiresAt time.Time
+}
+
+// storageUsageCache holds the storage usage of the workspaces, keyed by
+// workspace ID.
+type storageUsageCache struct {
+	mu      sync.Mutex
+	entries map[string]storageUsage
+}
+
+func newStorageUsageCache() *storageUsageCache {
+	return &storageUsageCache{entries: map[string]storageUsage{}}
+}
+
+func (sc *storageUsageCache) get(workspaceID string, now time.Time) (int64, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	usage, ok := sc.entries[workspaceID]
+	if !ok || now.After(usage.expiresAt) {
+		return 0, false
+	}
+	return usage.bytes, true
+}
+
+func (sc *storageUsageCache) set(workspaceID string, bytes int64, now time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.entries[workspaceID] = storageUsage{bytes: bytes, expiresAt: now.Add(storageUsageCacheTTL)}
+}
+
+// GetWorkspaceStats returns the board, block and member counts of a
+// workspace, along with the size of the files attached to its boards.
+func (a *App) GetWorkspaceStats(workspaceID string) (*model.WorkspaceStats, error) {
+	c := store.Container{WorkspaceID: workspaceID}
+
+	counts, err := a.store.GetWorkspaceBlockCountsByType(c)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.WorkspaceStats{
+		BoardCount: counts[model.TypeBoard],
+	}
+	for _, count := range counts {
+		stats.BlockCount += count
+	}
+
+	if stats.MemberCount, err = a.store.GetWorkspaceMemberCount(workspaceID); err != nil {
+		return nil, err
+	}
+
+	if stats.StorageUsage, err = a.getStorageUsage(c); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// getStorageUsage returns the total size of the files attached to the boards
+// of a workspace. Files that can't be found are left out.
+func (a *App) getStorageUsage(c store.Container) (int64, error) {
+	now := time.Now()
+	if bytes, ok := a.storageUsage.get(c.WorkspaceID, now); ok {
+		return bytes, nil
+	}
+
+	blocks, err := a.store.GetBlocksWithType(c, model.TypeImage)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, block := range blocks {
+		fileID, _ := block.Fields["fileId"].(string)
+		if fileID == "" {
+			continue
+		}
+
+		filePath := filepath.Join(c.WorkspaceID, block.RootID, fileID)
+		size, err := a.filesBackend.FileSize(filePath)
+		if err != nil {
+			a.logger.Warn("Cannot get size of attached file",
+				mlog.String("FilePath", filePath),
+				mlog.Err(err))
+			continue
+		}
+		total += size
+	}
+
+	a.storageUsage.set(c.WorkspaceID, total, now)
+	return total, nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
+)
+
+func TestGetWorkspaceStats(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	container := st.Container{
+		WorkspaceID: "workspace-id",
+	}
+
+	mockedFileBackend := &mocks.FileBackend{}
+	th.App.filesBackend = mockedFileBackend
+	mockedFileBackend.On("FileSize", "workspace-id/board-id/file-1.png").Return(int64(100), nil).Once()
+	mockedFileBackend.On("FileSize", "workspace-id/board-id/file-2.png").Return(int64(0), &TestError{}).Once()
+
+	images := []model.Block{
+		{ID: "image-1", RootID: "board-id", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "file-1.png"}},
+		{ID: "image-2", RootID: "board-id", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "file-2.png"}},
+		{ID: "image-3", RootID: "board-id", Type: model.TypeImage, Fields: map[string]interface{}{}},
+	}
+	counts := map[string]int64{model.TypeBoard: 2, model.TypeCard: 5, model.TypeImage: 3}
+
+	th.Store.EXPECT().GetWorkspaceBlockCountsByType(gomock.Eq(container)).Return(counts, nil).Times(2)
+	th.Store.EXPECT().GetWorkspaceMemberCount("workspace-id").Return(4, nil).Times(2)
+	th.Store.EXPECT().GetBlocksWithType(gomock.Eq(container), gomock.Eq(model.TypeImage)).Return(images, nil).Times(1)
+
+	stats, err := th.App.GetWorkspaceStats("workspace-id")
+	require.NoError(t, err)
+	require.Equal(t, &model.WorkspaceStats{BoardCount: 2, BlockCount: 10, MemberCount: 4, StorageUsage: 100}, stats)
+
+	// the storage usage is cached
+	stats, err = th.App.GetWorkspaceStats("workspace-id")
+	require.NoError(t, err)
+	require.EqualValues(t, 100, stats.StorageUsage)
+	mockedFileBackend.AssertExpectations(t)
+}
@@ -77,6 +77,20 @@ func TestGetSession(t *testing.T) {
 	}
 }
 
+func TestGetSessionExpired(t *testing.T) {
+	th := setupTestHelper(t)
+
+	expired := *mockSession
+	expired.Props = map[string]interface{}{
+		model.SessionPropExpiresAt: float64(utils.GetMillis() - 1000),
+	}
+	th.Store.EXPECT().GetSession("expiredToken", gomock.Any()).Return(&expired, nil)
+
+	session, err := th.Auth.GetSession("expiredToken")
+	require.Error(t, err)
+	require.Nil(t, session)
+}
+
 func TestIsValidReadToken(t *testing.T) {
 	th := setupTestHelper(t)
 
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/api"
@@ -174,14 +175,43 @@ func (c *Client) GetBlocks() ([]model.Block, *Response) {
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) PatchBlock(blockID string, blockPatch *model.BlockPatch) (bool, *Response) {
+func (c *Client) GetBlocksWithTypes(blockTypes []string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s?type=%s", c.GetBlocksRoute(), strings.Join(blockTypes, ",")), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetBlocksSortedByProperty(parentID, propertyID, order string) ([]model.Block, *Response) {
+	query := fmt.Sprintf("?parent_id=%s&sort_by=%s", parentID, url.QueryEscape(propertyID))
+	if order != "" {
+		query += "&sort_order=" + order
+	}
+	r, err := c.DoAPIGet(c.GetBlocksRoute()+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchBlock(blockID string, blockPatch *model.BlockPatch) (*model.Block, *Response) {
 	r, err := c.DoAPIPatch(c.GetBlockRoute(blockID), toJSON(blockPatch))
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
 }
 
 func (c *Client) InsertBlocks(blocks []model.Block) ([]model.Block, *Response) {
@@ -194,14 +224,18 @@ func (c *Client) InsertBlocks(blocks []model.Block) ([]model.Block, *Response) {
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
-func (c *Client) DeleteBlock(blockID string) (bool, *Response) {
+func (c *Client) DeleteBlock(blockID string) ([]string, *Response) {
 	r, err := c.DoAPIDelete(c.GetBlockRoute(blockID))
 	if err != nil {
-		return false, BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return true, BuildResponse(r)
+	var result api.DeleteBlockResponse
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return result.DeletedBlockIDs, BuildResponse(r)
 }
 
 func (c *Client) GetSubtree(blockID string) ([]model.Block, *Response) {
@@ -214,8 +248,341 @@ func (c *Client) GetSubtree(blockID string) ([]model.Block, *Response) {
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) GetSubtreeOrdered(blockID string, levels int, order string, limitPerLevel int) ([]model.Block, *Response) {
+	query := fmt.Sprintf("?l=%d&order=%s", levels, order)
+	if limitPerLevel > 0 {
+		query += fmt.Sprintf("&limit_per_level=%d", limitPerLevel)
+	}
+	r, err := c.DoAPIGet(c.GetSubtreeRoute(blockID)+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetSubtreeExpandingRelations(blockID string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetSubtreeRoute(blockID)+"?expand=relations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetSubtreeExpandingActors(blockID string) (*model.BlocksWithActors, *Response) {
+	r, err := c.DoAPIGet(c.GetSubtreeRoute(blockID)+"?expand=actors", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksWithActorsFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) GetServerInfo() (*model.ServerInfo, *Response) {
+	r, err := c.DoAPIGet("/server/info", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var info *model.ServerInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return info, BuildResponse(r)
+}
+
+// Boards
+
+func (c *Client) GetBoardRoute(boardID string) string {
+	return fmt.Sprintf("/workspaces/0/boards/%s", boardID)
+}
+
+func (c *Client) GetBoardStats(boardID string) (*model.BoardStats, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/stats", c.GetBoardRoute(boardID)), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var stats *model.BoardStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return stats, BuildResponse(r)
+}
+
+func (c *Client) GetPropertyOptionUsage(boardID, propertyID, optionID string) (*model.PropertyOptionUsage, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/properties/%s/options/%s/usage", c.GetBoardRoute(boardID), propertyID, optionID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var usage *model.PropertyOptionUsage
+	if err := json.NewDecoder(r.Body).Decode(&usage); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return usage, BuildResponse(r)
+}
+
+func (c *Client) ExportBlocks(rootID string, includeComments bool) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/export?root_id=%s&include_comments=%t", c.GetBlocksRoute(), rootID, includeComments), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ExportBoards(boardIDs []string) (*model.BoardsExport, *Response) {
+	r, err := c.DoAPIPost("/workspaces/0/boards/export", toJSON(model.BoardsExportRequest{BoardIDs: boardIDs}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var export *model.BoardsExport
+	if err := json.NewDecoder(r.Body).Decode(&export); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return export, BuildResponse(r)
+}
+
+func (c *Client) ExportBoardPDF(boardID, viewID string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/export.pdf?view_id=%s", c.GetBoardRoute(boardID), viewID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return data, BuildResponse(r)
+}
+
+func (c *Client) ExportBoardCSV(boardID, viewID string) ([]byte, *Response) {
+	route := c.GetBoardRoute(boardID) + "/export.csv"
+	if viewID != "" {
+		route += "?view_id=" + viewID
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return data, BuildResponse(r)
+}
+
+func (c *Client) GetBoardActivity(boardID string, from, to int64) ([]model.ActivityEntry, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/activity?from=%d&to=%d", c.GetBoardRoute(boardID), from, to), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var activity []model.ActivityEntry
+	if err := json.NewDecoder(r.Body).Decode(&activity); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return activity, BuildResponse(r)
+}
+
+func (c *Client) ExportBoardActivity(boardID string, from, to int64) ([]byte, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/activity.csv?from=%d&to=%d", c.GetBoardRoute(boardID), from, to), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return data, BuildResponse(r)
+}
+
+func (c *Client) GetBoardPresence(boardID string) (*model.BoardPresence, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/presence", c.GetBoardRoute(boardID)), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var presence *model.BoardPresence
+	if err := json.NewDecoder(r.Body).Decode(&presence); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return presence, BuildResponse(r)
+}
+
+func (c *Client) GetNotificationSettings(boardID string) (*model.NotificationSettings, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/notifications/settings", c.GetBoardRoute(boardID)), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var settings *model.NotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return settings, BuildResponse(r)
+}
+
+func (c *Client) UpdateNotificationSettings(boardID string, settings *model.NotificationSettings) (*model.NotificationSettings, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/notifications/settings", c.GetBoardRoute(boardID)), toJSON(settings))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.NotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) MuteCard(boardID, cardID string) (*model.CardMute, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/blocks/%s/mute", c.GetBoardRoute(boardID), cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var mute *model.CardMute
+	if err := json.NewDecoder(r.Body).Decode(&mute); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return mute, BuildResponse(r)
+}
+
+func (c *Client) UnmuteCard(boardID, cardID string) *Response {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/blocks/%s/unmute", c.GetBoardRoute(boardID), cardID), "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) AcknowledgeBoard(boardID string) (*model.BoardAcknowledgment, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/acknowledge", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var ack *model.BoardAcknowledgment
+	if err := json.NewDecoder(r.Body).Decode(&ack); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return ack, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAcknowledgments(boardID string) (*model.BoardAcknowledgments, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/acknowledgments", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var acks *model.BoardAcknowledgments
+	if err := json.NewDecoder(r.Body).Decode(&acks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return acks, BuildResponse(r)
+}
+
+func (c *Client) ClearBoard(boardID string, clearViews bool) (*model.ClearBoardResult, *Response) {
+	route := c.GetBoardRoute(boardID) + "/blocks/clear"
+	if clearViews {
+		route += "?clear_views=true"
+	}
+	r, err := c.DoAPIPost(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.ClearBoardResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) MoveCards(boardID string, move model.MoveCardsRequest) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/cards/move", c.GetBoardRoute(boardID)), toJSON(move))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) SetViewCardOrder(boardID, viewID string, order model.ViewCardOrder) (*model.Block, *Response) {
+	r, err := c.DoAPIPut(fmt.Sprintf("%s/views/%s/order", c.GetBoardRoute(boardID), viewID), toJSON(order))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var view *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&view); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return view, BuildResponse(r)
+}
+
 // Sharing
 
+func (c *Client) ImportMarkdown(markdown string) (*model.MarkdownImportResult, *Response) {
+	r, err := c.DoAPIPost("/workspaces/0/import/markdown", markdown)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.MarkdownImportResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
 func (c *Client) GetSharingRoute(rootID string) string {
 	return fmt.Sprintf("/workspaces/0/sharing/%s", rootID)
 }
@@ -231,6 +598,63 @@ func (c *Client) GetSharing(rootID string) (*model.Sharing, *Response) {
 	return &sharing, BuildResponse(r)
 }
 
+func (c *Client) GetBoardAPIKeysRoute(boardID string) string {
+	return fmt.Sprintf("%s/apikeys", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) CreateBoardAPIKey(boardID, name string) (*model.BoardAPIKey, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardAPIKeysRoute(boardID), toJSON(model.BoardAPIKeyRequest{Name: name}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var key *model.BoardAPIKey
+	if err := json.NewDecoder(r.Body).Decode(&key); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return key, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAPIKeys(boardID string) ([]*model.BoardAPIKey, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardAPIKeysRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var keys []*model.BoardAPIKey
+	if err := json.NewDecoder(r.Body).Decode(&keys); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return keys, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardAPIKey(boardID, keyID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(fmt.Sprintf("%s/%s", c.GetBoardAPIKeysRoute(boardID), keyID))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardsSharingStatus(boardIDs []string) (map[string]model.SharingStatus, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("/workspaces/0/boards/sharing?ids=%s", strings.Join(boardIDs, ",")), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var statuses map[string]model.SharingStatus
+	if err := json.NewDecoder(r.Body).Decode(&statuses); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return statuses, BuildResponse(r)
+}
+
 func (c *Client) PostSharing(sharing model.Sharing) (bool, *Response) {
 	r, err := c.DoAPIPost(c.GetSharingRoute(sharing.ID), toJSON(sharing))
 	if err != nil {
@@ -241,6 +665,48 @@ func (c *Client) PostSharing(sharing model.Sharing) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) PatchSharing(rootID string, patch *model.SharingPatch) (*model.Sharing, *Response) {
+	r, err := c.DoAPIPatch(c.GetSharingRoute(rootID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	sharing := model.SharingFromJSON(r.Body)
+	return &sharing, BuildResponse(r)
+}
+
+func (c *Client) PostSharingWithBoard(sharing model.Sharing) (*model.SharingWithBoard, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(sharing.ID)+"?expand=board", toJSON(sharing))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.SharingWithBoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchSharingWithBoard(rootID string, patch *model.SharingPatch) (*model.SharingWithBoard, *Response) {
+	r, err := c.DoAPIPatch(c.GetSharingRoute(rootID)+"?expand=board", toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.SharingWithBoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) RegenerateSharingToken(rootID string) (*model.Sharing, *Response) {
+	r, err := c.DoAPIPost(c.GetSharingRoute(rootID)+"/regenerate", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	sharing := model.SharingFromJSON(r.Body)
+	return &sharing, BuildResponse(r)
+}
+
 func (c *Client) GetRegisterRoute() string {
 	return "/register"
 }
@@ -296,6 +762,36 @@ func (c *Client) GetMe() (*model.User, *Response) {
 	return me, BuildResponse(r)
 }
 
+func (c *Client) GetAssignedCards(opts model.AssignedCardsOptions) ([]model.AssignedCard, *Response) {
+	query := []string{}
+	if opts.Status != "" {
+		query = append(query, "status="+url.QueryEscape(opts.Status))
+	}
+	if opts.DueAfter > 0 {
+		query = append(query, fmt.Sprintf("due_after=%d", opts.DueAfter))
+	}
+	if opts.DueBefore > 0 {
+		query = append(query, fmt.Sprintf("due_before=%d", opts.DueBefore))
+	}
+
+	route := c.GetMeRoute() + "/assigned"
+	if len(query) > 0 {
+		route += "?" + strings.Join(query, "&")
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []model.AssignedCard
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
 func (c *Client) GetUserRoute(id string) string {
 	return fmt.Sprintf("/users/%s", id)
 }
@@ -328,6 +824,25 @@ func (c *Client) UserChangePassword(id string, data *api.ChangePasswordRequest)
 	return true, BuildResponse(r)
 }
 
+func (c *Client) GetWorkspaceRoute(workspaceID string) string {
+	return fmt.Sprintf("/workspaces/%s", workspaceID)
+}
+
+func (c *Client) GetWorkspaceWithStats(workspaceID string) (*model.Workspace, *Response) {
+	r, err := c.DoAPIGet(c.GetWorkspaceRoute(workspaceID)+"?with_stats=true", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var workspace *model.Workspace
+	if err := json.NewDecoder(r.Body).Decode(&workspace); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return workspace, BuildResponse(r)
+}
+
 func (c *Client) GetWorkspaceUploadFileRoute(workspaceID, rootID string) string {
 	return fmt.Sprintf("/workspaces/%s/%s/files", workspaceID, rootID)
 }
@@ -362,6 +877,120 @@ func (c *Client) WorkspaceUploadFile(workspaceID, rootID string, data io.Reader)
 	return fileUploadResponse, BuildResponse(r)
 }
 
+func (c *Client) GetCardAttachmentsRoute(boardID, cardID string) string {
+	return fmt.Sprintf("%s/cards/%s/attachments", c.GetBoardRoute(boardID), cardID)
+}
+
+func (c *Client) AttachFileToCard(boardID, cardID string, data io.Reader) (*model.Block, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetCardAttachmentsRoute(boardID, cardID), body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
+}
+
+func (c *Client) GetCardAttachments(boardID, cardID string) ([]model.AttachmentInfo, *Response) {
+	r, err := c.DoAPIGet(c.GetCardAttachmentsRoute(boardID, cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var attachments []model.AttachmentInfo
+	if err := json.NewDecoder(r.Body).Decode(&attachments); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return attachments, BuildResponse(r)
+}
+
+func (c *Client) GetCardCommentsRoute(boardID, cardID string) string {
+	return fmt.Sprintf("%s/cards/%s/comments", c.GetBoardRoute(boardID), cardID)
+}
+
+func (c *Client) GetCommentRoute(boardID, cardID, commentID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardCommentsRoute(boardID, cardID), commentID)
+}
+
+func (c *Client) GetCommentThreads(boardID, cardID string, includeResolved bool) ([]model.CommentThread, *Response) {
+	route := c.GetCardCommentsRoute(boardID, cardID)
+	if includeResolved {
+		route += "?include_resolved=true"
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var threads []model.CommentThread
+	if err := json.NewDecoder(r.Body).Decode(&threads); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return threads, BuildResponse(r)
+}
+
+func (c *Client) ReplyToComment(boardID, cardID, commentID, text string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCommentRoute(boardID, cardID, commentID)+"/replies", toJSON(model.CommentReply{Text: text}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
+}
+
+func (c *Client) ResolveCommentThread(boardID, cardID, commentID string) (*model.Block, *Response) {
+	return c.setCommentThreadResolved(boardID, cardID, commentID, "resolve")
+}
+
+func (c *Client) UnresolveCommentThread(boardID, cardID, commentID string) (*model.Block, *Response) {
+	return c.setCommentThreadResolved(boardID, cardID, commentID, "unresolve")
+}
+
+func (c *Client) setCommentThreadResolved(boardID, cardID, commentID, action string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCommentRoute(boardID, cardID, commentID)+"/"+action, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return block, BuildResponse(r)
+}
+
 func (c *Client) GetSubscriptionsRoute(workspaceID string) string {
 	return fmt.Sprintf("/workspaces/%s/subscriptions", workspaceID)
 }
@@ -394,7 +1023,15 @@ func (c *Client) DeleteSubscription(workspaceID string, blockID string, subscrib
 
 func (c *Client) GetSubscriptions(workspaceID string, subscriberID string) ([]*model.Subscription, *Response) {
 	url := fmt.Sprintf("%s/%s", c.GetSubscriptionsRoute(workspaceID), subscriberID)
+	return c.getSubscriptions(url)
+}
+
+func (c *Client) GetSubscriptionsIncludingMuted(workspaceID string, subscriberID string) ([]*model.Subscription, *Response) {
+	url := fmt.Sprintf("%s/%s?include_muted=true", c.GetSubscriptionsRoute(workspaceID), subscriberID)
+	return c.getSubscriptions(url)
+}
 
+func (c *Client) getSubscriptions(url string) ([]*model.Subscription, *Response) {
 	r, err := c.DoAPIGet(url, "")
 	if err != nil {
 		return nil, BuildErrorResponse(r, err)
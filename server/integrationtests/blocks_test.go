@@ -1,9 +1,13 @@
 package integrationtests
 
 import (
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/mattermost/focalboard/server/client"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -54,6 +58,36 @@ func TestGetBlocks(t *testing.T) {
 	require.Contains(t, blockIDs, blockID2)
 }
 
+func TestGetBlocksWithTypes(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	textID := utils.NewID(utils.IDTypeBlock)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: cardID, RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: textID, RootID: boardID, ParentID: cardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeText},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 3)
+	boardID, cardID, textID = newBlocks[0].ID, newBlocks[1].ID, newBlocks[2].ID
+
+	blocks, resp := th.Client.GetBlocksWithTypes([]string{model.TypeCard, model.TypeText})
+	require.NoError(t, resp.Error)
+
+	blockIDs := make([]string, len(blocks))
+	for i, b := range blocks {
+		require.Contains(t, []model.BlockType{model.TypeCard, model.TypeText}, b.Type)
+		blockIDs[i] = b.ID
+	}
+	require.Contains(t, blockIDs, cardID)
+	require.Contains(t, blockIDs, textID)
+	require.NotContains(t, blockIDs, boardID)
+}
+
 func TestPostBlock(t *testing.T) {
 	th := SetupTestHelper().InitBasic()
 	defer th.TearDown()
@@ -165,6 +199,35 @@ func TestPostBlock(t *testing.T) {
 	})
 }
 
+func TestPostBlockServerTimestamps(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	// a client with its clock a day ahead
+	future := utils.GetMillis() + 24*60*60*1000
+	block := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: future,
+		UpdateAt: future,
+		Type:     model.TypeBoard,
+	}
+	block.RootID = block.ID
+
+	before := utils.GetMillis()
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{block})
+	require.NoError(t, resp.Error)
+	after := utils.GetMillis()
+
+	blocks, resp := th.Client.GetSubtree(newBlocks[0].ID)
+	require.NoError(t, resp.Error)
+	require.Len(t, blocks, 1)
+	for _, b := range []model.Block{newBlocks[0], blocks[0]} {
+		require.GreaterOrEqual(t, b.CreateAt, before)
+		require.LessOrEqual(t, b.CreateAt, after)
+		require.LessOrEqual(t, b.UpdateAt, after)
+	}
+}
+
 func TestPatchBlock(t *testing.T) {
 	th := SetupTestHelper().InitBasic()
 	defer th.TearDown()
@@ -191,13 +254,19 @@ func TestPatchBlock(t *testing.T) {
 	initialCount := len(blocks)
 
 	t.Run("Patch a block basic field", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
 		newTitle := "Updated title"
 		blockPatch := &model.BlockPatch{
 			Title: &newTitle,
 		}
 
-		_, resp := th.Client.PatchBlock(blockID, blockPatch)
+		patchedBlock, resp := th.Client.PatchBlock(blockID, blockPatch)
 		require.NoError(t, resp.Error)
+		require.Equal(t, blockID, patchedBlock.ID)
+		require.Equal(t, "Updated title", patchedBlock.Title)
 
 		blocks, resp := th.Client.GetBlocks()
 		require.NoError(t, resp.Error)
@@ -211,9 +280,14 @@ func TestPatchBlock(t *testing.T) {
 		}
 		require.NotNil(t, updatedBlock)
 		require.Equal(t, "Updated title", updatedBlock.Title)
+		require.Equal(t, updatedBlock.UpdateAt, patchedBlock.UpdateAt)
 	})
 
 	t.Run("Patch a block custom fields", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
 		blockPatch := &model.BlockPatch{
 			UpdatedFields: map[string]interface{}{
 				"test":  "new test value",
@@ -240,6 +314,10 @@ func TestPatchBlock(t *testing.T) {
 	})
 
 	t.Run("Patch a block to remove custom fields", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
 		blockPatch := &model.BlockPatch{
 			DeletedFields: []string{"test", "test3", "test100"},
 		}
@@ -307,13 +385,20 @@ func TestDeleteBlock(t *testing.T) {
 		// id,insert_at on block history
 		time.Sleep(10 * time.Millisecond)
 
-		_, resp := th.Client.DeleteBlock(blockID)
+		deletedIDs, resp := th.Client.DeleteBlock(blockID)
 		require.NoError(t, resp.Error)
+		require.Equal(t, []string{blockID}, deletedIDs)
 
 		blocks, resp := th.Client.GetBlocks()
 		require.NoError(t, resp.Error)
 		require.Len(t, blocks, initialCount)
 	})
+
+	t.Run("Delete a block that doesn't exist", func(t *testing.T) {
+		deletedIDs, resp := th.Client.DeleteBlock(blockID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, deletedIDs)
+	})
 }
 
 func TestGetSubtree(t *testing.T) {
@@ -385,3 +470,650 @@ func TestGetSubtree(t *testing.T) {
 		require.Contains(t, blockIDs, childBlockID2)
 	})
 }
+
+func TestReadOnlyMode(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+
+	th.Server.App().SetReadOnly(true)
+
+	t.Run("writes are rejected", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(newBlocks)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+
+	t.Run("reads are allowed", func(t *testing.T) {
+		_, resp := th.Client.GetBlocks()
+		require.NoError(t, resp.Error)
+	})
+
+	th.Server.App().SetReadOnly(false)
+
+	t.Run("writes are allowed again", func(t *testing.T) {
+		_, resp := th.Client.InsertBlocks(newBlocks)
+		require.NoError(t, resp.Error)
+	})
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	th.Server.App().GetConfig().MaxRequestBodySize = 1024
+	defer func() { th.Server.App().GetConfig().MaxRequestBodySize = 0 }()
+
+	t.Run("body within the limit", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		newBlocks := []model.Block{
+			{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		}
+		_, resp := th.Client.InsertBlocks(newBlocks)
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		newBlocks := []model.Block{
+			{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: strings.Repeat("a", 2048)},
+		}
+		_, resp := th.Client.InsertBlocks(newBlocks)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	})
+}
+
+func TestMalformedJSONBody(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("insert blocks", func(t *testing.T) {
+		r, err := th.Client.DoAPIPost(th.Client.GetBlocksRoute(), "[{")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode)
+	})
+
+	t.Run("patch blocks", func(t *testing.T) {
+		r, err := th.Client.DoAPIPatch(th.Client.GetBlocksRoute(), "not json")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode)
+	})
+}
+
+func TestCardCountWarning(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	th.Server.App().GetConfig().CardCountWarningThreshold = 2
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card1", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "card2", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Empty(t, resp.Header.Get("X-Card-Count-Warning"))
+	boardID := blocks[0].ID
+
+	newBlocks = []model.Block{
+		{ID: "card3", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	_, resp = th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Equal(t, boardID, resp.Header.Get("X-Card-Count-Warning"))
+}
+
+func TestImportMarkdown(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("creates a board", func(t *testing.T) {
+		markdown := "# Backlog\n\n- First card\n  - [ ] A task\n- Second card\n\n## Later\n\nNot on a card\n"
+		result, resp := th.Client.ImportMarkdown(markdown)
+		require.NoError(t, resp.Error)
+		require.Equal(t, 2, result.CardCount)
+		require.Len(t, result.Skipped, 1)
+		require.Equal(t, 9, result.Skipped[0].Line)
+
+		blocks, resp := th.Client.GetSubtree(result.BoardID)
+		require.NoError(t, resp.Error)
+
+		board := findBlock(t, blocks, result.BoardID)
+		require.Equal(t, "Backlog", board.Title)
+
+		cards := map[string]string{}
+		for _, block := range blocks {
+			if block.Type == model.TypeCard {
+				cards[block.Title] = block.ID
+			}
+		}
+		require.Len(t, cards, 2)
+
+		content, resp := th.Client.GetSubtree(cards["First card"])
+		require.NoError(t, resp.Error)
+		require.Len(t, content, 2)
+		for _, block := range content {
+			if block.ID != cards["First card"] {
+				require.Equal(t, model.BlockType("checkbox"), block.Type)
+				require.Equal(t, "A task", block.Title)
+			}
+		}
+	})
+
+	t.Run("no cards", func(t *testing.T) {
+		_, resp := th.Client.ImportMarkdown("Just a paragraph.")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetSubtreeExpandingActors(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+
+	require.NoError(t, th.InitUsers("user1", "user2"))
+	user1, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+	user2, resp := th.Client2.GetMe()
+	require.NoError(t, resp.Error)
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	blocks := []model.Block{
+		{
+			ID:       boardID,
+			RootID:   boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeBoard,
+		},
+		{
+			ID:       cardID,
+			RootID:   boardID,
+			ParentID: boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(blocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+	cardID = newBlocks[1].ID
+
+	// the history of a block is keyed by millisecond
+	time.Sleep(10 * time.Millisecond)
+
+	title := "edited"
+	_, resp = th.Client2.PatchBlock(cardID, &model.BlockPatch{Title: &title})
+	require.NoError(t, resp.Error)
+
+	expanded, resp := th.Client.GetSubtreeExpandingActors(boardID)
+	require.NoError(t, resp.Error)
+	require.Len(t, expanded.Blocks, 2)
+
+	actorIDs := []string{}
+	for _, actor := range expanded.Actors {
+		actorIDs = append(actorIDs, actor.ID)
+		require.NotEmpty(t, actor.Username)
+	}
+	require.ElementsMatch(t, []string{user1.ID, user2.ID}, actorIDs)
+
+	t.Run("read token", func(t *testing.T) {
+		token := utils.NewID(utils.IDTypeToken)
+		success, resp := th.Client.PostSharing(model.Sharing{ID: boardID, Token: token, Enabled: true})
+		require.True(t, success)
+		require.NoError(t, resp.Error)
+
+		anonymous := client.NewClient(th.Server.Config().ServerRoot, "")
+		r, err := anonymous.DoAPIGet(anonymous.GetSubtreeRoute(boardID)+"?expand=actors&read_token="+token, "")
+		require.NoError(t, err)
+		defer r.Body.Close()
+
+		// the users aren't resolved without a session
+		expanded := model.BlocksWithActorsFromJSON(r.Body)
+		require.Len(t, expanded.Blocks, 2)
+		require.Empty(t, expanded.Actors)
+	})
+}
+
+func TestGetSubtreeOrdered(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	board := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+	}
+	board.RootID = board.ID
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	// the cards are inserted one by one, as the server sets their create_at
+	cardIDs := []string{}
+	for i := 0; i < 3; i++ {
+		time.Sleep(10 * time.Millisecond)
+		card := model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			RootID:   boardID,
+			ParentID: boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		}
+		newBlocks, resp = th.Client.InsertBlocks([]model.Block{card})
+		require.NoError(t, resp.Error)
+		cardIDs = append(cardIDs, newBlocks[0].ID)
+	}
+
+	t.Run("descending", func(t *testing.T) {
+		blocks, resp := th.Client.GetSubtreeOrdered(boardID, 2, "desc", 0)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 4)
+		require.Equal(t, cardIDs[2], blocks[0].ID)
+		require.Equal(t, cardIDs[1], blocks[1].ID)
+		require.Equal(t, cardIDs[0], blocks[2].ID)
+		require.Equal(t, boardID, blocks[3].ID)
+	})
+
+	t.Run("limit per level", func(t *testing.T) {
+		blocks, resp := th.Client.GetSubtreeOrdered(boardID, 2, "asc", 2)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 3)
+		require.Equal(t, boardID, blocks[0].ID)
+		require.Equal(t, cardIDs[0], blocks[1].ID)
+		require.Equal(t, cardIDs[1], blocks[2].ID)
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, resp := th.Client.GetSubtreeOrdered(boardID, 2, "sideways", 0)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAllowedBlockTypes(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	board := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+		Fields:   map[string]interface{}{model.AllowedBlockTypesField: []interface{}{"card", "text"}},
+	}
+	board.RootID = board.ID
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	t.Run("allowed type", func(t *testing.T) {
+		card := model.Block{ID: "card", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard}
+		_, resp := th.Client.InsertBlocks([]model.Block{card})
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("type not allowed", func(t *testing.T) {
+		image := model.Block{ID: "image", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeImage}
+		_, resp := th.Client.InsertBlocks([]model.Block{image})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		blocks, resp := th.Client.GetSubtree(boardID)
+		require.NoError(t, resp.Error)
+		for _, block := range blocks {
+			require.NotEqual(t, model.BlockType(model.TypeImage), block.Type)
+		}
+	})
+
+	t.Run("unknown type on insert", func(t *testing.T) {
+		other := model.Block{
+			ID:       utils.NewID(utils.IDTypeBoard),
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeBoard,
+			Fields:   map[string]interface{}{model.AllowedBlockTypesField: []interface{}{"card", "txt"}},
+		}
+		other.RootID = other.ID
+		_, resp := th.Client.InsertBlocks([]model.Block{other})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unknown type on patch", func(t *testing.T) {
+		blockPatch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{model.AllowedBlockTypesField: []interface{}{"card", "txt"}},
+		}
+		_, resp := th.Client.PatchBlock(boardID, blockPatch)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestBlockRequiredFields(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	t.Run("required fields set", func(t *testing.T) {
+		image := model.Block{
+			ID: "image", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeImage,
+			Fields: map[string]interface{}{"fileId": "file.png"},
+		}
+		_, resp := th.Client.InsertBlocks([]model.Block{image})
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("required field missing", func(t *testing.T) {
+		view := model.Block{ID: "view", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView}
+		_, resp := th.Client.InsertBlocks([]model.Block{view})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.Contains(t, resp.Error.Error(), "viewType")
+	})
+}
+
+func TestDefaultViewSort(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	board := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties":       []interface{}{map[string]interface{}{"id": "priority", "type": "select"}},
+			model.DefaultSortField: map[string]interface{}{"propertyId": "priority"},
+		},
+	}
+	board.RootID = board.ID
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	t.Run("view without sort", func(t *testing.T) {
+		view := model.Block{
+			ID: "view", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView,
+			Fields: map[string]interface{}{model.ViewTypeField: "table", model.ViewSortOptionsField: []interface{}{}},
+		}
+		newBlocks, resp := th.Client.InsertBlocks([]model.Block{view})
+		require.NoError(t, resp.Error)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"propertyId": "priority", "reversed": false},
+		}, newBlocks[0].Fields[model.ViewSortOptionsField])
+	})
+
+	t.Run("unknown default sort", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			model.DefaultSortField: map[string]interface{}{"propertyId": "estimate"},
+		}}
+		_, resp := th.Client.PatchBlock(boardID, patch)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAllowedViewTypes(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	board := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+		Fields:   map[string]interface{}{model.AllowedViewTypesField: []interface{}{"table"}},
+	}
+	board.RootID = board.ID
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	t.Run("allowed view type", func(t *testing.T) {
+		view := model.Block{
+			ID: "table", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView,
+			Fields: map[string]interface{}{model.ViewTypeField: "table"},
+		}
+		_, resp := th.Client.InsertBlocks([]model.Block{view})
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("view type not allowed", func(t *testing.T) {
+		view := model.Block{
+			ID: "gallery", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView,
+			Fields: map[string]interface{}{model.ViewTypeField: "gallery"},
+		}
+		_, resp := th.Client.InsertBlocks([]model.Block{view})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("unknown allowed view type", func(t *testing.T) {
+		patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{model.AllowedViewTypesField: []interface{}{"timeline"}}}
+		_, resp := th.Client.PatchBlock(boardID, patch)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetBlocksSortedByProperty(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	board := model.Block{
+		ID:       "board",
+		RootID:   "board",
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "priority",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "high", "value": "High"},
+						map[string]interface{}{"id": "low", "value": "Low"},
+					},
+				},
+			},
+		},
+	}
+	card := func(title, priority string) model.Block {
+		return model.Block{
+			ID: title, RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: title,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"priority": priority}},
+		}
+	}
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board, card("low", "low"), card("none", ""), card("high", "high")})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].RootID
+
+	titles := func(blocks []model.Block) []string {
+		result := []string{}
+		for _, block := range blocks {
+			result = append(result, block.Title)
+		}
+		return result
+	}
+
+	t.Run("ascending", func(t *testing.T) {
+		blocks, resp := th.Client.GetBlocksSortedByProperty(boardID, "priority", "")
+		require.NoError(t, resp.Error)
+		require.Equal(t, []string{"high", "low", "none"}, titles(blocks))
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		blocks, resp := th.Client.GetBlocksSortedByProperty(boardID, "priority", "desc")
+		require.NoError(t, resp.Error)
+		require.Equal(t, []string{"low", "high", "none"}, titles(blocks))
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		_, resp := th.Client.GetBlocksSortedByProperty(boardID, "status", "")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, resp := th.Client.GetBlocksSortedByProperty(boardID, "priority", "up")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestBlocksWithMissingBoard(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("insert into a missing board", func(t *testing.T) {
+		card := model.Block{ID: "card", RootID: "missing-board", ParentID: "missing-board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard}
+		_, resp := th.Client.InsertBlocks([]model.Block{card})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("patch a missing block", func(t *testing.T) {
+		title := "New title"
+		_, resp := th.Client.PatchBlock("missing-card", &model.BlockPatch{Title: &title})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetBlocksEmptyResponse(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	for _, query := range []string{"?block_id=missing", "?parent_id=missing", "?parent_id=missing&expand=actors"} {
+		r, err := th.Client.DoAPIGet(th.Client.GetBlocksRoute()+query, "")
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		require.NoError(t, err)
+
+		if strings.Contains(query, "expand") {
+			require.JSONEq(t, `{"blocks":[],"actors":[]}`, string(body), query)
+		} else {
+			require.Equal(t, "[]", string(body), query)
+		}
+	}
+}
+
+func TestGetBlocksArchivedBoards(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	activeID := utils.NewID(utils.IDTypeBlock)
+	archivedID := utils.NewID(utils.IDTypeBlock)
+	newBlocks := []model.Block{
+		{
+			ID:       activeID,
+			RootID:   activeID,
+			Type:     model.TypeBoard,
+			CreateAt: 1,
+			UpdateAt: 1,
+		},
+		{
+			ID:       archivedID,
+			RootID:   archivedID,
+			Type:     model.TypeBoard,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Fields:   map[string]interface{}{model.ArchivedAtField: 1},
+		},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 2)
+
+	boards, resp := th.Client.GetBlocksWithTypes([]string{model.TypeBoard})
+	require.NoError(t, resp.Error)
+	blockIDs := make([]string, 0, len(boards))
+	for _, board := range boards {
+		blockIDs = append(blockIDs, board.ID)
+	}
+	require.Contains(t, blockIDs, newBlocks[0].ID)
+	require.NotContains(t, blockIDs, newBlocks[1].ID)
+
+	r, err := th.Client.DoAPIGet(th.Client.GetBlocksRoute()+"?type=board&include_archived=true", "")
+	require.NoError(t, err)
+	defer r.Body.Close()
+	boards = model.BlocksFromJSON(r.Body)
+	blockIDs = make([]string, 0, len(boards))
+	for _, board := range boards {
+		blockIDs = append(blockIDs, board.ID)
+	}
+	require.Contains(t, blockIDs, newBlocks[0].ID)
+	require.Contains(t, blockIDs, newBlocks[1].ID)
+}
+
+func TestGetBlocksComputedFormulas(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBlock)
+	cardID := utils.NewID(utils.IDTypeBlock)
+	board := model.Block{
+		ID:       boardID,
+		RootID:   boardID,
+		Type:     model.TypeBoard,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "estimate", "name": "Estimate", "type": "number"},
+				map[string]interface{}{"id": "double", "name": "Double", "type": model.PropTypeFormula, "formula": `prop("Estimate") * 2`},
+			},
+		},
+	}
+	card := model.Block{
+		ID:       cardID,
+		RootID:   boardID,
+		ParentID: boardID,
+		Type:     model.TypeCard,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"estimate": "21"}},
+	}
+
+	t.Run("circular formulas are rejected", func(t *testing.T) {
+		circular := board
+		circular.Fields = map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "a", "name": "A", "type": model.PropTypeFormula, "formula": `prop("a") + 1`},
+			},
+		}
+		_, resp := th.Client.InsertBlocks([]model.Block{circular})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board, card})
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 2)
+
+	r, err := th.Client.DoAPIGet(th.Client.GetBlocksRoute()+"?parent_id="+newBlocks[0].ID, "")
+	require.NoError(t, err)
+	defer r.Body.Close()
+	cards := model.BlocksFromJSON(r.Body)
+	require.Len(t, cards, 1)
+	require.Equal(t, map[string]interface{}{"double": 42.0}, cards[0].Computed)
+}
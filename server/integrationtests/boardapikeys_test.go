@@ -0,0 +1,98 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAPIKeys(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+	require.NoError(t, th.InitUsers("user1", "user2"))
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: otherBoardID, RootID: otherBoardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 3)
+	// the server generates new block IDs on insert
+	boardID = newBlocks[0].ID
+	cardID := newBlocks[1].ID
+	otherBoardID = newBlocks[2].ID
+
+	key, resp := th.Client.CreateBoardAPIKey(boardID, "dashboard")
+	require.NoError(t, resp.Error)
+	require.Equal(t, boardID, key.BoardID)
+	require.Equal(t, "dashboard", key.Name)
+	require.NotEmpty(t, key.Token)
+
+	keyClient := client.NewClient(th.Server.Config().ServerRoot, key.Token)
+
+	t.Run("list keys without tokens", func(t *testing.T) {
+		keys, resp := th.Client.GetBoardAPIKeys(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, keys, 1)
+		require.Equal(t, key.ID, keys[0].ID)
+		require.Empty(t, keys[0].Token)
+	})
+
+	t.Run("create without a name", func(t *testing.T) {
+		_, resp := th.Client.CreateBoardAPIKey(boardID, " ")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("create for a block that isn't a board", func(t *testing.T) {
+		_, resp := th.Client.CreateBoardAPIKey(cardID, "dashboard")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("read the board with the key", func(t *testing.T) {
+		blocks, resp := keyClient.GetSubtree(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 2)
+
+		blocks, resp = keyClient.GetSubtree(cardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 1)
+	})
+
+	t.Run("read another board with the key", func(t *testing.T) {
+		_, resp := keyClient.GetSubtree(otherBoardID)
+		require.Error(t, resp.Error)
+	})
+
+	t.Run("write with the key", func(t *testing.T) {
+		_, resp := keyClient.InsertBlocks([]model.Block{
+			{ID: "card2", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		})
+		require.Error(t, resp.Error)
+
+		_, resp = keyClient.CreateBoardAPIKey(boardID, "escalate")
+		require.Error(t, resp.Error)
+	})
+
+	t.Run("revoke the key", func(t *testing.T) {
+		success, resp := th.Client.DeleteBoardAPIKey(boardID, key.ID)
+		require.NoError(t, resp.Error)
+		require.True(t, success)
+
+		_, resp = keyClient.GetSubtree(boardID)
+		require.Error(t, resp.Error)
+
+		_, resp = th.Client.DeleteBoardAPIKey(boardID, key.ID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
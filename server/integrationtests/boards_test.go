@@ -0,0 +1,733 @@
+package integrationtests
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetViewCardOrder(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "view", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView, Fields: map[string]interface{}{model.ViewTypeField: "table"}},
+		{ID: "card1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "card2", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "card3", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 5)
+	// the server generates new block IDs on insert
+	boardID = newBlocks[0].ID
+	viewID := newBlocks[1].ID
+	card1, card2, card3 := newBlocks[2].ID, newBlocks[3].ID, newBlocks[4].ID
+
+	t.Run("success", func(t *testing.T) {
+		order := model.ViewCardOrder{CardOrderByGroup: map[string][]string{
+			"todo": {card2, card1},
+			"done": {card3},
+		}}
+		view, resp := th.Client.SetViewCardOrder(boardID, viewID, order)
+		require.NoError(t, resp.Error)
+		require.Equal(t, viewID, view.ID)
+		require.Equal(t, []interface{}{card3, card2, card1}, view.Fields[model.ViewCardOrderField])
+
+		blocks, resp := th.Client.GetSubtree(viewID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, map[string]interface{}{
+			"todo": []interface{}{card2, card1},
+			"done": []interface{}{card3},
+		}, blocks[0].Fields[model.ViewGroupCardOrderField])
+	})
+
+	t.Run("merges into the current order", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		order := model.ViewCardOrder{CardOrderByGroup: map[string][]string{
+			"done": {card3, card1},
+		}}
+		view, resp := th.Client.SetViewCardOrder(boardID, viewID, order)
+		require.NoError(t, resp.Error)
+		require.Equal(t, []interface{}{card3, card1, card2}, view.Fields[model.ViewCardOrderField])
+		require.Equal(t, map[string]interface{}{
+			"todo": []interface{}{card2},
+			"done": []interface{}{card3, card1},
+		}, view.Fields[model.ViewGroupCardOrderField])
+	})
+
+	t.Run("card not in the board", func(t *testing.T) {
+		order := model.ViewCardOrder{CardOrderByGroup: map[string][]string{"todo": {card1, "not-a-card"}}}
+		_, resp := th.Client.SetViewCardOrder(boardID, viewID, order)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("card ordered twice", func(t *testing.T) {
+		order := model.ViewCardOrder{CardOrderByGroup: map[string][]string{"todo": {card1}, "done": {card1}}}
+		_, resp := th.Client.SetViewCardOrder(boardID, viewID, order)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("view not found", func(t *testing.T) {
+		order := model.ViewCardOrder{CardOrderByGroup: map[string][]string{"todo": {card1}}}
+		_, resp := th.Client.SetViewCardOrder(boardID, card1, order)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetPropertyOptionUsage(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":   "status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "todo", "value": "To Do"},
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+			},
+		}},
+		{ID: "card1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		}},
+		{ID: "card2", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done"},
+		}},
+		{ID: "card3", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		}},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+
+	t.Run("success", func(t *testing.T) {
+		usage, resp := th.Client.GetPropertyOptionUsage(boardID, "status", "todo")
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, usage.BoardID)
+		require.Equal(t, 2, usage.CardCount)
+	})
+
+	t.Run("option not found", func(t *testing.T) {
+		_, resp := th.Client.GetPropertyOptionUsage(boardID, "status", "unknown")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("board not found", func(t *testing.T) {
+		_, resp := th.Client.GetPropertyOptionUsage("unknown", "status", "todo")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestGetBoardPresence(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = blocks[0].ID
+
+	t.Run("unknown board", func(t *testing.T) {
+		_, resp := th.Client.GetBoardPresence(utils.NewID(utils.IDTypeBoard))
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("no viewers", func(t *testing.T) {
+		presence, resp := th.Client.GetBoardPresence(boardID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, presence.BoardID)
+		require.Empty(t, presence.UserIDs)
+	})
+
+	conn := joinBoardWebSocket(t, th, boardID)
+	defer conn.Close()
+
+	t.Run("join event", func(t *testing.T) {
+		var msg map[string]string
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		require.NoError(t, conn.ReadJSON(&msg))
+		require.Equal(t, "UPDATE_PRESENCE", msg["action"])
+		require.Equal(t, boardID, msg["boardId"])
+		require.Equal(t, "join", msg["event"])
+	})
+
+	t.Run("viewer", func(t *testing.T) {
+		presence, resp := th.Client.GetBoardPresence(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, presence.UserIDs, 1)
+	})
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "LEAVE_BOARD", "boardId": boardID}))
+
+	t.Run("viewer left", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			presence, resp := th.Client.GetBoardPresence(boardID)
+			return resp.Error == nil && len(presence.UserIDs) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func TestRelayCursor(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID := blocks[0].ID
+
+	sender := joinBoardWebSocket(t, th, boardID)
+	defer sender.Close()
+	receiver := joinBoardWebSocket(t, th, boardID)
+	defer receiver.Close()
+
+	// keep sending until the receiver has joined the board too
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = sender.WriteJSON(map[string]interface{}{
+					"action":  "UPDATE_CURSOR",
+					"boardId": boardID,
+					"cursor":  map[string]string{"cardId": "card1"},
+				})
+			}
+		}
+	}()
+
+	var msg struct {
+		Action  string            `json:"action"`
+		BoardID string            `json:"boardId"`
+		Cursor  map[string]string `json:"cursor"`
+	}
+	require.NoError(t, receiver.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, receiver.ReadJSON(&msg))
+	require.Equal(t, "UPDATE_CURSOR", msg.Action)
+	require.Equal(t, boardID, msg.BoardID)
+	require.Equal(t, "card1", msg.Cursor["cardId"])
+}
+
+// joinBoardWebSocket opens a websocket connection authenticated as the
+// test client, and joins the board on it.
+func joinBoardWebSocket(t *testing.T, th *TestHelper, boardID string) *websocket.Conn {
+	wsURL := strings.Replace(th.Server.Config().ServerRoot, "http", "ws", 1) + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "AUTH", "token": th.Client.Token}))
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "JOIN_BOARD", "workspaceId": "0", "boardId": boardID}))
+	return conn
+}
+
+func TestExportBoardPDF(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Roadmap"},
+		{ID: "view", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeView, Title: "All", Fields: map[string]interface{}{model.ViewTypeField: "table"}},
+		{ID: "card", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First card"},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID := blocks[0].ID
+	viewID := blocks[1].ID
+
+	t.Run("not configured", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardPDF(boardID, viewID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+
+	// cat stands in for a real converter and returns the HTML page
+	th.Server.App().GetConfig().PDFConverter = "cat"
+
+	t.Run("export", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardPDF(boardID, viewID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+		require.Contains(t, string(data), "Roadmap")
+		require.Contains(t, string(data), "First card")
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardPDF(boardID, "unknown")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestExportBoardCSV(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Roadmap", Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "owner", "name": "Owner", "type": "text"},
+				map[string]interface{}{"id": "notes", "name": "Notes", "type": "text"},
+			},
+		}},
+		{ID: "view", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeView, Title: "All", Fields: map[string]interface{}{
+			model.ViewTypeField:  "table",
+			"visiblePropertyIds": []interface{}{"notes"},
+		}},
+		{ID: "card", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First card", Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"owner": "alice", "notes": "draft"},
+		}},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID, viewID := blocks[0].ID, blocks[1].ID
+
+	t.Run("export", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardCSV(boardID, "")
+		require.NoError(t, resp.Error)
+		require.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+		require.Equal(t, "Name,Owner,Notes\nFirst card,alice,draft\n", string(data))
+	})
+
+	t.Run("export view", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardCSV(boardID, viewID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, "Name,Notes\nFirst card,draft\n", string(data))
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardCSV(boardID, "unknown")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestExportBlocks(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Roadmap"},
+		{ID: "card", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First card"},
+		{ID: "comment", RootID: "board", ParentID: "card", CreateAt: 1, UpdateAt: 1, Type: model.TypeComment, Title: "Internal note"},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID, commentID := blocks[0].ID, blocks[2].ID
+
+	t.Run("with comments", func(t *testing.T) {
+		blocks, resp := th.Client.ExportBlocks(boardID, true)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 3)
+	})
+
+	t.Run("without comments", func(t *testing.T) {
+		blocks, resp := th.Client.ExportBlocks(boardID, false)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 2)
+		for _, block := range blocks {
+			require.NotEqual(t, commentID, block.ID)
+		}
+	})
+}
+
+func TestExportBoards(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board1", RootID: "board1", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Roadmap"},
+		{ID: "card1", RootID: "board1", ParentID: "board1", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First card"},
+		{ID: "board2", RootID: "board2", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Tasks"},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	board1ID, board2ID := blocks[0].ID, blocks[2].ID
+
+	t.Run("export", func(t *testing.T) {
+		export, resp := th.Client.ExportBoards([]string{board1ID, "missing", board2ID})
+		require.NoError(t, resp.Error)
+		require.Len(t, export.Blocks, 3)
+		require.Equal(t, []model.SkippedBoard{{BoardID: "missing", Reason: "board not found"}}, export.Skipped)
+	})
+
+	t.Run("no board", func(t *testing.T) {
+		_, resp := th.Client.ExportBoards([]string{})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCardRelations(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "board1", RootID: "board1", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "related", "type": "relation"},
+			},
+		}},
+		{ID: "card1", RootID: "board1", ParentID: "board1", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "Card 1"},
+		{ID: "board2", RootID: "board2", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card2", RootID: "board2", ParentID: "board2", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "Card 2"},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	board1ID, card1ID, board2ID, card2ID := blocks[0].ID, blocks[1].ID, blocks[2].ID, blocks[3].ID
+
+	t.Run("relation to a missing card", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(card1ID, &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"related": "missing"},
+		}})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("relation to a card of another board", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(card1ID, &model.BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{"related": []string{card2ID}},
+		}})
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetSubtreeExpandingRelations(board1ID)
+		require.NoError(t, resp.Error)
+		card := findBlock(t, blocks, card1ID)
+		require.Equal(t, []model.RelatedCard{{ID: card2ID, BoardID: board2ID, Title: "Card 2"}}, card.Relations["related"])
+	})
+
+	t.Run("relation to a deleted card", func(t *testing.T) {
+		_, resp := th.Client.DeleteBlock(card2ID)
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetSubtreeExpandingRelations(board1ID)
+		require.NoError(t, resp.Error)
+		card := findBlock(t, blocks, card1ID)
+		require.Equal(t, []model.RelatedCard{{ID: card2ID, Dangling: true}}, card.Relations["related"])
+	})
+}
+
+func findBlock(t *testing.T, blocks []model.Block, blockID string) model.Block {
+	for _, block := range blocks {
+		if block.ID == blockID {
+			return block
+		}
+	}
+	require.FailNow(t, "block not found", blockID)
+	return model.Block{}
+}
+
+func TestNotificationSettings(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = blocks[0].ID
+
+	t.Run("unknown board", func(t *testing.T) {
+		_, resp := th.Client.GetNotificationSettings(utils.NewID(utils.IDTypeBoard))
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("defaults to instant", func(t *testing.T) {
+		settings, resp := th.Client.GetNotificationSettings(boardID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, settings.BoardID)
+		require.Equal(t, model.NotifyMode(model.NotifyModeInstant), settings.Mode)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		settings, resp := th.Client.UpdateNotificationSettings(boardID, &model.NotificationSettings{Mode: model.NotifyModeDaily})
+		require.NoError(t, resp.Error)
+		require.Equal(t, model.NotifyMode(model.NotifyModeDaily), settings.Mode)
+		require.Equal(t, "single-user", settings.UserID)
+
+		settings, resp = th.Client.GetNotificationSettings(boardID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, model.NotifyMode(model.NotifyModeDaily), settings.Mode)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, resp := th.Client.UpdateNotificationSettings(boardID, &model.NotificationSettings{Mode: "weekly"})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestGetWorkspaceWithStats(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	workspace, resp := th.Client.GetWorkspaceWithStats("0")
+	require.NoError(t, resp.Error)
+	require.NotNil(t, workspace.Stats)
+	boardCount := workspace.Stats.BoardCount
+	blockCount := workspace.Stats.BlockCount
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	board := model.Block{
+		ID:       boardID,
+		RootID:   boardID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+	}
+	_, resp = th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+
+	workspace, resp = th.Client.GetWorkspaceWithStats("0")
+	require.NoError(t, resp.Error)
+	require.Equal(t, boardCount+1, workspace.Stats.BoardCount)
+	require.Equal(t, blockCount+1, workspace.Stats.BlockCount)
+	require.Zero(t, workspace.Stats.StorageUsage)
+}
+
+func TestExportBoardActivity(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+
+	require.NoError(t, th.InitUsers("user1", "user2"))
+	user1, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+	user2, resp := th.Client2.GetMe()
+	require.NoError(t, resp.Error)
+
+	newBlocks := []model.Block{
+		{ID: "board", RootID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard, Title: "Roadmap"},
+		{ID: "card", RootID: "board", ParentID: "board", CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "First card"},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID := blocks[0].ID
+	cardID := blocks[1].ID
+
+	// the history of a block is keyed by millisecond
+	time.Sleep(10 * time.Millisecond)
+	since := utils.GetMillis()
+
+	title := "Edited card"
+	_, resp = th.Client2.PatchBlock(cardID, &model.BlockPatch{
+		Title:         &title,
+		UpdatedFields: map[string]interface{}{"icon": "🚀"},
+	})
+	require.NoError(t, resp.Error)
+	time.Sleep(10 * time.Millisecond)
+	_, resp = th.Client.DeleteBlock(cardID)
+	require.NoError(t, resp.Error)
+
+	readRows := func(data []byte) [][]string {
+		rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, []string{"timestamp", "userId", "username", "blockTitle", "action", "changedFields", "changes"}, rows[0])
+		return rows[1:]
+	}
+
+	t.Run("all activity", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardActivity(boardID, 0, 0)
+		require.NoError(t, resp.Error)
+		require.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+		rows := readRows(data)
+		require.Len(t, rows, 4)
+		actions := []string{}
+		for _, row := range rows {
+			actions = append(actions, row[4])
+		}
+		require.ElementsMatch(t, []string{"created", "created", "updated", "deleted"}, actions)
+		require.Equal(t, []string{user2.ID, "user2", "Edited card", "updated", "title;fields.icon", "title: First card → Edited card"}, rows[2][1:])
+		require.Equal(t, []string{user1.ID, "user1", "Edited card", "deleted", "", ""}, rows[3][1:])
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardActivity(boardID, since, 0)
+		require.NoError(t, resp.Error)
+
+		rows := readRows(data)
+		require.Len(t, rows, 2)
+		require.Equal(t, "updated", rows[0][4])
+		require.Equal(t, "title;fields.icon", rows[0][5])
+		require.Equal(t, "deleted", rows[1][4])
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardActivity(boardID, since, since-1)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("not existing board", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardActivity("not-exists", 0, 0)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("as json", func(t *testing.T) {
+		activity, resp := th.Client.GetBoardActivity(boardID, since, 0)
+		require.NoError(t, resp.Error)
+		require.Len(t, activity, 2)
+		require.Equal(t, model.ActivityEntry{
+			Timestamp:     activity[0].Timestamp,
+			UserID:        user2.ID,
+			Username:      "user2",
+			BlockID:       cardID,
+			BlockTitle:    "Edited card",
+			Action:        "updated",
+			ChangedFields: []string{"title", "fields.icon"},
+			Changes:       []string{"title: First card → Edited card"},
+		}, activity[0])
+		require.Equal(t, "deleted", activity[1].Action)
+
+		_, resp = th.Client.GetBoardActivity(boardID, since, since-1)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		_, resp = th.Client.GetBoardActivity("not-exists", 0, 0)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestBoardAcknowledgments(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	user, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	newBlocks, resp = th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+	cardID := newBlocks[1].ID
+
+	t.Run("acknowledge a board", func(t *testing.T) {
+		ack, resp := th.Client.AcknowledgeBoard(boardID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, user.ID, ack.UserID)
+
+		acks, resp := th.Client.GetBoardAcknowledgments(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, acks.Acknowledged, 1)
+		require.Equal(t, user.ID, acks.Acknowledged[0].UserID)
+		require.NotContains(t, acks.PendingUserIDs, user.ID)
+	})
+
+	t.Run("new activity requires a new acknowledgment", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		title := "changed"
+		_, resp := th.Client.PatchBlock(cardID, &model.BlockPatch{Title: &title})
+		require.NoError(t, resp.Error)
+
+		acks, resp := th.Client.GetBoardAcknowledgments(boardID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, acks.Acknowledged)
+
+		_, resp = th.Client.AcknowledgeBoard(boardID)
+		require.NoError(t, resp.Error)
+		acks, resp = th.Client.GetBoardAcknowledgments(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, acks.Acknowledged, 1)
+	})
+
+	t.Run("missing board", func(t *testing.T) {
+		_, resp := th.Client.AcknowledgeBoard("missing")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestClearBoard(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "view1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeView, Fields: map[string]interface{}{"viewType": "board"}},
+		{ID: "card1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "text1", RootID: boardID, ParentID: "card1", CreateAt: 1, UpdateAt: 1, Type: model.TypeText},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+	viewID := newBlocks[1].ID
+
+	blockIDs := func() []string {
+		blocks, resp := th.Client.ExportBlocks(boardID, true)
+		require.NoError(t, resp.Error)
+		ids := make([]string, len(blocks))
+		for i, b := range blocks {
+			ids[i] = b.ID
+		}
+		return ids
+	}
+
+	// this avoids triggering uniqueness constraint of
+	// id,insert_at on block history
+	time.Sleep(10 * time.Millisecond)
+
+	t.Run("clear the cards", func(t *testing.T) {
+		result, resp := th.Client.ClearBoard(boardID, false)
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, result.BoardID)
+		require.Equal(t, 2, result.DeletedCount)
+		require.ElementsMatch(t, []string{boardID, viewID}, blockIDs())
+	})
+
+	t.Run("clear the views", func(t *testing.T) {
+		result, resp := th.Client.ClearBoard(boardID, true)
+		require.NoError(t, resp.Error)
+		require.Equal(t, 1, result.DeletedCount)
+		require.Equal(t, []string{boardID}, blockIDs())
+	})
+
+	t.Run("missing board", func(t *testing.T) {
+		_, resp := th.Client.ClearBoard("missing", false)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -71,8 +71,12 @@ func newTestServer(singleUserToken string) *server.Server {
 		panic(err)
 	}
 
+	return newTestServerWithConfig(singleUserToken, cfg)
+}
+
+func newTestServerWithConfig(singleUserToken string, cfg *config.Configuration) *server.Server {
 	logger, _ := mlog.NewLogger()
-	if err = logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
+	if err := logger.Configure("", cfg.LoggingCfgJSON, nil); err != nil {
 		panic(err)
 	}
 	db, err := server.NewStore(cfg, logger)
@@ -103,6 +107,23 @@ func SetupTestHelper() *TestHelper {
 	return th
 }
 
+// SetupTestHelperWithRoutePrefix returns a single user test helper for a
+// server with all its routes mounted under routePrefix.
+func SetupTestHelperWithRoutePrefix(routePrefix string) *TestHelper {
+	cfg, err := getTestConfig()
+	if err != nil {
+		panic(err)
+	}
+	cfg.ServerRoot += routePrefix
+	cfg.RoutePrefix = routePrefix
+
+	sessionToken := "TESTTOKEN"
+	th := &TestHelper{}
+	th.Server = newTestServerWithConfig(sessionToken, cfg)
+	th.Client = client.NewClient(th.Server.Config().ServerRoot, sessionToken)
+	return th
+}
+
 func SetupTestHelperWithoutToken() *TestHelper {
 	th := &TestHelper{}
 	th.Server = newTestServer("")
@@ -0,0 +1,97 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentThreads(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+	cardID := newBlocks[1].ID
+
+	comment := []model.Block{
+		{ID: "comment", RootID: boardID, ParentID: cardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeComment, Title: "first"},
+	}
+	comment, resp = th.Client.InsertBlocks(comment)
+	require.NoError(t, resp.Error)
+	commentID := comment[0].ID
+
+	t.Run("reply to a comment", func(t *testing.T) {
+		// this ensures the replies are created after the comment
+		time.Sleep(5 * time.Millisecond)
+		reply, resp := th.Client.ReplyToComment(boardID, cardID, commentID, "second")
+		require.NoError(t, resp.Error)
+		require.Equal(t, commentID, reply.ParentCommentID())
+
+		time.Sleep(5 * time.Millisecond)
+		nested, resp := th.Client.ReplyToComment(boardID, cardID, reply.ID, "third")
+		require.NoError(t, resp.Error)
+		require.Equal(t, commentID, nested.ParentCommentID())
+
+		threads, resp := th.Client.GetCommentThreads(boardID, cardID, false)
+		require.NoError(t, resp.Error)
+		require.Len(t, threads, 1)
+		require.Equal(t, commentID, threads[0].Comment.ID)
+		require.Len(t, threads[0].Replies, 2)
+		require.Equal(t, "second", threads[0].Replies[0].Title)
+		require.Equal(t, "third", threads[0].Replies[1].Title)
+	})
+
+	t.Run("empty reply", func(t *testing.T) {
+		_, resp := th.Client.ReplyToComment(boardID, cardID, commentID, "")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("resolve and unresolve a thread", func(t *testing.T) {
+		block, resp := th.Client.ResolveCommentThread(boardID, cardID, commentID)
+		require.NoError(t, resp.Error)
+		require.True(t, block.IsResolved())
+
+		threads, resp := th.Client.GetCommentThreads(boardID, cardID, false)
+		require.NoError(t, resp.Error)
+		require.Empty(t, threads)
+
+		threads, resp = th.Client.GetCommentThreads(boardID, cardID, true)
+		require.NoError(t, resp.Error)
+		require.Len(t, threads, 1)
+
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		block, resp = th.Client.UnresolveCommentThread(boardID, cardID, commentID)
+		require.NoError(t, resp.Error)
+		require.False(t, block.IsResolved())
+
+		threads, resp = th.Client.GetCommentThreads(boardID, cardID, false)
+		require.NoError(t, resp.Error)
+		require.Len(t, threads, 1)
+	})
+
+	t.Run("comment not found", func(t *testing.T) {
+		_, resp := th.Client.ResolveCommentThread(boardID, cardID, "missing")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		_, resp = th.Client.ReplyToComment(boardID, cardID, "missing", "text")
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
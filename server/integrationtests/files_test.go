@@ -0,0 +1,142 @@
+package integrationtests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachFileToCard(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	newBlocks := []model.Block{
+		{
+			ID:       boardID,
+			RootID:   boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeBoard,
+		},
+		{
+			ID:       cardID,
+			RootID:   boardID,
+			ParentID: boardID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		},
+	}
+	newBlocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 2)
+	// the server generates new block IDs on insert
+	boardID = newBlocks[0].ID
+	cardID = newBlocks[1].ID
+
+	t.Run("success", func(t *testing.T) {
+		block, resp := th.Client.AttachFileToCard(boardID, cardID, bytes.NewReader(randomBytes(t, 1024)))
+		require.NoError(t, resp.Error)
+		require.NotNil(t, block)
+		require.Equal(t, model.BlockType(model.TypeImage), block.Type)
+		require.Equal(t, cardID, block.ParentID)
+		require.NotEmpty(t, block.Fields["fileId"])
+
+		blocks, resp := th.Client.GetSubtree(cardID)
+		require.NoError(t, resp.Error)
+		var card model.Block
+		for _, b := range blocks {
+			if b.ID == cardID {
+				card = b
+			}
+		}
+		require.Equal(t, []interface{}{block.ID}, card.Fields["contentOrder"])
+	})
+
+	t.Run("only the content order of the card changes", func(t *testing.T) {
+		title := "card title"
+		_, resp := th.Client.PatchBlock(cardID, &model.BlockPatch{Title: &title})
+		require.NoError(t, resp.Error)
+
+		first, resp := th.Client.GetSubtree(cardID)
+		require.NoError(t, resp.Error)
+		var contentOrder []interface{}
+		for _, b := range first {
+			if b.ID == cardID {
+				contentOrder, _ = b.Fields["contentOrder"].([]interface{})
+			}
+		}
+
+		block, resp := th.Client.AttachFileToCard(boardID, cardID, bytes.NewReader(randomBytes(t, 1024)))
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetSubtree(cardID)
+		require.NoError(t, resp.Error)
+		for _, b := range blocks {
+			if b.ID == cardID {
+				require.Equal(t, title, b.Title)
+				require.Equal(t, append(contentOrder, block.ID), b.Fields["contentOrder"])
+			}
+		}
+
+		_, resp = th.Client.DeleteBlock(block.ID)
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("list attachments", func(t *testing.T) {
+		attachments, resp := th.Client.GetCardAttachments(boardID, cardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, attachments, 1)
+		require.Equal(t, "file", attachments[0].Filename)
+		require.EqualValues(t, 1024, attachments[0].Size)
+		require.Equal(t, "single-user", attachments[0].UploadedBy)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		block, resp := th.Client.AttachFileToCard(boardID, "not-a-card", bytes.NewReader(randomBytes(t, 1024)))
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Nil(t, block)
+	})
+}
+
+func TestUploadFileAllowedExtensions(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBoard := func(fields map[string]interface{}) string {
+		board := model.Block{
+			ID:       utils.NewID(utils.IDTypeBoard),
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeBoard,
+			Fields:   fields,
+		}
+		board.RootID = board.ID
+		blocks, resp := th.Client.InsertBlocks([]model.Block{board})
+		require.NoError(t, resp.Error)
+		return blocks[0].ID
+	}
+
+	t.Run("no allowed extensions", func(t *testing.T) {
+		boardID := newBoard(map[string]interface{}{})
+		result, resp := th.Client.WorkspaceUploadFile("0", boardID, bytes.NewReader(randomBytes(t, 1024)))
+		require.NoError(t, resp.Error)
+		require.NotEmpty(t, result.FileID)
+	})
+
+	t.Run("extension not allowed", func(t *testing.T) {
+		// the client uploads files without extension
+		boardID := newBoard(map[string]interface{}{model.AllowedFileExtensionsField: []interface{}{".png"}})
+		_, resp := th.Client.WorkspaceUploadFile("0", boardID, bytes.NewReader(randomBytes(t, 1024)))
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,144 @@
+package integrationtests
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("no headers without a limit", func(t *testing.T) {
+		_, resp := th.Client.GetBlocks()
+		require.NoError(t, resp.Error)
+		require.Empty(t, resp.Header.Get(api.HeaderRateLimitLimit))
+	})
+
+	config := th.Server.App().GetConfig()
+	config.RateLimitPerMinute = 2
+	defer func() { config.RateLimitPerMinute = 0 }()
+
+	t.Run("headers count down the limit", func(t *testing.T) {
+		_, resp := th.Client.GetBlocks()
+		require.NoError(t, resp.Error)
+		require.Equal(t, "2", resp.Header.Get(api.HeaderRateLimitLimit))
+		require.Equal(t, "1", resp.Header.Get(api.HeaderRateLimitRemaining))
+
+		reset, err := strconv.ParseInt(resp.Header.Get(api.HeaderRateLimitReset), 10, 64)
+		require.NoError(t, err)
+		require.InDelta(t, time.Now().Add(time.Minute).Unix(), reset, 2)
+
+		_, resp = th.Client.GetBlocks()
+		require.NoError(t, resp.Error)
+		require.Equal(t, "0", resp.Header.Get(api.HeaderRateLimitRemaining))
+	})
+
+	t.Run("requests past the limit fail", func(t *testing.T) {
+		_, resp := th.Client.GetBlocks()
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, "0", resp.Header.Get(api.HeaderRateLimitRemaining))
+		require.NotEmpty(t, resp.Header.Get("Retry-After"))
+	})
+
+	t.Run("clients are limited separately", func(t *testing.T) {
+		other := client.NewClient(th.Server.Config().ServerRoot, "other-token")
+		_, resp := other.GetBlocks()
+		require.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, "1", resp.Header.Get(api.HeaderRateLimitRemaining))
+	})
+
+	t.Run("invalid tokens share the limit of their address", func(t *testing.T) {
+		other := client.NewClient(th.Server.Config().ServerRoot, "another-token")
+		_, resp := other.GetBlocks()
+		require.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, "0", resp.Header.Get(api.HeaderRateLimitRemaining))
+
+		other = client.NewClient(th.Server.Config().ServerRoot, "yet-another-token")
+		_, resp = other.GetBlocks()
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+}
+
+func TestReadTokenRateLimit(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	config := th.Server.App().GetConfig()
+	config.ReadTokenRateLimitPerMinute = 1
+	defer func() { config.ReadTokenRateLimitPerMinute = 0 }()
+
+	tokens := map[string]string{}
+	for _, boardID := range []string{"board1", "board2", "board3"} {
+		tokens[boardID] = utils.NewID(utils.IDTypeToken)
+		success, resp := th.Client.PostSharing(model.Sharing{ID: boardID, Token: tokens[boardID], Enabled: true})
+		require.True(t, success)
+		require.NoError(t, resp.Error)
+	}
+
+	anonymous := client.NewClient(th.Server.Config().ServerRoot, "")
+	get := func(c *client.Client, url string) *http.Response {
+		r, _ := c.DoAPIRequest(http.MethodGet, url, "", "")
+		require.NotNil(t, r)
+		r.Body.Close()
+		return r
+	}
+	getSubtree := func(c *client.Client, blockID, readToken string) *http.Response {
+		return get(c, c.APIURL+"/workspaces/0/blocks/"+blockID+"/subtree?read_token="+readToken)
+	}
+
+	t.Run("anonymous requests past the limit fail", func(t *testing.T) {
+		r := getSubtree(anonymous, "board1", tokens["board1"])
+		require.NotEqual(t, http.StatusTooManyRequests, r.StatusCode)
+		require.Equal(t, "1", r.Header.Get(api.HeaderRateLimitLimit))
+
+		r = getSubtree(anonymous, "board1", tokens["board1"])
+		require.Equal(t, http.StatusTooManyRequests, r.StatusCode)
+		require.NotEmpty(t, r.Header.Get("Retry-After"))
+	})
+
+	t.Run("the blocks of a board share its limit", func(t *testing.T) {
+		r := getSubtree(anonymous, "card1", tokens["board1"])
+		require.Equal(t, http.StatusTooManyRequests, r.StatusCode)
+	})
+
+	t.Run("boards are limited separately", func(t *testing.T) {
+		r := getSubtree(anonymous, "board2", tokens["board2"])
+		require.NotEqual(t, http.StatusTooManyRequests, r.StatusCode)
+	})
+
+	t.Run("invalid tokens get the limit of their address", func(t *testing.T) {
+		config.RateLimitPerMinute = 5
+		defer func() { config.RateLimitPerMinute = 0 }()
+
+		r := getSubtree(anonymous, "board2", "invalid-token")
+		require.NotEqual(t, http.StatusTooManyRequests, r.StatusCode)
+		require.Equal(t, "5", r.Header.Get(api.HeaderRateLimitLimit))
+	})
+
+	t.Run("files are limited", func(t *testing.T) {
+		url := th.Server.Config().ServerRoot + "/files/workspaces/0/board3/file.png?read_token=" + tokens["board3"]
+		r := get(anonymous, url)
+		require.NotEqual(t, http.StatusTooManyRequests, r.StatusCode)
+
+		r = get(anonymous, url)
+		require.Equal(t, http.StatusTooManyRequests, r.StatusCode)
+	})
+
+	t.Run("authenticated requests aren't limited", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			r := getSubtree(th.Client, "board1", tokens["board1"])
+			require.NotEqual(t, http.StatusTooManyRequests, r.StatusCode)
+			require.Empty(t, r.Header.Get(api.HeaderRateLimitLimit))
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictedCards(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+	require.NoError(t, th.InitUsers("user1", "user2"))
+
+	me, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "open", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "secret", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Title: "secret title"},
+		{ID: "content", RootID: boardID, ParentID: "secret", CreateAt: 1, UpdateAt: 1, Type: model.TypeText},
+	}
+	newBlocks, resp = th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 4)
+	// the server generates new block IDs on insert
+	boardID = newBlocks[0].ID
+	openID, secretID, contentID := newBlocks[1].ID, newBlocks[2].ID, newBlocks[3].ID
+
+	t.Run("restrict a block that isn't a card", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(contentID, &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{model.RestrictedToField: []string{me.ID}},
+		})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	_, resp = th.Client.PatchBlock(secretID, &model.BlockPatch{
+		UpdatedFields: map[string]interface{}{model.RestrictedToField: []string{me.ID}},
+	})
+	require.NoError(t, resp.Error)
+
+	t.Run("allowed user sees the card", func(t *testing.T) {
+		blocks, resp := th.Client.GetSubtree(boardID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 3)
+
+		blocks, resp = th.Client.GetSubtree(secretID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 2)
+	})
+
+	t.Run("other user doesn't see the card", func(t *testing.T) {
+		blocks, resp := th.Client2.GetSubtree(boardID)
+		require.NoError(t, resp.Error)
+		blockIDs := []string{}
+		for _, block := range blocks {
+			blockIDs = append(blockIDs, block.ID)
+		}
+		require.ElementsMatch(t, []string{boardID, openID}, blockIDs)
+
+		blocks, resp = th.Client2.GetSubtree(secretID)
+		require.NoError(t, resp.Error)
+		require.Empty(t, blocks)
+
+		blocks, resp = th.Client2.GetBlocksWithTypes([]string{model.TypeCard})
+		require.NoError(t, resp.Error)
+		for _, block := range blocks {
+			require.NotEqual(t, secretID, block.ID)
+		}
+	})
+
+	t.Run("other user doesn't see the card in exports", func(t *testing.T) {
+		blocks, resp := th.Client2.ExportBlocks(boardID, true)
+		require.NoError(t, resp.Error)
+		blockIDs := []string{}
+		for _, block := range blocks {
+			blockIDs = append(blockIDs, block.ID)
+		}
+		require.ElementsMatch(t, []string{boardID, openID}, blockIDs)
+
+		data, resp := th.Client2.ExportBoardActivity(boardID, 0, 0)
+		require.NoError(t, resp.Error)
+		require.NotContains(t, string(data), "secret title")
+
+		activity, resp := th.Client2.GetBoardActivity(boardID, 0, 0)
+		require.NoError(t, resp.Error)
+		for _, entry := range activity {
+			require.NotEqual(t, secretID, entry.BlockID)
+		}
+
+		_, resp = th.Client2.GetCardAttachments(boardID, secretID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("other user can't add blocks to the card", func(t *testing.T) {
+		_, resp := th.Client2.InsertBlocks([]model.Block{
+			{ID: "reply", RootID: boardID, ParentID: secretID, CreateAt: 1, UpdateAt: 1, Type: model.TypeComment},
+		})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.InsertBlocks([]model.Block{
+			{ID: "reply", RootID: boardID, ParentID: openID, CreateAt: 1, UpdateAt: 1, Type: model.TypeComment},
+		})
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("other user can't change the card", func(t *testing.T) {
+		title := "changed"
+		_, resp := th.Client2.PatchBlock(secretID, &model.BlockPatch{Title: &title})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.PatchBlock(contentID, &model.BlockPatch{Title: &title})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.DeleteBlock(secretID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.MoveCards(boardID, model.MoveCardsRequest{CardIDs: []string{openID, secretID}, PropertyID: "status"})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.SetViewCardOrder(boardID, "view", model.ViewCardOrder{
+			CardOrderByGroup: map[string][]string{"": {secretID, openID}},
+		})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, resp = th.Client2.MuteCard(boardID, secretID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("lift the restriction", func(t *testing.T) {
+		_, resp := th.Client.PatchBlock(secretID, &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{model.RestrictedToField: []string{}},
+		})
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client2.GetSubtree(secretID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 2)
+	})
+}
@@ -0,0 +1,53 @@
+package integrationtests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutePrefix(t *testing.T) {
+	th := SetupTestHelperWithRoutePrefix("/boards").InitBasic()
+	defer th.TearDown()
+
+	t.Run("API is served under the prefix", func(t *testing.T) {
+		blocks, resp := th.Client.GetBlocks()
+		require.NoError(t, resp.Error)
+		require.NotNil(t, blocks)
+
+		r, err := http.Get("http://localhost:8888/api/v1/workspaces/0/blocks") //nolint:gosec
+		require.NoError(t, err)
+		r.Body.Close()
+		require.Equal(t, http.StatusNotFound, r.StatusCode)
+	})
+
+	t.Run("files are served under the prefix", func(t *testing.T) {
+		config := th.Server.App().GetConfig()
+		maxRequestBodySize := config.MaxRequestBodySize
+		config.MaxRequestBodySize = 100
+		defer func() { config.MaxRequestBodySize = maxRequestBodySize }()
+
+		rootID := utils.NewID(utils.IDTypeBlock)
+		data := randomBytes(t, 1024)
+		result, resp := th.Client.WorkspaceUploadFile("0", rootID, bytes.NewReader(data))
+		require.NoError(t, resp.Error)
+		require.NotEmpty(t, result.FileID)
+
+		req, err := http.NewRequest(http.MethodGet, th.Client.URL+"/files/workspaces/0/"+rootID+"/"+result.FileID, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+th.Client.Token)
+		req.Header.Set("X-Requested-With", "XMLHttpRequest")
+		r, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer r.Body.Close()
+		require.Equal(t, http.StatusOK, r.StatusCode)
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, data, body)
+	})
+}
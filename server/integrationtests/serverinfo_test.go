@@ -0,0 +1,34 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("authenticated", func(t *testing.T) {
+		info, resp := th.Client.GetServerInfo()
+		require.NoError(t, resp.Error)
+		require.Equal(t, model.CurrentVersion, info.Version)
+		require.Contains(t, info.Features, "filesS3")
+		require.False(t, info.Features["filesS3"])
+		require.NotNil(t, info.HistoryRetention)
+		require.Zero(t, info.HistoryRetention.MaxVersions)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		other := client.NewClient(th.Server.Config().ServerRoot, "")
+		info, resp := other.GetServerInfo()
+		require.NoError(t, resp.Error)
+		require.Equal(t, model.CurrentVersion, info.Version)
+		require.Nil(t, info.Features)
+		require.Nil(t, info.HistoryRetention)
+	})
+}
@@ -1,6 +1,7 @@
 package integrationtests
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -43,4 +44,138 @@ func TestSharing(t *testing.T) {
 		require.True(t, sharing.Enabled)
 		require.Equal(t, sharing.Token, token)
 	})
+
+	t.Run("Regenerate sharing token", func(t *testing.T) {
+		sharing, resp := th.Client.RegenerateSharingToken(rootID)
+		require.NoError(t, resp.Error)
+		require.True(t, sharing.Enabled)
+		require.NotEmpty(t, sharing.Token)
+		require.NotEqual(t, token, sharing.Token)
+
+		stored, resp := th.Client.GetSharing(rootID)
+		require.NoError(t, resp.Error)
+		require.True(t, stored.Enabled)
+		require.Equal(t, sharing.Token, stored.Token)
+	})
+
+	t.Run("PATCH sharing keeps the token", func(t *testing.T) {
+		before, resp := th.Client.GetSharing(rootID)
+		require.NoError(t, resp.Error)
+
+		enabled := false
+		sharing, resp := th.Client.PatchSharing(rootID, &model.SharingPatch{Enabled: &enabled})
+		require.NoError(t, resp.Error)
+		require.False(t, sharing.Enabled)
+		require.Equal(t, before.Token, sharing.Token)
+
+		stored, resp := th.Client.GetSharing(rootID)
+		require.NoError(t, resp.Error)
+		require.False(t, stored.Enabled)
+		require.Equal(t, before.Token, stored.Token)
+	})
+
+	t.Run("PATCH sharing without fields", func(t *testing.T) {
+		_, resp := th.Client.PatchSharing(rootID, &model.SharingPatch{})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("PATCH sharing of a block not shared", func(t *testing.T) {
+		enabled := true
+		sharing, resp := th.Client.PatchSharing(utils.NewID(utils.IDTypeBlock), &model.SharingPatch{Enabled: &enabled})
+		require.NoError(t, resp.Error)
+		require.True(t, sharing.Enabled)
+		require.NotEmpty(t, sharing.Token)
+	})
+
+	t.Run("Regenerate token of a block not shared", func(t *testing.T) {
+		sharing, resp := th.Client.RegenerateSharingToken(utils.NewID(utils.IDTypeBlock))
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		require.Nil(t, sharing)
+	})
+}
+
+func TestSharingExpandBoard(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	boardID := utils.NewID(utils.IDTypeBlock)
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{{
+		ID:       boardID,
+		RootID:   boardID,
+		Type:     model.TypeBoard,
+		CreateAt: 1,
+		UpdateAt: 1,
+	}})
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+
+	sharing := model.Sharing{
+		ID:      boardID,
+		Token:   utils.NewID(utils.IDTypeToken),
+		Enabled: true,
+	}
+
+	t.Run("PATCH sharing without expand", func(t *testing.T) {
+		success, resp := th.Client.PostSharing(sharing)
+		require.True(t, success)
+		require.NoError(t, resp.Error)
+
+		enabled := true
+		stored, resp := th.Client.PatchSharing(boardID, &model.SharingPatch{Enabled: &enabled})
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, stored.ID)
+		require.True(t, stored.Enabled)
+	})
+
+	t.Run("POST sharing with the board", func(t *testing.T) {
+		result, resp := th.Client.PostSharingWithBoard(sharing)
+		require.NoError(t, resp.Error)
+		require.Equal(t, boardID, result.Sharing.ID)
+		require.NotNil(t, result.Board)
+		require.Equal(t, boardID, result.Board.ID)
+		require.NotZero(t, result.Board.LastActivityAt)
+	})
+
+	t.Run("PATCH sharing with the board", func(t *testing.T) {
+		enabled := false
+		result, resp := th.Client.PatchSharingWithBoard(boardID, &model.SharingPatch{Enabled: &enabled})
+		require.NoError(t, resp.Error)
+		require.False(t, result.Sharing.Enabled)
+		require.Equal(t, boardID, result.Board.ID)
+	})
+}
+
+func TestGetBoardsSharingStatus(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	newBlocks := []model.Block{
+		{ID: "shared", RootID: "shared", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "private", RootID: "private", CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+	}
+	blocks, resp := th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	sharedID := blocks[0].ID
+	privateID := blocks[1].ID
+
+	success, resp := th.Client.PostSharing(model.Sharing{ID: sharedID, Token: utils.NewID(utils.IDTypeToken), Enabled: true})
+	require.True(t, success)
+	require.NoError(t, resp.Error)
+
+	t.Run("requested boards", func(t *testing.T) {
+		statuses, resp := th.Client.GetBoardsSharingStatus([]string{sharedID, privateID, "not-a-board"})
+		require.NoError(t, resp.Error)
+		require.Equal(t, map[string]model.SharingStatus{
+			sharedID:  {Enabled: true, HasToken: true},
+			privateID: {Enabled: false, HasToken: false},
+		}, statuses)
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		statuses, resp := th.Client.GetBoardsSharingStatus(nil)
+		require.NoError(t, resp.Error)
+		require.Empty(t, statuses)
+	})
 }
@@ -2,6 +2,7 @@ package integrationtests
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/client"
@@ -20,9 +21,10 @@ func createTestSubscriptions(client *client.Client, num int, workspaceID string)
 		return nil, "", fmt.Errorf("cannot get current user: %w", resp.Error)
 	}
 
+	boardID := utils.NewID(utils.IDTypeBoard)
 	board := model.Block{
-		ID:       utils.NewID(utils.IDTypeBoard),
-		RootID:   workspaceID,
+		ID:       boardID,
+		RootID:   boardID,
 		CreateAt: 1,
 		UpdateAt: 1,
 		Type:     model.TypeBoard,
@@ -171,3 +173,70 @@ func TestDeleteSubscription(t *testing.T) {
 		require.Error(t, resp.Error)
 	})
 }
+
+func TestMuteCard(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	user, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	newBlocks := []model.Block{
+		{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		{ID: "card1", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+		{ID: "card2", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard},
+	}
+	newBlocks, resp = th.Client.InsertBlocks(newBlocks)
+	require.NoError(t, resp.Error)
+	boardID = newBlocks[0].ID
+	card1, card2 := newBlocks[1].ID, newBlocks[2].ID
+
+	sub := &model.Subscription{
+		BlockType:      model.TypeCard,
+		BlockID:        card1,
+		WorkspaceID:    "0",
+		SubscriberType: model.SubTypeUser,
+		SubscriberID:   user.ID,
+	}
+	_, resp = th.Client.CreateSubscription("0", sub)
+	require.NoError(t, resp.Error)
+
+	t.Run("mute cards", func(t *testing.T) {
+		mute, resp := th.Client.MuteCard(boardID, card1)
+		require.NoError(t, resp.Error)
+		require.Equal(t, card1, mute.CardID)
+		require.Equal(t, user.ID, mute.UserID)
+
+		_, resp = th.Client.MuteCard(boardID, card2)
+		require.NoError(t, resp.Error)
+
+		subs, resp := th.Client.GetSubscriptions("0", user.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, subs, 1)
+		require.Equal(t, card1, subs[0].BlockID)
+		require.True(t, subs[0].Muted)
+
+		subs, resp = th.Client.GetSubscriptionsIncludingMuted("0", user.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, subs, 2)
+		require.Equal(t, card2, subs[1].BlockID)
+		require.True(t, subs[1].Muted)
+	})
+
+	t.Run("unmute card", func(t *testing.T) {
+		resp := th.Client.UnmuteCard(boardID, card1)
+		require.NoError(t, resp.Error)
+
+		subs, resp := th.Client.GetSubscriptions("0", user.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, subs, 1)
+		require.False(t, subs[0].Muted)
+	})
+
+	t.Run("card not found", func(t *testing.T) {
+		_, resp := th.Client.MuteCard(boardID, boardID)
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
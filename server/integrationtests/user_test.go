@@ -3,9 +3,15 @@ package integrationtests
 import (
 	"bytes"
 	"crypto/rand"
+	"io/ioutil"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +41,33 @@ func TestUserRegister(t *testing.T) {
 	require.False(t, success)
 }
 
+func TestUserRegisterWithAllowedEmailDomains(t *testing.T) {
+	cfg, err := getTestConfig()
+	require.NoError(t, err)
+	cfg.AllowedEmailDomains = []string{"example.com"}
+
+	th := &TestHelper{}
+	th.Server = newTestServerWithConfig("", cfg)
+	th.Client = client.NewClient(th.Server.Config().ServerRoot, "")
+	th.InitBasic()
+	defer th.TearDown()
+
+	registerRequest := &api.RegisterRequest{
+		Username: fakeUsername,
+		Email:    fakeEmail,
+		Password: utils.NewID(utils.IDTypeNone),
+	}
+	success, resp := th.Client.Register(registerRequest)
+	require.Error(t, resp.Error)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.False(t, success)
+
+	registerRequest.Email = "mock@example.com"
+	success, resp = th.Client.Register(registerRequest)
+	require.NoError(t, resp.Error)
+	require.True(t, success)
+}
+
 func TestUserLogin(t *testing.T) {
 	th := SetupTestHelperWithoutToken().InitBasic()
 	defer th.TearDown()
@@ -74,9 +107,39 @@ func TestUserLogin(t *testing.T) {
 		require.NoError(t, resp.Error)
 		require.NotNil(t, data)
 		require.NotNil(t, data.Token)
+
+		cookies := (&http.Response{Header: resp.Header}).Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, auth.SessionCookieToken, cookies[0].Name)
+		require.Equal(t, data.Token, cookies[0].Value)
+		require.Equal(t, "/", cookies[0].Path)
 	})
 }
 
+func TestUserLoginFailures(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+
+	password := utils.NewID(utils.IDTypeNone)
+	registerRequest := &api.RegisterRequest{
+		Username: fakeUsername,
+		Email:    fakeEmail,
+		Password: password,
+	}
+	success, resp := th.Client.Register(registerRequest)
+	require.NoError(t, resp.Error)
+	require.True(t, success)
+
+	_, unknownUserResp := th.Client.Login(&api.LoginRequest{Type: "normal", Username: "nonexistuser", Password: password})
+	_, badPasswordResp := th.Client.Login(&api.LoginRequest{Type: "normal", Username: fakeUsername, Password: "badpassword"})
+
+	require.Error(t, unknownUserResp.Error)
+	require.Error(t, badPasswordResp.Error)
+	require.Equal(t, http.StatusUnauthorized, unknownUserResp.StatusCode)
+	require.Equal(t, unknownUserResp.StatusCode, badPasswordResp.StatusCode)
+	require.Equal(t, unknownUserResp.Error.Error(), badPasswordResp.Error.Error())
+}
+
 func TestGetMe(t *testing.T) {
 	th := SetupTestHelperWithoutToken().InitBasic()
 	defer th.TearDown()
@@ -119,6 +182,111 @@ func TestGetMe(t *testing.T) {
 	})
 }
 
+func TestGetMeSingleUser(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	t.Run("default identity", func(t *testing.T) {
+		me, resp := th.Client.GetMe()
+		require.NoError(t, resp.Error)
+		require.Equal(t, api.SingleUser, me.ID)
+		require.Equal(t, api.SingleUser, me.Username)
+	})
+
+	t.Run("configured identity", func(t *testing.T) {
+		config := th.Server.App().GetConfig()
+		config.SingleUserID = "owner-id"
+		config.SingleUserName = "owner"
+		defer func() {
+			config.SingleUserID = ""
+			config.SingleUserName = ""
+		}()
+
+		me, resp := th.Client.GetMe()
+		require.NoError(t, resp.Error)
+		require.Equal(t, "owner-id", me.ID)
+		require.Equal(t, "owner", me.Username)
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		blocks, resp := th.Client.InsertBlocks([]model.Block{
+			{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard},
+		})
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "owner-id", blocks[0].CreatedBy)
+		require.Equal(t, "owner-id", blocks[0].ModifiedBy)
+	})
+}
+
+func TestGetAssignedCards(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	me, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+
+	board := model.Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeBoard,
+		Title:    "Roadmap",
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{
+					"id":      "status",
+					"type":    "select",
+					"options": []interface{}{map[string]interface{}{"id": "todo", "value": "To Do"}},
+				},
+				map[string]interface{}{"id": "owner", "type": "person"},
+				map[string]interface{}{"id": "due", "type": "date"},
+			},
+		},
+	}
+	board.RootID = board.ID
+	newBlocks, resp := th.Client.InsertBlocks([]model.Block{board})
+	require.NoError(t, resp.Error)
+	boardID := newBlocks[0].ID
+
+	cards := []model.Block{
+		{ID: "assigned", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": me.ID, "due": `{"from":1000}`},
+		}},
+		{ID: "unassigned", RootID: boardID, ParentID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeCard, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		}},
+	}
+	cards, resp = th.Client.InsertBlocks(cards)
+	require.NoError(t, resp.Error)
+
+	t.Run("assigned cards", func(t *testing.T) {
+		assigned, resp := th.Client.GetAssignedCards(model.AssignedCardsOptions{})
+		require.NoError(t, resp.Error)
+		require.Len(t, assigned, 1)
+		require.Equal(t, cards[0].ID, assigned[0].Card.ID)
+		require.Equal(t, boardID, assigned[0].BoardID)
+		require.Equal(t, "Roadmap", assigned[0].BoardTitle)
+		require.Equal(t, "To Do", assigned[0].Status)
+		require.Equal(t, int64(1000), assigned[0].DueDate)
+	})
+
+	t.Run("filtered out", func(t *testing.T) {
+		assigned, resp := th.Client.GetAssignedCards(model.AssignedCardsOptions{Status: "Done"})
+		require.NoError(t, resp.Error)
+		require.Empty(t, assigned)
+
+		assigned, resp = th.Client.GetAssignedCards(model.AssignedCardsOptions{DueAfter: 2000})
+		require.NoError(t, resp.Error)
+		require.Empty(t, assigned)
+	})
+
+	t.Run("invalid due date", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet("/users/me/assigned?due_before=soon", "")
+		require.Error(t, err)
+		require.Equal(t, http.StatusBadRequest, r.StatusCode)
+	})
+}
+
 func TestGetUser(t *testing.T) {
 	th := SetupTestHelperWithoutToken().InitBasic()
 	defer th.TearDown()
@@ -234,6 +402,72 @@ func TestWorkspaceUploadFile(t *testing.T) {
 		require.NoError(t, resp.Error)
 		require.NotNil(t, result)
 		require.NotEmpty(t, result.FileID)
+		require.Equal(t, "file", result.Filename)
+		require.EqualValues(t, len(data), result.Size)
+		require.NotZero(t, result.UploadedAt)
 		// TODO get the uploaded file
 	})
 }
+
+func TestImpersonationSession(t *testing.T) {
+	th := SetupTestHelperWithoutToken().InitBasic()
+	defer th.TearDown()
+
+	require.NoError(t, th.InitUsers("user1", "user2"))
+	user1, resp := th.Client.GetMe()
+	require.NoError(t, resp.Error)
+	th.Server.Config().ImpersonationExpireTime = 60
+
+	t.Run("read-only", func(t *testing.T) {
+		session, err := th.Server.App().ImpersonateUser(user1.ID, "admin", "support ticket", true)
+		require.NoError(t, err)
+		impersonator := client.NewClient(th.Server.Config().ServerRoot, session.Token)
+
+		me, resp := impersonator.GetMe()
+		require.NoError(t, resp.Error)
+		require.Equal(t, user1.ID, me.ID)
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		board := model.Block{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard}
+		_, resp = impersonator.InsertBlocks([]model.Block{board})
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("read-write", func(t *testing.T) {
+		session, err := th.Server.App().ImpersonateUser(user1.ID, "admin", "support ticket", false)
+		require.NoError(t, err)
+		impersonator := client.NewClient(th.Server.Config().ServerRoot, session.Token)
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		board := model.Block{ID: boardID, RootID: boardID, CreateAt: 1, UpdateAt: 1, Type: model.TypeBoard}
+		_, resp := impersonator.InsertBlocks([]model.Block{board})
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		th.Server.Config().ImpersonationExpireTime = 1
+
+		session, err := th.Server.App().ImpersonateUser(user1.ID, "admin", "support ticket", true)
+		require.NoError(t, err)
+		impersonator := client.NewClient(th.Server.Config().ServerRoot, session.Token)
+		time.Sleep(time.Second)
+
+		_, resp := impersonator.GetMe()
+		require.Error(t, resp.Error)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestGetWorkspaceUsersEmptyResponse(t *testing.T) {
+	th := SetupTestHelper().InitBasic()
+	defer th.TearDown()
+
+	// the single user isn't stored, so the workspace has no users
+	r, err := th.Client.DoAPIGet("/workspaces/0/users", "")
+	require.NoError(t, err)
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(body))
+}
@@ -0,0 +1,40 @@
+package model
+
+// ActivityEntry is a change made to a block of a board, as recorded in the
+// block history
+// swagger:model
+type ActivityEntry struct {
+	// Timestamp is the time of the change, in milliseconds
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+
+	// UserID is the id of the user who made the change
+	// required: true
+	UserID string `json:"userId"`
+
+	// Username is the name of the user who made the change, empty if the
+	// user can't be found
+	// required: true
+	Username string `json:"username"`
+
+	// BlockID is the id of the changed block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// BlockTitle is the title of the block after the change
+	// required: true
+	BlockTitle string `json:"blockTitle"`
+
+	// Action is created, updated or deleted
+	// required: true
+	Action string `json:"action"`
+
+	// ChangedFields are the changed title, properties and fields of the block
+	// required: true
+	ChangedFields []string `json:"changedFields"`
+
+	// Changes describe the changes of the title and of the card properties
+	// with their previous and new values, such as "Status: Todo → Done"
+	// required: true
+	Changes []string `json:"changes"`
+}
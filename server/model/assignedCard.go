@@ -0,0 +1,42 @@
+package model
+
+// AssignedCard is a card assigned to a user, along with its board
+// swagger:model
+type AssignedCard struct {
+	// The card
+	// required: true
+	Card Block `json:"card"`
+
+	// ID of the workspace of the board
+	// required: true
+	WorkspaceID string `json:"workspaceId"`
+
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Title of the board
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// Value of the first select property of the board, empty if unset
+	// required: false
+	Status string `json:"status,omitempty"`
+
+	// End of the first date property of the board in milliseconds, 0 if
+	// unset
+	// required: false
+	DueDate int64 `json:"dueDate,omitempty"`
+}
+
+// AssignedCardsOptions are the filters of the cards assigned to a user.
+type AssignedCardsOptions struct {
+	// Status keeps the cards whose status is the option with this ID or
+	// value, ignoring case. All cards are kept if empty.
+	Status string
+
+	// DueAfter and DueBefore keep the cards due in the range, in
+	// milliseconds. A zero bound is open.
+	DueAfter  int64
+	DueBefore int64
+}
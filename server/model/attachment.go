@@ -0,0 +1,33 @@
+package model
+
+// AttachmentInfo is the metadata of a file attached to a card
+// swagger:model
+type AttachmentInfo struct {
+	// ID of the block referencing the file
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// ID of the stored file
+	// required: true
+	FileID string `json:"fileId"`
+
+	// Name of the file when it was uploaded, or the file ID if unknown
+	// required: true
+	Filename string `json:"filename"`
+
+	// Size of the file in bytes
+	// required: true
+	Size int64 `json:"size"`
+
+	// MIME type of the file, derived from its extension
+	// required: false
+	ContentType string `json:"contentType"`
+
+	// ID of the user who uploaded the file
+	// required: true
+	UploadedBy string `json:"uploadedBy"`
+
+	// Upload time
+	// required: true
+	UploadedAt int64 `json:"uploadedAt"`
+}
@@ -2,14 +2,112 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
 	"github.com/mattermost/focalboard/server/utils"
 )
 
+const (
+	// MentionedUserIDsField is the block field holding the IDs of the users
+	// @mentioned in a comment.
+	MentionedUserIDsField = "mentionedUserIds"
+
+	// PinnedField is the block field flagging a comment or card content
+	// block to be shown ahead of its siblings.
+	PinnedField = "pinned"
+
+	// AllowedBlockTypesField is the board field listing the types of the
+	// blocks that can be added to the board. All types are allowed if unset.
+	AllowedBlockTypesField = "allowedBlockTypes"
+
+	// AllowedViewTypesField is the board field listing the types of the
+	// views that can be added to the board. All types are allowed if unset
+	// or empty.
+	AllowedViewTypesField = "allowedViewTypes"
+
+	// ViewTypeField is the view field holding how the view renders its
+	// cards, one of ViewTypes.
+	ViewTypeField = "viewType"
+
+	// AllowedFileExtensionsField is the board field listing the extensions
+	// of the files that can be uploaded to the board. All extensions are
+	// allowed if unset or empty.
+	AllowedFileExtensionsField = "allowedFileExtensions"
+
+	// RestrictedToField is the card field listing the IDs of the only users
+	// that can see and change the card and its contents. The card inherits
+	// the access to its board if unset or empty.
+	RestrictedToField = "restrictedTo"
+)
+
+// ErrBlockNotPinnable is returned when trying to pin a block that isn't a
+// comment or card content.
+var ErrBlockNotPinnable = errors.New("only comments and card contents can be pinned")
+
+// ErrBlockNotRestrictable is returned when trying to restrict the access to
+// a block that isn't a card.
+var ErrBlockNotRestrictable = errors.New("only cards can be restricted")
+
+// ErrBlockRestricted is returned when accessing a block of a card the user
+// isn't allowed to see.
+var ErrBlockRestricted = errors.New("access to the block is restricted")
+
+// ErrUnknownViewType is returned when a board allows a view type that isn't
+// one of ViewTypes.
+var ErrUnknownViewType = errors.New("unknown view type")
+
+// ErrUnknownBlockType is returned when a board allows a block type that isn't
+// a known block type.
+var ErrUnknownBlockType = errors.New("unknown block type")
+
+// ViewTypes are the types of views the clients can render.
+var ViewTypes = []string{ViewTypeBoard, ViewTypeTable, ViewTypeGallery, ViewTypeCalendar}
+
+const (
+	ViewTypeBoard    = "board"
+	ViewTypeTable    = "table"
+	ViewTypeGallery  = "gallery"
+	ViewTypeCalendar = "calendar"
+)
+
+// ErrInvalidBlock is returned when a block is missing required data.
+type ErrInvalidBlock struct {
+	msg string
+}
+
+func (e ErrInvalidBlock) Error() string {
+	return e.msg
+}
+
+// ErrMissingBlockField is returned when a block lacks a field required by
+// its type.
+type ErrMissingBlockField struct {
+	BlockID string
+	Field   string
+}
+
+func (e ErrMissingBlockField) Error() string {
+	return fmt.Sprintf("block %s is missing the required field %s", e.BlockID, e.Field)
+}
+
+// IsErrMissingBlockField returns true if `err` is or wraps an ErrMissingBlockField.
+func IsErrMissingBlockField(err error) bool {
+	var embf ErrMissingBlockField
+	return errors.As(err, &embf)
+}
+
+// requiredBlockFields are the fields the blocks of each type must set.
+var requiredBlockFields = map[BlockType][]string{
+	TypeView:  {ViewTypeField},
+	TypeImage: {"fileId"},
+}
+
 // Block is the basic data unit
 // swagger:model
 type Block struct {
@@ -64,6 +162,23 @@ type Block struct {
 	// The workspace id that the block belongs to
 	// required: true
 	WorkspaceID string `json:"workspaceId"`
+
+	// The last time any block of the board changed. Only set on boards
+	// returned by the API, it isn't stored
+	// required: false
+	LastActivityAt int64 `json:"lastActivityAt,omitempty"`
+
+	// The cards referenced by the relation properties of a card, keyed by
+	// property ID. Only set when requested with expand=relations, it isn't
+	// stored
+	// required: false
+	Relations map[string][]RelatedCard `json:"relations,omitempty"`
+
+	// The values of the formula properties of a card, keyed by property ID,
+	// null if the formula can't be evaluated. Computed when the card is
+	// read, it isn't stored
+	// required: false
+	Computed map[string]interface{} `json:"computed,omitempty"`
 }
 
 // BlockPatch is a patch for modify blocks
@@ -115,6 +230,26 @@ type Archive struct {
 	Blocks  []Block `json:"blocks"`
 }
 
+// BlocksWithActors is a list of blocks along with the users that created or
+// last modified them
+// swagger:model
+type BlocksWithActors struct {
+	// The blocks
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// The users referenced by the createdBy and modifiedBy fields of the
+	// blocks. Users that can't be found are left out
+	// required: true
+	Actors []*UserSummary `json:"actors"`
+}
+
+func BlocksWithActorsFromJSON(data io.Reader) *BlocksWithActors {
+	var blocks *BlocksWithActors
+	_ = json.NewDecoder(data).Decode(&blocks)
+	return blocks
+}
+
 func BlocksFromJSON(data io.Reader) []Block {
 	var blocks []Block
 	_ = json.NewDecoder(data).Decode(&blocks)
@@ -136,6 +271,170 @@ func (b Block) LogClone() interface{} {
 	}
 }
 
+// IsValid returns an ErrInvalidBlock if the block can't be stored.
+func (b Block) IsValid() error {
+	if b.ID == "" {
+		return ErrInvalidBlock{"missing block id"}
+	}
+	if b.RootID == "" {
+		return ErrInvalidBlock{"missing root id"}
+	}
+	if b.Type == "" {
+		return ErrInvalidBlock{"missing block type"}
+	}
+	return nil
+}
+
+// CheckRequiredFields returns an ErrMissingBlockField if a field required by
+// the type of the block is unset or empty.
+func (b Block) CheckRequiredFields() error {
+	for _, field := range requiredBlockFields[b.Type] {
+		if value, ok := b.Fields[field]; !ok || value == nil || value == "" {
+			return ErrMissingBlockField{BlockID: b.ID, Field: field}
+		}
+	}
+	return nil
+}
+
+// AllowsBlockType returns true if blocks of the type can be added to the
+// board, that is if the type is in its allowed block types or if it has none.
+func (b Block) AllowsBlockType(blockType BlockType) bool {
+	allowed, ok := b.Fields[AllowedBlockTypesField].([]interface{})
+	if !ok || len(allowed) == 0 {
+		return true
+	}
+	for _, t := range allowed {
+		if t == string(blockType) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAllowedBlockTypes returns ErrUnknownBlockType if the allowed block
+// types of the board aren't all known block types.
+func (b Block) CheckAllowedBlockTypes() error {
+	allowed, ok := b.Fields[AllowedBlockTypesField]
+	if !ok || allowed == nil {
+		return nil
+	}
+	types, ok := allowed.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownBlockType, allowed)
+	}
+	for _, t := range types {
+		name, _ := t.(string)
+		if blockType, err := BlockTypeFromString(name); err != nil || string(blockType) != name {
+			return fmt.Errorf("%w: %v", ErrUnknownBlockType, t)
+		}
+	}
+	return nil
+}
+
+// ViewType returns how a view block renders its cards, board if unset.
+func (b Block) ViewType() string {
+	viewType, _ := b.Fields[ViewTypeField].(string)
+	if viewType == "" {
+		return ViewTypeBoard
+	}
+	return viewType
+}
+
+// AllowsViewType returns true if views of the type can be added to the
+// board, that is if the type is in its allowed view types or if it has none.
+func (b Block) AllowsViewType(viewType string) bool {
+	allowed, ok := b.Fields[AllowedViewTypesField].([]interface{})
+	if !ok || len(allowed) == 0 {
+		return true
+	}
+	for _, t := range allowed {
+		if t == viewType {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAllowedViewTypes returns ErrUnknownViewType if the allowed view types
+// of the board aren't all known view types.
+func (b Block) CheckAllowedViewTypes() error {
+	allowed, ok := b.Fields[AllowedViewTypesField]
+	if !ok || allowed == nil {
+		return nil
+	}
+	types, ok := allowed.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownViewType, allowed)
+	}
+	for _, t := range types {
+		known := false
+		for _, viewType := range ViewTypes {
+			if t == viewType {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: %v", ErrUnknownViewType, t)
+		}
+	}
+	return nil
+}
+
+// AllowsFileExtension returns true if files with the extension, with or
+// without its leading dot, can be uploaded to the board.
+func (b Block) AllowsFileExtension(ext string) bool {
+	allowed, ok := b.Fields[AllowedFileExtensionsField].([]interface{})
+	if !ok || len(allowed) == 0 {
+		return true
+	}
+	ext = strings.TrimPrefix(strings.ToLower(ext), ".")
+	for _, e := range allowed {
+		if s, ok := e.(string); ok && strings.TrimPrefix(strings.ToLower(s), ".") == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// IsVisibleTo returns true if the user can see the card, that is if the
+// user is in its restricted users or if it has none.
+func (b Block) IsVisibleTo(userID string) bool {
+	allowed, ok := b.Fields[RestrictedToField].([]interface{})
+	if !ok || len(allowed) == 0 {
+		return true
+	}
+	for _, id := range allowed {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPinnable returns true if the block is a comment or card content and can be pinned.
+func (b Block) IsPinnable() bool {
+	switch b.Type {
+	case TypeComment, TypeText, TypeImage:
+		return true
+	}
+	return false
+}
+
+// IsPinned returns true if the block is flagged as pinned.
+func (b Block) IsPinned() bool {
+	pinned, _ := b.Fields[PinnedField].(bool)
+	return pinned
+}
+
+// SortPinnedFirst moves the pinned blocks ahead of the others, keeping the
+// relative order within both groups.
+func SortPinnedFirst(blocks []Block) {
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].IsPinned() && !blocks[j].IsPinned()
+	})
+}
+
 // Patch returns an update version of the block.
 func (p *BlockPatch) Patch(block *Block) *Block {
 	if p.ParentID != nil {
@@ -158,6 +457,9 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 		block.Title = *p.Title
 	}
 
+	if block.Fields == nil && len(p.UpdatedFields) > 0 {
+		block.Fields = make(map[string]interface{})
+	}
 	for key, field := range p.UpdatedFields {
 		block.Fields[key] = field
 	}
@@ -171,9 +473,12 @@ func (p *BlockPatch) Patch(block *Block) *Block {
 
 // QuerySubtreeOptions are query options that can be passed to GetSubTree methods.
 type QuerySubtreeOptions struct {
-	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
-	AfterUpdateAt  int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
-	Limit          uint64 // if non-zero then limit the number of returned records
+	BeforeUpdateAt  int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
+	AfterUpdateAt   int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
+	Limit           uint64 // if non-zero then limit the number of returned records
+	OrderByCreateAt bool   // if true then the records are sorted by create_at
+	Descending      bool   // if true then the records are sorted by create_at in descending order
+	LimitPerLevel   uint64 // if non-zero then limit the number of children returned for each block, the first ones by create_at
 }
 
 // QueryBlockHistoryOptions are query options that can be passed to GetBlockHistory.
@@ -181,9 +486,54 @@ type QueryBlockHistoryOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
 	AfterUpdateAt  int64  // if non-zero then filter for records with update_at greater than AfterUpdateAt
 	Limit          uint64 // if non-zero then limit the number of returned records
+	Offset         uint64 // if non-zero then skip this number of records
 	Descending     bool   // if true then the records are sorted by insert_at in descending order
 }
 
+// QueryPageOptions are query options that can be passed to the methods
+// reading blocks a page at a time. The pages are sorted by create_at and id,
+// and the next page starts after the last block of the previous one.
+type QueryPageOptions struct {
+	Limit         uint64 // if non-zero then limit the number of returned records
+	AfterCreateAt int64  // if AfterID is set then return the records after this create_at
+	AfterID       string // if non-empty then return the records after this create_at and id
+}
+
+// ReplaceReferences replaces the references the block makes to oldID, as
+// its parent, root or in its content order, with newID. It returns true if
+// any reference was replaced.
+func (b *Block) ReplaceReferences(oldID, newID string) bool {
+	replaced := false
+	if b.ParentID == oldID {
+		b.ParentID = newID
+		replaced = true
+	}
+	if b.RootID == oldID {
+		b.RootID = newID
+		replaced = true
+	}
+
+	contentOrder, _ := b.Fields["contentOrder"].([]interface{})
+	for i := range contentOrder {
+		switch v := contentOrder[i].(type) {
+		case string:
+			if v == oldID {
+				contentOrder[i] = newID
+				replaced = true
+			}
+		case []interface{}:
+			for j := range v {
+				if id, ok := v[j].(string); ok && id == oldID {
+					v[j] = newID
+					replaced = true
+				}
+			}
+		}
+	}
+
+	return replaced
+}
+
 // GenerateBlockIDs generates new IDs for all the blocks of the list,
 // keeping consistent any references that other blocks would made to
 // the original IDs, so a tree of blocks can get new IDs and maintain
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
@@ -252,3 +253,218 @@ func TestGenerateBlockIDs(t *testing.T) {
 		require.Equal(t, blocks[2].ID, block4ContentOrder[1].([]interface{})[1])
 	})
 }
+
+func TestSortPinnedFirst(t *testing.T) {
+	blocks := []Block{
+		{ID: "a"},
+		{ID: "b", Fields: map[string]interface{}{PinnedField: true}},
+		{ID: "c", Fields: map[string]interface{}{PinnedField: false}},
+		{ID: "d", Fields: map[string]interface{}{PinnedField: true}},
+	}
+
+	SortPinnedFirst(blocks)
+
+	ids := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.ID)
+	}
+	require.Equal(t, []string{"b", "d", "a", "c"}, ids)
+}
+
+func TestBlockReplaceReferences(t *testing.T) {
+	block := Block{
+		ID:       "card",
+		ParentID: "board",
+		RootID:   "board",
+		Fields: map[string]interface{}{
+			"contentOrder": []interface{}{"text1", []interface{}{"text2", "image"}},
+		},
+	}
+
+	require.False(t, block.ReplaceReferences("other", "new"))
+
+	require.True(t, block.ReplaceReferences("board", "newBoard"))
+	require.Equal(t, "newBoard", block.ParentID)
+	require.Equal(t, "newBoard", block.RootID)
+
+	require.True(t, block.ReplaceReferences("image", "newImage"))
+	require.Equal(t, []interface{}{"text1", []interface{}{"text2", "newImage"}}, block.Fields["contentOrder"])
+	require.Equal(t, "card", block.ID)
+}
+
+func TestAllowsBlockType(t *testing.T) {
+	t.Run("no allowed block types", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{}}
+		require.True(t, board.AllowsBlockType(TypeImage))
+		require.NoError(t, board.CheckAllowedBlockTypes())
+	})
+
+	t.Run("empty allowed block types", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{AllowedBlockTypesField: []interface{}{}}}
+		require.True(t, board.AllowsBlockType(TypeImage))
+		require.NoError(t, board.CheckAllowedBlockTypes())
+	})
+
+	t.Run("allowed block types", func(t *testing.T) {
+		board := Block{
+			Type:   TypeBoard,
+			Fields: map[string]interface{}{AllowedBlockTypesField: []interface{}{"card", "text"}},
+		}
+		require.True(t, board.AllowsBlockType(TypeCard))
+		require.False(t, board.AllowsBlockType(TypeImage))
+		require.NoError(t, board.CheckAllowedBlockTypes())
+	})
+
+	t.Run("unknown block type", func(t *testing.T) {
+		board := Block{
+			Type:   TypeBoard,
+			Fields: map[string]interface{}{AllowedBlockTypesField: []interface{}{"card", "txt"}},
+		}
+		require.ErrorIs(t, board.CheckAllowedBlockTypes(), ErrUnknownBlockType)
+	})
+}
+
+func TestCheckRequiredFields(t *testing.T) {
+	t.Run("no required fields", func(t *testing.T) {
+		card := Block{ID: "card-id", Type: TypeCard}
+		require.NoError(t, card.CheckRequiredFields())
+	})
+
+	t.Run("required fields set", func(t *testing.T) {
+		image := Block{ID: "image-id", Type: TypeImage, Fields: map[string]interface{}{"fileId": "file.png"}}
+		require.NoError(t, image.CheckRequiredFields())
+	})
+
+	t.Run("required field missing", func(t *testing.T) {
+		view := Block{ID: "view-id", Type: TypeView, Fields: map[string]interface{}{}}
+		err := view.CheckRequiredFields()
+		require.Equal(t, ErrMissingBlockField{BlockID: "view-id", Field: ViewTypeField}, err)
+		require.True(t, IsErrMissingBlockField(err))
+	})
+
+	t.Run("required field empty", func(t *testing.T) {
+		image := Block{ID: "image-id", Type: TypeImage, Fields: map[string]interface{}{"fileId": ""}}
+		require.True(t, IsErrMissingBlockField(image.CheckRequiredFields()))
+	})
+}
+
+func TestAllowsViewType(t *testing.T) {
+	t.Run("no allowed view types", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{AllowedViewTypesField: []interface{}{}}}
+		require.True(t, board.AllowsViewType(ViewTypeCalendar))
+		require.NoError(t, board.CheckAllowedViewTypes())
+	})
+
+	t.Run("allowed view types", func(t *testing.T) {
+		board := Block{
+			Type:   TypeBoard,
+			Fields: map[string]interface{}{AllowedViewTypesField: []interface{}{"table"}},
+		}
+		require.True(t, board.AllowsViewType(ViewTypeTable))
+		require.False(t, board.AllowsViewType(ViewTypeGallery))
+		require.NoError(t, board.CheckAllowedViewTypes())
+	})
+
+	t.Run("unknown view type", func(t *testing.T) {
+		board := Block{
+			Type:   TypeBoard,
+			Fields: map[string]interface{}{AllowedViewTypesField: []interface{}{"table", "timeline"}},
+		}
+		require.ErrorIs(t, board.CheckAllowedViewTypes(), ErrUnknownViewType)
+	})
+
+	t.Run("view type defaults to board", func(t *testing.T) {
+		view := Block{Type: TypeView, Fields: map[string]interface{}{}}
+		require.Equal(t, ViewTypeBoard, view.ViewType())
+	})
+}
+
+func TestCheckDefaultSort(t *testing.T) {
+	cardProperties := []interface{}{map[string]interface{}{"id": "priority", "type": "select"}}
+
+	t.Run("no default sort", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{}}
+		require.NoError(t, board.CheckDefaultSort())
+	})
+
+	t.Run("property of the board", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{
+			"cardProperties": cardProperties,
+			DefaultSortField: map[string]interface{}{"propertyId": "priority", "reversed": true},
+		}}
+		require.NoError(t, board.CheckDefaultSort())
+
+		sortOption, err := board.DefaultSort()
+		require.NoError(t, err)
+		require.Equal(t, &SortOption{PropertyID: "priority", Reversed: true}, sortOption)
+	})
+
+	t.Run("title", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{
+			DefaultSortField: map[string]interface{}{"propertyId": TitlePropertyID},
+		}}
+		require.NoError(t, board.CheckDefaultSort())
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{
+			"cardProperties": cardProperties,
+			DefaultSortField: map[string]interface{}{"propertyId": "estimate"},
+		}}
+		require.ErrorIs(t, board.CheckDefaultSort(), ErrInvalidDefaultSort)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{DefaultSortField: "priority"}}
+		require.ErrorIs(t, board.CheckDefaultSort(), ErrInvalidDefaultSort)
+	})
+}
+
+func TestAllowsFileExtension(t *testing.T) {
+	t.Run("no allowed file extensions", func(t *testing.T) {
+		board := Block{Type: TypeBoard, Fields: map[string]interface{}{AllowedFileExtensionsField: []interface{}{}}}
+		require.True(t, board.AllowsFileExtension(".exe"))
+	})
+
+	t.Run("allowed file extensions", func(t *testing.T) {
+		board := Block{
+			Type:   TypeBoard,
+			Fields: map[string]interface{}{AllowedFileExtensionsField: []interface{}{".png", "JPG"}},
+		}
+		require.True(t, board.AllowsFileExtension(".png"))
+		require.True(t, board.AllowsFileExtension(".jpg"))
+		require.True(t, board.AllowsFileExtension("PNG"))
+		require.False(t, board.AllowsFileExtension(".exe"))
+		require.False(t, board.AllowsFileExtension(""))
+	})
+}
+
+func TestIsVisibleTo(t *testing.T) {
+	t.Run("not restricted", func(t *testing.T) {
+		card := Block{Type: TypeCard, Fields: map[string]interface{}{RestrictedToField: []interface{}{}}}
+		require.True(t, card.IsVisibleTo("user-id"))
+		require.True(t, card.IsVisibleTo(""))
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		card := Block{
+			Type:   TypeCard,
+			Fields: map[string]interface{}{RestrictedToField: []interface{}{"user-id"}},
+		}
+		require.True(t, card.IsVisibleTo("user-id"))
+		require.False(t, card.IsVisibleTo("other-user-id"))
+		require.False(t, card.IsVisibleTo(""))
+	})
+}
+
+func TestChangeDebounce(t *testing.T) {
+	board := func(value interface{}) Block {
+		return Block{Type: TypeBoard, Fields: map[string]interface{}{ChangeDebounceField: value}}
+	}
+
+	require.Equal(t, time.Duration(0), Block{Type: TypeBoard}.ChangeDebounce())
+	require.Equal(t, time.Duration(0), board(float64(-5)).ChangeDebounce())
+	require.Equal(t, time.Duration(0), board("500").ChangeDebounce())
+	require.Equal(t, 500*time.Millisecond, board(float64(500)).ChangeDebounce())
+	require.Equal(t, MaxChangeDebounce, board(float64(10*60*1000)).ChangeDebounce())
+}
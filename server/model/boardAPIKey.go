@@ -0,0 +1,42 @@
+package model
+
+// BoardAPIKey is a read-only key that gives access to a single board, for
+// embedding its data outside of Focalboard without a user's credentials.
+// swagger:model
+type BoardAPIKey struct {
+	// ID of the key
+	// required: true
+	ID string `json:"id"`
+
+	// ID of the board the key gives access to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// ID of the workspace of the board
+	// required: true
+	WorkspaceID string `json:"workspaceId"`
+
+	// Name describing what the key is used for
+	// required: true
+	Name string `json:"name"`
+
+	// Token to authenticate with, only returned when the key is created
+	// required: false
+	Token string `json:"token,omitempty"`
+
+	// ID of the user who created the key
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Creation time
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// BoardAPIKeyRequest is the request to create a board API key.
+// swagger:model
+type BoardAPIKeyRequest struct {
+	// Name describing what the key is used for
+	// required: true
+	Name string `json:"name"`
+}
@@ -0,0 +1,55 @@
+package model
+
+// BoardAcknowledgment is a user's acknowledgment of having reviewed a board
+// as of its last activity
+// swagger:model
+type BoardAcknowledgment struct {
+	// BoardID is the id of the acknowledged board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// WorkspaceID is the id of the workspace the board belongs to
+	// required: true
+	WorkspaceID string `json:"workspaceId"`
+
+	// UserID is the id of the user who acknowledged the board
+	// required: true
+	UserID string `json:"userId"`
+
+	// BoardLastActivityAt is the last activity of the board when it was
+	// acknowledged
+	// required: true
+	BoardLastActivityAt int64 `json:"boardLastActivityAt"`
+
+	// CreateAt is the timestamp the board was acknowledged
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// IsCurrent returns true if the acknowledgment covers the board as of
+// lastActivityAt.
+func (a BoardAcknowledgment) IsCurrent(lastActivityAt int64) bool {
+	return a.BoardLastActivityAt >= lastActivityAt
+}
+
+// BoardAcknowledgments are the acknowledgments of a board since its last
+// activity
+// swagger:model
+type BoardAcknowledgments struct {
+	// BoardID is the id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// LastActivityAt is the last activity of the board
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+
+	// Acknowledged are the acknowledgments since the last activity
+	// required: true
+	Acknowledged []*BoardAcknowledgment `json:"acknowledged"`
+
+	// PendingUserIDs are the ids of the workspace users who haven't
+	// acknowledged the board since its last activity
+	// required: true
+	PendingUserIDs []string `json:"pendingUserIds"`
+}
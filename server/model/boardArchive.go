@@ -0,0 +1,58 @@
+package model
+
+const (
+	// ArchivedAtField is the board field holding when the board was
+	// archived, in milliseconds. Archived boards are left out of the board
+	// lists unless requested, and are restored by removing the field.
+	ArchivedAtField = "archivedAt"
+
+	// ArchiveNoticeAtField is the board field holding when the board was
+	// given notice of its archival for inactivity, in milliseconds.
+	ArchiveNoticeAtField = "archiveNoticeAt"
+
+	// AutoArchiveDisabledField is the board field opting the board out of
+	// its archival for inactivity.
+	AutoArchiveDisabledField = "autoArchiveDisabled"
+
+	// SystemUserID is the user the changes made by the server itself are
+	// attributed to.
+	SystemUserID = "system"
+)
+
+// IsArchived returns true if the board is archived.
+func (b Block) IsArchived() bool {
+	return fieldMillis(b.Fields[ArchivedAtField]) > 0
+}
+
+// ArchiveNoticeAt returns when the board was given notice of its archival
+// for inactivity, or 0 if it wasn't.
+func (b Block) ArchiveNoticeAt() int64 {
+	return fieldMillis(b.Fields[ArchiveNoticeAtField])
+}
+
+// AutoArchiveDisabled returns true if the board is opted out of its
+// archival for inactivity.
+func (b Block) AutoArchiveDisabled() bool {
+	disabled, _ := b.Fields[AutoArchiveDisabledField].(bool)
+	return disabled
+}
+
+// IsTemplate returns true if the board is a template.
+func (b Block) IsTemplate() bool {
+	isTemplate, _ := b.Fields["isTemplate"].(bool)
+	return isTemplate
+}
+
+// fieldMillis returns the timestamp held by a block field, or 0 if it
+// doesn't hold one.
+func fieldMillis(value interface{}) int64 {
+	switch value := value.(type) {
+	case float64:
+		return int64(value)
+	case int64:
+		return value
+	case int:
+		return int64(value)
+	}
+	return 0
+}
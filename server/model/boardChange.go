@@ -0,0 +1,50 @@
+package model
+
+import "time"
+
+const (
+	// ChangeDebounceField is the board field holding for how many
+	// milliseconds the changes of its blocks are collected before being
+	// notified as a single board change. Changes are notified one by one
+	// right away if unset or 0.
+	ChangeDebounceField = "changeDebounceMs"
+
+	// MaxChangeDebounce is the longest the changes of a board are collected
+	// for, longer debounces are shortened to it.
+	MaxChangeDebounce = time.Minute
+)
+
+// BoardChange is the set of blocks of a board changed during its debounce
+// swagger:model
+type BoardChange struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// IDs of the changed blocks, in the order they first changed
+	// required: true
+	BlockIDs []string `json:"blockIds"`
+}
+
+// ChangeDebounce returns for how long the changes of the blocks of the board
+// are collected, or 0 if they are notified right away.
+func (b Block) ChangeDebounce() time.Duration {
+	var ms float64
+	switch value := b.Fields[ChangeDebounceField].(type) {
+	case float64:
+		ms = value
+	case int:
+		ms = float64(value)
+	case int64:
+		ms = float64(value)
+	}
+	if ms <= 0 {
+		return 0
+	}
+
+	debounce := time.Duration(ms * float64(time.Millisecond))
+	if debounce > MaxChangeDebounce {
+		return MaxChangeDebounce
+	}
+	return debounce
+}
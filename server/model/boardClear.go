@@ -0,0 +1,13 @@
+package model
+
+// ClearBoardResult is the report of the clearing of a board
+// swagger:model
+type ClearBoardResult struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Number of blocks deleted
+	// required: true
+	DeletedCount int `json:"deletedCount"`
+}
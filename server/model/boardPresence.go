@@ -0,0 +1,13 @@
+package model
+
+// BoardPresence is the list of users currently viewing a board
+// swagger:model
+type BoardPresence struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// IDs of the users with the board open
+	// required: true
+	UserIDs []string `json:"userIds"`
+}
@@ -0,0 +1,71 @@
+package model
+
+// BoardStats is a summary of the cards of a board
+// swagger:model
+type BoardStats struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Number of cards in the board
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// ID of the select property used to group the cards
+	// required: false
+	GroupPropertyID string `json:"groupPropertyId"`
+
+	// Number of cards for each option of the group property, keyed by
+	// option ID. Cards without a value are counted under the empty key
+	// required: true
+	CardsByGroup map[string]int `json:"cardsByGroup"`
+
+	// Number of cards without a value in any person property
+	// required: true
+	UnassignedCardCount int `json:"unassignedCardCount"`
+
+	// Number of cards with a date property in the past
+	// required: true
+	OverdueCardCount int `json:"overdueCardCount"`
+
+	// Last time any block of the board was modified
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+}
+
+// CardStatsOptions are the properties the statistics of the cards of a
+// board are computed from.
+type CardStatsOptions struct {
+	// GroupPropertyID is the select property the cards are grouped by, the
+	// cards aren't grouped if it is empty
+	GroupPropertyID string
+
+	// PersonPropertyIDs are the properties a card is assigned through
+	PersonPropertyIDs []string
+
+	// DatePropertyIDs are the properties a card is overdue through, when
+	// their date is before Now
+	DatePropertyIDs []string
+	Now             int64
+}
+
+// PropertyOptionUsage is the number of cards of a board set to an option of
+// a select property
+// swagger:model
+type PropertyOptionUsage struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// ID of the select or multi-select property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// ID of the option
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// Number of cards set to the option
+	// required: true
+	CardCount int `json:"cardCount"`
+}
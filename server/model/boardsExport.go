@@ -0,0 +1,33 @@
+package model
+
+// BoardsExportRequest is the selection of boards to export
+// swagger:model
+type BoardsExportRequest struct {
+	// IDs of the boards to export
+	// required: true
+	BoardIDs []string `json:"boardIds"`
+}
+
+// BoardsExport is the export of a selection of boards
+// swagger:model
+type BoardsExport struct {
+	// The blocks of the exported boards, board by board
+	// required: true
+	Blocks []Block `json:"blocks"`
+
+	// Boards left out of the export
+	// required: true
+	Skipped []SkippedBoard `json:"skipped"`
+}
+
+// SkippedBoard is a board left out of an export
+// swagger:model
+type SkippedBoard struct {
+	// ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Why the board was skipped
+	// required: true
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import "errors"
+
+const (
+	// ParentCommentIDField is the comment field holding the ID of the comment
+	// that starts the thread the comment replies to.
+	ParentCommentIDField = "parentCommentId"
+
+	// ResolvedField is the comment field flagging the thread the comment
+	// starts as resolved.
+	ResolvedField = "resolved"
+)
+
+// ErrEmptyComment is returned when posting a reply without text.
+var ErrEmptyComment = errors.New("comment text is required")
+
+// CommentThread is a comment of a card and the replies to it
+// swagger:model
+type CommentThread struct {
+	// The comment starting the thread
+	// required: true
+	Comment Block `json:"comment"`
+
+	// The replies to the comment, oldest first
+	// required: true
+	Replies []Block `json:"replies"`
+}
+
+// CommentReply is the content of a reply to a comment
+// swagger:model
+type CommentReply struct {
+	// Text of the reply
+	// required: true
+	Text string `json:"text"`
+}
+
+// ParentCommentID returns the ID of the comment the block replies to, or an
+// empty string if it starts a thread.
+func (b Block) ParentCommentID() string {
+	parentCommentID, _ := b.Fields[ParentCommentIDField].(string)
+	return parentCommentID
+}
+
+// IsResolved returns true if the block is flagged as resolved.
+func (b Block) IsResolved() bool {
+	resolved, _ := b.Fields[ResolvedField].(bool)
+	return resolved
+}
@@ -0,0 +1,19 @@
+package model
+
+// FileInfo is the metadata of a file saved to the files storage.
+type FileInfo struct {
+	// ID of the stored file
+	ID string
+
+	// Name of the file when it was uploaded
+	Filename string
+
+	// Size of the file in bytes
+	Size int64
+
+	// MIME type of the file, derived from its extension
+	ContentType string
+
+	// Time the file was saved
+	CreateAt int64
+}
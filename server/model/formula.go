@@ -0,0 +1,306 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PropTypeFormula is the type of the card properties whose values are
+// computed from the other properties of the card when it is read.
+const PropTypeFormula = "formula"
+
+// ErrInvalidFormula is returned when the formula of a property can't be
+// parsed, or references properties that don't exist or itself.
+var ErrInvalidFormula = errors.New("invalid formula")
+
+// Formula expression operators.
+const (
+	FormulaNumber = "number"
+	FormulaString = "string"
+	FormulaCall   = "call"
+	FormulaNeg    = "neg"
+)
+
+// formulaFuncs are the functions formulas can call, with their minimum and
+// maximum number of arguments.
+var formulaFuncs = map[string][2]int{
+	"prop":          {1, 1},
+	"now":           {0, 0},
+	"dateDiff":      {2, 2},
+	"countChildren": {0, 1},
+}
+
+// FormulaExpr is a node of a parsed formula. Op is FormulaNumber or
+// FormulaString for literals, FormulaCall for function calls, FormulaNeg
+// for negations, or one of + - * / for arithmetic on Args.
+type FormulaExpr struct {
+	Op     string
+	Number float64
+	String string
+	Func   string
+	Args   []*FormulaExpr
+}
+
+// PropertyRefs returns the property IDs or names referenced by the prop
+// calls of the formula.
+func (e *FormulaExpr) PropertyRefs() []string {
+	refs := []string{}
+	if e.Op == FormulaCall && e.Func == "prop" {
+		return append(refs, e.Args[0].String)
+	}
+	for _, arg := range e.Args {
+		refs = append(refs, arg.PropertyRefs()...)
+	}
+	return refs
+}
+
+// ParseFormula parses a formula made of numbers, the + - * / operators,
+// parentheses and calls to prop("property"), now(), dateDiff(to, from) and
+// countChildren(["type"]).
+func ParseFormula(s string) (*FormulaExpr, error) {
+	p := &formulaParser{input: s}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return expr, nil
+}
+
+type formulaParser struct {
+	input string
+	pos   int
+}
+
+func (p *formulaParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFormula, fmt.Sprintf(format, args...))
+}
+
+func (p *formulaParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept consumes c if it is the next non space character.
+func (p *formulaParser) accept(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *formulaParser) parseSum() (*FormulaExpr, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch {
+		case p.accept('+'):
+			op = "+"
+		case p.accept('-'):
+			op = "-"
+		default:
+			return left, nil
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &FormulaExpr{Op: op, Args: []*FormulaExpr{left, right}}
+	}
+}
+
+func (p *formulaParser) parseProduct() (*FormulaExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch {
+		case p.accept('*'):
+			op = "*"
+		case p.accept('/'):
+			op = "/"
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FormulaExpr{Op: op, Args: []*FormulaExpr{left, right}}
+	}
+}
+
+func (p *formulaParser) parseUnary() (*FormulaExpr, error) {
+	if p.accept('-') {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &FormulaExpr{Op: FormulaNeg, Args: []*FormulaExpr{expr}}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *formulaParser) parseOperand() (*FormulaExpr, error) {
+	if p.accept('(') {
+		expr, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(')') {
+			return nil, p.errorf("missing )")
+		}
+		return expr, nil
+	}
+
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end")
+	}
+
+	start := p.pos
+	switch c := p.input[p.pos]; {
+	case c == '"':
+		end := strings.IndexByte(p.input[p.pos+1:], '"')
+		if end < 0 {
+			return nil, p.errorf("missing \"")
+		}
+		p.pos += end + 2
+		return &FormulaExpr{Op: FormulaString, String: p.input[start+1 : p.pos-1]}, nil
+
+	case c == '.' || (c >= '0' && c <= '9'):
+		for p.pos < len(p.input) && (p.input[p.pos] == '.' || (p.input[p.pos] >= '0' && p.input[p.pos] <= '9')) {
+			p.pos++
+		}
+		number, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.input[start:p.pos])
+		}
+		return &FormulaExpr{Op: FormulaNumber, Number: number}, nil
+
+	case unicode.IsLetter(rune(c)):
+		for p.pos < len(p.input) && unicode.IsLetter(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		return p.parseCall(p.input[start:p.pos])
+	}
+	return nil, p.errorf("unexpected %q", p.input[p.pos:])
+}
+
+func (p *formulaParser) parseCall(name string) (*FormulaExpr, error) {
+	arity, ok := formulaFuncs[name]
+	if !ok {
+		return nil, p.errorf("unknown function %s", name)
+	}
+	if !p.accept('(') {
+		return nil, p.errorf("missing ( after %s", name)
+	}
+
+	expr := &FormulaExpr{Op: FormulaCall, Func: name, Args: []*FormulaExpr{}}
+	if !p.accept(')') {
+		for {
+			arg, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+			expr.Args = append(expr.Args, arg)
+			if p.accept(')') {
+				break
+			}
+			if !p.accept(',') {
+				return nil, p.errorf("missing ) after the arguments of %s", name)
+			}
+		}
+	}
+
+	if len(expr.Args) < arity[0] || len(expr.Args) > arity[1] {
+		return nil, p.errorf("wrong number of arguments for %s", name)
+	}
+	if (name == "prop" || name == "countChildren") && len(expr.Args) == 1 && expr.Args[0].Op != FormulaString {
+		return nil, p.errorf("%s takes a string", name)
+	}
+	return expr, nil
+}
+
+// Property returns the property of the schema with the given ID, or else
+// with the given name.
+func (s PropSchema) Property(ref string) (PropDef, bool) {
+	if prop, ok := s[ref]; ok {
+		return prop, true
+	}
+	for _, prop := range s {
+		if prop.Name == ref {
+			return prop, true
+		}
+	}
+	return PropDef{}, false
+}
+
+// CheckFormulas returns ErrInvalidFormula if a formula property of the board
+// can't be parsed, references a property the board doesn't have, or
+// references itself, directly or through other formulas.
+func (b Block) CheckFormulas() error {
+	schema, err := ParsePropertySchema(&b)
+	if err != nil {
+		return nil
+	}
+
+	refs := map[string][]string{}
+	for id, prop := range schema {
+		if prop.Type != PropTypeFormula {
+			continue
+		}
+		expr, err := ParseFormula(prop.Formula)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", prop.Name, err)
+		}
+		for _, ref := range expr.PropertyRefs() {
+			refProp, ok := schema.Property(ref)
+			if !ok {
+				return fmt.Errorf("property %q: %w: unknown property %q", prop.Name, ErrInvalidFormula, ref)
+			}
+			refs[id] = append(refs[id], refProp.ID)
+		}
+	}
+
+	// depth first search of the references, a property met again while its
+	// references are visited is part of a cycle
+	const visiting, visited = 1, 2
+	state := map[string]int{}
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("property %q: %w: circular reference", schema[id].Name, ErrInvalidFormula)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, ref := range refs[id] {
+			if err := visit(ref); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+	for id := range refs {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormula(t *testing.T) {
+	t.Run("valid formulas", func(t *testing.T) {
+		for _, formula := range []string{
+			`1 + 2 * 3`,
+			`-(prop("Estimate") - 1) / 2`,
+			`dateDiff(prop("Due"), now())`,
+			`countChildren() + countChildren("checkbox")`,
+		} {
+			_, err := ParseFormula(formula)
+			require.NoError(t, err, formula)
+		}
+	})
+
+	t.Run("invalid formulas", func(t *testing.T) {
+		for _, formula := range []string{
+			``,
+			`1 +`,
+			`(1 + 2`,
+			`1 2`,
+			`prop(Estimate)`,
+			`prop("Estimate"`,
+			`unknown()`,
+			`now(1)`,
+			`dateDiff(now())`,
+		} {
+			_, err := ParseFormula(formula)
+			require.ErrorIs(t, err, ErrInvalidFormula, formula)
+		}
+	})
+
+	t.Run("property references", func(t *testing.T) {
+		expr, err := ParseFormula(`dateDiff(prop("Due"), now()) * prop("rate")`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Due", "rate"}, expr.PropertyRefs())
+	})
+}
+
+func TestCheckFormulas(t *testing.T) {
+	board := func(props ...map[string]interface{}) Block {
+		cardProperties := make([]interface{}, 0, len(props))
+		for _, prop := range props {
+			cardProperties = append(cardProperties, prop)
+		}
+		return Block{Type: TypeBoard, Fields: map[string]interface{}{"cardProperties": cardProperties}}
+	}
+
+	t.Run("valid references", func(t *testing.T) {
+		b := board(
+			map[string]interface{}{"id": "estimate", "name": "Estimate", "type": "number"},
+			map[string]interface{}{"id": "double", "name": "Double", "type": PropTypeFormula, "formula": `prop("Estimate") * 2`},
+			map[string]interface{}{"id": "quad", "name": "Quad", "type": PropTypeFormula, "formula": `prop("double") * 2`},
+		)
+		require.NoError(t, b.CheckFormulas())
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		b := board(map[string]interface{}{"id": "f", "name": "F", "type": PropTypeFormula, "formula": `prop("missing")`})
+		require.ErrorIs(t, b.CheckFormulas(), ErrInvalidFormula)
+	})
+
+	t.Run("circular references", func(t *testing.T) {
+		b := board(
+			map[string]interface{}{"id": "a", "name": "A", "type": PropTypeFormula, "formula": `prop("B") + 1`},
+			map[string]interface{}{"id": "b", "name": "B", "type": PropTypeFormula, "formula": `prop("a") + 1`},
+		)
+		require.ErrorIs(t, b.CheckFormulas(), ErrInvalidFormula)
+
+		b = board(map[string]interface{}{"id": "a", "name": "A", "type": PropTypeFormula, "formula": `prop("A")`})
+		require.ErrorIs(t, b.CheckFormulas(), ErrInvalidFormula)
+	})
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SkippedBlock is a block left out of an import
+// swagger:model
+type SkippedBlock struct {
+	// Position of the block in the imported array
+	// required: true
+	Index int `json:"index"`
+
+	// ID of the block, if it could be read
+	// required: false
+	BlockID string `json:"blockId"`
+
+	// Why the block was skipped
+	// required: true
+	Reason string `json:"reason"`
+}
+
+// ImportResult is the report of an import that skips invalid blocks
+// swagger:model
+type ImportResult struct {
+	// Number of blocks imported
+	// required: true
+	ImportedCount int `json:"importedCount"`
+
+	// Blocks left out of the import
+	// required: true
+	Skipped []SkippedBlock `json:"skipped"`
+
+	// Types of the imported blocks that are unknown, after the legacy
+	// types were remapped
+	// required: false
+	UnknownTypes []string `json:"unknownTypes,omitempty"`
+}
+
+// RemapBlockTypes renames the legacy types of the blocks with mappings,
+// keyed by the last schema version the legacy types were used in. The
+// mappings of all the versions from the schema version of a block on are
+// applied in order, so a type renamed several times gets its current name.
+// It returns the types of the blocks that are still unknown, sorted.
+func RemapBlockTypes(blocks []Block, mappings map[int64]map[string]string) []string {
+	versions := make([]int64, 0, len(mappings))
+	for version := range mappings {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	unknown := map[string]bool{}
+	for i := range blocks {
+		for _, version := range versions {
+			if version < blocks[i].Schema {
+				continue
+			}
+			if current, ok := mappings[version][strings.ToLower(string(blocks[i].Type))]; ok {
+				blocks[i].Type = BlockType(current)
+			}
+		}
+		if _, err := BlockTypeFromString(string(blocks[i].Type)); err != nil {
+			unknown[string(blocks[i].Type)] = true
+		}
+	}
+
+	types := make([]string, 0, len(unknown))
+	for blockType := range unknown {
+		types = append(types, blockType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ValidBlocksFromJSON reads a JSON array of blocks, leaving out the ones that
+// can't be decoded or aren't valid, and the ones whose parent or root was
+// left out. It only fails if data isn't a JSON array.
+func ValidBlocksFromJSON(data []byte) ([]Block, []SkippedBlock, error) {
+	var rawBlocks []json.RawMessage
+	if err := json.Unmarshal(data, &rawBlocks); err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]Block, 0, len(rawBlocks))
+	indexes := make([]int, 0, len(rawBlocks))
+	skipped := []SkippedBlock{}
+	skippedIDs := map[string]bool{}
+
+	for i, rawBlock := range rawBlocks {
+		var block Block
+		if err := json.Unmarshal(rawBlock, &block); err != nil {
+			// the ID may still be readable, so descendants can be skipped too
+			var ref struct {
+				ID string `json:"id"`
+			}
+			_ = json.Unmarshal(rawBlock, &ref)
+			skipped = append(skipped, SkippedBlock{Index: i, BlockID: ref.ID, Reason: fmt.Sprintf("invalid block: %s", err)})
+			if ref.ID != "" {
+				skippedIDs[ref.ID] = true
+			}
+			continue
+		}
+		err := block.IsValid()
+		if err == nil {
+			err = block.CheckRequiredFields()
+		}
+		if err != nil {
+			skipped = append(skipped, SkippedBlock{Index: i, BlockID: block.ID, Reason: err.Error()})
+			if block.ID != "" {
+				skippedIDs[block.ID] = true
+			}
+			continue
+		}
+		candidates = append(candidates, block)
+		indexes = append(indexes, i)
+	}
+
+	// skipping a block orphans its descendants, which can be anywhere in
+	// the array, so repeat until no more blocks are skipped
+	for skipping := len(skippedIDs) > 0; skipping; {
+		skipping = false
+		valid := candidates[:0]
+		validIndexes := indexes[:0]
+		for i, block := range candidates {
+			reason := ""
+			switch {
+			case skippedIDs[block.RootID]:
+				reason = "root block " + block.RootID + " was skipped"
+			case skippedIDs[block.ParentID]:
+				reason = "parent block " + block.ParentID + " was skipped"
+			}
+			if reason == "" {
+				valid = append(valid, block)
+				validIndexes = append(validIndexes, indexes[i])
+				continue
+			}
+			skipped = append(skipped, SkippedBlock{Index: indexes[i], BlockID: block.ID, Reason: reason})
+			skippedIDs[block.ID] = true
+			skipping = true
+		}
+		candidates = valid
+		indexes = validIndexes
+	}
+
+	sort.Slice(skipped, func(i, j int) bool {
+		return skipped[i].Index < skipped[j].Index
+	})
+	return candidates, skipped, nil
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidBlocksFromJSON(t *testing.T) {
+	t.Run("not an array", func(t *testing.T) {
+		_, _, err := ValidBlocksFromJSON([]byte(`{"id": "board"}`))
+		require.Error(t, err)
+	})
+
+	t.Run("skips invalid blocks and their descendants", func(t *testing.T) {
+		data := []byte(`[
+			{"id": "text", "parentId": "card2", "rootId": "board", "type": "text"},
+			{"id": "board", "rootId": "board", "type": "board"},
+			{"id": "card1", "parentId": "board", "rootId": "board", "type": "card"},
+			{"id": "card2", "parentId": "board", "rootId": "board"},
+			{"id": 3, "parentId": "board", "rootId": "board", "type": "card"},
+			{"id": "board2", "rootId": "board2", "type": "board", "title": 42},
+			{"id": "card4", "parentId": "board2", "rootId": "board2", "type": "card"}
+		]`)
+
+		blocks, skipped, err := ValidBlocksFromJSON(data)
+		require.NoError(t, err)
+
+		require.Len(t, blocks, 2)
+		require.Equal(t, "board", blocks[0].ID)
+		require.Equal(t, "card1", blocks[1].ID)
+
+		require.Len(t, skipped, 5)
+		require.Equal(t, SkippedBlock{Index: 0, BlockID: "text", Reason: "parent block card2 was skipped"}, skipped[0])
+		require.Equal(t, SkippedBlock{Index: 3, BlockID: "card2", Reason: "missing block type"}, skipped[1])
+		require.Equal(t, 4, skipped[2].Index)
+		require.Empty(t, skipped[2].BlockID)
+		require.Equal(t, 5, skipped[3].Index)
+		require.Equal(t, "board2", skipped[3].BlockID)
+		require.Equal(t, SkippedBlock{Index: 6, BlockID: "card4", Reason: "root block board2 was skipped"}, skipped[4])
+	})
+}
+
+func TestRemapBlockTypes(t *testing.T) {
+	mappings := map[int64]map[string]string{
+		1: {"rule": "separator", "todo": "checkbox"},
+		2: {"separator": "divider"},
+	}
+
+	blocks := []Block{
+		{ID: "v1-rule", Schema: 1, Type: "rule"},
+		{ID: "v1-todo", Schema: 1, Type: "TODO"},
+		{ID: "v2-separator", Schema: 2, Type: "separator"},
+		{ID: "v3-separator", Schema: 3, Type: "separator"},
+		{ID: "v2-rule", Schema: 2, Type: "rule"},
+		{ID: "card", Schema: 1, Type: TypeCard},
+	}
+
+	unknown := RemapBlockTypes(blocks, mappings)
+	require.Equal(t, BlockType(TypeDivider), blocks[0].Type)
+	require.Equal(t, BlockType(TypeCheckbox), blocks[1].Type)
+	require.Equal(t, BlockType(TypeDivider), blocks[2].Type)
+	require.Equal(t, BlockType("separator"), blocks[3].Type)
+	require.Equal(t, BlockType("rule"), blocks[4].Type)
+	require.Equal(t, BlockType(TypeCard), blocks[5].Type)
+	require.Equal(t, []string{"rule", "separator"}, unknown)
+
+	require.Empty(t, RemapBlockTypes([]Block{{Type: TypeCard}}, nil))
+}
@@ -0,0 +1,376 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	markdownSectionProperty = "Section"
+	markdownOptionColor     = "propColorDefault"
+	frontMatterDelimiter    = "---"
+
+	// typeCheckbox is the type of the checkbox content blocks of the webapp.
+	typeCheckbox BlockType = "checkbox"
+)
+
+var (
+	markdownHeadingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownListItemRe = regexp.MustCompile(`^(\s*)(?:[-*+]|\d+[.)])\s+(.*)$`)
+	markdownCheckboxRe = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+)
+
+// SkippedSection is a part of an imported Markdown document that couldn't be
+// converted to blocks
+// swagger:model
+type SkippedSection struct {
+	// Line number of the section in the document, starting at 1
+	// required: true
+	Line int `json:"line"`
+
+	// Text of the section
+	// required: true
+	Text string `json:"text"`
+
+	// Why the section was skipped
+	// required: true
+	Reason string `json:"reason"`
+}
+
+// MarkdownImportResult is the report of a Markdown import
+// swagger:model
+type MarkdownImportResult struct {
+	// ID of the created board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Number of cards created
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// Sections of the document left out of the board
+	// required: true
+	Skipped []SkippedSection `json:"skipped"`
+}
+
+// markdownProperty is a select property built while parsing a document.
+type markdownProperty struct {
+	id      string
+	name    string
+	options []map[string]interface{}
+}
+
+func (p *markdownProperty) optionID(value string) string {
+	for _, option := range p.options {
+		if strings.EqualFold(option["value"].(string), value) {
+			return option["id"].(string)
+		}
+	}
+	return ""
+}
+
+func (p *markdownProperty) addOption(value string) string {
+	if id := p.optionID(value); id != "" {
+		return id
+	}
+	id := utils.NewID(utils.IDTypeBlock)
+	p.options = append(p.options, map[string]interface{}{"id": id, "value": value, "color": markdownOptionColor})
+	return id
+}
+
+type markdownImport struct {
+	now         int64
+	title       string
+	description []string
+	properties  []*markdownProperty
+	board       Block
+	cards       []Block
+	contents    []Block
+	skipped     []SkippedSection
+
+	card    *Block
+	indents []int
+	section string
+
+	// lastText is the index in contents of the text block the next
+	// paragraph line continues, or -1
+	lastText int
+}
+
+// BlocksFromMarkdown converts a Markdown outline to a board with a card for
+// each top-level list item. Nested items become checkbox or text blocks of
+// their card, or set its properties if they read "Property: value". Headings
+// group the cards with a Section property. The keys of the front matter, if
+// any, are select properties with comma separated options, except title and
+// description which set the board's. The parts of the document that can't be
+// placed on a card are returned as skipped.
+func BlocksFromMarkdown(markdown string) ([]Block, []SkippedSection) {
+	imp := &markdownImport{now: GetMillis(), lastText: -1}
+	imp.board = Block{
+		ID:       utils.NewID(utils.IDTypeBoard),
+		Type:     TypeBoard,
+		CreateAt: imp.now,
+		UpdateAt: imp.now,
+	}
+	imp.board.RootID = imp.board.ID
+
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	start := imp.parseFrontMatter(lines)
+	for i := start; i < len(lines); i++ {
+		imp.parseLine(i+1, lines[i])
+	}
+
+	return imp.blocks(), imp.skipped
+}
+
+// parseFrontMatter reads the front matter at the start of the document, if
+// any, and returns the index of the first line after it.
+func (imp *markdownImport) parseFrontMatter(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return 0
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == frontMatterDelimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		imp.skip(1, lines[0], "front matter isn't closed")
+		return 1
+	}
+
+	for i := 1; i < end; i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			imp.skip(i+1, lines[i], "front matter line isn't a key: value pair")
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+
+		switch strings.ToLower(key) {
+		case "title":
+			imp.title = value
+		case "description":
+			imp.description = append(imp.description, value)
+		default:
+			prop := imp.property(key)
+			for _, option := range strings.Split(value, ",") {
+				if option = strings.TrimSpace(option); option != "" {
+					prop.addOption(option)
+				}
+			}
+		}
+	}
+	return end + 1
+}
+
+func (imp *markdownImport) parseLine(lineNumber int, line string) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		imp.lastText = -1
+		return
+	}
+
+	if m := markdownHeadingRe.FindStringSubmatch(trimmed); m != nil {
+		imp.lastText = -1
+		imp.card = nil
+		if len(m[1]) == 1 && imp.title == "" && len(imp.cards) == 0 {
+			imp.title = m[2]
+			return
+		}
+		imp.section = m[2]
+		return
+	}
+
+	if m := markdownListItemRe.FindStringSubmatch(line); m != nil {
+		imp.lastText = -1
+		if markdownIndent(m[1]) < 2 {
+			imp.addCard(m[2])
+			return
+		}
+		if imp.card == nil {
+			imp.skip(lineNumber, line, "nested item isn't under a card")
+			return
+		}
+		imp.addCardItem(lineNumber, line, m[2], markdownIndent(m[1]))
+		return
+	}
+
+	switch {
+	case imp.lastText >= 0:
+		imp.contents[imp.lastText].Title += "\n" + trimmed
+	case imp.card != nil:
+		imp.addContent(TypeText, trimmed, map[string]interface{}{})
+		imp.lastText = len(imp.contents) - 1
+	case len(imp.cards) == 0 && imp.section == "":
+		imp.description = append(imp.description, trimmed)
+	default:
+		imp.skip(lineNumber, line, "text isn't under a card")
+	}
+}
+
+func (imp *markdownImport) addCard(title string) {
+	if m := markdownCheckboxRe.FindStringSubmatch(title); m != nil {
+		title = m[2]
+	}
+
+	properties := map[string]interface{}{}
+	if imp.section != "" {
+		prop := imp.property(markdownSectionProperty)
+		properties[prop.id] = prop.addOption(imp.section)
+	}
+
+	imp.cards = append(imp.cards, Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		ParentID: imp.board.ID,
+		RootID:   imp.board.ID,
+		Type:     TypeCard,
+		Title:    title,
+		Fields: map[string]interface{}{
+			"icon":         "",
+			"isTemplate":   false,
+			"properties":   properties,
+			"contentOrder": []interface{}{},
+		},
+		CreateAt: imp.now,
+		UpdateAt: imp.now,
+	})
+	imp.card = &imp.cards[len(imp.cards)-1]
+	imp.indents = nil
+}
+
+func (imp *markdownImport) addCardItem(lineNumber int, line, text string, indent int) {
+	if m := markdownCheckboxRe.FindStringSubmatch(text); m != nil {
+		imp.addContent(typeCheckbox, m[2], map[string]interface{}{"value": m[1] != " "})
+		return
+	}
+
+	if parts := strings.SplitN(text, ":", 2); len(parts) == 2 {
+		if prop := imp.findProperty(strings.TrimSpace(parts[0])); prop != nil {
+			value := strings.TrimSpace(parts[1])
+			optionID := prop.optionID(value)
+			if optionID == "" {
+				imp.skip(lineNumber, line, "value isn't an option of the "+prop.name+" property")
+				return
+			}
+			imp.card.Fields["properties"].(map[string]interface{})[prop.id] = optionID
+			return
+		}
+	}
+
+	// deeper items keep their nesting as Markdown
+	for len(imp.indents) > 0 && imp.indents[len(imp.indents)-1] > indent {
+		imp.indents = imp.indents[:len(imp.indents)-1]
+	}
+	if len(imp.indents) == 0 || imp.indents[len(imp.indents)-1] < indent {
+		imp.indents = append(imp.indents, indent)
+	}
+	if depth := len(imp.indents) - 1; depth > 0 {
+		text = strings.Repeat("  ", depth-1) + "- " + text
+	}
+	imp.addContent(TypeText, text, map[string]interface{}{})
+}
+
+func (imp *markdownImport) addContent(blockType BlockType, title string, fields map[string]interface{}) {
+	block := Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		ParentID: imp.card.ID,
+		RootID:   imp.board.ID,
+		Type:     blockType,
+		Title:    title,
+		Fields:   fields,
+		CreateAt: imp.now,
+		UpdateAt: imp.now,
+	}
+	imp.contents = append(imp.contents, block)
+	imp.card.Fields["contentOrder"] = append(imp.card.Fields["contentOrder"].([]interface{}), block.ID)
+}
+
+func (imp *markdownImport) findProperty(name string) *markdownProperty {
+	for _, prop := range imp.properties {
+		if strings.EqualFold(prop.name, name) {
+			return prop
+		}
+	}
+	return nil
+}
+
+func (imp *markdownImport) property(name string) *markdownProperty {
+	if prop := imp.findProperty(name); prop != nil {
+		return prop
+	}
+	prop := &markdownProperty{id: utils.NewID(utils.IDTypeBlock), name: name}
+	imp.properties = append(imp.properties, prop)
+	return prop
+}
+
+func (imp *markdownImport) skip(lineNumber int, text, reason string) {
+	imp.skipped = append(imp.skipped, SkippedSection{Line: lineNumber, Text: text, Reason: reason})
+}
+
+// blocks returns the board, with a board view grouped by the first property,
+// followed by its cards and their contents.
+func (imp *markdownImport) blocks() []Block {
+	cardProperties := []interface{}{}
+	for _, prop := range imp.properties {
+		options := []interface{}{}
+		for _, option := range prop.options {
+			options = append(options, option)
+		}
+		cardProperties = append(cardProperties, map[string]interface{}{
+			"id":      prop.id,
+			"name":    prop.name,
+			"type":    "select",
+			"options": options,
+		})
+	}
+
+	imp.board.Title = imp.title
+	imp.board.Fields = map[string]interface{}{
+		"icon":            "",
+		"description":     strings.Join(imp.description, "\n"),
+		"showDescription": len(imp.description) > 0,
+		"isTemplate":      false,
+		"cardProperties":  cardProperties,
+	}
+
+	viewFields := map[string]interface{}{
+		"viewType":           "board",
+		"visiblePropertyIds": []interface{}{},
+		"cardOrder":          []interface{}{},
+	}
+	if len(imp.properties) > 0 {
+		viewFields["groupById"] = imp.properties[0].id
+	}
+	view := Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		ParentID: imp.board.ID,
+		RootID:   imp.board.ID,
+		Type:     TypeView,
+		Title:    "Board view",
+		Fields:   viewFields,
+		CreateAt: imp.now,
+		UpdateAt: imp.now,
+	}
+
+	blocks := make([]Block, 0, 2+len(imp.cards)+len(imp.contents))
+	blocks = append(blocks, imp.board, view)
+	blocks = append(blocks, imp.cards...)
+	return append(blocks, imp.contents...)
+}
+
+// markdownIndent returns the width of the indentation of a line, counting
+// tabs as four spaces.
+func markdownIndent(s string) int {
+	return len(strings.ReplaceAll(s, "\t", "    "))
+}
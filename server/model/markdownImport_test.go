@@ -0,0 +1,133 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMarkdownOutline = `---
+title: Launch
+Priority: High, Low
+bogus line
+---
+
+Everything for the launch.
+
+## Todo
+
+- Write the announcement
+  - [ ] Draft
+  - [x] Outline
+  - Priority: high
+  - Notes about the tone
+    - keep it short
+  Second line of the notes
+- [ ] Book the venue
+  - Priority: urgent
+
+## Done
+
+* Pick a date
+
+## Later
+
+Stray paragraph.
+`
+
+func TestBlocksFromMarkdown(t *testing.T) {
+	blocks, skipped := BlocksFromMarkdown(testMarkdownOutline)
+
+	board := blocks[0]
+	require.Equal(t, BlockType(TypeBoard), board.Type)
+	require.Equal(t, "Launch", board.Title)
+	require.Equal(t, "Everything for the launch.", board.Fields["description"])
+
+	schema, err := ParsePropertySchema(&board)
+	require.NoError(t, err)
+	require.Len(t, schema, 2)
+	var priority, section PropDef
+	for _, prop := range schema {
+		switch prop.Name {
+		case "Priority":
+			priority = prop
+		case "Section":
+			section = prop
+		}
+	}
+	require.Len(t, priority.Options, 2)
+	require.Len(t, section.Options, 2)
+
+	view := blocks[1]
+	require.Equal(t, BlockType(TypeView), view.Type)
+	require.Equal(t, priority.ID, view.Fields["groupById"])
+
+	cards := []Block{}
+	contents := map[string][]Block{}
+	for _, block := range blocks[2:] {
+		require.Equal(t, board.ID, block.RootID)
+		if block.Type == TypeCard {
+			cards = append(cards, block)
+			continue
+		}
+		contents[block.ParentID] = append(contents[block.ParentID], block)
+	}
+	require.Len(t, cards, 3)
+	require.Equal(t, "Write the announcement", cards[0].Title)
+	require.Equal(t, "Book the venue", cards[1].Title)
+	require.Equal(t, "Pick a date", cards[2].Title)
+
+	props := cards[0].Fields["properties"].(map[string]interface{})
+	require.Equal(t, "High", priority.Options[props[priority.ID].(string)].Value)
+	require.Equal(t, "Todo", section.Options[props[section.ID].(string)].Value)
+
+	props = cards[2].Fields["properties"].(map[string]interface{})
+	require.Equal(t, "Done", section.Options[props[section.ID].(string)].Value)
+
+	content := contents[cards[0].ID]
+	require.Len(t, content, 5)
+	require.Equal(t, typeCheckbox, content[0].Type)
+	require.Equal(t, "Draft", content[0].Title)
+	require.Equal(t, false, content[0].Fields["value"])
+	require.Equal(t, true, content[1].Fields["value"])
+	require.Equal(t, BlockType(TypeText), content[2].Type)
+	require.Equal(t, "Notes about the tone", content[2].Title)
+	require.Equal(t, "- keep it short", content[3].Title)
+	require.Equal(t, "Second line of the notes", content[4].Title)
+
+	order := cards[0].Fields["contentOrder"].([]interface{})
+	require.Len(t, order, 5)
+	require.Equal(t, content[0].ID, order[0])
+
+	require.Empty(t, contents[cards[1].ID])
+	require.Empty(t, contents[cards[2].ID])
+
+	require.Len(t, skipped, 3)
+	require.Equal(t, 4, skipped[0].Line)
+	require.Equal(t, 19, skipped[1].Line)
+	require.Equal(t, "value isn't an option of the Priority property", skipped[1].Reason)
+	require.Equal(t, 27, skipped[2].Line)
+	require.Equal(t, "text isn't under a card", skipped[2].Reason)
+}
+
+func TestBlocksFromMarkdownWithoutFrontMatter(t *testing.T) {
+	t.Run("title from the first heading", func(t *testing.T) {
+		blocks, skipped := BlocksFromMarkdown("# Backlog\n\n1. First\n2. Second\n")
+		require.Empty(t, skipped)
+		require.Equal(t, "Backlog", blocks[0].Title)
+		require.Len(t, blocks, 4)
+		require.Empty(t, blocks[0].Fields["cardProperties"])
+		require.NotContains(t, blocks[1].Fields, "groupById")
+	})
+
+	t.Run("front matter not closed", func(t *testing.T) {
+		_, skipped := BlocksFromMarkdown("---\n- card\n")
+		require.Len(t, skipped, 1)
+		require.Equal(t, 1, skipped[0].Line)
+	})
+
+	t.Run("no list items", func(t *testing.T) {
+		blocks, _ := BlocksFromMarkdown("Just some text.")
+		require.Len(t, blocks, 2)
+	})
+}
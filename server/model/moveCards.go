@@ -0,0 +1,18 @@
+package model
+
+// MoveCardsRequest sets the same value of a select property on several
+// cards, moving them to another group
+// swagger:model
+type MoveCardsRequest struct {
+	// IDs of the cards to move
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// ID of the select property to change
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// ID of the option to set, or empty to clear the property
+	// required: false
+	Value string `json:"value"`
+}
@@ -0,0 +1,115 @@
+package model
+
+const (
+	NotifyModeInstant = "instant"
+	NotifyModeHourly  = "hourly"
+	NotifyModeDaily   = "daily"
+	NotifyModeOff     = "off"
+)
+
+// NotifyMode is how a user is notified of the changes to the cards they follow.
+type NotifyMode string
+
+func (m NotifyMode) IsValid() bool {
+	switch m {
+	case NotifyModeInstant, NotifyModeHourly, NotifyModeDaily, NotifyModeOff:
+		return true
+	}
+	return false
+}
+
+// IsDigest returns true if the notifications are batched into digests.
+func (m NotifyMode) IsDigest() bool {
+	return m == NotifyModeHourly || m == NotifyModeDaily
+}
+
+// NotificationSettings are the notification settings of a user for a board.
+// swagger:model
+type NotificationSettings struct {
+	// BoardID is the id of the board the settings apply to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// WorkspaceID is the id of the workspace the board belongs to
+	// required: true
+	WorkspaceID string `json:"workspaceId"`
+
+	// UserID is the id of the user the settings belong to
+	// required: true
+	UserID string `json:"userId"`
+
+	// Mode is how the user is notified: instant, hourly, daily or off
+	// required: true
+	Mode NotifyMode `json:"mode"`
+
+	// UpdateAt is the timestamp of the last update of the settings
+	// required: false
+	UpdateAt int64 `json:"updateAt,omitempty"`
+}
+
+func (s *NotificationSettings) IsValid() error {
+	if s == nil {
+		return ErrInvalidNotificationSettings{"cannot be nil"}
+	}
+	if s.BoardID == "" {
+		return ErrInvalidNotificationSettings{"missing board id"}
+	}
+	if s.WorkspaceID == "" {
+		return ErrInvalidNotificationSettings{"missing workspace id"}
+	}
+	if s.UserID == "" {
+		return ErrInvalidNotificationSettings{"missing user id"}
+	}
+	if !s.Mode.IsValid() {
+		return ErrInvalidNotificationSettings{"invalid mode"}
+	}
+	return nil
+}
+
+type ErrInvalidNotificationSettings struct {
+	msg string
+}
+
+func (e ErrInvalidNotificationSettings) Error() string {
+	return e.msg
+}
+
+// CardMute is a card a user isn't notified about, even when following its
+// board.
+// swagger:model
+type CardMute struct {
+	// CardID is the id of the muted card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// BoardID is the id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// WorkspaceID is the id of the workspace the board belongs to
+	// required: true
+	WorkspaceID string `json:"workspaceId"`
+
+	// UserID is the id of the user who muted the card
+	// required: true
+	UserID string `json:"userId"`
+
+	// CreateAt is the timestamp the card was muted
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// NotificationDigestItem is a notification held back to be delivered with
+// the next digest of a user.
+type NotificationDigestItem struct {
+	ID          string
+	WorkspaceID string
+	BoardID     string
+	UserID      string
+
+	// Attachments is the JSON encoded notification
+	Attachments string
+
+	CreateAt  int64
+	DeliverAt int64
+}
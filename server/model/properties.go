@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/utils"
@@ -47,6 +49,18 @@ type PropDefOption struct {
 	Value string `json:"value"`
 }
 
+// MaxDecimals is the most decimal places a number can be displayed with,
+// larger numbers of decimal places are reduced to it.
+const MaxDecimals = 20
+
+// PropDefFormat is how the values of a number property are displayed.
+type PropDefFormat struct {
+	// Decimals is the number of decimal places, the value is kept as is if
+	// it isn't set.
+	Decimals *int   `json:"decimals,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
 // PropDef represents a property definition as defined in a board's Fields member.
 type PropDef struct {
 	ID      string                   `json:"id"`
@@ -54,6 +68,8 @@ type PropDef struct {
 	Name    string                   `json:"name"`
 	Type    string                   `json:"type"`
 	Options map[string]PropDefOption `json:"options"`
+	Format  PropDefFormat            `json:"format"`
+	Formula string                   `json:"formula,omitempty"`
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
@@ -117,10 +133,45 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 			sb.WriteString(strings.ToUpper(opt.Value))
 		}
 		return sb.String(), nil
+
+	case "number":
+		return pd.FormatNumber(fmt.Sprintf("%v", v)), nil
 	}
 	return fmt.Sprintf("%v", v), nil
 }
 
+// FormatNumber rounds a number property value to the decimal places of the
+// format of the property and prefixes it with its currency symbol. Values
+// that aren't numbers are returned unchanged.
+func (pd PropDef) FormatNumber(s string) string {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return s
+	}
+	if pd.Format.Decimals != nil {
+		s = strconv.FormatFloat(f, 'f', *pd.Format.Decimals, 64)
+	}
+	return pd.Format.Currency + s
+}
+
+// ParseDateRange returns the start and end of a date property value in
+// milliseconds. The end equals the start when the value is not a range.
+func ParseDateRange(s string) (from int64, to int64, err error) {
+	var m map[string]int64
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return 0, 0, err
+	}
+	from, ok := m["from"]
+	if !ok {
+		return 0, 0, ErrInvalidDate
+	}
+	to, ok = m["to"]
+	if !ok {
+		to = from
+	}
+	return from, to, nil
+}
+
 func (pd PropDef) ParseDate(s string) (string, error) {
 	// s is a JSON snippet of the form: {"from":1642161600000, "to":1642161600000} in milliseconds UTC
 	// The UI does not yet support date ranges.
@@ -174,6 +225,8 @@ func ParsePropertySchema(board *Block) (PropSchema, error) {
 			Name:    getMapString("name", prop),
 			Type:    getMapString("type", prop),
 			Options: make(map[string]PropDefOption),
+			Format:  parsePropFormat(prop),
+			Formula: getMapString("formula", prop),
 		}
 		optsIface, ok := prop["options"]
 		if ok {
@@ -200,6 +253,21 @@ func ParsePropertySchema(board *Block) (PropSchema, error) {
 	return schema, nil
 }
 
+// parsePropFormat returns the format of a property definition.
+func parsePropFormat(prop map[string]interface{}) PropDefFormat {
+	format := PropDefFormat{}
+	m, ok := prop["format"].(map[string]interface{})
+	if !ok {
+		return format
+	}
+	if decimals, ok := m["decimals"].(float64); ok {
+		d := int(math.Min(math.Max(decimals, 0), MaxDecimals))
+		format.Decimals = &d
+	}
+	format.Currency = getMapString("currency", m)
+	return format
+}
+
 func getMapString(key string, m map[string]interface{}) string {
 	iface, ok := m[key]
 	if !ok {
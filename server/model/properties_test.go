@@ -45,6 +45,62 @@ func Test_parsePropertySchema(t *testing.T) {
 	})
 }
 
+func TestPropDefFormatNumber(t *testing.T) {
+	board := &Block{
+		ID:   utils.NewID(utils.IDTypeBoard),
+		Type: TypeBoard,
+		Fields: map[string]interface{}{
+			"cardProperties": []interface{}{
+				map[string]interface{}{"id": "plain", "name": "Plain", "type": "number"},
+				map[string]interface{}{
+					"id":     "price",
+					"name":   "Price",
+					"type":   "number",
+					"format": map[string]interface{}{"decimals": float64(2), "currency": "$"},
+				},
+			},
+		},
+	}
+
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+	plain := schema["plain"]
+	price := schema["price"]
+	require.Nil(t, plain.Format.Decimals)
+	require.NotNil(t, price.Format.Decimals)
+	require.Equal(t, 2, *price.Format.Decimals)
+	require.Equal(t, "$", price.Format.Currency)
+
+	t.Run("decimals are clamped", func(t *testing.T) {
+		for decimals, expected := range map[float64]int{-1: 0, 25: MaxDecimals, 400: MaxDecimals} {
+			format := parsePropFormat(map[string]interface{}{
+				"format": map[string]interface{}{"decimals": decimals},
+			})
+			require.NotNil(t, format.Decimals)
+			require.Equal(t, expected, *format.Decimals)
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		prop     PropDef
+		value    interface{}
+		expected string
+	}{
+		{"no format", plain, "0.30000000000000004", "0.30000000000000004"},
+		{"rounded with currency", price, "0.30000000000000004", "$0.30"},
+		{"padded", price, "12", "$12.00"},
+		{"not a number", price, "n/a", "n/a"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := tc.prop.GetValue(tc.value, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+}
+
 const (
 	fieldsExample = `
 	{
@@ -136,3 +192,23 @@ const (
 	 }	
 	`
 )
+
+func TestRelationCardIDs(t *testing.T) {
+	ids, err := RelationCardIDs("card-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"card-1"}, ids)
+
+	ids, err = RelationCardIDs([]interface{}{"card-1", "card-2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"card-1", "card-2"}, ids)
+
+	ids, err = RelationCardIDs("")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	_, err = RelationCardIDs(42.0)
+	require.ErrorIs(t, err, ErrInvalidPropertyValueType)
+
+	_, err = RelationCardIDs([]interface{}{"card-1", 42.0})
+	require.ErrorIs(t, err, ErrInvalidPropertyValueType)
+}
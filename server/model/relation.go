@@ -0,0 +1,58 @@
+package model
+
+import "errors"
+
+// PropTypeRelation is the type of the card properties whose values are the
+// IDs of other cards, from any board of the workspace.
+const PropTypeRelation = "relation"
+
+// ErrInvalidRelation is returned when a relation property references a
+// block that isn't an existing card.
+var ErrInvalidRelation = errors.New("relation does not reference an existing card")
+
+// RelatedCard is a card referenced by a relation property
+// swagger:model
+type RelatedCard struct {
+	// ID of the card
+	// required: true
+	ID string `json:"id"`
+
+	// ID of the board of the card
+	// required: false
+	BoardID string `json:"boardId,omitempty"`
+
+	// Title of the card
+	// required: false
+	Title string `json:"title,omitempty"`
+
+	// True if the card was deleted after the relation was set
+	// required: true
+	Dangling bool `json:"dangling"`
+}
+
+// RelationCardIDs returns the card IDs of the value of a relation property,
+// which is either a single ID or a list of IDs.
+func RelationCardIDs(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []interface{}:
+		ids := make([]string, 0, len(v))
+		for _, item := range v {
+			id, ok := item.(string)
+			if !ok || id == "" {
+				return nil, ErrInvalidPropertyValueType
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	case []string:
+		return v, nil
+	}
+	return nil, ErrInvalidPropertyValueType
+}
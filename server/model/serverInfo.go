@@ -0,0 +1,53 @@
+package model
+
+// ServerInfo is the version of the server and the optional features enabled
+// on it
+// swagger:model
+type ServerInfo struct {
+	// Version of the server
+	// required: true
+	Version string `json:"version"`
+
+	// Build number of the server
+	// required: true
+	BuildNumber string `json:"buildNumber"`
+
+	// Hash of the commit the server was built from
+	// required: true
+	BuildHash string `json:"buildHash"`
+
+	// Date the server was built at
+	// required: true
+	BuildDate string `json:"buildDate"`
+
+	// Edition of the server
+	// required: true
+	Edition string `json:"edition"`
+
+	// Optional features and whether they are enabled, keyed by name. Only
+	// returned to authenticated users
+	// required: false
+	Features map[string]bool `json:"features,omitempty"`
+
+	// Retention of the history of the blocks. Only returned to authenticated
+	// users
+	// required: false
+	HistoryRetention *HistoryRetention `json:"historyRetention,omitempty"`
+}
+
+// HistoryRetention is how long the history of the blocks is kept
+// swagger:model
+type HistoryRetention struct {
+	// Number of days the history is kept for, 0 if it is kept forever
+	// required: true
+	MaxAgeDays int `json:"maxAgeDays"`
+
+	// Number of versions kept for each block, 0 if all the versions are kept
+	// required: true
+	MaxVersions int `json:"maxVersions"`
+}
+
+// Enabled returns true if the history of the blocks is pruned.
+func (r HistoryRetention) Enabled() bool {
+	return r.MaxAgeDays > 0 || r.MaxVersions > 0
+}
@@ -29,8 +29,84 @@ type Sharing struct {
 	UpdateAt int64 `json:"update_at,omitempty"`
 }
 
+// SharingWithBoard is the sharing information of a root block along with the
+// root block, for clients to refresh their copy of it
+// swagger:model
+type SharingWithBoard struct {
+	// The sharing information
+	// required: true
+	Sharing *Sharing `json:"sharing"`
+
+	// The root block, with its last activity set if it is a board. Null if
+	// the root block doesn't exist
+	// required: true
+	Board *Block `json:"board"`
+}
+
+// SharingStatus is whether sharing is enabled for a board, without its
+// access token
+// swagger:model
+type SharingStatus struct {
+	// Is sharing enabled
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// Does the board have an access token
+	// required: true
+	HasToken bool `json:"hasToken"`
+}
+
+// SharingPatch is a partial update of the sharing information of a root
+// block. Only the fields that are set are changed
+// swagger:model
+type SharingPatch struct {
+	// Is sharing enabled
+	// required: false
+	Enabled *bool `json:"enabled"`
+
+	// Access token
+	// required: false
+	Token *string `json:"token"`
+}
+
+// IsValid returns an error if the patch changes nothing or clears the token.
+func (p *SharingPatch) IsValid() error {
+	if p.Enabled == nil && p.Token == nil {
+		return ErrInvalidSharingPatch{"at least one field must be set"}
+	}
+	if p.Token != nil && *p.Token == "" {
+		return ErrInvalidSharingPatch{"token cannot be empty"}
+	}
+	return nil
+}
+
+// Patch applies the patch to the sharing.
+func (p *SharingPatch) Patch(sharing *Sharing) *Sharing {
+	if p.Enabled != nil {
+		sharing.Enabled = *p.Enabled
+	}
+	if p.Token != nil {
+		sharing.Token = *p.Token
+	}
+	return sharing
+}
+
+type ErrInvalidSharingPatch struct {
+	msg string
+}
+
+func (e ErrInvalidSharingPatch) Error() string {
+	return e.msg
+}
+
 func SharingFromJSON(data io.Reader) Sharing {
 	var sharing Sharing
 	_ = json.NewDecoder(data).Decode(&sharing)
 	return sharing
 }
+
+func SharingWithBoardFromJSON(data io.Reader) *SharingWithBoard {
+	var sharing *SharingWithBoard
+	_ = json.NewDecoder(data).Decode(&sharing)
+	return sharing
+}
@@ -54,6 +54,10 @@ type Subscription struct {
 	// DeleteAt is the timestamp this subscription was deleted, or zero if not deleted
 	// required: true
 	DeleteAt int64 `json:"deleteAt"`
+
+	// Muted is true if the subscriber muted the card subscribed to
+	// required: false
+	Muted bool `json:"muted,omitempty"`
 }
 
 func (s *Subscription) IsValid() error {
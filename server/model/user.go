@@ -53,6 +53,40 @@ type User struct {
 	IsBot bool `json:"is_bot"`
 }
 
+// UserSummary is the part of a user needed to display who created or
+// modified a block
+// swagger:model
+type UserSummary struct {
+	// The user ID
+	// required: true
+	ID string `json:"id"`
+
+	// The user name
+	// required: true
+	Username string `json:"username"`
+
+	// If the user is a bot or not
+	// required: true
+	IsBot bool `json:"is_bot"`
+}
+
+// Summary returns the summary of the user.
+func (u *User) Summary() *UserSummary {
+	return &UserSummary{
+		ID:       u.ID,
+		Username: u.Username,
+		IsBot:    u.IsBot,
+	}
+}
+
+// Props of the sessions an admin creates to impersonate a user.
+const (
+	SessionPropImpersonatedBy      = "impersonatedBy"
+	SessionPropImpersonationReason = "impersonationReason"
+	SessionPropReadOnly            = "readOnly"
+	SessionPropExpiresAt           = "expiresAt"
+)
+
 type Session struct {
 	ID          string                 `json:"id"`
 	Token       string                 `json:"token"`
@@ -63,6 +97,35 @@ type Session struct {
 	UpdateAt    int64                  `json:"update_at,omitempty"`
 }
 
+// ImpersonatedBy returns the admin that created the session to impersonate
+// its user, or an empty string if it isn't an impersonation session.
+func (s *Session) ImpersonatedBy() string {
+	impersonatedBy, _ := s.Props[SessionPropImpersonatedBy].(string)
+	return impersonatedBy
+}
+
+// IsReadOnly returns true if the session can't be used to modify data.
+func (s *Session) IsReadOnly() bool {
+	readOnly, _ := s.Props[SessionPropReadOnly].(bool)
+	return readOnly
+}
+
+// IsExpired returns true if the session has an expiry time before now.
+// Sessions without one expire through the session expire time instead.
+func (s *Session) IsExpired(now int64) bool {
+	var expiresAt int64
+	switch v := s.Props[SessionPropExpiresAt].(type) {
+	case int64:
+		expiresAt = v
+	case float64:
+		// props read back from JSON
+		expiresAt = int64(v)
+	default:
+		return false
+	}
+	return expiresAt <= now
+}
+
 func UserFromJSON(data io.Reader) (*User, error) {
 	var user User
 	if err := json.NewDecoder(data).Decode(&user); err != nil {
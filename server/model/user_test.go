@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionImpersonationProps(t *testing.T) {
+	t.Run("regular session", func(t *testing.T) {
+		session := &Session{Props: map[string]interface{}{}}
+		require.Empty(t, session.ImpersonatedBy())
+		require.False(t, session.IsReadOnly())
+		require.False(t, session.IsExpired(1000))
+	})
+
+	t.Run("impersonation session", func(t *testing.T) {
+		session := &Session{Props: map[string]interface{}{
+			SessionPropImpersonatedBy: "admin",
+			SessionPropReadOnly:       true,
+			SessionPropExpiresAt:      int64(1000),
+		}}
+		require.Equal(t, "admin", session.ImpersonatedBy())
+		require.True(t, session.IsReadOnly())
+		require.False(t, session.IsExpired(999))
+		require.True(t, session.IsExpired(1000))
+	})
+
+	t.Run("props read back from JSON", func(t *testing.T) {
+		data, err := json.Marshal(map[string]interface{}{SessionPropExpiresAt: int64(1000)})
+		require.NoError(t, err)
+		session := &Session{}
+		require.NoError(t, json.Unmarshal(data, &session.Props))
+		require.True(t, session.IsExpired(1001))
+	})
+}
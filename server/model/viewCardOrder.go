@@ -0,0 +1,122 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// ViewCardOrderField is the view field holding the manual order of all
+	// the cards of the view.
+	ViewCardOrderField = "cardOrder"
+
+	// ViewGroupCardOrderField is the view field holding the manual order of
+	// the cards of each group, keyed by the ID of the group option.
+	ViewGroupCardOrderField = "cardOrderByGroup"
+)
+
+// ErrInvalidCardOrder is returned when a card order references a card that
+// isn't in the board, or a card more than once.
+type ErrInvalidCardOrder struct {
+	msg string
+}
+
+func (e ErrInvalidCardOrder) Error() string {
+	return e.msg
+}
+
+// ViewCardOrder is the manual order of the cards of a view
+// swagger:model
+type ViewCardOrder struct {
+	// Ordered card IDs for each group, keyed by the ID of the group option
+	// required: true
+	CardOrderByGroup map[string][]string `json:"cardOrderByGroup"`
+}
+
+// Validate returns an ErrInvalidCardOrder if the order references a card
+// not in cardIDs, or the same card more than once.
+func (o ViewCardOrder) Validate(cardIDs map[string]bool) error {
+	seen := map[string]bool{}
+	for groupID, order := range o.CardOrderByGroup {
+		for _, cardID := range order {
+			if !cardIDs[cardID] {
+				return ErrInvalidCardOrder{fmt.Sprintf("card %s of group %s is not in the board", cardID, groupID)}
+			}
+			if seen[cardID] {
+				return ErrInvalidCardOrder{fmt.Sprintf("card %s is ordered more than once", cardID)}
+			}
+			seen[cardID] = true
+		}
+	}
+	return nil
+}
+
+// Flatten returns the card IDs of all the groups, in group ID order, for
+// clients that keep a single card order per view.
+func (o ViewCardOrder) Flatten() []string {
+	groupIDs := make([]string, 0, len(o.CardOrderByGroup))
+	for groupID := range o.CardOrderByGroup {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+
+	cardOrder := []string{}
+	for _, groupID := range groupIDs {
+		cardOrder = append(cardOrder, o.CardOrderByGroup[groupID]...)
+	}
+	return cardOrder
+}
+
+// Merge applies the order on top of the current order of a view, read from
+// its fields. The groups of o replace the current ones, and the cards of o
+// leave the groups they were ordered in before. The cards only in the
+// current flat order are kept, in their order, after the ordered groups.
+// It returns the merged order and its flat card order.
+func (o ViewCardOrder) Merge(viewFields map[string]interface{}) (ViewCardOrder, []string) {
+	moved := map[string]bool{}
+	for _, order := range o.CardOrderByGroup {
+		for _, cardID := range order {
+			moved[cardID] = true
+		}
+	}
+
+	merged := ViewCardOrder{CardOrderByGroup: map[string][]string{}}
+	current, _ := viewFields[ViewGroupCardOrderField].(map[string]interface{})
+	for groupID, order := range current {
+		kept := []string{}
+		for _, cardID := range stringSlice(order) {
+			if !moved[cardID] {
+				kept = append(kept, cardID)
+			}
+		}
+		merged.CardOrderByGroup[groupID] = kept
+	}
+	for groupID, order := range o.CardOrderByGroup {
+		merged.CardOrderByGroup[groupID] = order
+	}
+
+	cardOrder := merged.Flatten()
+	ordered := make(map[string]bool, len(cardOrder))
+	for _, cardID := range cardOrder {
+		ordered[cardID] = true
+	}
+	for _, cardID := range stringSlice(viewFields[ViewCardOrderField]) {
+		if !ordered[cardID] {
+			ordered[cardID] = true
+			cardOrder = append(cardOrder, cardID)
+		}
+	}
+	return merged, cardOrder
+}
+
+// stringSlice returns the strings of a JSON array value.
+func stringSlice(value interface{}) []string {
+	values, _ := value.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,75 @@
+package model
+
+import "errors"
+
+const (
+	// ViewSortOptionsField is the view field listing the properties the cards
+	// of the view are sorted by.
+	ViewSortOptionsField = "sortOptions"
+
+	// DefaultSortField is the board field holding the sort option of the
+	// views added to the board without one.
+	DefaultSortField = "defaultSort"
+
+	// TitlePropertyID is the ID sort options use for the title of the cards.
+	TitlePropertyID = "__title"
+)
+
+// ErrInvalidDefaultSort is returned when the default sort of a board doesn't
+// reference a property of the board.
+var ErrInvalidDefaultSort = errors.New("default sort must reference a property of the board")
+
+// SortOption is a property the cards of a view are sorted by
+// swagger:model
+type SortOption struct {
+	// ID of the property, or __title for the title of the cards
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// Whether the cards are sorted in descending order
+	// required: false
+	Reversed bool `json:"reversed"`
+}
+
+// DefaultSort returns the default sort of the board, or nil if unset.
+func (b Block) DefaultSort() (*SortOption, error) {
+	value, ok := b.Fields[DefaultSortField]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, ErrInvalidDefaultSort
+	}
+	propertyID, _ := m["propertyId"].(string)
+	if propertyID == "" {
+		return nil, ErrInvalidDefaultSort
+	}
+	reversed, _ := m["reversed"].(bool)
+	return &SortOption{PropertyID: propertyID, Reversed: reversed}, nil
+}
+
+// CheckDefaultSort returns ErrInvalidDefaultSort if the default sort of the
+// board is malformed or references a property the board doesn't have.
+func (b Block) CheckDefaultSort() error {
+	sortOption, err := b.DefaultSort()
+	if err != nil || sortOption == nil || sortOption.PropertyID == TitlePropertyID {
+		return err
+	}
+
+	schema, err := ParsePropertySchema(&b)
+	if err != nil {
+		return ErrInvalidDefaultSort
+	}
+	if _, ok := schema[sortOption.PropertyID]; !ok {
+		return ErrInvalidDefaultSort
+	}
+	return nil
+}
+
+// HasSortOptions returns true if the view is sorted by at least a property.
+func (b Block) HasSortOptions() bool {
+	sortOptions, _ := b.Fields[ViewSortOptionsField].([]interface{})
+	return len(sortOptions) > 0
+}
@@ -26,6 +26,31 @@ type Workspace struct {
 	// Updated time
 	// required: true
 	UpdateAt int64 `json:"updateAt"`
+
+	// Usage statistics of the workspace. Only set when requested with
+	// with_stats=true, it isn't stored
+	// required: false
+	Stats *WorkspaceStats `json:"stats,omitempty"`
+}
+
+// WorkspaceStats are the usage statistics of a workspace
+// swagger:model
+type WorkspaceStats struct {
+	// Number of boards, templates included
+	// required: true
+	BoardCount int64 `json:"boardCount"`
+
+	// Number of blocks of all types
+	// required: true
+	BlockCount int64 `json:"blockCount"`
+
+	// Number of users with access to the workspace
+	// required: true
+	MemberCount int `json:"memberCount"`
+
+	// Total size in bytes of the files attached to the boards
+	// required: true
+	StorageUsage int64 `json:"storageUsage"`
 }
 
 // UserWorkspace is a summary of a single association between
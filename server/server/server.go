@@ -42,6 +42,8 @@ import (
 const (
 	cleanupSessionTaskFrequency = 10 * time.Minute
 	updateMetricsTaskFrequency  = 15 * time.Minute
+	archiveBoardsTaskFrequency  = 1 * time.Hour
+	pruneHistoryTaskFrequency   = 1 * time.Hour
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
@@ -60,6 +62,8 @@ type Server struct {
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	archiveBoardsTask      *scheduler.ScheduledTask
+	pruneHistoryTask       *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
 	servicesStartStopMutex sync.Mutex
@@ -80,31 +84,31 @@ func New(params Params) (*Server, error) {
 	// if no ws adapter is provided, we spin up a websocket server
 	wsAdapter := params.WSAdapter
 	if wsAdapter == nil {
-		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger)
-	}
-
-	filesBackendSettings := filestore.FileBackendSettings{}
-	filesBackendSettings.DriverName = params.Cfg.FilesDriver
-	filesBackendSettings.Directory = params.Cfg.FilesPath
-	filesBackendSettings.AmazonS3AccessKeyId = params.Cfg.FilesS3Config.AccessKeyID
-	filesBackendSettings.AmazonS3SecretAccessKey = params.Cfg.FilesS3Config.SecretAccessKey
-	filesBackendSettings.AmazonS3Bucket = params.Cfg.FilesS3Config.Bucket
-	filesBackendSettings.AmazonS3PathPrefix = params.Cfg.FilesS3Config.PathPrefix
-	filesBackendSettings.AmazonS3Region = params.Cfg.FilesS3Config.Region
-	filesBackendSettings.AmazonS3Endpoint = params.Cfg.FilesS3Config.Endpoint
-	filesBackendSettings.AmazonS3SSL = params.Cfg.FilesS3Config.SSL
-	filesBackendSettings.AmazonS3SignV2 = params.Cfg.FilesS3Config.SignV2
-	filesBackendSettings.AmazonS3SSE = params.Cfg.FilesS3Config.SSE
-	filesBackendSettings.AmazonS3Trace = params.Cfg.FilesS3Config.Trace
-
-	filesBackend, appErr := filestore.NewFileBackend(filesBackendSettings)
+		wsAdapter = ws.NewServer(authenticator, params.DBStore, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger)
+	}
+
+	filesBackend, appErr := filestore.NewFileBackend(filesBackendSettings(params.Cfg.FilesDriver, params.Cfg.FilesPath, params.Cfg.FilesS3Config))
 	if appErr != nil {
 		params.Logger.Error("Unable to initialize the files storage", mlog.Err(appErr))
 
 		return nil, errors.New("unable to initialize the files storage")
 	}
 
-	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
+	var secondaryFilesBackend filestore.FileBackend
+	if params.Cfg.SecondaryFilesDriver != "" {
+		secondaryFilesBackend, appErr = filestore.NewFileBackend(filesBackendSettings(params.Cfg.SecondaryFilesDriver, params.Cfg.SecondaryFilesPath, params.Cfg.SecondaryFilesS3Config))
+		if appErr != nil {
+			params.Logger.Error("Unable to initialize the secondary files storage", mlog.Err(appErr))
+
+			return nil, errors.New("unable to initialize the secondary files storage")
+		}
+	}
+
+	webhookClient, err := webhook.NewClient(params.Cfg, params.Logger)
+	if err != nil {
+		params.Logger.Error("Invalid webhook configuration", mlog.Err(err))
+		return nil, err
+	}
 
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -123,6 +127,7 @@ func New(params Params) (*Server, error) {
 	if err := auditService.Configure(params.Cfg.AuditCfgFile, params.Cfg.AuditCfgJSON); err != nil {
 		return nil, fmt.Errorf("unable to initialize the audit service: %w", err)
 	}
+	auditService.SetSampleRates(params.Cfg.AuditSampleRates)
 
 	// Init notification services
 	notificationService, errNotify := initNotificationService(params.NotifyBackends, params.Logger)
@@ -131,13 +136,14 @@ func New(params Params) (*Server, error) {
 	}
 
 	appServices := app.Services{
-		Auth:          authenticator,
-		Store:         params.DBStore,
-		FilesBackend:  filesBackend,
-		Webhook:       webhookClient,
-		Metrics:       metricsService,
-		Notifications: notificationService,
-		Logger:        params.Logger,
+		Auth:                  authenticator,
+		Store:                 params.DBStore,
+		FilesBackend:          filesBackend,
+		SecondaryFilesBackend: secondaryFilesBackend,
+		Webhook:               webhookClient,
+		Metrics:               metricsService,
+		Notifications:         notificationService,
+		Logger:                params.Logger,
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
@@ -153,7 +159,7 @@ func New(params Params) (*Server, error) {
 		return nil, err
 	}
 
-	webServer := web.NewServer(params.Cfg.WebPath, params.Cfg.ServerRoot, params.Cfg.Port,
+	webServer := web.NewServer(params.Cfg.WebPath, params.Cfg.ServerRoot, params.Cfg.RoutePrefix, params.Cfg.Port,
 		params.Cfg.UseSSL, params.Cfg.LocalOnly, params.Logger)
 	// if the adapter is a routed service, register it before the API
 	if routedService, ok := wsAdapter.(web.RoutedService); ok {
@@ -207,6 +213,11 @@ func New(params Params) (*Server, error) {
 }
 
 func NewStore(config *config.Configuration, logger *mlog.Logger) (store.Store, error) {
+	blockHistoryDiffs, err := config.BlockHistoryDiffs()
+	if err != nil {
+		return nil, err
+	}
+
 	sqlDB, err := sql.Open(config.DBType, config.DBConfigString)
 	if err != nil {
 		logger.Error("connectDatabase failed", mlog.Err(err))
@@ -226,6 +237,11 @@ func NewStore(config *config.Configuration, logger *mlog.Logger) (store.Store, e
 		Logger:           logger,
 		DB:               sqlDB,
 		IsPlugin:         false,
+
+		DisableTemplateSeeding: config.DisableTemplateSeeding,
+		SeedTemplates:          config.SeedTemplates,
+		BlockUpdateCoalesceMS:  config.BlockUpdateCoalesceMS,
+		BlockHistoryDiffs:      blockHistoryDiffs,
 	}
 
 	var db store.Store
@@ -243,6 +259,25 @@ func NewStore(config *config.Configuration, logger *mlog.Logger) (store.Store, e
 	return db, nil
 }
 
+// filesBackendSettings returns the settings of a files backend using the
+// driver, storing the files under directory for the local driver.
+func filesBackendSettings(driver, directory string, s3Config config.AmazonS3Config) filestore.FileBackendSettings {
+	return filestore.FileBackendSettings{
+		DriverName:              driver,
+		Directory:               directory,
+		AmazonS3AccessKeyId:     s3Config.AccessKeyID,
+		AmazonS3SecretAccessKey: s3Config.SecretAccessKey,
+		AmazonS3Bucket:          s3Config.Bucket,
+		AmazonS3PathPrefix:      s3Config.PathPrefix,
+		AmazonS3Region:          s3Config.Region,
+		AmazonS3Endpoint:        s3Config.Endpoint,
+		AmazonS3SSL:             s3Config.SSL,
+		AmazonS3SignV2:          s3Config.SignV2,
+		AmazonS3SSE:             s3Config.SSE,
+		AmazonS3Trace:           s3Config.Trace,
+	}
+}
+
 func (s *Server) Start() error {
 	s.logger.Info("Server.Start")
 
@@ -291,6 +326,22 @@ func (s *Server) Start() error {
 	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
 	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
 
+	if s.config.BoardArchiveAfterDays > 0 {
+		s.archiveBoardsTask = scheduler.CreateRecurringTask("archiveBoards", func() {
+			if err := s.app.ArchiveInactiveBoards(); err != nil {
+				s.logger.Error("Unable to archive the inactive boards", mlog.Err(err))
+			}
+		}, archiveBoardsTaskFrequency)
+	}
+
+	if s.app.HistoryRetention().Enabled() {
+		s.pruneHistoryTask = scheduler.CreateRecurringTask("pruneBlockHistory", func() {
+			if err := s.app.PruneBlockHistory(); err != nil {
+				s.logger.Error("Unable to prune the block history", mlog.Err(err))
+			}
+		}, pruneHistoryTaskFrequency)
+	}
+
 	if s.config.Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
@@ -332,6 +383,14 @@ func (s *Server) Shutdown() error {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.archiveBoardsTask != nil {
+		s.archiveBoardsTask.Cancel()
+	}
+
+	if s.pruneHistoryTask != nil {
+		s.pruneHistoryTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
@@ -1,21 +1,25 @@
 package audit
 
 import (
+	"sync"
+
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 const (
 	DefMaxQueueSize = 1000
 
-	KeyAPIPath     = "api_path"
-	KeyEvent       = "event"
-	KeyStatus      = "status"
-	KeyUserID      = "user_id"
-	KeySessionID   = "session_id"
-	KeyClient      = "client"
-	KeyIPAddress   = "ip_address"
-	KeyClusterID   = "cluster_id"
-	KeyWorkspaceID = "workspace_id"
+	KeyAPIPath        = "api_path"
+	KeyEvent          = "event"
+	KeyStatus         = "status"
+	KeyUserID         = "user_id"
+	KeySessionID      = "session_id"
+	KeyClient         = "client"
+	KeyIPAddress      = "ip_address"
+	KeyClusterID      = "cluster_id"
+	KeyWorkspaceID    = "workspace_id"
+	KeyImpersonatedBy = "impersonated_by"
+	KeyBoardAPIKeyID  = "board_api_key_id"
 
 	Success = "success"
 	Attempt = "attempt"
@@ -31,6 +35,10 @@ var (
 // Audit provides auditing service.
 type Audit struct {
 	auditLogger *mlog.Logger
+
+	mux         sync.Mutex
+	sampleRates map[string]int
+	counts      map[string]int
 }
 
 // NewAudit creates a new Audit instance which can be configured via `(*Audit).Configure`.
@@ -41,6 +49,7 @@ func NewAudit(options ...mlog.Option) (*Audit, error) {
 	}
 	return &Audit{
 		auditLogger: logger,
+		counts:      map[string]int{},
 	}, nil
 }
 
@@ -56,14 +65,54 @@ func (a *Audit) Configure(cfgFile string, cfgEscaped string) error {
 	return a.auditLogger.Configure(cfgFile, cfgEscaped, nil)
 }
 
+// SetSampleRates sets the sample rates of the audit records, keyed by level
+// name: with a rate of N, 1 in N records of the level is logged. The records
+// of the levels without a rate, or with a rate of 1 or less, are all logged,
+// and so are the authentication, modification and impersonation records
+// whatever their rate.
+func (a *Audit) SetSampleRates(rates map[string]int) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	a.sampleRates = make(map[string]int, len(rates))
+	for name, rate := range rates {
+		a.sampleRates[name] = rate
+	}
+	a.counts = map[string]int{}
+}
+
+// sampled returns true if the record, the next one of the level, must be
+// logged.
+func (a *Audit) sampled(level mlog.Level, rec *Record) bool {
+	if level.ID == LevelAuth.ID || level.ID == LevelModify.ID || rec.isImpersonation() {
+		return true
+	}
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	rate := a.sampleRates[level.Name]
+	if rate <= 1 {
+		return true
+	}
+	count := a.counts[level.Name]
+	a.counts[level.Name] = (count + 1) % rate
+	return count == 0
+}
+
 // Shutdown shuts down the audit service after making best efforts to flush any
 // remaining records.
 func (a *Audit) Shutdown() error {
 	return a.auditLogger.Shutdown()
 }
 
-// LogRecord emits an audit record with complete info.
+// LogRecord emits an audit record with complete info, unless it is left out
+// by the sample rate of its level.
 func (a *Audit) LogRecord(level mlog.Level, rec *Record) {
+	if !a.sampled(level, rec) {
+		return
+	}
+
 	fields := make([]mlog.Field, 0, 7+len(rec.Meta))
 
 	fields = append(fields, mlog.String(KeyAPIPath, rec.APIPath))
@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit_Sampled(t *testing.T) {
+	audit, err := NewAudit()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, audit.Shutdown())
+	}()
+
+	countSampled := func(level mlog.Level, n int) int {
+		sampled := 0
+		for i := 0; i < n; i++ {
+			if audit.sampled(level, &Record{}) {
+				sampled++
+			}
+		}
+		return sampled
+	}
+
+	t.Run("no sample rates", func(t *testing.T) {
+		require.Equal(t, 10, countSampled(LevelRead, 10))
+		require.Equal(t, 10, countSampled(LevelAuth, 10))
+	})
+
+	t.Run("sampled levels", func(t *testing.T) {
+		audit.SetSampleRates(map[string]int{"read": 5})
+
+		require.True(t, audit.sampled(LevelRead, &Record{}))
+		require.Equal(t, 2, countSampled(LevelRead, 10))
+	})
+
+	t.Run("authentications and modifications always logged", func(t *testing.T) {
+		audit.SetSampleRates(map[string]int{"auth": 100, "mod": 100})
+
+		require.Equal(t, 10, countSampled(LevelAuth, 10))
+		require.Equal(t, 10, countSampled(LevelModify, 10))
+	})
+
+	t.Run("impersonations always logged", func(t *testing.T) {
+		audit.SetSampleRates(map[string]int{"read": 100})
+		rec := &Record{}
+		rec.AddMeta(KeyImpersonatedBy, "admin-id")
+
+		for i := 0; i < 10; i++ {
+			require.True(t, audit.sampled(LevelRead, rec))
+		}
+	})
+}
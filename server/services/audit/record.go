@@ -38,6 +38,17 @@ func (rec *Record) Fail() {
 	rec.Status = Fail
 }
 
+// isImpersonation returns true if the record is of an impersonation, or of
+// a request made while impersonating a user.
+func (rec *Record) isImpersonation() bool {
+	for _, meta := range rec.Meta {
+		if meta.K == KeyImpersonatedBy {
+			return true
+		}
+	}
+	return false
+}
+
 // AddMeta adds a single name/value pair to this audit record's metadata.
 func (rec *Record) AddMeta(name string, val interface{}) {
 	if rec.Meta == nil {
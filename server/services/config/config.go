@@ -1,7 +1,12 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -11,6 +16,14 @@ const (
 	DefaultPort       = 8000
 )
 
+const (
+	// BlockHistoryModeSnapshot records every version of a block in full.
+	BlockHistoryModeSnapshot = "snapshot"
+	// BlockHistoryModeDiff records only the fields changed by the updates
+	// of a block.
+	BlockHistoryModeDiff = "diff"
+)
+
 type AmazonS3Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
@@ -24,6 +37,19 @@ type AmazonS3Config struct {
 	Trace           bool
 }
 
+// ErrSameSiteNoneRequiresSecure is returned when the session cookie is
+// configured with SameSite=None but without the Secure attribute.
+var ErrSameSiteNoneRequiresSecure = errors.New("session_cookie_samesite none requires secureCookie to be enabled")
+
+// InsertValidationWebhookConfig is a webhook called with the blocks about to
+// be inserted in a workspace, which can reject the insert. Webhooks are
+// configured per workspace, keyed by workspace ID.
+type InsertValidationWebhookConfig struct {
+	URL            string
+	TimeoutSeconds int
+	FailOpen       bool
+}
+
 // Configuration is the app configuration stored in a json file.
 type Configuration struct {
 	ServerRoot               string            `json:"serverRoot" mapstructure:"serverRoot"`
@@ -44,6 +70,7 @@ type Configuration struct {
 	Secret                   string            `json:"secret" mapstructure:"secret"`
 	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
 	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
+	ImpersonationExpireTime  int64             `json:"impersonation_expire_time" mapstructure:"impersonation_expire_time"`
 	LocalOnly                bool              `json:"localonly" mapstructure:"localonly"`
 	EnableLocalMode          bool              `json:"enableLocalMode" mapstructure:"enableLocalMode"`
 	LocalModeSocketLocation  string            `json:"localModeSocketLocation" mapstructure:"localModeSocketLocation"`
@@ -58,8 +85,67 @@ type Configuration struct {
 	AuditCfgFile string `json:"audit_cfg_file" mapstructure:"audit_cfg_file"`
 	AuditCfgJSON string `json:"audit_cfg_json" mapstructure:"audit_cfg_json"`
 
+	AuditSampleRates map[string]int `json:"audit_sample_rates" mapstructure:"audit_sample_rates"`
+
 	NotifyFreqCardSeconds  int `json:"notify_freq_card_seconds" mapstructure:"notify_freq_card_seconds"`
 	NotifyFreqBoardSeconds int `json:"notify_freq_board_seconds" mapstructure:"notify_freq_board_seconds"`
+
+	MaxBlocksPerBoard int   `json:"max_blocks_per_board" mapstructure:"max_blocks_per_board"`
+	MaxFileSize       int64 `json:"maxfilesize" mapstructure:"maxfilesize"`
+
+	MaxBlocksPerInsert    int `json:"max_blocks_per_insert" mapstructure:"max_blocks_per_insert"`
+	InsertBlocksBatchSize int `json:"insert_blocks_batch_size" mapstructure:"insert_blocks_batch_size"`
+
+	MaxRequestBodySize int64 `json:"max_request_body_size" mapstructure:"max_request_body_size"`
+
+	RateLimitPerMinute          int `json:"rate_limit_per_minute" mapstructure:"rate_limit_per_minute"`
+	ReadTokenRateLimitPerMinute int `json:"read_token_rate_limit_per_minute" mapstructure:"read_token_rate_limit_per_minute"`
+
+	CardCountWarningThreshold int `json:"card_count_warning_threshold" mapstructure:"card_count_warning_threshold"`
+
+	WebhookInsertValidation map[string]InsertValidationWebhookConfig `json:"webhook_insert_validation" mapstructure:"webhook_insert_validation"`
+
+	SessionCookieHTTPOnly bool   `json:"session_cookie_httponly" mapstructure:"session_cookie_httponly"`
+	SessionCookieSameSite string `json:"session_cookie_samesite" mapstructure:"session_cookie_samesite"`
+	SessionCookieDomain   string `json:"session_cookie_domain" mapstructure:"session_cookie_domain"`
+	SessionCookiePath     string `json:"session_cookie_path" mapstructure:"session_cookie_path"`
+
+	ReadOnlyMode bool `json:"readOnlyMode" mapstructure:"readOnlyMode"`
+
+	PDFConverter string `json:"pdf_converter" mapstructure:"pdf_converter"`
+
+	RoutePrefix string `json:"route_prefix" mapstructure:"route_prefix"`
+
+	DisableTemplateSeeding bool     `json:"disable_template_seeding" mapstructure:"disable_template_seeding"`
+	SeedTemplates          []string `json:"seed_templates" mapstructure:"seed_templates"`
+
+	AllowedEmailDomains []string `json:"allowed_email_domains" mapstructure:"allowed_email_domains"`
+
+	SingleUserID   string `json:"single_user_id" mapstructure:"single_user_id"`
+	SingleUserName string `json:"single_user_name" mapstructure:"single_user_name"`
+
+	BlockUpdateCoalesceMS int64 `json:"block_update_coalesce_ms" mapstructure:"block_update_coalesce_ms"`
+
+	SecondaryFilesDriver   string         `json:"secondary_filesdriver" mapstructure:"secondary_filesdriver"`
+	SecondaryFilesS3Config AmazonS3Config `json:"secondary_filess3config" mapstructure:"secondary_filess3config"`
+	SecondaryFilesPath     string         `json:"secondary_filespath" mapstructure:"secondary_filespath"`
+
+	RejectUnknownBlockTypes bool `json:"reject_unknown_block_types" mapstructure:"reject_unknown_block_types"`
+
+	PropertyValueMaxLengths map[string]int `json:"property_value_max_lengths" mapstructure:"property_value_max_lengths"`
+
+	BlockHistoryMode string `json:"block_history_mode" mapstructure:"block_history_mode"`
+
+	BoardArchiveAfterDays  int `json:"board_archive_after_days" mapstructure:"board_archive_after_days"`
+	BoardArchiveNoticeDays int `json:"board_archive_notice_days" mapstructure:"board_archive_notice_days"`
+
+	BlockHistoryMaxAgeDays  int `json:"block_history_max_age_days" mapstructure:"block_history_max_age_days"`
+	BlockHistoryMaxVersions int `json:"block_history_max_versions" mapstructure:"block_history_max_versions"`
+
+	ImportBlockTypeMappings map[string]map[string]string `json:"import_block_type_mappings" mapstructure:"import_block_type_mappings"`
+
+	WebhookAllowedHosts []string `json:"webhook_allowed_hosts" mapstructure:"webhook_allowed_hosts"`
+	WebhookDeniedHosts  []string `json:"webhook_denied_hosts" mapstructure:"webhook_denied_hosts"`
 }
 
 // ReadConfigFile read the configuration from the filesystem.
@@ -84,8 +170,9 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("Telemetry", true)
 	viper.SetDefault("TelemetryID", "")
 	viper.SetDefault("WebhookUpdate", nil)
-	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
-	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
+	viper.SetDefault("SessionExpireTime", 60*60*24*30)   // 30 days session lifetime
+	viper.SetDefault("SessionRefreshTime", 60*60*5)      // 5 minutes session refresh
+	viper.SetDefault("impersonation_expire_time", 60*60) // 1 hour impersonation session lifetime
 	viper.SetDefault("LocalOnly", false)
 	viper.SetDefault("EnableLocalMode", false)
 	viper.SetDefault("LocalModeSocketLocation", "/var/tmp/focalboard_local.socket")
@@ -94,6 +181,45 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("AuthMode", "native")
 	viper.SetDefault("NotifyFreqCardSeconds", 120)    // 2 minutes after last card edit
 	viper.SetDefault("NotifyFreqBoardSeconds", 86400) // 1 day after last card edit
+	viper.SetDefault("max_blocks_per_board", 100000)  // 0 disables the limit
+	viper.SetDefault("MaxFileSize", 50*1024*1024)     // 50 MB, 0 disables the limit
+	viper.SetDefault("max_blocks_per_insert", 0)      // 0 disables the limit
+	viper.SetDefault("insert_blocks_batch_size", 1000)
+	viper.SetDefault("max_request_body_size", 10*1024*1024)
+	viper.SetDefault("rate_limit_per_minute", 0)            // 0 disables the limit
+	viper.SetDefault("read_token_rate_limit_per_minute", 0) // 0 disables the limit
+	viper.SetDefault("audit_sample_rates", map[string]int{})
+	viper.SetDefault("card_count_warning_threshold", 10000)
+	viper.SetDefault("webhook_insert_validation", map[string]InsertValidationWebhookConfig{})
+	viper.SetDefault("session_cookie_httponly", true)
+	viper.SetDefault("session_cookie_samesite", "lax")
+	viper.SetDefault("session_cookie_domain", "")
+	viper.SetDefault("session_cookie_path", "/")
+	viper.SetDefault("readOnlyMode", false)
+	viper.SetDefault("pdf_converter", "")
+	viper.SetDefault("route_prefix", "")
+	viper.SetDefault("disable_template_seeding", false)
+	viper.SetDefault("seed_templates", []string{})
+	viper.SetDefault("allowed_email_domains", []string{})
+	viper.SetDefault("single_user_id", "")
+	viper.SetDefault("single_user_name", "")
+	viper.SetDefault("block_update_coalesce_ms", 0) // 0 records every update in the history
+	viper.SetDefault("secondary_filesdriver", "")   // no failover if empty
+	viper.SetDefault("property_value_max_lengths", map[string]int{
+		"text":   10000,
+		"url":    2048,
+		"email":  320,
+		"phone":  64,
+		"number": 64,
+	})
+	viper.SetDefault("block_history_mode", BlockHistoryModeSnapshot)
+	viper.SetDefault("board_archive_after_days", 0) // 0 disables the archival of inactive boards
+	viper.SetDefault("board_archive_notice_days", 7)
+	viper.SetDefault("block_history_max_age_days", 0) // 0 keeps the history of the blocks forever
+	viper.SetDefault("block_history_max_versions", 0) // 0 keeps all the versions of the blocks
+	viper.SetDefault("import_block_type_mappings", map[string]map[string]string{})
+	viper.SetDefault("webhook_allowed_hosts", []string{}) // empty allows any host but the private addresses
+	viper.SetDefault("webhook_denied_hosts", []string{})
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -107,12 +233,74 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 		return nil, err
 	}
 
+	if _, err = configuration.SessionCookieSameSiteMode(); err != nil {
+		return nil, err
+	}
+
+	if _, err = configuration.BlockHistoryDiffs(); err != nil {
+		return nil, err
+	}
+
+	if _, err = configuration.ImportBlockTypeMappingsByVersion(); err != nil {
+		return nil, err
+	}
+
 	log.Println("readConfigFile")
 	log.Printf("%+v", removeSecurityData(configuration))
 
 	return &configuration, nil
 }
 
+// BlockHistoryDiffs returns true if the updates of the blocks are recorded
+// in their history as the fields they change, false if they are recorded as
+// full snapshots.
+func (c *Configuration) BlockHistoryDiffs() (bool, error) {
+	switch strings.ToLower(c.BlockHistoryMode) {
+	case "", BlockHistoryModeSnapshot:
+		return false, nil
+	case BlockHistoryModeDiff:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid block_history_mode %q, must be one of snapshot or diff", c.BlockHistoryMode)
+	}
+}
+
+// ImportBlockTypeMappingsByVersion returns the mappings of the legacy block
+// types to their current names, keyed by the last schema version the legacy
+// types were used in.
+func (c *Configuration) ImportBlockTypeMappingsByVersion() (map[int64]map[string]string, error) {
+	mappings := make(map[int64]map[string]string, len(c.ImportBlockTypeMappings))
+	for key, mapping := range c.ImportBlockTypeMappings {
+		version, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || version < 0 {
+			return nil, fmt.Errorf("invalid import_block_type_mappings schema version %q, must be a non-negative number", key)
+		}
+		mappings[version] = mapping
+	}
+	return mappings, nil
+}
+
+// SessionCookieSameSiteMode returns the SameSite attribute of the session
+// cookie. SameSite=None is only accepted along with SecureCookie, as
+// browsers reject it on insecure cookies.
+func (c *Configuration) SessionCookieSameSiteMode() (http.SameSite, error) {
+	switch strings.ToLower(c.SessionCookieSameSite) {
+	case "", "default":
+		return http.SameSiteDefaultMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		if !c.SecureCookie {
+			return 0, ErrSameSiteNoneRequiresSecure
+		}
+		return http.SameSiteNoneMode, nil
+	default:
+		return 0, fmt.Errorf("invalid session_cookie_samesite %q, must be one of default, lax, strict or none", c.SessionCookieSameSite)
+	}
+}
+
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
 	return clean
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readConfig reads a config.json holding only the given settings.
+func readConfig(t *testing.T, settings string) *Configuration {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(settings), 0600))
+
+	config, err := ReadConfigFile(configFile)
+	require.NoError(t, err)
+	return config
+}
+
+func TestReadConfigFileDefaults(t *testing.T) {
+	config := readConfig(t, `{"port": 8000}`)
+
+	require.Equal(t, 100000, config.MaxBlocksPerBoard)
+	require.True(t, config.SessionCookieHTTPOnly)
+	require.Equal(t, "lax", config.SessionCookieSameSite)
+	require.Equal(t, "/", config.SessionCookiePath)
+	require.Equal(t, int64(10*1024*1024), config.MaxRequestBodySize)
+	require.Equal(t, 10000, config.CardCountWarningThreshold)
+	require.Equal(t, 1000, config.InsertBlocksBatchSize)
+	require.Equal(t, int64(60*60), config.ImpersonationExpireTime)
+	require.Equal(t, map[string]int{
+		"text":   10000,
+		"url":    2048,
+		"email":  320,
+		"phone":  64,
+		"number": 64,
+	}, config.PropertyValueMaxLengths)
+}
+
+func TestReadConfigFileSettings(t *testing.T) {
+	config := readConfig(t, `{"property_value_max_lengths": {"text": 100}}`)
+
+	require.Equal(t, map[string]int{"text": 100}, config.PropertyValueMaxLengths)
+}
+
+func TestReadConfigFileImportBlockTypeMappings(t *testing.T) {
+	t.Run("valid schema versions", func(t *testing.T) {
+		config := readConfig(t, `{"import_block_type_mappings": {"0": {"rule": "divider"}, "2": {"todo": "checkbox"}}}`)
+
+		mappings, err := config.ImportBlockTypeMappingsByVersion()
+		require.NoError(t, err)
+		require.Equal(t, map[int64]map[string]string{0: {"rule": "divider"}, 2: {"todo": "checkbox"}}, mappings)
+	})
+
+	for _, key := range []string{"v1", "-1"} {
+		t.Run("invalid schema version "+key, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "config.json")
+			settings := `{"import_block_type_mappings": {"` + key + `": {"rule": "divider"}}}`
+			require.NoError(t, os.WriteFile(configFile, []byte(settings), 0600))
+
+			_, err := ReadConfigFile(configFile)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "must be a non-negative number")
+		})
+	}
+}
@@ -14,9 +14,9 @@ import (
 
 var atMentionRegexp = regexp.MustCompile(`\B@[[:alnum:]][[:alnum:]\.\-_:]*`)
 
-// extractMentions extracts any mentions in the specified block and returns
+// ExtractMentions extracts any mentions in the specified block and returns
 // a slice of usernames.
-func extractMentions(block *model.Block) map[string]struct{} {
+func ExtractMentions(block *model.Block) map[string]struct{} {
 	mentions := make(map[string]struct{})
 	if block == nil || !strings.Contains(block.Title, "@") {
 		return mentions
@@ -84,12 +84,12 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 		return nil
 	}
 
-	mentions := extractMentions(evt.BlockChanged)
+	mentions := ExtractMentions(evt.BlockChanged)
 	if len(mentions) == 0 {
 		return nil
 	}
 
-	oldMentions := extractMentions(evt.BlockOld)
+	oldMentions := ExtractMentions(evt.BlockOld)
 	merr := merror.New()
 
 	b.mux.RLock()
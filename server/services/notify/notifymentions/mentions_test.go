@@ -12,7 +12,7 @@ import (
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
 )
 
-func Test_extractMentions(t *testing.T) {
+func TestExtractMentions(t *testing.T) {
 	tests := []struct {
 		name  string
 		block *model.Block
@@ -28,8 +28,8 @@ func Test_extractMentions(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := extractMentions(tt.block); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("extractMentions() = %v, want %v", got, tt.want)
+			if got := ExtractMentions(tt.block); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractMentions() = %v, want %v", got, tt.want)
 			}
 		})
 	}
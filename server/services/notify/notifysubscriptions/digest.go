@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	digestCheckInterval = time.Minute
+)
+
+// getNotifyMode returns how a subscriber is notified of the changes to a board.
+// Channels and users without settings for the board are notified instantly.
+func (n *notifier) getNotifyMode(c store.Container, boardID string, sub *model.Subscriber) model.NotifyMode {
+	if sub.SubscriberType != model.SubTypeUser {
+		return model.NotifyModeInstant
+	}
+
+	settings, err := n.store.GetNotificationSettings(c, boardID, sub.SubscriberID)
+	if err != nil {
+		if !n.store.IsErrNotFound(err) {
+			n.logger.Error("Cannot get notification settings, notifying instantly",
+				mlog.String("board_id", boardID),
+				mlog.String("subscriber_id", sub.SubscriberID),
+				mlog.Err(err),
+			)
+		}
+		return model.NotifyModeInstant
+	}
+	return settings.Mode
+}
+
+// queueDigest holds back a notification until the next digest of the user.
+func (n *notifier) queueDigest(workspaceID, boardID, userID string, mode model.NotifyMode, attachments []*mm_model.SlackAttachment) error {
+	data, err := json.Marshal(attachments)
+	if err != nil {
+		return err
+	}
+
+	item := &model.NotificationDigestItem{
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		UserID:      userID,
+		Attachments: string(data),
+		DeliverAt:   utils.GetMillisForTime(nextDigestAt(time.Now(), mode)),
+	}
+
+	n.logger.Debug("notifySubscribers - queue digest",
+		mlog.String("board_id", boardID),
+		mlog.String("subscriber_id", userID),
+		mlog.String("mode", string(mode)),
+	)
+	return n.store.AddNotificationDigestItem(item)
+}
+
+// nextDigestAt returns when the next digest of a mode is sent: at the start
+// of the next hour for hourly digests, and at midnight UTC for daily ones.
+func nextDigestAt(now time.Time, mode model.NotifyMode) time.Time {
+	if mode == model.NotifyModeDaily {
+		return now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+	return now.Truncate(time.Hour).Add(time.Hour)
+}
+
+type digestKey struct {
+	workspaceID string
+	userID      string
+}
+
+// sendDigests delivers the queued notifications that are due, in one
+// notification per user.
+func (n *notifier) sendDigests() {
+	items, err := n.store.GetDueNotificationDigestItems(utils.GetMillis())
+	if err != nil {
+		n.logger.Error("Cannot fetch due notification digests", mlog.Err(err))
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	keys := []digestKey{}
+	digests := map[digestKey][]*mm_model.SlackAttachment{}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+
+		var attachments []*mm_model.SlackAttachment
+		if err := json.Unmarshal([]byte(item.Attachments), &attachments); err != nil {
+			n.logger.Error("Cannot decode notification digest item",
+				mlog.String("id", item.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		key := digestKey{workspaceID: item.WorkspaceID, userID: item.UserID}
+		if _, ok := digests[key]; !ok {
+			keys = append(keys, key)
+		}
+		digests[key] = append(digests[key], attachments...)
+	}
+
+	for _, key := range keys {
+		n.logger.Debug("sendDigests - deliver",
+			mlog.String("subscriber_id", key.userID),
+			mlog.Int("attachment_count", len(digests[key])),
+		)
+		err := n.delivery.SubscriptionDeliverSlackAttachments(key.workspaceID, key.userID, model.SubTypeUser, digests[key])
+		if err != nil {
+			n.logger.Error("Cannot deliver notification digest",
+				mlog.String("subscriber_id", key.userID),
+				mlog.Err(err),
+			)
+		}
+	}
+
+	// the digests were at least attempted, don't send them again.
+	if err := n.store.DeleteNotificationDigestItems(ids); err != nil {
+		n.logger.Error("Cannot delete delivered notification digests", mlog.Err(err))
+	}
+}
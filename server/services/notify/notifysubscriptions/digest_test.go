@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestNextDigestAt(t *testing.T) {
+	now := time.Date(2021, 12, 7, 15, 42, 10, 0, time.UTC)
+
+	t.Run("hourly", func(t *testing.T) {
+		assert.Equal(t, time.Date(2021, 12, 7, 16, 0, 0, 0, time.UTC), nextDigestAt(now, model.NotifyModeHourly).UTC())
+	})
+
+	t.Run("daily", func(t *testing.T) {
+		assert.Equal(t, time.Date(2021, 12, 8, 0, 0, 0, 0, time.UTC), nextDigestAt(now, model.NotifyModeDaily).UTC())
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -225,6 +226,35 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			mode := n.getNotifyMode(c, board.ID, sub)
+			if mode == model.NotifyModeOff {
+				n.logger.Debug("notifySubscribers - deliver, skipping subscriber with notifications off",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+				)
+				continue
+			}
+
+			subAttachments, err := n.getSubscriberAttachments(c, sub, diffs, attachments, opts)
+			if err != nil {
+				merr.Append(fmt.Errorf("cannot filter muted cards for subscriber %s: %w", sub.SubscriberID, err))
+				continue
+			}
+			if len(subAttachments) == 0 {
+				n.logger.Debug("notifySubscribers - deliver, skipping subscriber with muted cards",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+				)
+				continue
+			}
+
+			if mode.IsDigest() {
+				if err = n.queueDigest(hint.WorkspaceID, board.ID, sub.SubscriberID, mode, subAttachments); err != nil {
+					merr.Append(fmt.Errorf("cannot queue digest notification for subscriber %s: %w", sub.SubscriberID, err))
+				}
+				continue
+			}
+
 			n.logger.Debug("notifySubscribers - deliver",
 				mlog.Any("hint", hint),
 				mlog.String("modified_by_id", hint.ModifiedByID),
@@ -232,7 +262,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				mlog.String("subscriber_type", string(sub.SubscriberType)),
 			)
 
-			if err = n.delivery.SubscriptionDeliverSlackAttachments(hint.WorkspaceID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			if err = n.delivery.SubscriptionDeliverSlackAttachments(hint.WorkspaceID, sub.SubscriberID, sub.SubscriberType, subAttachments); err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
 			}
@@ -265,3 +295,59 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 
 	return merr.ErrorOrNil()
 }
+
+// getSubscriberAttachments returns the notification of a subscriber, without
+// the changes to the cards the subscriber muted. The notification shared by
+// all the subscribers is returned if none of its cards is muted.
+func (n *notifier) getSubscriberAttachments(c store.Container, sub *model.Subscriber, diffs []*Diff,
+	attachments []*mm_model.SlackAttachment, opts DiffConvOpts) ([]*mm_model.SlackAttachment, error) {
+	if sub.SubscriberType != model.SubTypeUser {
+		return attachments, nil
+	}
+
+	mutes, err := n.store.GetMutedCards(c, sub.SubscriberID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mutes) == 0 {
+		return attachments, nil
+	}
+
+	muted := make(map[string]bool, len(mutes))
+	for _, mute := range mutes {
+		muted[mute.CardID] = true
+	}
+
+	unmuted := withoutMutedCards(diffs, muted)
+	if len(unmuted) == len(diffs) {
+		return attachments, nil
+	}
+	if len(unmuted) == 0 {
+		return nil, nil
+	}
+	return Diffs2SlackAttachments(unmuted, opts)
+}
+
+// withoutMutedCards returns the diffs that aren't changes to a muted card.
+func withoutMutedCards(diffs []*Diff, muted map[string]bool) []*Diff {
+	result := make([]*Diff, 0, len(diffs))
+	for _, d := range diffs {
+		if cardID := diffCardID(d); cardID != "" && muted[cardID] {
+			continue
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+// diffCardID returns the ID of the card a diff is a change to, either the
+// card itself or a block in it, or an empty string if it isn't in a card.
+func diffCardID(d *Diff) string {
+	if d.BlockType == model.TypeCard && d.NewBlock != nil {
+		return d.NewBlock.ID
+	}
+	if d.Card != nil && d.Card.Type == model.TypeCard {
+		return d.Card.ID
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestWithoutMutedCards(t *testing.T) {
+	board := &model.Block{ID: "board-id", Type: model.TypeBoard}
+	card1 := &model.Block{ID: "card-1", Type: model.TypeCard}
+	card2 := &model.Block{ID: "card-2", Type: model.TypeCard}
+	text := &model.Block{ID: "text-id", Type: model.TypeText}
+
+	boardDiff := &Diff{Board: board, BlockType: model.TypeBoard, NewBlock: board}
+	card1Diff := &Diff{Board: board, Card: board, BlockType: model.TypeCard, NewBlock: card1}
+	card2Diff := &Diff{Board: board, Card: card2, BlockType: model.TypeCard, NewBlock: card2}
+	textDiff := &Diff{Board: board, Card: card1, BlockType: model.TypeText, NewBlock: text}
+	diffs := []*Diff{boardDiff, card1Diff, card2Diff, textDiff}
+
+	t.Run("no muted cards", func(t *testing.T) {
+		assert.Equal(t, diffs, withoutMutedCards(diffs, map[string]bool{}))
+	})
+
+	t.Run("muted card", func(t *testing.T) {
+		result := withoutMutedCards(diffs, map[string]bool{"card-1": true})
+		assert.Equal(t, []*Diff{boardDiff, card2Diff}, result)
+	})
+}
@@ -26,5 +26,11 @@ type Store interface {
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
 
+	GetNotificationSettings(c store.Container, boardID string, userID string) (*model.NotificationSettings, error)
+	AddNotificationDigestItem(item *model.NotificationDigestItem) error
+	GetDueNotificationDigestItems(deliverAt int64) ([]*model.NotificationDigestItem, error)
+	DeleteNotificationDigestItems(ids []string) error
+	GetMutedCards(c store.Container, userID string) ([]*model.CardMute, error)
+
 	IsErrNotFound(err error) bool
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/scheduler"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/ws"
 	"github.com/wiggin77/merror"
@@ -35,6 +36,7 @@ type Backend struct {
 	store                  Store
 	delivery               SubscriptionDelivery
 	notifier               *notifier
+	digestTask             *scheduler.ScheduledTask
 	wsAdapter              ws.Adapter
 	logger                 *mlog.Logger
 	notifyFreqCardSeconds  int
@@ -59,12 +61,17 @@ func (b *Backend) Start() error {
 		mlog.Int("freq_board", b.notifyFreqBoardSeconds),
 	)
 	b.notifier.start()
+	b.digestTask = scheduler.CreateRecurringTask("notificationDigests", b.notifier.sendDigests, digestCheckInterval)
 	return nil
 }
 
 func (b *Backend) ShutDown() error {
 	b.logger.Debug("Stopping subscriptions backend")
 	b.notifier.stop()
+	if b.digestTask != nil {
+		b.digestTask.Cancel()
+		b.digestTask = nil
+	}
 	_ = b.logger.Flush()
 	return nil
 }
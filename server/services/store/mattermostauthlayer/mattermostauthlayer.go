@@ -305,6 +305,41 @@ func (s *MattermostAuthLayer) GetUsersByWorkspace(workspaceID string) ([]*model.
 	return users, nil
 }
 
+func (s *MattermostAuthLayer) GetUsersByIDs(userIDs []string) ([]*model.User, error) {
+	if len(userIDs) == 0 {
+		return []*model.User{}, nil
+	}
+
+	usersByID, err := s.getUsersByCondition(sq.Eq{"id": userIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*model.User, 0, len(usersByID))
+	for _, user := range usersByID {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (s *MattermostAuthLayer) GetWorkspaceMemberCount(workspaceID string) (int, error) {
+	query := s.getQueryBuilder().
+		Select("count(*)").
+		From("Users").
+		Join("ChannelMembers ON ChannelMembers.UserID = Users.ID").
+		Where(sq.Eq{"Users.deleteAt": 0}).
+		Where(sq.Eq{"ChannelMembers.ChannelId": workspaceID})
+	row := query.QueryRow()
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (s *MattermostAuthLayer) usersFromRows(rows *sql.Rows) ([]*model.User, error) {
 	users := []*model.User{}
 
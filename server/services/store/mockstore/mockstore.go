@@ -36,6 +36,20 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddNotificationDigestItem mocks base method.
+func (m *MockStore) AddNotificationDigestItem(arg0 *model.NotificationDigestItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotificationDigestItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotificationDigestItem indicates an expected call of AddNotificationDigestItem.
+func (mr *MockStoreMockRecorder) AddNotificationDigestItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotificationDigestItem", reflect.TypeOf((*MockStore)(nil).AddNotificationDigestItem), arg0)
+}
+
 // CleanUpSessions mocks base method.
 func (m *MockStore) CleanUpSessions(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -50,6 +64,20 @@ func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
 }
 
+// CreateBoardAPIKey mocks base method.
+func (m *MockStore) CreateBoardAPIKey(arg0 store.Container, arg1 *model.BoardAPIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardAPIKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBoardAPIKey indicates an expected call of CreateBoardAPIKey.
+func (mr *MockStoreMockRecorder) CreateBoardAPIKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardAPIKey", reflect.TypeOf((*MockStore)(nil).CreateBoardAPIKey), arg0, arg1)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -107,6 +135,49 @@ func (mr *MockStoreMockRecorder) DeleteBlock(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStore)(nil).DeleteBlock), arg0, arg1, arg2)
 }
 
+// DeleteBoardAPIKey mocks base method.
+func (m *MockStore) DeleteBoardAPIKey(arg0 store.Container, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardAPIKey", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardAPIKey indicates an expected call of DeleteBoardAPIKey.
+func (mr *MockStoreMockRecorder) DeleteBoardAPIKey(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardAPIKey", reflect.TypeOf((*MockStore)(nil).DeleteBoardAPIKey), arg0, arg1, arg2)
+}
+
+// DeleteBoardBlocks mocks base method.
+func (m *MockStore) DeleteBoardBlocks(arg0 store.Container, arg1 string, arg2 []string, arg3 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardBlocks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBoardBlocks indicates an expected call of DeleteBoardBlocks.
+func (mr *MockStoreMockRecorder) DeleteBoardBlocks(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardBlocks), arg0, arg1, arg2, arg3)
+}
+
+// DeleteNotificationDigestItems mocks base method.
+func (m *MockStore) DeleteNotificationDigestItems(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationDigestItems", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationDigestItems indicates an expected call of DeleteNotificationDigestItems.
+func (mr *MockStoreMockRecorder) DeleteNotificationDigestItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationDigestItems", reflect.TypeOf((*MockStore)(nil).DeleteNotificationDigestItems), arg0)
+}
+
 // DeleteNotificationHint mocks base method.
 func (m *MockStore) DeleteNotificationHint(arg0 store.Container, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -194,6 +265,36 @@ func (mr *MockStoreMockRecorder) GetBlock(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockStore)(nil).GetBlock), arg0, arg1)
 }
 
+// GetBlockCountWithRootID mocks base method.
+func (m *MockStore) GetBlockCountWithRootID(arg0 store.Container, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountWithRootID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountWithRootID indicates an expected call of GetBlockCountWithRootID.
+func (mr *MockStoreMockRecorder) GetBlockCountWithRootID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountWithRootID", reflect.TypeOf((*MockStore)(nil).GetBlockCountWithRootID), arg0, arg1)
+}
+
+// GetBlockCountWithRootIDAndType mocks base method.
+func (m *MockStore) GetBlockCountWithRootIDAndType(arg0 store.Container, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountWithRootIDAndType", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountWithRootIDAndType indicates an expected call of GetBlockCountWithRootIDAndType.
+func (mr *MockStoreMockRecorder) GetBlockCountWithRootIDAndType(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountWithRootIDAndType", reflect.TypeOf((*MockStore)(nil).GetBlockCountWithRootIDAndType), arg0, arg1, arg2)
+}
+
 // GetBlockCountsByType mocks base method.
 func (m *MockStore) GetBlockCountsByType() (map[string]int64, error) {
 	m.ctrl.T.Helper()
@@ -254,6 +355,36 @@ func (mr *MockStoreMockRecorder) GetBlocksWithParentAndType(arg0, arg1, arg2 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndType), arg0, arg1, arg2)
 }
 
+// GetBlocksWithParentAndTypePage mocks base method.
+func (m *MockStore) GetBlocksWithParentAndTypePage(arg0 store.Container, arg1, arg2 string, arg3 model.QueryPageOptions) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithParentAndTypePage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithParentAndTypePage indicates an expected call of GetBlocksWithParentAndTypePage.
+func (mr *MockStoreMockRecorder) GetBlocksWithParentAndTypePage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndTypePage", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndTypePage), arg0, arg1, arg2, arg3)
+}
+
+// GetBlocksWithParentAndTypes mocks base method.
+func (m *MockStore) GetBlocksWithParentAndTypes(arg0 store.Container, arg1 string, arg2 []string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithParentAndTypes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithParentAndTypes indicates an expected call of GetBlocksWithParentAndTypes.
+func (mr *MockStoreMockRecorder) GetBlocksWithParentAndTypes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithParentAndTypes", reflect.TypeOf((*MockStore)(nil).GetBlocksWithParentAndTypes), arg0, arg1, arg2)
+}
+
 // GetBlocksWithRootID mocks base method.
 func (m *MockStore) GetBlocksWithRootID(arg0 store.Container, arg1 string) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -284,6 +415,66 @@ func (mr *MockStoreMockRecorder) GetBlocksWithType(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithType", reflect.TypeOf((*MockStore)(nil).GetBlocksWithType), arg0, arg1)
 }
 
+// GetBlocksWithTypes mocks base method.
+func (m *MockStore) GetBlocksWithTypes(arg0 store.Container, arg1 []string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksWithTypes", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksWithTypes indicates an expected call of GetBlocksWithTypes.
+func (mr *MockStoreMockRecorder) GetBlocksWithTypes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksWithTypes", reflect.TypeOf((*MockStore)(nil).GetBlocksWithTypes), arg0, arg1)
+}
+
+// GetBoardAPIKeyByToken mocks base method.
+func (m *MockStore) GetBoardAPIKeyByToken(arg0 string) (*model.BoardAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAPIKeyByToken", arg0)
+	ret0, _ := ret[0].(*model.BoardAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAPIKeyByToken indicates an expected call of GetBoardAPIKeyByToken.
+func (mr *MockStoreMockRecorder) GetBoardAPIKeyByToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAPIKeyByToken", reflect.TypeOf((*MockStore)(nil).GetBoardAPIKeyByToken), arg0)
+}
+
+// GetBoardAPIKeys mocks base method.
+func (m *MockStore) GetBoardAPIKeys(arg0 store.Container, arg1 string) ([]*model.BoardAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAPIKeys", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAPIKeys indicates an expected call of GetBoardAPIKeys.
+func (mr *MockStoreMockRecorder) GetBoardAPIKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAPIKeys", reflect.TypeOf((*MockStore)(nil).GetBoardAPIKeys), arg0, arg1)
+}
+
+// GetBoardAcknowledgments mocks base method.
+func (m *MockStore) GetBoardAcknowledgments(arg0 store.Container, arg1 string) ([]*model.BoardAcknowledgment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardAcknowledgments", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardAcknowledgment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardAcknowledgments indicates an expected call of GetBoardAcknowledgments.
+func (mr *MockStoreMockRecorder) GetBoardAcknowledgments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAcknowledgments", reflect.TypeOf((*MockStore)(nil).GetBoardAcknowledgments), arg0, arg1)
+}
+
 // GetBoardAndCard mocks base method.
 func (m *MockStore) GetBoardAndCard(arg0 store.Container, arg1 *model.Block) (*model.Block, *model.Block, error) {
 	m.ctrl.T.Helper()
@@ -316,6 +507,156 @@ func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0, arg1)
 }
 
+// GetBoardCardStats mocks base method.
+func (m *MockStore) GetBoardCardStats(arg0 store.Container, arg1 string, arg2 model.CardStatsOptions) (*model.BoardStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardCardStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BoardStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardCardStats indicates an expected call of GetBoardCardStats.
+func (mr *MockStoreMockRecorder) GetBoardCardStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCardStats", reflect.TypeOf((*MockStore)(nil).GetBoardCardStats), arg0, arg1, arg2)
+}
+
+// GetBoardHistory mocks base method.
+func (m *MockStore) GetBoardHistory(arg0 store.Container, arg1 string, arg2 model.QueryBlockHistoryOptions) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardHistory indicates an expected call of GetBoardHistory.
+func (mr *MockStoreMockRecorder) GetBoardHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardHistory", reflect.TypeOf((*MockStore)(nil).GetBoardHistory), arg0, arg1, arg2)
+}
+
+// GetBoardsSharingStatus mocks base method.
+func (m *MockStore) GetBoardsSharingStatus(arg0 store.Container, arg1 []string) (map[string]model.SharingStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsSharingStatus", arg0, arg1)
+	ret0, _ := ret[0].(map[string]model.SharingStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsSharingStatus indicates an expected call of GetBoardsSharingStatus.
+func (mr *MockStoreMockRecorder) GetBoardsSharingStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsSharingStatus", reflect.TypeOf((*MockStore)(nil).GetBoardsSharingStatus), arg0, arg1)
+}
+
+// GetCardChildrenWithRootID mocks base method.
+func (m *MockStore) GetCardChildrenWithRootID(arg0 store.Container, arg1 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardChildrenWithRootID", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardChildrenWithRootID indicates an expected call of GetCardChildrenWithRootID.
+func (mr *MockStoreMockRecorder) GetCardChildrenWithRootID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardChildrenWithRootID", reflect.TypeOf((*MockStore)(nil).GetCardChildrenWithRootID), arg0, arg1)
+}
+
+// GetDueNotificationDigestItems mocks base method.
+func (m *MockStore) GetDueNotificationDigestItems(arg0 int64) ([]*model.NotificationDigestItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNotificationDigestItems", arg0)
+	ret0, _ := ret[0].([]*model.NotificationDigestItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNotificationDigestItems indicates an expected call of GetDueNotificationDigestItems.
+func (mr *MockStoreMockRecorder) GetDueNotificationDigestItems(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNotificationDigestItems", reflect.TypeOf((*MockStore)(nil).GetDueNotificationDigestItems), arg0)
+}
+
+// GetEnabledSharingByToken mocks base method.
+func (m *MockStore) GetEnabledSharingByToken(arg0 store.Container, arg1 string) (*model.Sharing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledSharingByToken", arg0, arg1)
+	ret0, _ := ret[0].(*model.Sharing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledSharingByToken indicates an expected call of GetEnabledSharingByToken.
+func (mr *MockStoreMockRecorder) GetEnabledSharingByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledSharingByToken", reflect.TypeOf((*MockStore)(nil).GetEnabledSharingByToken), arg0, arg1)
+}
+
+// GetInactiveBoards mocks base method.
+func (m *MockStore) GetInactiveBoards(arg0 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInactiveBoards", arg0)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInactiveBoards indicates an expected call of GetInactiveBoards.
+func (mr *MockStoreMockRecorder) GetInactiveBoards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInactiveBoards", reflect.TypeOf((*MockStore)(nil).GetInactiveBoards), arg0)
+}
+
+// GetLastActivityByRootIDs mocks base method.
+func (m *MockStore) GetLastActivityByRootIDs(arg0 store.Container, arg1 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastActivityByRootIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastActivityByRootIDs indicates an expected call of GetLastActivityByRootIDs.
+func (mr *MockStoreMockRecorder) GetLastActivityByRootIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastActivityByRootIDs", reflect.TypeOf((*MockStore)(nil).GetLastActivityByRootIDs), arg0, arg1)
+}
+
+// GetLastActivityWithRootID mocks base method.
+func (m *MockStore) GetLastActivityWithRootID(arg0 store.Container, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastActivityWithRootID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastActivityWithRootID indicates an expected call of GetLastActivityWithRootID.
+func (mr *MockStoreMockRecorder) GetLastActivityWithRootID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastActivityWithRootID", reflect.TypeOf((*MockStore)(nil).GetLastActivityWithRootID), arg0, arg1)
+}
+
+// GetMutedCards mocks base method.
+func (m *MockStore) GetMutedCards(arg0 store.Container, arg1 string) ([]*model.CardMute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedCards", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CardMute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedCards indicates an expected call of GetMutedCards.
+func (mr *MockStoreMockRecorder) GetMutedCards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedCards", reflect.TypeOf((*MockStore)(nil).GetMutedCards), arg0, arg1)
+}
+
 // GetNextNotificationHint mocks base method.
 func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -346,6 +687,21 @@ func (mr *MockStoreMockRecorder) GetNotificationHint(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0, arg1)
 }
 
+// GetNotificationSettings mocks base method.
+func (m *MockStore) GetNotificationSettings(arg0 store.Container, arg1, arg2 string) (*model.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationSettings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationSettings indicates an expected call of GetNotificationSettings.
+func (mr *MockStoreMockRecorder) GetNotificationSettings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationSettings", reflect.TypeOf((*MockStore)(nil).GetNotificationSettings), arg0, arg1, arg2)
+}
+
 // GetParentID mocks base method.
 func (m *MockStore) GetParentID(arg0 store.Container, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -601,6 +957,21 @@ func (mr *MockStoreMockRecorder) GetUserWorkspaces(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWorkspaces", reflect.TypeOf((*MockStore)(nil).GetUserWorkspaces), arg0)
 }
 
+// GetUsersByIDs mocks base method.
+func (m *MockStore) GetUsersByIDs(arg0 []string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockStoreMockRecorder) GetUsersByIDs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockStore)(nil).GetUsersByIDs), arg0)
+}
+
 // GetUsersByWorkspace mocks base method.
 func (m *MockStore) GetUsersByWorkspace(arg0 string) ([]*model.User, error) {
 	m.ctrl.T.Helper()
@@ -631,6 +1002,21 @@ func (mr *MockStoreMockRecorder) GetWorkspace(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockStore)(nil).GetWorkspace), arg0)
 }
 
+// GetWorkspaceBlockCountsByType mocks base method.
+func (m *MockStore) GetWorkspaceBlockCountsByType(arg0 store.Container) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBlockCountsByType", arg0)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBlockCountsByType indicates an expected call of GetWorkspaceBlockCountsByType.
+func (mr *MockStoreMockRecorder) GetWorkspaceBlockCountsByType(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBlockCountsByType", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBlockCountsByType), arg0)
+}
+
 // GetWorkspaceCount mocks base method.
 func (m *MockStore) GetWorkspaceCount() (int64, error) {
 	m.ctrl.T.Helper()
@@ -646,6 +1032,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceCount))
 }
 
+// GetWorkspaceMemberCount mocks base method.
+func (m *MockStore) GetWorkspaceMemberCount(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMemberCount", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMemberCount indicates an expected call of GetWorkspaceMemberCount.
+func (mr *MockStoreMockRecorder) GetWorkspaceMemberCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMemberCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceMemberCount), arg0)
+}
+
 // HasWorkspaceAccess mocks base method.
 func (m *MockStore) HasWorkspaceAccess(arg0, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -703,6 +1104,20 @@ func (mr *MockStoreMockRecorder) IsErrNotFound(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotFound", reflect.TypeOf((*MockStore)(nil).IsErrNotFound), arg0)
 }
 
+// MuteCard mocks base method.
+func (m *MockStore) MuteCard(arg0 store.Container, arg1 *model.CardMute) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteCard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteCard indicates an expected call of MuteCard.
+func (mr *MockStoreMockRecorder) MuteCard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteCard", reflect.TypeOf((*MockStore)(nil).MuteCard), arg0, arg1)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 store.Container, arg1 string, arg2 *model.BlockPatch, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -731,6 +1146,21 @@ func (mr *MockStoreMockRecorder) PatchBlocks(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlocks", reflect.TypeOf((*MockStore)(nil).PatchBlocks), arg0, arg1, arg2)
 }
 
+// PruneBlockHistory mocks base method.
+func (m *MockStore) PruneBlockHistory(arg0 int64, arg1 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneBlockHistory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneBlockHistory indicates an expected call of PruneBlockHistory.
+func (mr *MockStoreMockRecorder) PruneBlockHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBlockHistory", reflect.TypeOf((*MockStore)(nil).PruneBlockHistory), arg0, arg1)
+}
+
 // RefreshSession mocks base method.
 func (m *MockStore) RefreshSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -773,6 +1203,20 @@ func (mr *MockStoreMockRecorder) Shutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockStore)(nil).Shutdown))
 }
 
+// UnmuteCard mocks base method.
+func (m *MockStore) UnmuteCard(arg0 store.Container, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteCard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteCard indicates an expected call of UnmuteCard.
+func (mr *MockStoreMockRecorder) UnmuteCard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteCard", reflect.TypeOf((*MockStore)(nil).UnmuteCard), arg0, arg1, arg2)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -843,6 +1287,20 @@ func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1)
 }
 
+// UpsertBoardAcknowledgment mocks base method.
+func (m *MockStore) UpsertBoardAcknowledgment(arg0 store.Container, arg1 *model.BoardAcknowledgment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBoardAcknowledgment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertBoardAcknowledgment indicates an expected call of UpsertBoardAcknowledgment.
+func (mr *MockStoreMockRecorder) UpsertBoardAcknowledgment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBoardAcknowledgment", reflect.TypeOf((*MockStore)(nil).UpsertBoardAcknowledgment), arg0, arg1)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -858,12 +1316,28 @@ func (mr *MockStoreMockRecorder) UpsertNotificationHint(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationHint", reflect.TypeOf((*MockStore)(nil).UpsertNotificationHint), arg0, arg1)
 }
 
+// UpsertNotificationSettings mocks base method.
+func (m *MockStore) UpsertNotificationSettings(arg0 store.Container, arg1 *model.NotificationSettings) (*model.NotificationSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationSettings", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationSettings indicates an expected call of UpsertNotificationSettings.
+func (mr *MockStoreMockRecorder) UpsertNotificationSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationSettings", reflect.TypeOf((*MockStore)(nil).UpsertNotificationSettings), arg0, arg1)
+}
+
 // UpsertSharing mocks base method.
-func (m *MockStore) UpsertSharing(arg0 store.Container, arg1 model.Sharing) error {
+func (m *MockStore) UpsertSharing(arg0 store.Container, arg1 model.Sharing) (*model.Sharing, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpsertSharing", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*model.Sharing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpsertSharing indicates an expected call of UpsertSharing.
@@ -0,0 +1,394 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// blockHistoryFields are the fields of the block history records: the fields
+// of the blocks, followed by whether the record holds only the fields changed
+// since the previous record.
+func (s *SQLStore) blockHistoryFields() []string {
+	return append(s.blockFields(), "fields_diff")
+}
+
+// updateAtRange is the range of update times of the records of a block.
+type updateAtRange struct {
+	min, max int64
+}
+
+// blockHistoryFromRows reads the history records selected with
+// blockHistoryFields. The records holding the changed fields only are
+// returned with all the fields of the block at the time.
+func (s *SQLStore) blockHistoryFromRows(db sq.BaseRunner, c store.Container, rows *sql.Rows) ([]model.Block, error) {
+	results := []model.Block{}
+	insertAts := []string{}
+	diffBlockIDs := map[string]bool{}
+	ranges := map[string]*updateAtRange{}
+
+	for rows.Next() {
+		var fieldsDiff sql.NullBool
+		block, insertAt, err := s.scanBlock(rows, &fieldsDiff)
+		if err != nil {
+			return nil, err
+		}
+
+		if fieldsDiff.Bool {
+			diffBlockIDs[block.ID] = true
+		}
+		if r, ok := ranges[block.ID]; !ok {
+			ranges[block.ID] = &updateAtRange{min: block.UpdateAt, max: block.UpdateAt}
+		} else if block.UpdateAt < r.min {
+			r.min = block.UpdateAt
+		} else if block.UpdateAt > r.max {
+			r.max = block.UpdateAt
+		}
+		results = append(results, block)
+		insertAts = append(insertAts, insertAt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for blockID := range diffBlockIDs {
+		fields, err := s.getBlockHistoryFields(db, c, blockID, ranges[blockID])
+		if err != nil {
+			return nil, err
+		}
+		if !hasBlockHistoryFields(results, insertAts, blockID, fields) {
+			// the update times of the records are out of order, the whole
+			// history is replayed instead
+			if fields, err = s.getBlockHistoryFields(db, c, blockID, nil); err != nil {
+				return nil, err
+			}
+		}
+		for i := range results {
+			if results[i].ID == blockID {
+				results[i].Fields = fields[insertAts[i]]
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// hasBlockHistoryFields returns true if fields holds the fields of all the
+// records of the block among results.
+func hasBlockHistoryFields(results []model.Block, insertAts []string, blockID string, fields map[string]map[string]interface{}) bool {
+	for i := range results {
+		if results[i].ID != blockID {
+			continue
+		}
+		if _, ok := fields[insertAts[i]]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// getBlockHistoryFields returns all the fields of a block at each of its
+// history records, keyed by insertion time, replaying the changed fields
+// recorded since its last full record. If updateAts is set, only the records
+// from the last full record before its start to its end are replayed.
+func (s *SQLStore) getBlockHistoryFields(db sq.BaseRunner, c store.Container, blockID string, updateAts *updateAtRange) (map[string]map[string]interface{}, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"COALESCE(fields, '{}')",
+			s.timestampToCharField("insert_at", "insertAt"),
+			"fields_diff",
+		).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		OrderBy("insert_at")
+
+	if updateAts != nil {
+		from, err := s.getLastFullBlockHistoryRecord(db, c, blockID, updateAts.min)
+		if err != nil {
+			return nil, err
+		}
+		if from != nil {
+			query = query.Where(sq.GtOrEq{"insert_at": from})
+		}
+		query = query.Where(sq.LtOrEq{"update_at": updateAts.max})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlockHistoryFields ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	result := map[string]map[string]interface{}{}
+	var fields map[string]interface{}
+	for rows.Next() {
+		var fieldsJSON string
+		var insertAt string
+		var fieldsDiff sql.NullBool
+		if err := rows.Scan(&fieldsJSON, &insertAt, &fieldsDiff); err != nil {
+			return nil, err
+		}
+
+		var recorded map[string]interface{}
+		if err := json.Unmarshal([]byte(fieldsJSON), &recorded); err != nil {
+			return nil, err
+		}
+
+		if fieldsDiff.Bool {
+			fields = applyFieldsDiff(fields, recorded)
+		} else {
+			fields = recorded
+		}
+		result[insertAt] = fields
+	}
+
+	return result, rows.Err()
+}
+
+// getLastFullBlockHistoryRecord returns the insertion time, as selected by
+// insertAtKeyField, of the last history record of the block holding all its
+// fields and updated at or before updateAt, or nil if there is none.
+func (s *SQLStore) getLastFullBlockHistoryRecord(db sq.BaseRunner, c store.Container, blockID string, updateAt int64) (interface{}, error) {
+	row := s.getQueryBuilder(db).
+		Select(s.insertAtKeyField()).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Or{sq.Eq{"fields_diff": nil}, sq.Eq{"fields_diff": false}}).
+		Where(sq.LtOrEq{"update_at": updateAt}).
+		OrderBy("insert_at DESC").
+		Limit(1).
+		QueryRow()
+
+	var insertAt interface{}
+	err := row.Scan(&insertAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return insertAt, nil
+}
+
+// fieldsDiff returns the fields that changed from previous to fields, with
+// the removed ones set to nil.
+func fieldsDiff(previous, fields map[string]interface{}) map[string]interface{} {
+	diff := map[string]interface{}{}
+	for key, value := range fields {
+		if prev, ok := previous[key]; !ok || !reflect.DeepEqual(prev, value) {
+			diff[key] = value
+		}
+	}
+	for key := range previous {
+		if _, ok := fields[key]; !ok {
+			diff[key] = nil
+		}
+	}
+	return diff
+}
+
+// applyFieldsDiff returns a copy of fields with the changes of diff applied.
+func applyFieldsDiff(fields, diff map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields)+len(diff))
+	for key, value := range fields {
+		result[key] = value
+	}
+	for key, value := range diff {
+		if value == nil {
+			delete(result, key)
+		} else {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// insertAtKeyField returns the insert_at column of the block history in a
+// form that can be scanned and used as is to select the same records.
+func (s *SQLStore) insertAtKeyField() string {
+	if s.dbType == sqliteDBType {
+		// the sqlite driver parses DATETIME columns to time.Time values
+		// that don't compare equal to the stored text
+		return "CAST(insert_at AS TEXT)"
+	}
+	return "insert_at"
+}
+
+// pruneBlockHistory deletes the history records of the blocks that are older
+// than before, or beyond the maxVersions most recent records of the block,
+// 0 disabling either limit. The most recent record older than before is kept,
+// as it holds the state of the block at before, and so is the most recent
+// record of each block. If the oldest kept record holds only the changed
+// fields, it is rewritten with all the fields of the block at the time first.
+func (s *SQLStore) pruneBlockHistory(db sq.BaseRunner, before int64, maxVersions int) (int64, error) {
+	if before <= 0 && maxVersions <= 0 {
+		return 0, nil
+	}
+
+	blocks, err := s.getBlocksToPrune(db, before, maxVersions)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, block := range blocks {
+		count, err := s.pruneBlockHistoryRecords(db, block.container, block.blockID, before, maxVersions)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += count
+	}
+	return deleted, nil
+}
+
+// historyBlockKey identifies the history records of a block.
+type historyBlockKey struct {
+	container store.Container
+	blockID   string
+}
+
+// getBlocksToPrune returns the blocks with history records to delete, that
+// is with more than one record older than before, as the most recent of them
+// is kept, or with more than maxVersions records.
+func (s *SQLStore) getBlocksToPrune(db sq.BaseRunner, before int64, maxVersions int) ([]historyBlockKey, error) {
+	having := sq.Or{}
+	if before > 0 {
+		having = append(having, sq.Expr("SUM(CASE WHEN update_at < ? THEN 1 ELSE 0 END) > 1", before))
+	}
+	if maxVersions > 0 {
+		having = append(having, sq.Gt{"COUNT(*)": maxVersions})
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(workspace_id, '0')", "id").
+		From(s.tablePrefix+"blocks_history").
+		GroupBy("workspace_id", "id").
+		Having(having)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlocksToPrune ERROR`, mlog.Err(err))
+		return nil, err
+	}
+
+	blocks := []historyBlockKey{}
+	for rows.Next() {
+		var key historyBlockKey
+		if err = rows.Scan(&key.container.WorkspaceID, &key.blockID); err != nil {
+			s.CloseRows(rows)
+			return nil, err
+		}
+		blocks = append(blocks, key)
+	}
+	s.CloseRows(rows)
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// pruneBlockHistoryRecords prunes the history records of a block, as
+// described in pruneBlockHistory.
+func (s *SQLStore) pruneBlockHistoryRecords(db sq.BaseRunner, c store.Container, blockID string, before int64, maxVersions int) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			s.insertAtKeyField(),
+			"update_at",
+			"COALESCE(fields, '{}')",
+			"fields_diff",
+		).
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		OrderBy("insert_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`pruneBlockHistoryRecords ERROR`, mlog.Err(err))
+		return 0, err
+	}
+
+	type record struct {
+		insertAt   interface{}
+		updateAt   int64
+		fields     map[string]interface{}
+		fieldsDiff bool
+	}
+	records := []record{}
+	for rows.Next() {
+		var r record
+		var fieldsJSON string
+		var fieldsDiff sql.NullBool
+		if err = rows.Scan(&r.insertAt, &r.updateAt, &fieldsJSON, &fieldsDiff); err != nil {
+			s.CloseRows(rows)
+			return 0, err
+		}
+		if err = json.Unmarshal([]byte(fieldsJSON), &r.fields); err != nil {
+			s.CloseRows(rows)
+			return 0, err
+		}
+		if r.fieldsDiff = fieldsDiff.Bool; r.fieldsDiff && len(records) > 0 {
+			r.fields = applyFieldsDiff(records[len(records)-1].fields, r.fields)
+		}
+		records = append(records, r)
+	}
+	s.CloseRows(rows)
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// the records are kept from the most recent one back to the first that
+	// is older than before, which is the base of the kept history, or to the
+	// last within the number of versions
+	keepFrom := len(records) - 1
+	for keepFrom > 0 {
+		if (before > 0 && records[keepFrom].updateAt < before) || (maxVersions > 0 && len(records)-keepFrom >= maxVersions) {
+			break
+		}
+		keepFrom--
+	}
+	if keepFrom <= 0 {
+		return 0, nil
+	}
+
+	oldest := records[keepFrom]
+	if oldest.fieldsDiff {
+		fieldsJSON, err := json.Marshal(oldest.fields)
+		if err != nil {
+			return 0, err
+		}
+		_, err = s.getQueryBuilder(db).
+			Update(s.tablePrefix+"blocks_history").
+			Set("fields", fieldsJSON).
+			Set("fields_diff", false).
+			Where(sq.Eq{"id": blockID}).
+			Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+			Where(sq.Eq{"insert_at": oldest.insertAt}).
+			Exec()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": blockID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Lt{"insert_at": oldest.insertAt}).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
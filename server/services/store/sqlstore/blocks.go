@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -20,6 +22,10 @@ const (
 	maxSearchDepth = 50
 )
 
+// maxHistoryInsertRetries is the number of times the insert of a block
+// history record is retried when it conflicts with a concurrent one.
+const maxHistoryInsertRetries = 5
+
 type RootIDNilError struct{}
 
 func (re RootIDNilError) Error() string {
@@ -45,6 +51,20 @@ func (s *SQLStore) timestampToCharField(name string, as string) string {
 	}
 }
 
+// insertedWithin returns the condition of the block history records inserted
+// in the last ms milliseconds. Both ends are read from the database clock, as
+// insert_at is set by the database.
+func (s *SQLStore) insertedWithin(ms int64) sq.Sqlizer {
+	switch s.dbType {
+	case mysqlDBType:
+		return sq.Expr("insert_at > NOW(6) - INTERVAL ? MICROSECOND", ms*1000)
+	case postgresDBType:
+		return sq.Expr("insert_at > NOW() - CAST(? AS INTERVAL)", fmt.Sprintf("%d milliseconds", ms))
+	default:
+		return sq.Expr("insert_at > STRFTIME('%Y-%m-%d %H:%M:%f', 'NOW', ?)", fmt.Sprintf("-%.3f seconds", float64(ms)/1000))
+	}
+}
+
 func (s *SQLStore) blockFields() []string {
 	return []string{
 		"id",
@@ -65,16 +85,57 @@ func (s *SQLStore) blockFields() []string {
 }
 
 func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, c store.Container, parentID string, blockType string) ([]model.Block, error) {
+	return s.getBlocksWithParentAndTypes(db, c, parentID, []string{blockType})
+}
+
+// getBlocksWithParentAndTypes returns the children of parentID that are of
+// any of blockTypes.
+func (s *SQLStore) getBlocksWithParentAndTypes(db sq.BaseRunner, c store.Container, parentID string, blockTypes []string) ([]model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
 		Where(sq.Eq{"parent_id": parentID}).
-		Where(sq.Eq{"type": blockType})
+		Where(sq.Eq{"type": blockTypes})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlocksWithParentAndTypes ERROR`, mlog.Err(err))
+
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getBlocksWithParentAndTypePage returns a page of the children of parentID
+// of the type, sorted by creation time. The pages are keyed on create_at and
+// id rather than offset, so that reading a page doesn't scan the previous ones
+// and the blocks inserted meanwhile don't shift the pages.
+func (s *SQLStore) getBlocksWithParentAndTypePage(db sq.BaseRunner, c store.Container, parentID string, blockType string, opts model.QueryPageOptions) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix+"blocks").
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Eq{"parent_id": parentID}).
+		Where(sq.Eq{"type": blockType}).
+		OrderBy("create_at", "id")
+
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	if opts.AfterID != "" {
+		query = query.Where(sq.Or{
+			sq.Gt{"create_at": opts.AfterCreateAt},
+			sq.And{sq.Eq{"create_at": opts.AfterCreateAt}, sq.Gt{"id": opts.AfterID}},
+		})
+	}
 
 	rows, err := query.Query()
 	if err != nil {
-		s.logger.Error(`getBlocksWithParentAndType ERROR`, mlog.Err(err))
+		s.logger.Error(`getBlocksWithParentAndTypePage ERROR`, mlog.Err(err))
 
 		return nil, err
 	}
@@ -119,16 +180,43 @@ func (s *SQLStore) getBlocksWithRootID(db sq.BaseRunner, c store.Container, root
 	return s.blocksFromRows(rows)
 }
 
+// getCardChildrenWithRootID returns the blocks of the board rootID whose
+// parent is a card, in one query for all the cards of the board.
+func (s *SQLStore) getCardChildrenWithRootID(db sq.BaseRunner, c store.Container, rootID string) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"root_id": rootID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Expr("parent_id IN (SELECT id FROM "+s.tablePrefix+"blocks WHERE root_id = ? AND type = ?)", rootID, model.TypeCard))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getCardChildrenWithRootID ERROR`, mlog.Err(err))
+
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
 func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, c store.Container, blockType string) ([]model.Block, error) {
+	return s.getBlocksWithTypes(db, c, []string{blockType})
+}
+
+// getBlocksWithTypes returns the blocks of the workspace that are of any of
+// blockTypes.
+func (s *SQLStore) getBlocksWithTypes(db sq.BaseRunner, c store.Container, blockTypes []string) ([]model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
-		Where(sq.Eq{"type": blockType}).
+		Where(sq.Eq{"type": blockTypes}).
 		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID})
 
 	rows, err := query.Query()
 	if err != nil {
-		s.logger.Error(`getBlocksWithParentAndType ERROR`, mlog.Err(err))
+		s.logger.Error(`getBlocksWithTypes ERROR`, mlog.Err(err))
 
 		return nil, err
 	}
@@ -139,6 +227,10 @@ func (s *SQLStore) getBlocksWithType(db sq.BaseRunner, c store.Container, blockT
 
 // getSubTree2 returns blocks within 2 levels of the given blockID.
 func (s *SQLStore) getSubTree2(db sq.BaseRunner, c store.Container, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	if opts.OrderByCreateAt || opts.Descending || opts.LimitPerLevel != 0 {
+		return s.getOrderedSubTree(db, c, blockID, 2, opts)
+	}
+
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
@@ -171,6 +263,10 @@ func (s *SQLStore) getSubTree2(db sq.BaseRunner, c store.Container, blockID stri
 
 // getSubTree3 returns blocks within 3 levels of the given blockID.
 func (s *SQLStore) getSubTree3(db sq.BaseRunner, c store.Container, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	if opts.OrderByCreateAt || opts.Descending || opts.LimitPerLevel != 0 {
+		return s.getOrderedSubTree(db, c, blockID, 3, opts)
+	}
+
 	// This first subquery returns repeated blocks
 	query := s.getQueryBuilder(db).Select(
 		"l3.id",
@@ -224,6 +320,101 @@ func (s *SQLStore) getSubTree3(db sq.BaseRunner, c store.Container, blockID stri
 	return s.blocksFromRows(rows)
 }
 
+// getOrderedSubTree returns the blocks within levels of blockID sorted by
+// create_at. The blocks are read a level at a time, so that the children of
+// each block are sorted, and limited to opts.LimitPerLevel, by the database.
+// MySQL 5.7 has no window functions, so there the children are limited once
+// read instead.
+func (s *SQLStore) getOrderedSubTree(db sq.BaseRunner, c store.Container, blockID string, levels int, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	order := "create_at"
+	if opts.Descending {
+		order += " DESC"
+	}
+
+	blocks := []model.Block{}
+	parentIDs := []string{}
+	for level := 0; level < levels; level++ {
+		levelQuery := s.getQueryBuilder(db).
+			Select().
+			From(s.tablePrefix + "blocks").
+			Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID})
+		if level == 0 {
+			levelQuery = levelQuery.Where(sq.Eq{"id": blockID})
+		} else {
+			levelQuery = levelQuery.Where(sq.Eq{"parent_id": parentIDs})
+		}
+		if opts.BeforeUpdateAt != 0 {
+			levelQuery = levelQuery.Where(sq.LtOrEq{"update_at": opts.BeforeUpdateAt})
+		}
+		if opts.AfterUpdateAt != 0 {
+			levelQuery = levelQuery.Where(sq.GtOrEq{"update_at": opts.AfterUpdateAt})
+		}
+
+		query := levelQuery.Columns(s.blockFields()...)
+		limitRead := level > 0 && opts.LimitPerLevel != 0 && s.dbType == mysqlDBType
+		if level > 0 && opts.LimitPerLevel != 0 && !limitRead {
+			// the children are numbered in the order of each parent, to
+			// keep the first ones of every parent in a single query
+			ranked := levelQuery.Columns("*", "ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY "+order+", id) AS child_rank")
+			query = s.getQueryBuilder(db).
+				Select(s.blockFields()...).
+				FromSelect(ranked, "children").
+				Where(sq.LtOrEq{"child_rank": opts.LimitPerLevel})
+		}
+
+		rows, err := query.OrderBy(order, "id").Query()
+		if err != nil {
+			s.logger.Error(`getOrderedSubTree ERROR`, mlog.Int("level", level), mlog.Err(err))
+			return nil, err
+		}
+		levelBlocks, err := s.blocksFromRows(rows)
+		s.CloseRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		if limitRead {
+			levelBlocks = limitChildrenPerParent(levelBlocks, opts.LimitPerLevel)
+		}
+		if len(levelBlocks) == 0 {
+			break
+		}
+
+		blocks = append(blocks, levelBlocks...)
+		parentIDs = make([]string, 0, len(levelBlocks))
+		for _, block := range levelBlocks {
+			parentIDs = append(parentIDs, block.ID)
+		}
+	}
+
+	// the levels are each sorted already, and only need to be merged
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if opts.Descending {
+			return blocks[i].CreateAt > blocks[j].CreateAt
+		}
+		return blocks[i].CreateAt < blocks[j].CreateAt
+	})
+
+	if opts.Limit != 0 && uint64(len(blocks)) > opts.Limit {
+		blocks = blocks[:opts.Limit]
+	}
+	return blocks, nil
+}
+
+// limitChildrenPerParent keeps the first limit blocks of each parent in
+// blocks, in their order.
+func limitChildrenPerParent(blocks []model.Block, limit uint64) []model.Block {
+	counts := map[string]uint64{}
+	limited := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if counts[block.ParentID] >= limit {
+			continue
+		}
+		counts[block.ParentID]++
+		limited = append(limited, block)
+	}
+	return limited
+}
+
 func (s *SQLStore) getAllBlocks(db sq.BaseRunner, c store.Container) ([]model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
@@ -245,49 +436,63 @@ func (s *SQLStore) blocksFromRows(rows *sql.Rows) ([]model.Block, error) {
 	results := []model.Block{}
 
 	for rows.Next() {
-		var block model.Block
-		var fieldsJSON string
-		var modifiedBy sql.NullString
-		var insertAt sql.NullString
-
-		err := rows.Scan(
-			&block.ID,
-			&block.ParentID,
-			&block.RootID,
-			&block.CreatedBy,
-			&modifiedBy,
-			&block.Schema,
-			&block.Type,
-			&block.Title,
-			&fieldsJSON,
-			&insertAt,
-			&block.CreateAt,
-			&block.UpdateAt,
-			&block.DeleteAt,
-			&block.WorkspaceID)
+		block, _, err := s.scanBlock(rows)
 		if err != nil {
-			// handle this error
-			s.logger.Error(`ERROR blocksFromRows`, mlog.Err(err))
-
 			return nil, err
 		}
 
-		if modifiedBy.Valid {
-			block.ModifiedBy = modifiedBy.String
-		}
+		results = append(results, block)
+	}
 
-		err = json.Unmarshal([]byte(fieldsJSON), &block.Fields)
-		if err != nil {
-			// handle this error
-			s.logger.Error(`ERROR blocksFromRows fields`, mlog.Err(err))
+	return results, nil
+}
 
-			return nil, err
-		}
+// scanBlock reads a block selected with blockFields from the current row,
+// along with its insertion time. The values of the columns selected after
+// the block fields are read into extra.
+func (s *SQLStore) scanBlock(rows *sql.Rows, extra ...interface{}) (model.Block, string, error) {
+	var block model.Block
+	var fieldsJSON string
+	var modifiedBy sql.NullString
+	var insertAt sql.NullString
+
+	dest := []interface{}{
+		&block.ID,
+		&block.ParentID,
+		&block.RootID,
+		&block.CreatedBy,
+		&modifiedBy,
+		&block.Schema,
+		&block.Type,
+		&block.Title,
+		&fieldsJSON,
+		&insertAt,
+		&block.CreateAt,
+		&block.UpdateAt,
+		&block.DeleteAt,
+		&block.WorkspaceID,
+	}
+	err := rows.Scan(append(dest, extra...)...)
+	if err != nil {
+		// handle this error
+		s.logger.Error(`ERROR blocksFromRows`, mlog.Err(err))
 
-		results = append(results, block)
+		return block, "", err
 	}
 
-	return results, nil
+	if modifiedBy.Valid {
+		block.ModifiedBy = modifiedBy.String
+	}
+
+	err = json.Unmarshal([]byte(fieldsJSON), &block.Fields)
+	if err != nil {
+		// handle this error
+		s.logger.Error(`ERROR blocksFromRows fields`, mlog.Err(err))
+
+		return block, "", err
+	}
+
+	return block, insertAt.String, nil
 }
 
 func (s *SQLStore) getRootID(db sq.BaseRunner, c store.Container, blockID string) (string, error) {
@@ -378,7 +583,11 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, c store.Container, block *model
 	}
 
 	if existingBlock != nil {
-		// block with ID exists, so this is an update operation
+		// block with ID exists, so this is an update operation, which keeps
+		// the creation time of the block
+		block.CreateAt = existingBlock.CreateAt
+		insertQueryValues["create_at"] = block.CreateAt
+
 		query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
 			Where(sq.Eq{"id": block.ID}).
 			Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
@@ -407,17 +616,102 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, c store.Container, block *model
 
 		query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks")
 		if _, err := query.Exec(); err != nil {
+			if isUniqueViolation(err) {
+				// another block with the same ID was inserted since we checked
+				return store.NewErrConflict(block.ID)
+			}
 			return err
 		}
 	}
 
+	if s.coalescesUpdate(existingBlock, block) {
+		coalesced, err := s.coalesceBlockHistory(db, c, block, existingBlock.UpdateAt, fieldsJSON)
+		if err != nil {
+			return err
+		}
+		if coalesced {
+			return nil
+		}
+	}
+
 	// writing block history
-	query := insertQuery.SetMap(insertQueryValues).Into(s.tablePrefix + "blocks_history")
-	if _, err := query.Exec(); err != nil {
-		return err
+	historyValues := insertQueryValues
+	if s.blockHistoryDiffs && existingBlock != nil {
+		diffJSON, err := json.Marshal(fieldsDiff(existingBlock.Fields, block.Fields))
+		if err != nil {
+			return err
+		}
+
+		historyValues = make(map[string]interface{}, len(insertQueryValues)+1)
+		for column, value := range insertQueryValues {
+			historyValues[column] = value
+		}
+		historyValues["fields"] = diffJSON
+		historyValues["fields_diff"] = true
 	}
 
-	return nil
+	return s.insertBlockHistory(insertQuery.SetMap(historyValues).Into(s.tablePrefix + "blocks_history"))
+}
+
+// insertBlockHistory runs the insert of a block history record. The records
+// are keyed on the time of their insert, so a record of the block saved
+// concurrently within the same millisecond conflicts, and the insert is
+// retried a millisecond later. Postgres aborts the transaction on the
+// conflict, but records the time with microseconds.
+func (s *SQLStore) insertBlockHistory(query sq.InsertBuilder) error {
+	for retries := 0; ; retries++ {
+		_, err := query.Exec()
+		if err == nil || !isUniqueViolation(err) || retries >= maxHistoryInsertRetries || s.dbType == postgresDBType {
+			return err
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// coalescesUpdate returns true if the update of the block follows an update
+// by the same user closely enough to be merged with it in the history.
+// Deletions and restorations are always recorded.
+func (s *SQLStore) coalescesUpdate(existingBlock, block *model.Block) bool {
+	if s.blockUpdateCoalesceMS <= 0 || existingBlock == nil {
+		return false
+	}
+	return existingBlock.ModifiedBy == block.ModifiedBy &&
+		existingBlock.DeleteAt == 0 && block.DeleteAt == 0 &&
+		block.UpdateAt-existingBlock.UpdateAt < s.blockUpdateCoalesceMS
+}
+
+// coalesceBlockHistory overwrites the history entry of the previous update of
+// the block, made at previousUpdateAt, with its new state, recorded in full.
+// The window is bounded from the insertion of the entry, that is from the
+// first update it coalesces, so that a steady stream of updates still records
+// an entry per window. It returns false if there is no such entry, in which
+// case a new one has to be recorded.
+func (s *SQLStore) coalesceBlockHistory(db sq.BaseRunner, c store.Container, block *model.Block, previousUpdateAt int64, fieldsJSON []byte) (bool, error) {
+	query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"id": block.ID}).
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Eq{"modified_by": block.ModifiedBy}).
+		Where(sq.Eq{"update_at": previousUpdateAt}).
+		Where(s.insertedWithin(s.blockUpdateCoalesceMS)).
+		Set("parent_id", block.ParentID).
+		Set("root_id", block.RootID).
+		Set(s.escapeField("schema"), block.Schema).
+		Set("type", block.Type).
+		Set("title", block.Title).
+		Set("fields", fieldsJSON).
+		Set("fields_diff", false).
+		Set("update_at", block.UpdateAt)
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error(`InsertBlock error occurred while coalescing block history`, mlog.String("blockID", block.ID), mlog.Err(err))
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
 }
 
 func (s *SQLStore) patchBlock(db sq.BaseRunner, c store.Container, blockID string, blockPatch *model.BlockPatch, userID string) error {
@@ -516,6 +810,33 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, c store.Container, blockID stri
 
 	return nil
 }
+
+// deleteBoardBlocks deletes the blocks of a board, except the board itself
+// and the blocks of keepTypes, and returns the deleted blocks.
+func (s *SQLStore) deleteBoardBlocks(db sq.BaseRunner, c store.Container, boardID string, keepTypes []string, modifiedBy string) ([]model.Block, error) {
+	blocks, err := s.getBlocksWithRootID(db, c, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := map[model.BlockType]bool{}
+	for _, blockType := range keepTypes {
+		keep[model.BlockType(blockType)] = true
+	}
+
+	deleted := []model.Block{}
+	for _, block := range blocks {
+		if block.ID == boardID || keep[block.Type] {
+			continue
+		}
+		if err := s.deleteBlock(db, c, block.ID, modifiedBy); err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, block)
+	}
+	return deleted, nil
+}
+
 func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, error) {
 	query := s.getQueryBuilder(db).
 		Select(
@@ -549,6 +870,162 @@ func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, err
 	return m, nil
 }
 
+func (s *SQLStore) getWorkspaceBlockCountsByType(db sq.BaseRunner, c store.Container) (map[string]int64, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"type",
+			"COUNT(*) AS count",
+		).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		GroupBy("type")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetWorkspaceBlockCountsByType ERROR`, mlog.Err(err))
+
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	m := make(map[string]int64)
+
+	for rows.Next() {
+		var blockType string
+		var count int64
+
+		err := rows.Scan(&blockType, &count)
+		if err != nil {
+			s.logger.Error("Failed to fetch block count", mlog.Err(err))
+			return nil, err
+		}
+		m[blockType] = count
+	}
+	return m, nil
+}
+
+func (s *SQLStore) getBlockCountWithRootID(db sq.BaseRunner, c store.Container, rootID string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"root_id": rootID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID})
+
+	var count int64
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error(`getBlockCountWithRootID ERROR`, mlog.Err(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *SQLStore) getBlockCountWithRootIDAndType(db sq.BaseRunner, c store.Container, rootID string, blockType string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"root_id": rootID}).
+		Where(sq.Eq{"type": blockType}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID})
+
+	var count int64
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error(`getBlockCountWithRootIDAndType ERROR`, mlog.Err(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// getLastActivityWithRootID returns the latest update time of any block
+// with the given root, including blocks that have since been deleted. The
+// changes made by the server itself aren't activity.
+func (s *SQLStore) getLastActivityWithRootID(db sq.BaseRunner, c store.Container, rootID string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(MAX(update_at), 0)").
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"root_id": rootID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.NotEq{"COALESCE(modified_by, '')": model.SystemUserID})
+
+	var lastActivity int64
+	if err := query.QueryRow().Scan(&lastActivity); err != nil {
+		s.logger.Error(`getLastActivityWithRootID ERROR`, mlog.Err(err))
+		return 0, err
+	}
+
+	return lastActivity, nil
+}
+
+func (s *SQLStore) getLastActivityByRootIDs(db sq.BaseRunner, c store.Container, rootIDs []string) (map[string]int64, error) {
+	lastActivities := make(map[string]int64, len(rootIDs))
+	if len(rootIDs) == 0 {
+		return lastActivities, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("root_id", "MAX(update_at)").
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"root_id": rootIDs}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.NotEq{"COALESCE(modified_by, '')": model.SystemUserID}).
+		GroupBy("root_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getLastActivityByRootIDs ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var rootID string
+		var lastActivity int64
+		if err := rows.Scan(&rootID, &lastActivity); err != nil {
+			return nil, err
+		}
+		lastActivities[rootID] = lastActivity
+	}
+
+	return lastActivities, rows.Err()
+}
+
+// getInactiveBoards returns the boards of all the workspaces whose last
+// activity is before the given time, with their last activity set.
+func (s *SQLStore) getInactiveBoards(db sq.BaseRunner, before int64) ([]model.Block, error) {
+	lastActivity := fmt.Sprintf(
+		"(SELECT COALESCE(MAX(h.update_at), 0) FROM %sblocks_history h WHERE h.root_id = b.id AND COALESCE(h.workspace_id, '0') = COALESCE(b.workspace_id, '0') AND COALESCE(h.modified_by, '') <> '%s')",
+		s.tablePrefix,
+		model.SystemUserID,
+	)
+
+	query := s.getQueryBuilder(db).
+		Select(append(s.blockFields(), lastActivity)...).
+		From(s.tablePrefix+"blocks b").
+		Where(sq.Eq{"type": model.TypeBoard}).
+		Where(lastActivity+" < ?", before)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getInactiveBoards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boards := []model.Block{}
+	for rows.Next() {
+		var lastActivityAt int64
+		board, _, err := s.scanBlock(rows, &lastActivityAt)
+		if err != nil {
+			return nil, err
+		}
+		board.LastActivityAt = lastActivityAt
+		boards = append(boards, board)
+	}
+
+	return boards, rows.Err()
+}
+
 func (s *SQLStore) getBlock(db sq.BaseRunner, c store.Container, blockID string) (*model.Block, error) {
 	query := s.getQueryBuilder(db).
 		Select(s.blockFields()...).
@@ -581,7 +1058,7 @@ func (s *SQLStore) getBlockHistory(db sq.BaseRunner, c store.Container, blockID
 	}
 
 	query := s.getQueryBuilder(db).
-		Select(s.blockFields()...).
+		Select(s.blockHistoryFields()...).
 		From(s.tablePrefix + "blocks_history").
 		Where(sq.Eq{"id": blockID}).
 		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
@@ -599,13 +1076,61 @@ func (s *SQLStore) getBlockHistory(db sq.BaseRunner, c store.Container, blockID
 		query = query.Limit(opts.Limit)
 	}
 
+	if opts.Offset != 0 {
+		query = query.Offset(opts.Offset)
+	}
+
 	rows, err := query.Query()
 	if err != nil {
 		s.logger.Error(`GetBlockHistory ERROR`, mlog.Err(err))
 		return nil, err
 	}
 
-	return s.blocksFromRows(rows)
+	defer s.CloseRows(rows)
+
+	return s.blockHistoryFromRows(db, c, rows)
+}
+
+// getBoardHistory returns the history records of all the blocks of a board,
+// deleted ones included, sorted by update_at.
+func (s *SQLStore) getBoardHistory(db sq.BaseRunner, c store.Container, boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
+	var order string
+	if opts.Descending {
+		order = " DESC "
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockHistoryFields()...).
+		From(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"root_id": boardID}).
+		Where(sq.Eq{"coalesce(workspace_id, '0')": c.WorkspaceID}).
+		OrderBy("update_at"+order, "insert_at"+order, "id"+order)
+
+	if opts.BeforeUpdateAt != 0 {
+		query = query.Where(sq.Lt{"update_at": opts.BeforeUpdateAt})
+	}
+
+	if opts.AfterUpdateAt != 0 {
+		query = query.Where(sq.Gt{"update_at": opts.AfterUpdateAt})
+	}
+
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	if opts.Offset != 0 {
+		query = query.Offset(opts.Offset)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetBoardHistory ERROR`, mlog.Err(err))
+		return nil, err
+	}
+
+	defer s.CloseRows(rows)
+
+	return s.blockHistoryFromRows(db, c, rows)
 }
 
 // getBoardAndCardByID returns the first parent of type `card` and first parent of type `board` for the block specified by ID.
@@ -0,0 +1,393 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockUpdateCoalescing(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+
+	patchTitle := func(t *testing.T, blockID, title, userID string) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, sqlStore.PatchBlock(container, blockID, &model.BlockPatch{Title: &title}, userID))
+	}
+
+	insertBlock := func(t *testing.T, blockID string) {
+		block := model.Block{ID: blockID, RootID: "board-id", ParentID: "board-id", Type: model.TypeCard, Title: "v0"}
+		require.NoError(t, sqlStore.InsertBlock(container, &block, "user-1"))
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		insertBlock(t, "block-1")
+		patchTitle(t, "block-1", "v1", "user-1")
+		patchTitle(t, "block-1", "v2", "user-1")
+
+		history, err := sqlStore.GetBlockHistory(container, "block-1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+	})
+
+	sqlStore.blockUpdateCoalesceMS = 60 * 1000
+	defer func() { sqlStore.blockUpdateCoalesceMS = 0 }()
+
+	t.Run("successive updates by the same user", func(t *testing.T) {
+		insertBlock(t, "block-2")
+		patchTitle(t, "block-2", "v1", "user-1")
+		patchTitle(t, "block-2", "v2", "user-1")
+
+		block, err := sqlStore.GetBlock(container, "block-2")
+		require.NoError(t, err)
+		require.Equal(t, "v2", block.Title)
+
+		history, err := sqlStore.GetBlockHistory(container, "block-2", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.Equal(t, "v2", history[0].Title)
+		require.Equal(t, block.UpdateAt, history[0].UpdateAt)
+	})
+
+	t.Run("updates by another user", func(t *testing.T) {
+		insertBlock(t, "block-3")
+		patchTitle(t, "block-3", "v1", "user-2")
+		patchTitle(t, "block-3", "v2", "user-1")
+
+		history, err := sqlStore.GetBlockHistory(container, "block-3", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		require.Equal(t, "v2", history[0].Title)
+		require.Equal(t, "v1", history[1].Title)
+	})
+
+	t.Run("updates out of the window", func(t *testing.T) {
+		sqlStore.blockUpdateCoalesceMS = 1
+		defer func() { sqlStore.blockUpdateCoalesceMS = 60 * 1000 }()
+
+		insertBlock(t, "block-4")
+		patchTitle(t, "block-4", "v1", "user-1")
+
+		history, err := sqlStore.GetBlockHistory(container, "block-4", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+	})
+
+	t.Run("window bounded from the first update", func(t *testing.T) {
+		sqlStore.blockUpdateCoalesceMS = 25
+		defer func() { sqlStore.blockUpdateCoalesceMS = 60 * 1000 }()
+
+		// each update follows the previous one within the window, but the
+		// last ones are out of the window of the first
+		insertBlock(t, "block-6")
+		patchTitle(t, "block-6", "v1", "user-1")
+		patchTitle(t, "block-6", "v2", "user-1")
+		patchTitle(t, "block-6", "v3", "user-1")
+		patchTitle(t, "block-6", "v4", "user-1")
+
+		history, err := sqlStore.GetBlockHistory(container, "block-6", model.QueryBlockHistoryOptions{Descending: true})
+		require.NoError(t, err)
+		require.Greater(t, len(history), 1)
+		require.Equal(t, "v4", history[0].Title)
+	})
+
+	t.Run("deletions are recorded", func(t *testing.T) {
+		insertBlock(t, "block-5")
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, sqlStore.DeleteBlock(container, "block-5", "user-1"))
+
+		history, err := sqlStore.GetBlockHistory(container, "block-5", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+	})
+}
+
+func TestBlockHistoryFieldDiffs(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+
+	sqlStore.blockHistoryDiffs = true
+	defer func() { sqlStore.blockHistoryDiffs = false }()
+
+	patch := func(t *testing.T, blockID string, patch *model.BlockPatch) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, sqlStore.PatchBlock(container, blockID, patch, "user-1"))
+	}
+
+	block := model.Block{ID: "block-1", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard, Title: "v0", Fields: map[string]interface{}{
+		"icon":       "🚀",
+		"properties": map[string]interface{}{"status": "todo"},
+	}}
+	require.NoError(t, sqlStore.InsertBlock(container, &block, "user-1"))
+	patch(t, "block-1", &model.BlockPatch{UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"status": "done"}}})
+	patch(t, "block-1", &model.BlockPatch{DeletedFields: []string{"icon"}})
+	title := "v1"
+	patch(t, "block-1", &model.BlockPatch{Title: &title})
+
+	expected := []map[string]interface{}{
+		{"icon": "🚀", "properties": map[string]interface{}{"status": "todo"}},
+		{"icon": "🚀", "properties": map[string]interface{}{"status": "done"}},
+		{"properties": map[string]interface{}{"status": "done"}},
+		{"properties": map[string]interface{}{"status": "done"}},
+	}
+
+	t.Run("updates record the changed fields", func(t *testing.T) {
+		rows, err := sqlStore.getQueryBuilder(sqlStore.db).
+			Select("fields").
+			From(sqlStore.tablePrefix+"blocks_history").
+			Where("id = ?", "block-1").
+			OrderBy("insert_at").
+			Query()
+		require.NoError(t, err)
+		defer sqlStore.CloseRows(rows)
+
+		recorded := []string{}
+		for rows.Next() {
+			var fields string
+			require.NoError(t, rows.Scan(&fields))
+			recorded = append(recorded, fields)
+		}
+		require.Len(t, recorded, 4)
+		require.JSONEq(t, `{"properties":{"status":"done"}}`, recorded[1])
+		require.JSONEq(t, `{"icon":null}`, recorded[2])
+		require.JSONEq(t, `{}`, recorded[3])
+	})
+
+	t.Run("block history has all the fields", func(t *testing.T) {
+		history, err := sqlStore.GetBlockHistory(container, "block-1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+		for i := range history {
+			require.Equal(t, expected[i], history[i].Fields)
+		}
+		require.Equal(t, "v1", history[3].Title)
+
+		latest, err := sqlStore.GetBlockHistory(container, "block-1", model.QueryBlockHistoryOptions{Descending: true, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		require.Equal(t, expected[3], latest[0].Fields)
+	})
+
+	t.Run("board history has all the fields", func(t *testing.T) {
+		history, err := sqlStore.GetBoardHistory(container, "board-id", model.QueryBlockHistoryOptions{Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, expected[1], history[0].Fields)
+		require.Equal(t, expected[2], history[1].Fields)
+	})
+
+	t.Run("only the records up to the page are replayed", func(t *testing.T) {
+		history, err := sqlStore.GetBlockHistory(container, "block-1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 4)
+
+		// the replay starts from the full record of the insertion, and stops
+		// at the requested record
+		fields, err := sqlStore.getBlockHistoryFields(sqlStore.db, container, "block-1", &updateAtRange{min: history[2].UpdateAt, max: history[2].UpdateAt})
+		require.NoError(t, err)
+		require.Len(t, fields, 3)
+	})
+
+	t.Run("deletions are recorded in full", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, sqlStore.DeleteBlock(container, "block-1", "user-1"))
+
+		history, err := sqlStore.GetBlockHistory(container, "block-1", model.QueryBlockHistoryOptions{Descending: true, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.NotZero(t, history[0].DeleteAt)
+		require.Equal(t, expected[3], history[0].Fields)
+	})
+}
+
+func TestPruneBlockHistory(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+
+	// insertVersions inserts a block and updates its status, one version per
+	// status
+	insertVersions := func(t *testing.T, blockID string, statuses ...string) {
+		block := model.Block{ID: blockID, RootID: "board-id", ParentID: "board-id", Type: model.TypeCard, Fields: map[string]interface{}{
+			"icon":       "🚀",
+			"properties": map[string]interface{}{"status": statuses[0]},
+		}}
+		require.NoError(t, sqlStore.InsertBlock(container, &block, "user-1"))
+		for _, status := range statuses[1:] {
+			// this avoids triggering uniqueness constraint of
+			// id,insert_at on block history
+			time.Sleep(10 * time.Millisecond)
+			patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{"status": status}}}
+			require.NoError(t, sqlStore.PatchBlock(container, blockID, patch, "user-1"))
+		}
+	}
+
+	statuses := func(t *testing.T, blockID string) []interface{} {
+		history, err := sqlStore.GetBlockHistory(container, blockID, model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		result := []interface{}{}
+		for _, block := range history {
+			require.Equal(t, "🚀", block.Fields["icon"])
+			result = append(result, block.Fields["properties"].(map[string]interface{})["status"])
+		}
+		return result
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		insertVersions(t, "block-disabled", "a", "b")
+		deleted, err := sqlStore.PruneBlockHistory(0, 0)
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+		require.Equal(t, []interface{}{"a", "b"}, statuses(t, "block-disabled"))
+	})
+
+	t.Run("by number of versions", func(t *testing.T) {
+		insertVersions(t, "block-versions", "a", "b", "c", "d")
+		deleted, err := sqlStore.PruneBlockHistory(0, 2)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, deleted, int64(2))
+		require.Equal(t, []interface{}{"c", "d"}, statuses(t, "block-versions"))
+	})
+
+	t.Run("by age keeps the current version", func(t *testing.T) {
+		insertVersions(t, "block-age", "a", "b", "c")
+		history, err := sqlStore.GetBlockHistory(container, "block-age", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+
+		// a is the version of the block at before, so it's kept, and the
+		// block isn't even loaded
+		blocks, err := sqlStore.getBlocksToPrune(sqlStore.db, history[1].UpdateAt, 0)
+		require.NoError(t, err)
+		require.NotContains(t, blocks, historyBlockKey{container: container, blockID: "block-age"})
+		_, err = sqlStore.PruneBlockHistory(history[1].UpdateAt, 0)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"a", "b", "c"}, statuses(t, "block-age"))
+
+		blocks, err = sqlStore.getBlocksToPrune(sqlStore.db, history[1].UpdateAt+1, 0)
+		require.NoError(t, err)
+		require.Contains(t, blocks, historyBlockKey{container: container, blockID: "block-age"})
+		_, err = sqlStore.PruneBlockHistory(history[1].UpdateAt+1, 0)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"b", "c"}, statuses(t, "block-age"))
+
+		_, err = sqlStore.PruneBlockHistory(utils.GetMillis()+1000, 0)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"c"}, statuses(t, "block-age"))
+
+		block, err := sqlStore.GetBlock(container, "block-age")
+		require.NoError(t, err)
+		require.Equal(t, "c", block.Fields["properties"].(map[string]interface{})["status"])
+	})
+
+	t.Run("field diffs are replayed into the oldest kept version", func(t *testing.T) {
+		sqlStore.blockHistoryDiffs = true
+		defer func() { sqlStore.blockHistoryDiffs = false }()
+
+		insertVersions(t, "block-diffs", "a", "b", "c", "d")
+		_, err := sqlStore.PruneBlockHistory(0, 2)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"c", "d"}, statuses(t, "block-diffs"))
+	})
+
+	t.Run("field diffs are replayed into the version at before", func(t *testing.T) {
+		sqlStore.blockHistoryDiffs = true
+		defer func() { sqlStore.blockHistoryDiffs = false }()
+
+		insertVersions(t, "block-age-diffs", "a", "b", "c", "d")
+		history, err := sqlStore.GetBlockHistory(container, "block-age-diffs", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+
+		_, err = sqlStore.PruneBlockHistory(history[2].UpdateAt-1, 0)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"b", "c", "d"}, statuses(t, "block-age-diffs"))
+	})
+}
+
+// racingRunner runs race before the first insert of a block, as another
+// request would between the check for the block and its insert.
+type racingRunner struct {
+	sq.BaseRunner
+	table string
+	race  func()
+}
+
+func (r *racingRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if r.race != nil && strings.HasPrefix(query, "INSERT INTO "+r.table+" ") {
+		race := r.race
+		r.race = nil
+		race()
+	}
+	return r.BaseRunner.Exec(query, args...)
+}
+
+func TestInsertBlockConflict(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+
+	other := model.Block{ID: "block-id", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard, Title: "other"}
+	runner := &racingRunner{
+		BaseRunner: sqlStore.db,
+		table:      sqlStore.tablePrefix + "blocks",
+		race: func() {
+			require.NoError(t, sqlStore.InsertBlock(container, &other, "user-2"))
+		},
+	}
+
+	block := model.Block{ID: "block-id", RootID: "board-id", ParentID: "board-id", Type: model.TypeCard, Title: "mine"}
+	err := sqlStore.insertBlock(runner, container, &block, "user-1")
+	require.True(t, st.IsErrConflict(err), "unexpected error: %v", err)
+
+	saved, err := sqlStore.GetBlock(container, "block-id")
+	require.NoError(t, err)
+	require.Equal(t, "other", saved.Title)
+
+	// once the block exists, saving it again updates it
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, sqlStore.InsertBlock(container, &block, "user-1"))
+	saved, err = sqlStore.GetBlock(container, "block-id")
+	require.NoError(t, err)
+	require.Equal(t, "mine", saved.Title)
+}
+
+func TestLimitChildrenPerParent(t *testing.T) {
+	blocks := []model.Block{
+		{ID: "a1", ParentID: "a"},
+		{ID: "b1", ParentID: "b"},
+		{ID: "a2", ParentID: "a"},
+		{ID: "a3", ParentID: "a"},
+		{ID: "b2", ParentID: "b"},
+		{ID: "c1", ParentID: "c"},
+	}
+
+	ids := []string{}
+	for _, block := range limitChildrenPerParent(blocks, 2) {
+		ids = append(ids, block.ID)
+	}
+	require.Equal(t, []string{"a1", "b1", "a2", "b2", "c1"}, ids)
+
+	require.Len(t, limitChildrenPerParent(blocks, 10), len(blocks))
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardAcknowledgmentFields = []string{
+	"board_id",
+	"workspace_id",
+	"user_id",
+	"board_last_activity_at",
+	"create_at",
+}
+
+func (s *SQLStore) boardAcknowledgmentsFromRows(rows *sql.Rows) ([]*model.BoardAcknowledgment, error) {
+	acks := []*model.BoardAcknowledgment{}
+
+	for rows.Next() {
+		var ack model.BoardAcknowledgment
+		err := rows.Scan(
+			&ack.BoardID,
+			&ack.WorkspaceID,
+			&ack.UserID,
+			&ack.BoardLastActivityAt,
+			&ack.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		acks = append(acks, &ack)
+	}
+	return acks, nil
+}
+
+// upsertBoardAcknowledgment records the acknowledgment of a board by a user,
+// replacing the previous one of the user.
+func (s *SQLStore) upsertBoardAcknowledgment(db sq.BaseRunner, c store.Container, ack *model.BoardAcknowledgment) error {
+	ack.WorkspaceID = c.WorkspaceID
+	ack.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_acknowledgments").
+		Columns(boardAcknowledgmentFields...).
+		Values(ack.BoardID, ack.WorkspaceID, ack.UserID, ack.BoardLastActivityAt, ack.CreateAt)
+
+	if s.dbType == mysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE board_last_activity_at = ?, create_at = ?", ack.BoardLastActivityAt, ack.CreateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id,user_id) DO UPDATE SET board_last_activity_at = ?, create_at = ?", ack.BoardLastActivityAt, ack.CreateAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot upsert board acknowledgment",
+			mlog.String("board_id", ack.BoardID),
+			mlog.String("user_id", ack.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getBoardAcknowledgments fetches the latest acknowledgment of a board by
+// each user, oldest first.
+func (s *SQLStore) getBoardAcknowledgments(db sq.BaseRunner, c store.Container, boardID string) ([]*model.BoardAcknowledgment, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardAcknowledgmentFields...).
+		From(s.tablePrefix+"board_acknowledgments").
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "user_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board acknowledgments",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardAcknowledgmentsFromRows(rows)
+}
@@ -0,0 +1,140 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var boardAPIKeyFields = []string{
+	"id",
+	"workspace_id",
+	"board_id",
+	"name",
+	"token",
+	"created_by",
+	"create_at",
+}
+
+func (s *SQLStore) boardAPIKeysFromRows(rows *sql.Rows) ([]*model.BoardAPIKey, error) {
+	keys := []*model.BoardAPIKey{}
+
+	for rows.Next() {
+		var key model.BoardAPIKey
+		err := rows.Scan(
+			&key.ID,
+			&key.WorkspaceID,
+			&key.BoardID,
+			&key.Name,
+			&key.Token,
+			&key.CreatedBy,
+			&key.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, &key)
+	}
+	return keys, nil
+}
+
+// createBoardAPIKey stores a new API key for a board.
+func (s *SQLStore) createBoardAPIKey(db sq.BaseRunner, c store.Container, key *model.BoardAPIKey) error {
+	key.WorkspaceID = c.WorkspaceID
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_api_keys").
+		Columns(boardAPIKeyFields...).
+		Values(
+			key.ID,
+			key.WorkspaceID,
+			key.BoardID,
+			key.Name,
+			key.Token,
+			key.CreatedBy,
+			key.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create board API key",
+			mlog.String("board_id", key.BoardID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getBoardAPIKeys returns the API keys of a board, oldest first.
+func (s *SQLStore) getBoardAPIKeys(db sq.BaseRunner, c store.Container, boardID string) ([]*model.BoardAPIKey, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardAPIKeyFields...).
+		From(s.tablePrefix+"board_api_keys").
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot get board API keys",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardAPIKeysFromRows(rows)
+}
+
+// getBoardAPIKeyByToken returns the API key with the token, in any
+// workspace.
+func (s *SQLStore) getBoardAPIKeyByToken(db sq.BaseRunner, token string) (*model.BoardAPIKey, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardAPIKeyFields...).
+		From(s.tablePrefix + "board_api_keys").
+		Where(sq.Eq{"token": token})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot get board API key by token", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	keys, err := s.boardAPIKeysFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, store.NewErrNotFound("board API key")
+	}
+	return keys[0], nil
+}
+
+// deleteBoardAPIKey revokes an API key of a board.
+func (s *SQLStore) deleteBoardAPIKey(db sq.BaseRunner, c store.Container, boardID string, keyID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_api_keys").
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"id": keyID})
+
+	result, err := query.Exec()
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return store.NewErrNotFound(keyID)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// cardPropertyExpr returns an expression extracting the value of a card
+// property from the fields of a block as text, and its argument.
+func (s *SQLStore) cardPropertyExpr(propertyID string) (string, interface{}) {
+	if s.dbType == postgresDBType {
+		return "(fields->'properties'->>?)", propertyID
+	}
+	path := `$.properties."` + strings.ReplaceAll(propertyID, `"`, `\"`) + `"`
+	return "JSON_UNQUOTE(JSON_EXTRACT(fields, ?))", path
+}
+
+// getBoardCardStats counts the cards of a board by the option of their
+// group property, along with the cards that aren't assigned to anyone and
+// the cards that are overdue.
+func (s *SQLStore) getBoardCardStats(db sq.BaseRunner, c store.Container, boardID string, opts model.CardStatsOptions) (*model.BoardStats, error) {
+	stats := &model.BoardStats{
+		BoardID:         boardID,
+		GroupPropertyID: opts.GroupPropertyID,
+		CardsByGroup:    map[string]int{},
+	}
+
+	// the sqlite driver is built without the JSON functions
+	if s.dbType == sqliteDBType {
+		return stats, s.countCardStatsFromFields(db, c, boardID, opts, stats)
+	}
+
+	groupExpr, groupArgs := "''", []interface{}{}
+	if opts.GroupPropertyID != "" {
+		expr, arg := s.cardPropertyExpr(opts.GroupPropertyID)
+		groupExpr, groupArgs = "COALESCE("+expr+", '')", []interface{}{arg}
+	}
+
+	// a card is unassigned when all its person properties are empty
+	unassignedConds := []string{"1 = 1"}
+	unassignedArgs := []interface{}{}
+	for _, propertyID := range opts.PersonPropertyIDs {
+		expr, arg := s.cardPropertyExpr(propertyID)
+		unassignedConds = append(unassignedConds, "COALESCE("+expr+", '') = ''")
+		unassignedArgs = append(unassignedArgs, arg)
+	}
+	unassignedExpr := "CASE WHEN " + strings.Join(unassignedConds, " AND ") + " THEN 1 ELSE 0 END"
+
+	cards := s.getQueryBuilder(db).
+		Select().
+		Column(sq.Expr(groupExpr+" AS group_option", groupArgs...)).
+		Column(sq.Expr(unassignedExpr+" AS unassigned", unassignedArgs...)).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Eq{"parent_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard})
+
+	query := s.getQueryBuilder(db).
+		Select("group_option", "COUNT(*)", "SUM(unassigned)").
+		FromSelect(cards, "cards").
+		GroupBy("group_option")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardCardStats ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var option string
+		var count, unassigned int
+		if err := rows.Scan(&option, &count, &unassigned); err != nil {
+			return nil, err
+		}
+		stats.CardCount += count
+		stats.UnassignedCardCount += unassigned
+		if opts.GroupPropertyID != "" {
+			stats.CardsByGroup[option] += count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	overdue, err := s.getOverdueCardCount(db, c, boardID, opts)
+	if err != nil {
+		return nil, err
+	}
+	stats.OverdueCardCount = overdue
+
+	return stats, nil
+}
+
+// getOverdueCardCount counts the cards of a board with a date property
+// before opts.Now. Dates are JSON ranges held in a string, which the
+// databases can't all parse safely, so only the dates of the cards having
+// one are read, and parsed here.
+func (s *SQLStore) getOverdueCardCount(db sq.BaseRunner, c store.Container, boardID string, opts model.CardStatsOptions) (int, error) {
+	if len(opts.DatePropertyIDs) == 0 {
+		return 0, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select().
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Eq{"parent_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard})
+
+	hasDate := sq.Or{}
+	for _, propertyID := range opts.DatePropertyIDs {
+		expr, arg := s.cardPropertyExpr(propertyID)
+		query = query.Column(sq.Expr(expr, arg))
+		hasDate = append(hasDate, sq.Expr(fmt.Sprintf("COALESCE(%s, '') <> ''", expr), arg))
+	}
+	query = query.Where(hasDate)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getOverdueCardCount ERROR`, mlog.Err(err))
+		return 0, err
+	}
+	defer s.CloseRows(rows)
+
+	count := 0
+	dates := make([]sql.NullString, len(opts.DatePropertyIDs))
+	dest := make([]interface{}, len(dates))
+	for i := range dates {
+		dest[i] = &dates[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return 0, err
+		}
+		for _, date := range dates {
+			if _, to, err := model.ParseDateRange(date.String); date.Valid && err == nil && to < opts.Now {
+				count++
+				break
+			}
+		}
+	}
+	return count, rows.Err()
+}
+
+// countCardStatsFromFields reads the properties of the cards of a board and
+// counts their statistics into stats, for the databases without JSON
+// functions.
+func (s *SQLStore) countCardStatsFromFields(db sq.BaseRunner, c store.Container, boardID string, opts model.CardStatsOptions, stats *model.BoardStats) error {
+	query := s.getQueryBuilder(db).
+		Select("fields").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"COALESCE(workspace_id, '0')": c.WorkspaceID}).
+		Where(sq.Eq{"parent_id": boardID}).
+		Where(sq.Eq{"type": model.TypeCard})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`countCardStatsFromFields ERROR`, mlog.Err(err))
+		return err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var fieldsJSON string
+		if err := rows.Scan(&fieldsJSON); err != nil {
+			return err
+		}
+		var fields struct {
+			Properties map[string]interface{} `json:"properties"`
+		}
+		if err := json.Unmarshal([]byte(fieldsJSON), &fields); err != nil {
+			s.logger.Error("countCardStatsFromFields ERROR", mlog.String("boardID", boardID), mlog.Err(err))
+			return err
+		}
+
+		stats.CardCount++
+		if opts.GroupPropertyID != "" {
+			option, _ := fields.Properties[opts.GroupPropertyID].(string)
+			stats.CardsByGroup[option]++
+		}
+
+		assigned := false
+		for _, propertyID := range opts.PersonPropertyIDs {
+			if userID, _ := fields.Properties[propertyID].(string); userID != "" {
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			stats.UnassignedCardCount++
+		}
+
+		for _, propertyID := range opts.DatePropertyIDs {
+			date, _ := fields.Properties[propertyID].(string)
+			if _, to, err := model.ParseDateRange(date); err == nil && to < opts.Now {
+				stats.OverdueCardCount++
+				break
+			}
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var cardMuteFields = []string{
+	"card_id",
+	"workspace_id",
+	"board_id",
+	"user_id",
+	"create_at",
+}
+
+func (s *SQLStore) cardMutesFromRows(rows *sql.Rows) ([]*model.CardMute, error) {
+	mutes := []*model.CardMute{}
+
+	for rows.Next() {
+		var mute model.CardMute
+		err := rows.Scan(
+			&mute.CardID,
+			&mute.WorkspaceID,
+			&mute.BoardID,
+			&mute.UserID,
+			&mute.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mutes = append(mutes, &mute)
+	}
+	return mutes, nil
+}
+
+// muteCard mutes a card for a user. Muting a card again keeps the time it
+// was first muted at.
+func (s *SQLStore) muteCard(db sq.BaseRunner, c store.Container, mute *model.CardMute) error {
+	mute.WorkspaceID = c.WorkspaceID
+	mute.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_mutes").
+		Columns(cardMuteFields...).
+		Values(mute.CardID, mute.WorkspaceID, mute.BoardID, mute.UserID, mute.CreateAt)
+
+	if s.dbType == mysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = card_id")
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id,user_id) DO NOTHING")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot mute card",
+			mlog.String("card_id", mute.CardID),
+			mlog.String("user_id", mute.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// unmuteCard unmutes a card for a user.
+func (s *SQLStore) unmuteCard(db sq.BaseRunner, c store.Container, cardID string, userID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_mutes").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"user_id": userID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot unmute card",
+			mlog.String("card_id", cardID),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getMutedCards fetches the cards muted by a user, oldest first.
+func (s *SQLStore) getMutedCards(db sq.BaseRunner, c store.Container, userID string) ([]*model.CardMute, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardMuteFields...).
+		From(s.tablePrefix+"card_mutes").
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("create_at", "card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch muted cards",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardMutesFromRows(rows)
+}
@@ -20,6 +20,10 @@ func SetupTests(t *testing.T) (store.Store, func()) {
 	require.NoError(t, err)
 	err = sqlDB.Ping()
 	require.NoError(t, err)
+	if dbType == sqliteDBType {
+		// every connection to :memory: opens a new empty database
+		sqlDB.SetMaxOpenConns(1)
+	}
 
 	storeParams := Params{
 		DBType:           dbType,
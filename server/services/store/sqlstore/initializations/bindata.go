@@ -1,6 +1,10 @@
 // Code generated for package initializations by go-bindata DO NOT EDIT. (@generated)
 // sources:
-// templates/templates.json
+// templates/meeting-notes.json
+// templates/personal-goals.json
+// templates/personal-tasks.json
+// templates/project-tasks.json
+// templates/roadmap.json
 package initializations
 
 import (
@@ -77,22 +81,102 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7c\xdf\x8e\x5c\xc7\x71\xfe\xab\x9c\xdf\xe8\xe2\x07\x04\x5b\x48\x77\x75\x75\x77\x35\xef\x28\xd1\x96\x09\xc8\x12\x6d\xd2\x71\x82\x58\x30\xfa\x4f\xf5\x72\xac\xd9\x99\xc5\xcc\x59\xd2\x0b\x81\x57\xb9\xb5\x63\xc7\x30\x60\x24\x32\x22\x23\x46\x02\xdf\xc5\x41\x72\x11\x24\xc8\x8d\x1f\xc5\x2f\x10\x3d\x42\xd0\xb3\x14\x39\xbb\x7b\x86\x6c\xee\x8a\xe4\xda\x09\xa4\x8b\xe1\x9c\x99\x3e\x67\xaa\xbe\xfa\xea\xab\xea\xea\xfd\x74\xf6\x48\xd6\x9b\xf9\x6a\x39\xbb\xa5\x0f\x66\x25\x8e\x32\xbb\xa5\x9d\x62\x83\x56\x05\x85\x5a\x1f\xcc\xd2\x62\x95\x3f\xd9\xcc\x6e\xfd\xe5\xa7\xb3\x79\x99\xdd\x9a\x61\x4a\xbe\x64\x53\x20\x1b\x17\x81\x44\x29\x60\x51\x15\xa2\x2b\x46\xc7\xac\xac\xc9\x7e\x76\x30\x3b\x8e\x6b\x59\x8e\x77\xdb\x37\x66\x07\xb3\xf5\x6a\x75\xf6\xba\xf3\xdb\x9b\xfc\x50\x8e\xe2\xf6\xa1\xc6\xd3\x63\x99\xdd\x9a\xa5\x55\x5c\x97\xd9\xc1\x6c\x9c\x8f\x8b\xf6\xef\x7b\xeb\xd5\x0f\x24\x8f\xc3\x83\xb8\xf9\x64\x33\x3b\x98\xd5\xb9\x2c\xca\x66\x76\xeb\xd3\x59\x8e\xeb\x72\x6f\xbd\x3a\x96\xf5\x38\x97\x9d\x07\x8f\xc1\x63\xc9\x3e\x82\xad\x94\x81\x6c\x41\x60\x45\x04\x9c\x91\xb3\x0b\x5e\xfb\xaa\x67\x07\xb3\x65\x3c\x6a\xeb\xdf\x1f\xe3\x78\xd2\x16\x5e\x1d\x8f\xf3\xd5\xf2\x6c\x9d\xbc\x5a\xac\xd6\xb3\x5b\xb3\xe3\xf5\xea\xf8\xbd\xf6\xfa\x8e\xd4\x78\xb2\x18\x67\x07\x67\xb7\x20\xf2\x92\x2b\x69\x28\xd5\x16\x20\x14\x0d\x1c\x62\x02\xaf\x49\x9c\xb7\x12\x9d\x6f\xb7\x78\x14\x17\x27\xed\x1e\x1f\xca\x0f\xc7\xe1\x3b\xc7\xb3\x27\x07\x13\x4b\xff\x85\x2c\x16\xab\xc7\x5f\xae\x5c\x8a\x4f\x26\xfa\x00\x92\xb0\x00\x05\x63\x81\x83\x0d\x80\x41\xd9\x10\x4b\xb0\xde\xc4\x9d\x95\xef\x2e\x87\x7b\xeb\xd5\xe1\x5a\x36\x9b\xe9\xd5\xdf\x5f\x8b\x2c\x77\x16\x8f\x09\x53\x81\x90\xbd\x03\x2a\x12\x80\x55\x65\xb0\xda\x8b\x76\xb5\x68\xb6\xbb\x8f\xfd\xde\xea\xe8\x78\x21\xa3\x94\xdf\xfd\xea\x77\xbf\xfa\xe2\xf3\xbf\xfd\xd1\xf4\x2d\xde\x5d\xaf\x1e\x3f\xbb\x85\xb3\x62\x73\x88\x06\x4c\x8a\x11\xa8\x6a\x0f\xac\x5d\x06\x6c\x37\x8e\xde\x14\x29\x61\xe7\x16\xb7\xd7\xf9\xe1\xfc\x91\x94\xd9\x93\x8f\x9f\x21\x60\x23\x0b\xc9\xe3\xf6\x5e\x67\x4f\x6d\x8a\x63\x4c\x15\x44\x79\x02\x0a\x25\x00\x97\x6a\xc1\x1b\x54\x01\x75\xc6\x82\xe6\xb9\x3f\xef\xad\xe7\xab\xf5\x7c\x3c\x7d\x99\x47\xbf\x2d\xe5\x99\x59\x4c\xaa\xc9\xaa\x0a\xd5\xba\x00\x94\x62\x01\x8e\x26\x42\x29\xda\x66\x53\x9d\xd2\x6a\xd7\x2c\xdf\x98\x1f\x3e\x1c\xbe\xf8\xfc\xe7\xff\xd8\xe3\x4f\xf6\xd5\x06\xcf\x04\x89\x6d\x00\xca\x48\xc0\x92\x04\xb4\xcf\xde\x39\x51\xac\x4b\xd9\x59\xfb\x9b\x52\xe6\x27\x47\xfb\x5c\x19\x4f\xbf\x5c\x36\x70\xb4\xde\xa1\x07\x55\x9b\x27\xbd\x2e\xc0\x56\x15\x08\xba\x1a\x29\xa1\x16\xd4\x66\x67\xd9\x0f\x56\x8f\x5f\x64\x61\x8c\xb6\x44\x83\x0a\xbc\xb1\x19\x48\x05\x03\xec\xd9\x37\x7b\x88\xb6\xb9\x54\xd9\x1a\xeb\xa9\x85\xef\xc4\x51\x86\xf7\xd6\x12\xc7\xed\xbb\xcf\xad\xfc\xfc\x06\xf9\xec\xea\x83\xf9\x91\x6c\xef\x5b\x64\x93\xd7\xf3\xed\x07\xcf\x78\x62\x9e\xb7\xaf\xbe\xf8\xfc\xaf\xff\xb9\xfd\x6b\xf3\x40\x8e\x8e\x17\x5b\x56\x1a\xd7\x27\xf2\xe4\xe0\xe9\x0a\xb7\xc7\x2d\x4d\x79\x87\xda\x3b\x6d\x0d\x1e\xcc\x4e\x8e\xcb\xb9\x0b\x88\x8c\x14\x5c\xbb\x47\xc3\x6a\xbb\xa0\x9e\xfd\x30\x53\xa3\x45\x25\x09\x8c\xca\x05\x88\x2d\x02\x63\x88\x90\xa2\x49\xd9\x0b\xb3\xa0\xdb\xcf\x61\x9d\xdf\x7e\x39\x87\x7d\x53\x64\x9c\x2f\x0f\x87\x0f\x57\xa3\xf4\x71\x98\xcf\xa8\x51\x3c\x43\x30\x64\x81\xb2\x53\xc0\x3a\x59\x50\x49\x89\xf1\x59\xc8\x99\xfa\xdc\x23\x0f\xda\x6d\x5f\x82\xf7\xf3\xb0\x34\xba\x44\xab\x72\x84\xaa\x63\x00\x2a\xa8\x81\x9d\x71\xa0\x1b\x69\xb3\xcf\x3a\xe2\x2e\x7e\x6e\x97\xe1\x1b\xab\xbc\x27\xfc\xdb\x47\xbe\x8c\x24\xa9\xce\x68\x9f\x41\xb2\xd6\x40\x5a\xb5\x48\x72\x09\x24\x92\xd3\x06\x55\x35\x01\x77\x96\xbd\x3f\xc6\x65\x39\xd9\xc3\x8b\xf7\x4e\xd6\xc7\x8b\x67\x2b\x7b\xa5\x2a\x9b\xca\xe0\x22\x69\x20\x97\x0b\xb0\x17\x04\x6b\x44\x15\x95\x34\xe6\x6d\x3e\xf9\x72\xe5\xef\x8a\x7c\xb2\x38\x1d\xee\x9f\x2e\xf3\x8b\x80\xaf\x4d\x41\x13\x28\x82\x24\x2b\x40\x15\xb1\x65\x09\x04\x67\xb5\x95\x4c\x3a\x53\xa4\x9d\x54\x71\x72\x74\x14\xd7\xa7\x7b\x30\x3f\xca\x0f\xc7\x97\x80\xfd\x17\x3f\xfb\xef\x7f\xff\x49\x0f\xde\xbd\xf6\xda\xb9\xe0\xdc\x45\xbc\x7b\xed\x8d\x33\x81\xf5\x34\xde\x5d\x12\x13\x72\xd6\x50\xb1\x05\xb2\x4f\x15\xb8\x50\x80\xea\x84\x22\xab\x5c\xd9\xe3\x7e\xbc\x77\x7e\xbb\x23\x67\xcb\x7a\xb3\x5a\xc6\xc5\xf0\xfe\x2a\x2e\x3a\x93\x76\x75\x35\xa7\xc4\x90\xa3\x75\x40\xc9\x1b\x60\xd3\x42\xcf\x93\xf1\x29\x44\xe7\x7c\x79\xd5\xa4\xbd\x43\xf1\xa9\x5a\x4c\x55\x1c\xc4\xdc\x34\x41\x20\x06\x46\x5d\xdb\x3d\x62\xd4\xd9\x27\x45\xbb\x69\xf5\xc1\x6a\xb8\xb3\xea\xa1\x77\xef\xb3\x35\x21\x56\x30\x2a\x64\xa0\x92\x34\xb0\xc1\x00\x05\x95\x54\x2f\x41\x62\xde\xa5\xf7\x3b\xab\xf9\xf2\xb0\x23\x51\x07\x4e\x45\x62\xa3\xf7\x9c\x05\x28\x17\x01\x2e\xd9\x01\x6a\x8c\xa5\x18\x27\xee\x9c\x0a\xb8\xb3\x5a\xca\x70\x96\xa0\x5f\x90\x46\x83\x47\x5b\x34\x41\xb1\x91\x81\x58\x2c\x70\x11\x0d\xae\x72\x56\x8a\x62\x70\xac\x9e\x5b\xf8\xbd\x38\xca\xe1\x6a\xfd\xd2\x34\x7a\x3e\x4a\x0d\x92\x8d\x06\x0b\x54\xc7\x0c\xe4\x4c\x02\xf6\xb5\xa5\x3c\xaf\x09\xb3\x4e\x26\xec\x46\xe9\x07\xf3\x2a\xc3\xfd\x4f\xe6\x8b\x45\x8f\x7a\x61\x95\x64\xab\x25\x6a\x13\x77\x14\x90\x81\x89\x03\x70\x52\xa8\x59\x55\x0a\x96\x76\x16\xff\xfa\x7c\x19\x97\x59\xa6\x17\xfe\x68\x1d\x97\x87\xcf\x1e\xbb\xd6\x64\x6a\xb0\x1a\x12\xf9\x0a\xa4\xdb\x63\x57\x5d\x00\x0d\x3b\xe7\x3c\xb2\x52\xbc\x2b\x00\x24\x2e\xc6\x87\x2f\xb4\xb5\x4b\x1a\x29\x24\x48\x59\x33\x90\xad\x19\x98\x83\x40\x92\x4c\x5c\xb3\xb0\x92\x1d\xfa\xbe\x73\x22\x43\x4b\xaa\xaf\x28\x42\x43\x54\x41\x89\x31\x90\xb4\x56\x40\xe8\x18\x38\xa8\x00\xda\x60\xb6\x4a\x61\x0e\x4a\x76\x9e\xfa\x5b\x7a\xda\x14\x17\x56\x55\x91\x31\x78\x5f\xa1\x05\x0c\x90\xf5\x09\x98\x74\x02\xc1\xe4\xab\x77\x35\x59\xb4\xbb\xab\x62\xd7\xaa\x4e\xbc\x36\x41\x08\xac\xb1\x0d\x18\x29\x01\x67\xa5\xc1\x27\x85\x21\x5a\x9f\x58\xed\x02\xfa\x5b\xa6\x6b\xd5\x62\x8d\xd7\xd9\x19\x70\xae\x3d\x2b\x35\x0b\x78\xc3\x90\xa9\x64\x4a\x12\x89\x69\x37\x8b\x7d\x8b\xa6\x7c\xf6\x02\xc6\xfe\xfd\x67\xbf\xed\x26\x6c\x8b\x9a\xd1\xab\xcb\x84\x6d\xdd\xf6\xff\x69\xc2\x0e\x86\x6a\xc0\xe4\x21\xd4\xa2\x81\x52\x53\x5e\x35\x6b\x50\x44\x31\x95\x28\x39\xe7\xb0\x9f\xb0\x3b\xbf\xfd\x0a\x84\xdd\x5f\x65\x15\xef\x7d\x13\x42\xc0\x1e\x19\x48\x15\x0d\xec\xa3\x03\x1b\x48\xb9\x94\x52\xaa\x49\xbd\x2a\x61\xdf\x9b\x2f\x3f\x79\x46\x25\x24\x29\x64\xb4\x50\x6c\xf3\xed\x56\xf5\xdb\x20\x50\x3d\xd5\x56\x4e\x1a\x25\xfe\x2a\x8c\x5d\x8c\x8f\x4e\x57\x82\x6a\x0c\x36\xc6\x6e\x04\x82\x05\xac\xa8\x18\x74\x8c\xa8\xe4\x2a\x8c\x5d\x62\x8a\xa5\x84\x04\xb1\x54\x0d\x54\x42\x05\xf6\xaa\x55\x9e\x36\x66\x97\xa5\x3a\x85\xbd\x8c\xfd\x22\x44\xfe\xf2\xe7\x9d\x88\x74\xa8\x82\xb1\x56\x5f\x92\x10\x0e\xb5\x09\xd6\xe2\x1e\x44\x16\x5f\xd9\x78\x2e\xc0\x7a\xab\x3c\x19\x81\xa9\x38\x88\xb5\x26\x49\xbe\x16\x5f\xe2\x7e\x44\x76\x7e\xfb\xe5\x88\xfc\xf6\x2a\x96\xa3\x78\xdc\x05\x45\xab\xb4\xf6\xc5\x22\xa4\x9c\x3d\x90\xb7\x0a\x18\x63\x04\x36\x3a\x1a\xab\x33\x51\x7c\x65\x28\x5e\x60\x1a\xce\xd6\xfa\xea\x02\x24\x25\x05\xc8\x49\x06\x36\xd1\x00\x4b\x8d\x29\x14\xf2\x52\x77\x59\xf1\xc3\xd5\x38\xdc\x1f\xe3\xba\x55\x48\x1d\x98\x94\xec\x0a\xa6\x6c\xa1\x54\x4c\x40\xd5\x7b\x60\xf2\x01\xc4\x86\x2c\x49\x99\x10\xc8\x5d\xb9\xe8\x67\x0a\xde\xb9\x14\xc1\xba\x68\x81\xb8\x05\x2a\xbb\x0a\xa8\x9d\xc7\x4a\xda\x9a\x60\x27\x8a\xfe\x97\xea\x09\x54\x5e\xfb\x58\x0c\x58\xdf\x14\x39\x69\x07\x6c\x6a\xd3\x13\xda\x98\x5a\xab\x29\x5a\x5f\xa7\x44\x21\x24\x89\x56\x0c\x84\x18\x35\x90\xf2\x16\x38\xdb\x0c\x4a\x27\xa2\xe4\x9a\x06\xda\x4d\xf7\x5f\x3b\x9e\xe7\xe1\xf7\x9f\xfd\xb6\xc3\x22\x4e\x24\x06\x97\x5b\x39\xee\x34\x50\x68\x69\xc3\x5a\x6a\xaa\xd3\x1a\x2f\x5c\x3d\xed\xa6\xfb\xc6\x89\xad\xde\xff\xcd\xf4\xd2\x3b\x2a\x53\xd7\x92\x92\xd5\x16\xa4\x14\x04\x8a\xd5\x02\xab\x9a\x81\x8c\x97\x82\xa8\x75\xa4\xdd\xe6\xc7\xbb\x27\x87\xc3\x17\x9f\xff\xf4\xef\x5f\x64\x65\xa7\x02\xdb\x4a\x0e\x8c\x34\x2b\xb3\x93\x26\x5c\x0d\x60\x0a\xec\x89\x94\x44\x9d\x77\xb0\x7d\xbc\x9e\x2f\xc7\x57\xc4\x76\x56\xda\x79\x97\x5b\x5d\xbb\xcd\xf8\x86\x80\x93\x58\xc8\x59\x10\xd9\x97\x18\x5d\xde\x2d\xda\xb6\xf7\x18\xfa\xd4\x84\x14\x8a\xd6\x90\x02\x72\xaa\xc5\x8d\x4e\xc0\x6c\x18\x30\x5b\x27\x2c\x62\x43\x95\xcb\x6b\xf7\x69\x0a\x4d\x1c\xd0\xb0\x02\x1d\x1b\x93\x63\x29\xc0\xca\x10\x44\x95\x25\x1a\x6c\x25\xba\xbb\xbc\xb6\x79\x91\xb5\xab\xaf\x26\x16\x42\x48\x46\x47\xa0\x98\x11\x58\x57\x05\xc8\xd2\x0a\x20\x9b\x0c\xc9\xb5\x5a\x4d\x39\xb1\xe4\x12\x33\x18\x4e\x02\x54\x8c\x03\xf6\x1a\x9b\x62\xb1\x9c\x33\x47\x96\x5d\x84\xdc\xd3\xdd\x8d\x26\x71\xd1\x57\x0c\x1e\x88\x9a\xb9\x3d\x1b\xe0\x98\x0c\x98\x58\x6d\x8a\x0e\x73\xd4\xbb\x4d\xac\x7b\x7b\x6c\xbc\xdb\x64\xca\x0e\xb5\x47\x89\x60\x4b\xf4\x40\xa5\x91\x9e\x66\x07\xc6\xa3\xf3\x85\x45\x42\xdc\x4d\xc1\xf7\x26\x4d\xfb\xe2\x82\xf8\x3f\xba\xb3\x19\x5a\xb4\x8a\xe9\x72\x36\x33\xa8\x50\x91\x9a\xce\x66\x4a\xf9\x1c\xb3\x73\x40\x31\x59\xa0\xd4\x52\x83\x4d\x08\xa8\x42\x09\x62\x6d\xcc\x2a\x9f\xcf\x66\x9d\x8d\xeb\xeb\x37\xba\xf3\xf9\x84\x77\x5f\xc6\x71\xbe\x3c\xdc\x0c\xdf\xf9\xf3\x73\x49\xef\x79\xa7\xec\xb3\x33\x5b\x1d\xef\x64\xc0\x4f\xfb\x9a\xdd\xdd\x6d\xe5\xae\x56\x6b\x67\x53\xf3\xc9\x54\x17\x2f\x04\xab\x69\xaa\x8b\xa7\xc8\x06\x15\xa6\x9d\xa8\xb3\xb1\xae\x36\xed\x87\x1c\x81\x92\x16\x60\xd7\x9e\xaa\x92\x84\x42\xd1\xeb\x6d\x26\xb8\x01\x4e\x6c\xa9\x62\xca\x7b\x13\x10\xbf\xa2\x23\x3b\x77\x1e\x5e\xb7\x23\x91\x94\x45\x9a\xd0\x96\x48\x5a\x2b\x34\xfb\x1c\x59\xc8\x50\x52\xe0\x6d\x74\x40\xde\x14\x60\x34\x02\xc1\x72\xcc\x81\x63\x75\xae\xbc\x25\x47\x3e\x9a\xcb\xe3\x1d\x47\xbe\x7b\x3a\xec\xb0\xfb\x79\x09\xfa\xd1\xba\xc8\xfa\xac\xf5\x97\x57\x8b\x93\xa3\xe5\x77\xe7\x65\x7c\xd8\x2e\x3f\x69\x1f\x5d\x8c\xed\xea\xa7\x4f\x5f\x3d\xed\x11\x36\x4f\xc7\xa7\x04\x18\x97\x4d\x14\xce\x0e\xd7\xab\x93\xe3\x77\x4f\xef\xbe\xc2\x16\xc7\xc3\x79\x29\xb2\xfc\x68\xcb\xa4\x77\xcb\xd3\xa5\x37\xab\xf5\xf8\xd1\x6e\x3f\xb2\xfd\x94\x07\xe7\x55\xf5\xa3\xf9\x66\x9e\x16\x72\xe1\x9b\x4f\xdf\x7d\x2a\xa9\x4f\xcf\xde\xef\x7b\x96\x8f\xf7\xb6\xe8\xcd\x54\x8b\xde\x1a\xb7\xaf\xde\x70\xd5\xd8\x62\x92\x01\x95\x8a\x07\x8a\x6a\xdb\x19\x20\x20\xcb\x2a\x60\x36\xae\xb2\xb9\x19\xc1\x7d\x27\x8e\x31\xc5\x8d\x0c\xf7\xcf\x3e\x39\xc9\xd2\x3f\xfb\xaf\xab\x52\xf4\x57\x1b\xd9\x5d\x7b\x5a\x93\x14\xdd\xea\x05\xe3\x27\xaa\xc6\x40\xde\x39\x9a\xf6\x62\x0a\x45\xd7\xdc\x88\x59\xe9\x26\xd6\xab\x03\xd6\x39\x41\x74\x2a\x29\x6d\x42\x4e\x7c\x43\x22\xfb\xf6\x62\x5f\x97\x63\x7f\x5c\xf7\x6d\x90\xdd\xd2\x3e\x1c\xcc\xbe\xff\xfd\xa7\x77\x42\x56\x07\x9d\xbe\xd7\x88\xbd\x8e\xd5\x5a\xbd\x12\xcb\xbc\x32\x65\x8c\x31\x6d\xfb\xb9\xaf\x42\x19\x9d\x3b\xee\x9d\x2c\xd7\x65\xec\x29\x02\x42\x74\x14\xdc\x14\x01\x19\xa7\x8d\xc5\x69\xe8\x66\x95\x8b\x64\xcd\x80\x1c\x0c\x50\x90\x00\x4c\x92\xc1\xa2\x2d\x49\x45\x4b\xc5\xa4\x9b\x01\xdd\x77\x4f\x87\x67\xcd\x8b\xd7\x9e\x92\x3a\x7d\xfa\x26\x52\xd2\xb4\xaf\x89\x7c\x98\x28\x07\x10\x2d\x7b\xf2\xd3\xbe\xae\x2e\x86\xa2\x25\x81\x33\x4e\x80\x6a\xb6\xc0\x46\x7b\xc8\x8c\x31\x78\xc3\xda\x79\x7b\x33\x92\xcd\xed\x7b\x77\x87\x0f\xe2\xa9\xac\xa7\xd3\xcc\x8f\xfe\xed\x66\xa4\x99\xab\x0b\x48\xe5\x94\x9b\xf4\x9f\x76\x9a\x70\x4f\x39\xd7\xb2\x4b\x40\xe7\x21\x6d\xc5\x02\x65\x0f\x6c\x10\x01\x91\x44\xe5\x8a\xe2\x1d\x9f\xf7\x5f\xe7\x1e\xfe\xf5\xf7\xfc\x2f\xf8\xef\xc3\xd5\xa3\x01\xcf\xc7\xe9\x6a\x39\xca\x72\xfc\x32\x54\x67\x5e\x54\x2c\x51\xb5\xaa\x94\x35\x50\x29\x11\x58\x15\x07\xd5\x5a\x65\xab\x64\x2e\x2e\xcd\x3e\xde\x1d\x95\xf8\xcf\x4b\x3e\xef\xda\xbf\xbe\x35\xdb\x76\xbf\x86\xc5\xfc\xf0\xe1\xf8\xff\x66\x07\x7d\xc3\x05\x9d\x9b\xf9\x97\x7d\xeb\xb5\x47\x34\xe8\xec\x05\xdf\x6e\x67\xcd\x58\x19\xb7\xa7\xca\x4b\xac\x55\xd6\x21\x80\xc4\xa6\x58\x9c\x62\xe0\xa4\x0c\xa0\x8a\x92\x02\x72\xd5\xdb\x0d\x85\xb7\xe1\xdb\xcb\x3c\x3c\x9e\xb5\x33\x5f\x3b\x0b\x77\xce\x81\xbc\x91\xc2\xa0\x0b\x6c\x97\xb9\x7a\x3b\xcb\xe0\x95\xba\x54\xf5\x7b\xcd\x8a\x15\xee\xdd\x88\x60\x6d\xd1\x57\x07\x2e\x98\x0a\x44\x4d\xc1\xd6\x50\xa1\x7a\x0c\x0a\x15\xb5\x5c\x7f\x33\xf0\xf0\xa0\x49\xa6\xe1\xe9\x9b\xdd\x9a\xb2\x2f\x76\x5d\x93\x86\x7d\x01\xab\x8d\x3d\x2f\x3f\x6f\x9e\x50\xec\x84\xf3\x35\x90\x16\x94\x52\x53\x53\x33\x64\x3d\xd3\x9e\xa9\x19\x54\x5a\x25\x22\x86\x8c\x01\x5b\x4a\x4c\xc0\x31\x79\xc8\xc5\x59\xa7\x73\xca\x2a\x5d\xe8\x2f\x75\x4e\xca\x5c\x7f\xb2\xe6\x62\x93\x70\x8c\xeb\x71\x88\x43\x89\xf3\xc5\xe9\xf0\x83\xd5\xc9\x7a\x19\x17\x53\x0a\xe1\xf7\xbf\xfc\xf1\x64\xb7\xb0\x67\xca\xa6\x7b\x5a\xa6\x6b\xca\xa1\x7b\xa2\xa0\x6b\x3e\xa5\x73\xc4\x64\x2a\x2b\x59\x6b\x3d\x35\x08\x5c\xda\xa0\xb7\xac\x9d\xe6\x3d\xd8\x70\x39\x47\x8e\x19\x72\x22\x06\x8a\xc6\x03\xb3\x25\x60\x4a\x51\x42\xd2\x81\x89\xdf\x12\x36\x2e\x67\xa5\xcd\x9b\xab\x0e\xfa\xc6\xb5\xde\x4c\xc3\xaa\x6f\xb0\xa9\x0b\xad\x53\xa4\x62\x11\xad\xf1\x13\xe9\xcb\x5a\xcd\xe8\xf6\x94\x1a\x86\x2b\x25\x51\x1e\xaa\xae\xb1\xc5\x50\x06\xce\xda\x42\x0c\xca\x2b\xe5\x6a\x88\xd6\xde\x0c\x52\xf9\x40\xe2\x7a\x39\x8c\xab\xe1\x38\x9e\x6d\x1e\x4e\x6d\x3e\xfc\xe6\xaa\x5c\xd2\x39\x21\xd7\xc9\x25\x9d\x33\x4f\xaf\x9d\x4b\x5a\x15\x64\x2f\x75\x1a\xbc\xb6\x14\xb4\xe1\x3d\x4d\x32\xab\xb4\xc9\x1c\x1b\x83\x48\xab\xa9\x54\x05\x0e\x06\x41\xeb\x40\x41\x45\xad\x55\xb9\x21\x79\xe6\xa3\x63\x59\x0e\x6b\x19\xe7\x6b\x39\x92\xe5\x38\xc4\x9c\x57\x27\xfb\xb0\xf1\x93\x7f\xfa\x63\xcc\x33\x5d\xd3\x86\xd3\xd8\x60\x67\xed\xc5\xea\x67\x4b\x17\x4a\x29\xda\x33\xa9\x6e\x99\xad\x66\x16\x10\xef\xb1\x55\x3f\x15\xd8\x47\x04\x76\x46\x71\xae\x99\x7c\x56\x37\x03\x1b\xdf\x3e\x59\x0e\x66\x18\xe7\x47\xb2\x19\xe2\xf0\x58\x64\x72\xc7\xeb\x8b\xcf\x7f\xf2\x57\x37\x03\x16\x9d\xa3\x87\x9d\xb0\xe8\x1a\x15\x9d\x84\x05\x19\xd4\x97\x77\xcc\xb6\xb0\x30\xac\xf7\x50\x46\x4c\xd6\x19\x89\x09\x12\x29\x0f\x64\x28\x02\x7b\xcd\xa0\xb9\x64\x66\x2f\x4a\x61\xbd\x31\xf2\xa3\x9c\xc8\x50\xce\xe6\x58\x5f\xff\x8e\x59\xdf\x84\xed\xcd\x11\x20\x93\xca\xc2\x22\x86\x69\x49\x6a\xd8\xd8\x3d\xca\x42\xf9\x14\x6d\x34\x0a\x8c\x04\x07\x24\x52\x80\x4b\xac\xc0\x96\x72\x52\x31\x7a\xa3\x2e\x1c\xcc\xeb\x9c\x13\xbd\xfe\x5c\xe9\x45\x4c\x34\x63\x0e\x7f\xf6\x6a\x85\xf1\xeb\xad\x5a\xbf\xa2\xf6\xf3\xd9\x6c\xe5\xc4\x2e\xd9\xb3\x0b\x93\x9e\x13\x09\xb6\x9a\x00\x59\xb0\x69\xa1\xa6\x5e\x34\x6b\xd0\x39\xa9\x82\x52\xa3\xd3\xf5\x2d\x79\xee\x02\xc9\xbf\x1f\xd7\x45\x96\xf3\xe5\xe1\x9e\xf6\xf3\xbf\x5e\xe2\xf6\xae\x79\xe0\xce\xb1\xde\xc9\x7d\x49\x43\x4a\xe1\xe4\x34\xab\x65\x1f\xf6\x34\x8c\x2d\x1a\xd2\x98\x35\x18\x6b\x22\x10\xd6\xd4\x2c\x5e\x21\x96\xe4\x12\x8a\x4d\xd9\x97\x9b\x61\xf1\x0f\xe5\xf1\x70\x8d\xf1\x91\xd7\x6e\x7e\x22\xa7\xcd\x25\xaa\x6a\x17\xac\x56\x6e\x8f\xf9\x7d\x51\x9a\x1c\x07\x90\x1a\x2a\x50\xae\x1e\xd8\x71\x81\x24\x16\xbd\xb8\x6a\x5c\x71\x37\xc3\xfc\x5f\x17\x29\xc3\xd7\x17\x27\xb5\x9e\x4e\x43\xfe\xa7\xff\xf2\xe6\x21\x8f\x8a\x1d\x4d\x6d\xc5\x63\x60\x6b\xf7\xd8\x3c\x78\xb2\x4e\xa5\x00\xb9\xda\x0a\x44\x2a\xb7\xa2\x46\x01\x5a\xa7\x6d\xf2\xda\xa0\x96\x9b\x61\xf3\xf7\x57\x43\x5d\xad\x9b\x88\x8c\x8b\x3d\x22\xf2\x6f\x7e\xfd\x16\x88\x46\x3b\xeb\x2f\xe9\xf7\x76\xc1\x38\xdc\xb7\x8b\x4c\x21\x54\xcb\x92\x21\x91\x46\xa0\xcc\x04\x9c\x2b\x41\xf0\x11\x9d\xe3\xe0\x39\x5e\x20\x9a\xce\x51\xf9\xeb\x8f\xd6\x5f\x4c\xca\x27\x87\x9b\xb3\xb1\xe3\x57\x68\x56\x77\xb4\x95\xb7\x3b\x5c\x65\xfe\x34\x3f\xcf\x97\x79\x71\x52\xb6\xa7\x5d\x8f\x9f\x65\xd3\xfe\xa9\xf1\xed\x4c\xe9\xd9\xae\x43\xcf\x4c\xf5\xc7\x4f\xae\x2c\x0f\x3e\x3d\xff\x7c\x9d\x13\xc0\x6b\x79\x24\xeb\x8d\x94\xd9\xad\x1a\x17\x1b\x79\x72\xfd\xd6\x78\xe7\x21\x86\x4e\xfb\x75\x8e\x8d\x77\xfd\xda\x29\xfd\x43\x84\x16\x79\x82\x9a\x88\x3c\xba\x7d\xfa\xc7\x8a\xab\xda\x26\x0d\x88\x89\x81\xa4\xf9\x12\x31\x42\x2c\x88\xa6\xa0\xce\xa8\xd4\x5b\x0a\x92\x8b\x45\xae\x34\x77\x0e\xb7\xef\xdd\x1d\x8a\x6c\xe6\x87\xcb\x69\x7a\xfa\xec\xd7\x53\x5d\xf6\x2e\x1f\x75\x1f\x70\xe8\x02\x46\xf7\x09\x95\x2e\x60\x74\xcf\xd6\x77\xe1\xa7\x73\x36\x7d\x72\x4a\xc0\x86\xa0\x98\x27\x86\xbe\xb5\x62\x8b\x7b\x8a\x66\xcf\x21\xb1\xa1\x04\xa4\xd1\x02\xd9\xac\xcf\x0a\xa4\x62\x5c\x72\x21\x0b\x32\xda\x1b\xc2\xc5\xa7\xc3\xb3\x23\x1b\xaf\xbd\x64\xee\xe4\x84\xb7\xc4\x99\x57\x29\xbc\x3b\xc9\xf0\xca\x2c\x67\x94\x6a\x95\xf8\x14\xfc\x88\x8d\xdb\xb3\x65\x14\x0c\xaa\xe2\x48\x41\xae\xc5\x03\xd9\x6a\x80\x33\x6a\x28\x51\xa1\xd2\x5e\xb2\xb2\xe9\x66\xb0\xdc\xdd\xbc\x5a\x6e\x86\xb2\x5a\xfe\xff\x71\x28\xf3\xcd\xf1\x22\xee\x11\xbf\x3f\xfe\xc5\x55\x49\xae\xf3\x48\xd4\x5b\x21\xb9\xce\xc3\x49\x9d\x24\xd7\x75\xb4\x67\x92\xe4\x82\x61\x54\x13\xa5\x95\xd1\x0a\xad\xda\x33\x1e\x91\x98\x03\xb3\x8b\x50\x2b\x07\x20\x31\x8d\xa1\xab\x03\x8b\x41\x9c\xd7\xd1\xd4\xc0\x37\x04\x65\x47\xc7\xab\xf5\x38\xfc\xe9\xf0\xb5\x1f\xb6\x17\xd3\x08\xfb\xbb\x7f\xb8\x2a\xc2\x3a\x4f\xf3\x75\x22\xac\xf3\x28\x66\x27\xc2\x3a\x8f\xd6\xbd\xf6\x34\xda\x7e\x82\x09\x93\x08\x23\xeb\xf7\x14\x92\x29\xe5\xe2\xac\x8f\x10\xb7\x33\xdd\xae\x78\x60\xaf\x2c\xe4\x18\x88\xc9\x63\x55\x12\x6e\x46\x1a\xdd\xce\x73\x9f\x1d\xd1\xfc\x83\xa8\x69\xba\x20\xfb\x7f\x35\xcd\x1b\xaf\x69\x38\x90\x99\x28\xfc\xc9\x2a\xf6\x7a\xcf\xf0\x50\xca\x2e\x45\xaa\x06\xc8\xfa\x6d\x8b\xab\x00\xc7\x10\xa0\x56\x97\x94\xb7\x25\xaa\xf8\xb6\xb2\xfd\x85\x28\xf9\xda\xf1\x3c\x6f\xb6\x47\xa4\xff\x20\x82\xa4\xab\x3c\xfa\xea\x82\xa4\x13\x5d\xff\xdb\x83\xc4\x68\x67\x3c\x4d\x05\x09\x79\x0e\x66\x8f\x24\xce\x5c\xc9\xb2\x2a\x60\x6c\x52\x40\xc6\x6c\x7b\x76\x19\x32\x6b\xc7\xd9\x46\x76\x17\xff\xcc\xc9\xdb\xac\xc8\x5e\x3c\x45\xd5\xab\xef\x3b\x05\x5a\x57\x4f\xe4\x2b\xac\x02\x3b\xe1\xf6\xc7\x57\x05\xbe\xee\xc8\x40\x8b\x76\x72\x87\xc4\x68\x24\xaf\xf6\x88\x2c\xe1\xec\x85\x94\x82\x4c\x31\x02\xa1\x6f\x15\x8d\x30\x44\xf2\x3a\x59\x61\x8d\xf1\x6d\xc9\xf8\x49\x91\x95\xbe\xba\x33\x48\x37\x51\x5c\xbd\x9e\x48\xb9\x3e\xc6\x3b\x83\xab\x0b\xe3\x93\xda\xc7\xa2\x63\x9a\x38\x3a\x47\x96\x8c\xdb\x37\xe3\xd8\x75\x84\xe5\x1c\x78\x3b\x0f\xf0\x5c\x7f\x44\x7f\xfb\xd7\x18\x9f\x83\xf7\x9d\x77\x86\x3b\xf3\x4d\x3e\xd9\x6c\xe6\xab\xe5\x30\x1f\xe5\x68\xf3\xbd\xe5\x9f\x0c\x1f\x2d\xe5\x7b\xcb\xf6\xdf\x3b\xef\x0c\xb7\xf3\x78\xee\xda\xdd\x51\x8e\x06\x18\x56\x8f\x97\xe7\x0f\x61\x5d\xb4\xde\xb3\x73\x2d\x17\xad\xb7\x7b\x61\xd7\x7a\x1f\x3f\xf9\x9f\x00\x00\x00\xff\xff\x3d\x47\x42\xf2\x8e\x59\x00\x00")
+var _meetingNotesJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x56\xcd\x6e\xe3\x36\x10\x7e\x15\x55\x7b\x2b\x4c\x80\x7f\x22\x29\xdf\x92\x16\x68\x73\xe8\x66\x81\x04\x5d\x2c\x76\x83\x05\x45\x8e\x12\x61\x65\x49\x90\x68\xa7\x86\xe1\x57\x68\x81\x5e\x7a\xec\x6b\x2c\xd0\x87\x6a\x1f\xa1\x23\x59\x4d\xac\xd4\x0b\x28\xc8\xa1\x85\x01\x83\x9c\x19\x7e\x24\x67\xbe\x6f\xa8\x5d\xbc\x81\xb6\x2b\xea\x2a\x5e\xb2\x45\xec\x6d\x00\x1c\x28\x6a\x04\x4f\x68\x4a\x39\x43\x63\x80\x55\x53\xa2\xe3\xc7\xa3\xc0\xac\xac\xdd\xa7\x2e\x5e\xbe\xdf\xc5\x85\x8f\x97\xb1\xc8\x6d\xc2\x29\x64\x44\x50\xe7\x89\x34\x09\x27\x86\xa7\x96\x64\x56\x64\x4e\x83\x31\xc0\x55\xbc\x88\x1b\xdb\x42\x15\x2e\xfa\x15\x38\x6b\xeb\xfa\x30\x9e\xb9\xba\x73\x77\xb0\xb2\xc3\xfe\x61\xdb\xe0\x41\xe3\xac\xb6\xad\x47\x4f\x28\x42\xd9\xcf\x7f\x00\x08\x45\x75\x1b\xbd\xae\x03\x74\x68\xcf\x0b\x28\x3d\x1e\x73\x17\x3b\x0c\x7c\xd3\xd6\x0d\xb4\xa1\x80\xa3\x83\x6b\xc7\x19\x07\x6d\x48\x2a\x64\x42\xa4\x53\x94\x18\x96\x25\x84\x66\x14\x84\x76\x20\x95\xc8\x11\xa8\xb2\xab\x1e\xff\xba\xdf\x76\x11\xd7\x4d\xc0\x44\x1c\x50\x5c\x5d\xd6\x2d\xba\x1a\x04\xff\xa6\x1f\xbf\x83\xb2\xac\xef\x31\xea\x90\x18\xe6\x6d\x42\x9d\x25\x39\xb3\x29\x91\x9e\x33\x62\x94\x50\x84\x69\xef\x84\xd1\x8e\x59\x2e\x30\x76\x63\xcb\x75\xbf\xc1\x99\x8f\xbe\xaf\x5d\xbc\x5f\x9c\x00\x3e\xef\x43\x46\x58\x0f\xb9\x12\x4c\x3b\x02\x8e\x31\x22\x19\xf5\xc4\x58\x95\x11\xb0\x52\x31\xc1\x69\x2e\x52\x7e\x04\x7b\x15\x6c\xe5\xd7\xcd\x69\xdc\x37\xeb\xb6\x29\x1f\x90\x35\xa5\xb9\x11\xb9\x21\xca\x4a\x44\x56\x58\x10\xa3\x81\x93\x44\x00\xf5\x34\x63\xdc\x39\x7d\x84\xfc\x16\xe0\x53\xb9\x8d\xae\xb6\x15\x9e\xfa\xe6\xa1\x30\x1d\x94\xe0\xc2\xb0\xdf\x80\xca\x84\xe7\x22\x95\x96\x40\x96\x00\x91\x39\xc7\x0a\x3b\xfc\x53\x09\x4b\xc0\x49\xe6\xa4\x95\x8f\x69\xbe\x5a\xaf\x56\xb6\xdd\x4e\x32\xfd\x88\x1d\xe0\xa7\x30\xec\xe5\xa1\x73\x6d\x31\x44\x1c\x08\x55\xb8\x61\xf4\xd7\xef\xbf\xfd\xfa\xe7\xe7\x5f\x7a\x43\x77\x3d\x92\x37\x5e\x86\x76\x0d\xfb\x45\xec\x5a\xc0\xe9\x59\x18\x68\xae\x35\xd3\x4c\xa9\x54\xa9\x45\xbc\x6e\xfc\xd4\x21\x94\x48\x4d\x2f\x0a\xbc\xcb\xc1\x41\x1f\xee\x63\x28\x63\x29\x57\x9a\x64\x99\xd7\x44\x4a\xa7\x09\x4a\x86\x13\xce\x25\x50\x97\x23\xa3\x94\x99\xf2\x7d\x26\xc7\x5f\xae\x09\x37\x95\xc4\xeb\x7a\x13\xf1\xa9\x14\xea\x2a\xe0\xa9\x2e\x5b\x0f\xc8\x83\xf7\xb1\x06\x6a\xbd\xa5\xc8\xfe\xcc\x60\xc5\xbd\xb7\xc4\x50\xaf\x48\x9e\x24\x34\xc9\xc1\x19\xaf\xb2\xf8\xe6\x28\xbb\x3f\xff\xd1\x5f\xed\x48\x4c\xbb\x79\xf5\x5d\xc6\xdf\xb5\x00\x55\x54\x16\xb7\x77\xe1\x2b\xc4\x98\x25\xbe\x99\x64\xdf\x9f\xaa\x2d\xe7\x82\xab\xe4\x49\x6d\x87\xde\x66\xa8\x50\xe9\xe9\xda\x62\x16\xa8\x63\x69\x8a\xf8\x0a\x25\x8b\x0b\x88\xc9\xa8\x20\x9c\x5a\xc8\x52\x6e\x72\x96\xd1\xff\xa8\xb6\x9b\x02\xee\x8f\x6a\x7b\xbe\x8d\xc2\xa1\x23\x4d\x1b\xdd\x3f\xa5\xc5\xa2\xa1\xda\xd7\xab\xea\x6d\xe1\xc3\x5d\xef\xde\xf7\xa1\x65\xe8\xbd\xbb\x71\x34\x8a\xab\xaf\xa6\x1d\xa5\x84\xcd\x02\xa5\x1b\xdf\xb6\xf5\xba\x39\xdf\x5e\x3c\xa3\x4f\xde\x15\xde\x43\x75\x39\x68\xf2\xc2\x8f\xd0\x5d\xdd\x86\xcb\x63\x21\xf7\xd7\xb8\x9e\x76\xf0\x4d\xd1\x15\x59\x09\x4f\x56\x8e\xd6\xb1\x71\x6f\x0f\xf6\x79\x64\xbb\x39\xad\x75\x6c\x6f\xfc\xdf\x5a\x37\xc8\x07\xce\x93\xd3\x7c\xf0\x86\x25\x5c\xe7\x8a\xa8\x54\xe4\xa8\x75\x8b\xf4\xcb\xd3\x9c\xe4\x9a\xe3\x0b\x49\xa5\x92\xa9\xfa\x7f\xf0\xe1\xda\x62\xb2\xa2\xd1\x38\x97\x12\x33\xb5\xab\x38\x9f\x2b\x58\x26\x30\x93\x1f\x3f\x8e\xa7\xe2\x86\x3e\x8b\x75\xcf\xa6\x50\xe8\x6f\xfd\x4c\x0a\xcd\xa4\xf3\x0b\x98\x96\x52\x4a\x4f\xbd\x2a\x32\xd1\x46\x7e\xe1\x55\x99\xd5\x89\x27\x4c\x9b\xf9\x0e\xbd\x9c\x69\xc3\xa3\xfb\xc8\xb4\x57\xaf\xa2\x6f\x8b\xce\xad\xbb\xfe\x8b\x30\x2a\xf0\x13\xb1\xfb\x50\x7d\x1d\x5d\x56\xf0\xa1\xea\x7f\xe8\x3f\x73\x61\xe2\xbb\xc0\x41\x44\xa2\xfa\xbe\x42\x1e\x1c\xd1\xf3\x69\xf6\x1e\xda\xb3\xf8\x52\xdf\x16\xd3\xec\xdd\xec\xff\x06\xbb\xa8\x65\xc7\xc5\x0a\x00\x00")
 
-func templatesJsonBytes() ([]byte, error) {
+func meetingNotesJsonBytes() ([]byte, error) {
 	return bindataRead(
-		_templatesJson,
-		"templates.json",
+		_meetingNotesJson,
+		"meeting-notes.json",
 	)
 }
 
-func templatesJson() (*asset, error) {
-	bytes, err := templatesJsonBytes()
+func meetingNotesJson() (*asset, error) {
+	bytes, err := meetingNotesJsonBytes()
 	if err != nil {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates.json", size: 22926, mode: os.FileMode(436), modTime: time.Unix(1641808881, 0)}
+	info := bindataFileInfo{name: "meeting-notes.json", size: 2757, mode: os.FileMode(436), modTime: time.Unix(1792062479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _personalGoalsJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdd\x57\xcb\x6e\x1c\x45\x14\xfd\x95\x56\xaf\x5d\x52\xbd\x1f\xde\x91\x58\x84\x48\x91\x9c\x84\x08\x84\xa2\x2c\xea\x71\xcb\x6e\xd2\xee\x1e\xf5\xf4\x38\xb2\x2c\xaf\xd8\x02\x59\x23\x90\xb2\x43\x2c\x59\xf2\x3d\xfc\x00\xf9\x04\x6e\xcd\x8c\x9d\x99\xa8\x4d\x1a\x50\x84\x85\xd4\x8b\xea\x7a\x9c\xba\x75\xeb\xdc\x73\x6f\x5d\xd6\xe7\x30\x2c\x9b\xbe\xab\x0f\xd9\x41\x9d\xfc\x08\xd8\xd0\xd4\x0a\xae\xa8\xa3\x9c\x61\xe7\x08\x67\x8b\x16\x07\xbe\xd8\x99\x18\xda\x3e\xbe\x5c\xd6\x87\xcf\x2f\xeb\x26\xd5\x87\xb5\x0e\x20\x5c\x8c\x8c\x64\xae\x22\x91\x26\x64\x62\x93\x74\x24\x6b\x90\xde\xd2\x98\xad\xe1\xf5\x41\xbd\xf0\x03\x74\xe3\xc3\xb2\x02\xff\x86\xbe\xdf\xb4\x67\xae\x5e\xc6\x53\x38\xf3\xeb\xfd\xc7\x8b\x05\x1a\x5a\x87\xde\x0f\x09\x47\xc6\x66\x6c\xcb\xff\x63\x34\xb1\xef\x7c\x5b\x3d\xe8\x7d\xbb\xc4\x81\xdc\x40\x9b\xd0\xce\xcb\x3a\xe2\xcc\xc7\x43\xbf\x80\x61\x6c\x60\xc7\x72\x9f\x75\x8e\x21\x58\x12\xbd\xd2\x44\x06\x23\x88\x15\x10\x88\x30\x52\x98\xe0\xbc\xd6\xa6\xec\xd0\xf9\xb3\xb2\xc1\xe7\xa3\x1f\x57\x05\xb8\x5f\x8c\xe8\x8b\x0d\x4e\xec\xdb\x7e\xc0\xc1\x05\xc2\xdf\x2f\xed\xa7\x50\x96\xac\xe1\x43\x56\x3c\x64\xd0\xc4\x47\x89\x47\x73\xd2\x12\xcb\x59\x2e\x7b\x78\xcf\xa2\x09\x54\x7a\x9c\x7b\xee\xdb\x55\xc1\x7f\xd6\x57\x47\x7d\x7d\x75\x30\x01\xfa\x15\xb4\x6d\xff\xea\x1a\xd7\x98\xa8\x84\xf3\x99\x08\xea\x10\x37\x05\x86\x90\xdc\x91\xc4\x29\x64\x03\x0e\x7c\x4c\x3b\xb8\x47\x7d\xd3\x9d\x4c\xe3\x3e\x18\x00\xba\x6b\x58\x67\x43\x02\xcf\x0d\xa1\x31\x02\x91\x31\x01\xde\x44\xd4\x84\x33\xee\x53\x12\x1a\xb4\xf0\x7b\xb0\x1d\x54\x6f\xdf\xfc\xf0\x6d\x7d\xf5\xe2\xe6\x52\x96\xd0\x42\x1c\xd7\x9b\xad\x31\x93\x33\x5c\x25\x26\x49\x52\xde\x12\x69\x41\x21\x26\x30\xa2\xb3\x8d\x14\x8f\xef\xb4\xa5\xef\x3c\x7c\x1f\x99\x76\xd2\x0f\x17\x1f\xf2\xf1\xe3\xd5\xb0\xc0\x3b\xdf\xda\x2d\xb8\x54\x5e\xf0\x84\x94\xb1\xb8\x87\x16\x81\x58\x93\x25\xb1\x60\x98\xe4\x91\x05\xe1\xcc\x8e\xdd\x8f\x9a\x0c\xd5\xe7\x2f\x9b\x16\x49\xf2\x61\xa7\x58\x1a\xc0\x32\x1d\x49\x8e\xc6\xe3\x1d\x72\xbc\x43\x69\x1d\xb1\x01\x03\xc4\xd2\x2c\x9d\x92\x3b\xe0\x9f\x36\x9d\xef\x22\x4c\x03\x1f\x0f\xbe\x3b\xb9\x31\x3b\xe7\x20\xb2\x53\x8c\x04\x69\x32\x91\xac\x98\x9d\x59\x22\x5c\x58\x8d\xc4\xe3\x96\x52\xbb\x83\xfc\x19\xf8\x76\x3c\xfd\x4b\x5f\xeb\xc0\xb8\x74\x81\x84\xc8\xd0\x0f\x2a\x47\x62\xad\x03\x12\x20\x4a\x9b\x23\x58\x64\xc7\x3b\x5f\x1f\xad\xa0\x3a\x2a\x21\xff\x01\x5f\x1f\x41\xf6\xab\x76\xbc\x21\x89\x47\x69\x00\x21\x48\x60\x8c\x12\xc9\x35\xfa\xc3\x51\x47\x98\xe0\x51\x51\xca\x23\x8e\xee\x58\xfd\x84\x4d\xbb\xe2\x3d\x54\xea\x2d\x77\x06\xdd\x50\x02\x06\x4d\x37\xe8\x0b\xc9\x02\x01\x1e\x4c\x36\x3a\x07\xc5\xd5\x2e\x2a\x9f\x85\xaa\x91\x00\xc2\x81\x24\x4a\xa8\x42\x8c\x80\xa8\x91\x32\x82\x81\xc7\x9d\xc7\x4d\x2c\xdd\x25\xf4\x13\x31\x0b\x35\x29\x61\x58\xd4\x82\x68\x5d\x6c\x95\xc5\x03\x46\xa0\x86\xc8\x14\x65\x00\x2f\xad\x14\xbb\xa8\x72\xea\xce\xb0\x27\xc1\x32\x0e\xcd\xda\xf5\x1b\x49\x6c\xe2\xba\xf5\xfb\x8f\xbf\xfe\xf1\xdb\xeb\xf2\xbf\x7c\xb6\x55\xdf\xfa\x70\x1c\x56\x70\x75\x50\xc7\x01\xf0\xf7\x93\x71\xad\xd3\xc6\x30\x85\x1c\xe4\x86\x1e\xd4\xab\x45\xda\x1f\xd0\xeb\xaf\xec\xd2\xc2\x66\x80\xde\x10\x85\x53\x46\x83\x44\x35\x8a\xdc\x71\xbc\x43\x54\x3c\xeb\x83\x21\x31\x69\x5c\x14\x43\xc4\xd1\x7d\xc1\x9e\x29\xd2\xff\x5e\xd4\xe3\xbe\xa6\xa3\xe4\x0e\x63\xe5\xab\xe4\x9b\xf6\xa2\xfa\xba\x5f\x0d\x28\xf0\x7b\xd2\x7e\xed\xb4\x9f\xbe\xdb\x38\x6d\xb1\x23\xf3\x97\xf3\x04\x7e\xb6\x50\xcf\x0a\xb0\xd9\x64\x9e\x25\x8d\x33\xd5\xed\x6a\x8a\x1b\x4a\x19\x29\xd9\x04\x37\x14\x0a\x1a\xb3\xb7\x70\x43\xc7\xe8\xad\x8f\x24\x06\xf4\x82\xf4\xc2\xe0\x19\x15\x6e\x27\x83\x07\x17\x98\x43\xf5\xfb\x8f\xb8\x71\xde\xc0\xab\x1d\x6e\xdc\xbb\xa8\x96\xd7\x19\x79\x3f\xd5\x1f\x0f\x09\x30\x82\x9f\x63\x90\x61\x30\xaf\xce\xba\x2f\x9b\x34\x9e\x96\xe1\xab\x32\xb5\x1d\xcb\xe8\xe5\xb6\xb5\xdc\x4c\x2c\xac\xf1\xdb\x58\xf4\x5d\x42\x25\xa8\x4f\x86\x7e\xb5\xb8\x77\xf1\xf0\x6f\x54\x0a\xa7\x4d\x4a\xd0\x1d\xaf\x83\xfa\x61\xda\x42\x2f\xfb\x61\x3c\xbe\x91\x58\xec\x28\x07\x79\xb6\x5f\xc4\x9c\x37\xcb\x26\xb4\xf0\xde\xca\x6d\xef\xb6\x74\xb9\xd8\xf4\xcf\xcd\xa9\xb3\xd8\xfa\x62\x52\x54\x38\x4a\x1c\x9f\x22\x0e\xaa\x8d\x36\xd3\xc4\x11\x36\xa3\xfa\x51\x43\x32\xcb\x25\x51\x62\x20\xd9\xc8\x14\xf1\x8e\x1a\x4a\x75\x46\xc1\x55\x77\x43\x54\x1e\x81\x1f\xba\x6a\xec\xab\x85\x6f\xba\x71\x4a\x4d\xde\xbe\xf9\xfe\x97\x7f\xaa\x25\x33\x8b\xb3\x99\x5a\x32\x33\xdd\x7e\x74\x2d\x11\x9c\x3a\x25\x26\x28\x21\x1d\x13\x56\x4e\x53\x42\x51\x26\xa2\xf5\x45\x41\x30\x09\x4b\x47\xf1\xc2\x9c\xe0\x84\x31\x87\x3f\x1e\x4f\x94\xee\x48\x9e\x39\x5e\x40\x57\x0d\x30\x36\x03\x9c\xa1\x2d\x95\x8f\xb1\x5f\xdd\xc6\x8d\xd7\x3f\xff\x1f\xf3\xcc\xac\x42\x77\x9a\x1b\x56\x63\xae\x99\x92\x0b\x8a\xf8\xfa\x16\x6e\x60\x56\x61\xc8\x0b\x02\xc6\x60\x0d\xa2\x0b\x37\x8c\xe7\xc4\x6a\x41\x6d\xcc\x51\x9a\x48\xef\x06\x37\x9e\xae\xba\x4a\x54\x63\x73\x06\x4b\xac\x44\x5e\x01\xbc\xbc\x85\x16\xdf\xdc\x0d\x5a\xcc\xac\x7a\x67\xd2\x62\xd6\x2b\x65\x92\x16\x52\x70\x56\x6e\x7f\x82\x16\xc2\xb2\x5b\x24\xc3\x07\xa5\xf1\xd4\x78\x46\x89\xa9\x44\x0a\xe9\x91\x16\x78\x5a\x86\x2f\x51\x6b\x0d\xa0\xe4\xe5\x3b\x53\x7e\x24\x7c\x42\xa5\xcd\x13\xea\xa3\x17\x20\x33\x1f\x77\x77\xa7\x00\x99\xac\x2c\xb0\xb4\x70\xd3\x25\xa9\xb0\x42\xbd\x57\x59\xbc\xb8\xfa\x13\xc9\xa5\x2e\xe4\xa1\x12\x00\x00")
+
+func personalGoalsJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_personalGoalsJson,
+		"personal-goals.json",
+	)
+}
+
+func personalGoalsJson() (*asset, error) {
+	bytes, err := personalGoalsJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "personal-goals.json", size: 4769, mode: os.FileMode(436), modTime: time.Unix(1792062479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _personalTasksJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x55\xcd\x6a\x1c\x47\x10\x7e\x95\xa5\xcf\xdb\xd0\xff\x3d\xad\x5b\x12\x63\xe3\x8b\x25\xb0\x70\x08\xc6\x87\xfe\xa9\xb6\x06\x8d\x66\x96\x99\x59\x09\xb1\xec\x1b\x18\x92\x40\x2e\x81\x80\x0f\x7e\x82\x90\x4b\x9e\xc7\x2f\x60\x3f\x42\xaa\x67\x37\x62\xd7\x19\x81\x20\x60\x6b\x4f\xdd\x55\x35\xd5\xd5\xdf\xf7\xf5\xb7\x1b\x72\x0d\xfd\x50\x77\x2d\x39\xe1\x4b\x92\xfc\x08\xb8\x30\xac\x92\x42\x33\xc7\x04\xc7\xe0\x08\x57\xab\x06\x13\xaf\x0e\x0a\x43\xd3\xc5\xcb\x81\x9c\xbc\xde\x90\x3a\x91\x13\xe2\xa4\xca\x4e\x04\x4b\x5d\x4e\x9c\xaa\xe0\x24\xad\x72\xe4\x94\x29\xe5\x43\xf2\x10\x63\x74\x64\x49\x56\xbe\x87\x76\x7c\x5e\xbe\xc0\x5d\xdf\x75\xbb\xf5\x03\xbf\x1e\xe2\x05\x5c\xf9\xe9\xfc\xf1\x76\x85\x83\x92\xd0\xf9\x3e\x61\x66\xac\xc7\xa6\xec\xcf\x70\xc4\xae\xf5\xcd\xe2\xdc\x0f\x38\xde\x92\xe4\x1a\x9a\x84\x73\x6e\x48\xc4\xca\xb3\xbe\x5b\x41\x3f\xd6\x70\x30\x79\xb2\xd6\x06\x2f\x03\xad\xac\xa8\xa8\x62\x38\x40\x65\xbd\xa1\xda\x29\x66\x02\xfe\x72\x60\xd8\xa8\xf5\x57\xe5\x80\x97\xa3\x1f\xd7\xa5\x71\xb7\x1a\x11\x8b\x5d\x9f\xd8\x35\x5d\x8f\xc9\x15\xb6\xff\xa1\xac\xcf\xea\xf6\x12\x6b\xa6\xfe\x52\x41\x70\x51\x68\x9a\x74\xc8\x54\xb9\xe4\x68\xa5\x1d\xd0\x6c\x55\x56\xc0\x98\x64\x60\xb1\xf6\xda\x37\xeb\x72\xc0\x79\xb7\x78\xd2\x91\xed\x72\xa6\xeb\x4f\xd0\x34\xdd\xcd\xbf\x7d\x93\xc4\x29\x79\x56\x34\x4b\x29\xa8\x4a\x01\xfb\x06\x91\xa8\x06\xe6\x1d\xf7\x5e\x30\x48\x07\x7d\x9f\x74\x75\xfb\x76\xbe\xef\xb3\x1e\xa0\xbd\x6b\xeb\x83\x4f\xc9\x05\xea\x53\x46\x2a\x92\xcb\x08\x07\x13\xb4\x62\xda\x47\x13\x21\x1b\x26\x8e\xda\xb6\xb0\xf8\xfc\xfe\xf7\x77\x64\xfb\xe6\x8e\x95\x01\x1a\x88\xe3\x14\x49\x30\xc4\xbe\x9e\xb0\xda\x71\x5e\xc7\x69\xf5\xf1\x8f\xdf\x3e\xfd\xfd\x73\xd9\x0f\xe7\x7b\x79\x91\x93\xb1\x5f\xc3\x76\x49\x62\x0f\xb8\xfd\x6e\x9c\x84\x68\x8d\x60\x4e\x6a\xcd\xcd\x92\xac\x57\xe9\x28\xc1\xa5\xd3\x5a\xe8\x72\x4a\x03\xbb\x04\x2b\x37\x9c\x2e\xc2\x90\x55\xed\x25\xa3\x12\x9c\xa1\x0a\x20\xd1\x2a\x79\xbc\x8d\x56\x31\x30\xef\xad\x64\xf6\x58\x91\x0f\x54\xe1\xff\x57\xed\x75\x0d\x37\x07\xa2\xfd\xbe\x88\x78\xf1\x6a\x17\x3c\x16\xec\x69\x9f\x00\xb9\x7a\x8d\x48\x22\x6d\xeb\xab\xf6\xc7\x3a\x8d\x17\x25\xbd\x2d\xa5\xcd\x58\xb2\x9b\xfd\x6a\xd8\x15\x16\x89\xfb\x3d\xe0\xbe\x4d\xc8\x39\xb9\xa8\x53\x82\xf6\x74\xa2\xe1\x79\xda\xd7\x0d\x5d\x3f\x9e\xde\xa9\x18\x03\x65\xac\xf3\xe3\x77\x75\x5d\x0f\x75\x68\xe0\x8b\x2f\xf7\xd1\xfd\x6b\xba\xdd\xc7\xef\x65\xce\xfe\x97\xb9\xbb\xc4\x2c\x73\x00\x4e\x67\xe9\x68\x04\x11\x51\x82\xa6\xa2\x15\xaf\x38\xe5\xc8\x5b\x12\x90\x8b\xec\xbf\x11\x73\xf1\xd8\x6e\x9e\xe1\x16\xda\xf2\xb0\x0e\x89\xdb\x4b\xfc\xf3\xfb\x77\x7f\x95\x31\x0f\x3c\x67\xf3\x30\xb7\x79\xa0\x69\x6c\x67\x10\xe7\x52\x31\x26\x66\xdf\x8a\xae\xac\x63\xf3\x88\x6b\x21\x15\x17\x08\x80\xd4\xd2\x53\x25\x72\x28\x88\x67\xf4\x80\x60\x82\x00\x1d\xa2\x4d\x8f\x03\xf1\x17\x70\x33\x79\xfb\x1c\xe0\x33\x6e\xf2\xd5\xe1\x57\xca\x70\xc9\x67\xe0\x57\x9a\x33\x73\x0f\xfc\x36\x31\xae\x4c\xe5\x28\x64\xb4\x5b\x15\xb3\xa5\x95\xa9\x12\x0d\xa0\x85\x05\x93\xa5\x49\xe6\x71\xc0\xff\x14\x6d\x74\xf1\xb4\x59\xe7\x7c\x3b\x2f\xf9\x5f\xfe\xfc\xfa\x92\x17\xac\x32\x6a\xc6\x64\xb8\x70\x95\xd6\xf7\x60\xee\xac\xd2\x86\xe1\x9f\x66\xcc\x1a\x8f\x53\x2c\xe2\x54\x99\x51\xa1\x0d\xd7\xc1\x72\x29\x38\x3c\x12\x93\xe9\x16\xb9\xeb\x17\x7e\x71\xe3\x9b\xcb\x79\xd4\x7f\xfd\xf0\x0d\x8c\x86\x1b\x6d\xf5\x9c\xd1\x48\x23\xb4\x38\x46\xfd\xcd\xf6\x1f\x26\x42\xb5\x70\x68\x0a\x00\x00")
+
+func personalTasksJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_personalTasksJson,
+		"personal-tasks.json",
+	)
+}
+
+func personalTasksJson() (*asset, error) {
+	bytes, err := personalTasksJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "personal-tasks.json", size: 2664, mode: os.FileMode(436), modTime: time.Unix(1792062479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _projectTasksJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x57\x4b\x8f\xdc\x44\x10\xfe\x2b\x23\x9f\xa7\xa5\x7e\x3f\xf6\xb6\x49\x24\x58\x29\x90\x88\x2c\x2f\xa1\x28\xea\x47\xf5\xae\xc1\x3b\x1e\xd9\x9e\x0d\xa3\xd5\xfe\x83\x08\x24\xc4\x05\x71\x48\x2e\x9c\xb9\x70\xe0\xc2\x25\x7f\x88\xfc\x04\xca\x33\x93\x8d\x07\xbc\xe0\x05\x05\x96\xd3\xf4\xb8\xda\x55\xe5\xef\xab\xfe\xaa\xfa\xa2\x38\x87\xa6\x2d\xeb\x45\x71\xc0\xe6\x45\xf2\x1d\xe0\x42\x53\x2b\xb8\xa2\x8e\x72\x86\x0f\x3b\x38\x5b\x56\x68\xf8\x68\xb0\x31\x54\x75\xfc\xa2\x2d\x0e\x3e\xbb\x28\xca\x54\x1c\x14\x3c\x04\x93\xa2\x48\x24\x0a\xed\x89\x04\x4a\x89\x05\x9a\x89\xd7\x49\x30\x1f\xa9\x12\xd1\x14\xf3\x62\xe9\x1b\x58\x74\x47\xfd\x1b\xf8\xaf\xa9\xeb\xed\x7a\xe2\xdb\x6d\x3c\x85\x33\xbf\x89\xdf\xad\x97\x98\x68\x11\x6a\xdf\x24\xb4\x74\x65\x57\xf5\xff\x1f\x36\xf5\xe7\x10\xbb\xd9\xb1\x6f\x31\xbb\x79\x91\x4b\xa8\x12\xa6\x79\x51\x44\xdc\x88\xd6\x25\x34\x5d\x09\x83\xc4\xbd\x33\x3c\x45\xe3\x89\xca\x32\x12\xa9\x12\x27\x96\x4a\x49\x6c\xe4\x36\x6a\x67\x98\xc9\x0c\x1d\x2d\xfc\x59\xef\xff\x51\xe7\xbb\x55\xef\xb8\x5e\x76\x08\xc5\xd6\x4f\xac\xab\xba\x41\xe3\x12\xdd\xdf\xed\xd7\xf7\x20\xfb\x55\xd5\xe1\xb6\x4d\x08\x29\x0d\xc4\x2c\x19\x49\x59\x25\x22\x39\x30\x62\x9d\x0f\xc4\x30\x09\xda\x28\xf0\xda\xf4\x21\xce\x7d\xb5\xea\x63\xbc\x0f\x5f\x76\xb3\x0f\x97\xc5\xe5\x7c\xc4\xf5\xa7\x50\x55\xf5\xd3\xd7\x9e\x53\x32\x41\x78\xe3\x08\x04\x8e\x9e\x9d\x50\xe8\x59\x39\xc2\x1d\x55\xce\x27\xa7\x8c\xf0\x03\xcf\x47\x8b\x19\x42\x70\xd2\x40\xdb\x8e\x7b\x7f\xa7\x01\x58\x0c\x9c\xfb\xc0\x43\x22\x2e\x1a\x4d\x64\x02\x87\xc8\x64\x4b\x14\x33\xc0\x74\x4e\xcc\xaa\x61\xda\x77\x6b\xac\x12\xe8\x20\xbd\x7c\xf1\xf2\xc5\xab\xe7\xdf\x3d\x1b\x0f\x71\xa7\xa9\x9f\x5e\x85\xd0\x0a\x54\x74\x5e\x10\x11\x3c\xf2\x9e\x99\x21\x96\xe9\x48\x78\x1f\xd8\x1b\x91\x20\xb9\x41\x88\xc3\x26\x9e\x96\xe7\x90\x8a\xcb\xc7\x57\x15\xd0\x42\x85\x84\x6f\x62\x6d\xb3\x16\x49\x5b\x1e\x32\x01\x6a\x24\x42\x92\x30\x6b\xc4\x9d\x18\xc1\xa9\xe3\x2c\xf2\xc4\xc5\x1b\x3e\x1f\x36\x65\xdd\x94\xdd\xfa\xaf\x18\xfd\x00\xd2\x15\x2c\x22\xe4\xa0\xb0\x38\xb3\xd2\x8e\xc8\xe0\x13\xb1\x5e\x78\x92\x12\x53\x51\x64\x4d\x19\x1d\xc2\xf2\x6e\x79\x72\x3a\x7b\xf5\xfc\xdb\x1f\xa6\xf0\x69\x4d\x56\xce\x58\x49\x82\x45\x16\x65\xe4\x58\x87\x10\x80\x30\x83\x14\x68\xa0\x96\xa5\x34\xf0\xfd\x1e\xa4\x72\x75\x76\x1d\x95\x7e\xfd\xda\xad\xb3\x5e\x19\xcd\x0d\xa1\xb9\x67\xd2\x30\x4c\x59\x51\x24\x96\x65\x81\x08\xe7\xc4\x99\x18\xb8\xbd\x8f\x09\xfd\x09\xc2\xdc\xab\xe4\x11\x4d\x84\x54\xe1\x89\xa1\x4e\x10\x6b\xac\xe9\xf1\x00\x84\x20\x65\xd8\x80\xb5\x43\xf8\x1e\x0a\xc7\xec\x6e\x03\xf8\x93\xf6\x50\x7e\x13\x20\x6e\xad\xc7\x25\xbe\xd0\xc7\x4d\xd0\xc6\xa6\xdc\x6c\xdc\xea\x44\x19\x37\xab\x57\xcf\xbf\xfa\xb1\xff\xd7\x1e\xef\x04\xa9\x38\xe8\x9a\x15\x5c\xce\x77\x1e\x0e\xbb\x8d\x74\xe1\x97\x32\xa3\x99\x12\x7c\x5e\xac\x96\x69\xcf\xc0\xb9\xe5\xd2\xe9\x3e\x46\x5f\xab\xbd\x81\x5e\x7d\x18\xa5\x26\xfa\xa8\x11\x21\x1f\x14\x32\xab\x50\x85\x54\xe0\x04\x0b\x27\x39\x50\x0a\x85\x28\xee\x6b\xd8\x44\xdd\xfa\xe7\x3a\x17\xf7\x65\xee\x11\x74\x5d\xb9\x38\x69\x67\x1f\x7e\xb2\x27\x72\x6f\x80\xfa\xfe\xd7\x9f\xbf\xee\x73\x1d\x28\xde\xc5\x34\xad\x9b\xac\x2a\x93\x4e\xda\xc4\x9a\xbe\x1c\x23\xd1\x39\xc5\xe4\x18\x89\x54\x2a\x47\xdd\x38\x89\x2c\x0a\xa5\xb3\x03\x92\xb9\x45\x88\x03\x03\x62\x75\x9f\x55\x96\xe0\x92\xf4\x86\x09\x79\x3b\x48\xec\x7b\xd4\x18\x7b\x23\x25\xfe\x37\x89\x9c\xd8\x78\xde\x36\x91\x5c\x52\xc5\xa5\x1e\x21\x52\x32\x46\xb9\xb8\x8e\xc8\x24\x85\x0c\x28\x33\xca\xf7\xa2\x85\x80\x5b\x2e\x80\x38\x65\x7d\x44\x45\xcb\x5a\xa7\xff\x88\xc8\xf3\x12\x9e\x0e\x88\xbc\xb3\x9e\x0d\xfa\xc8\xfe\xc8\xf1\xa0\x49\xd0\x6c\xd5\x0e\x25\x7a\x75\xb6\xf8\xb8\x4c\xdd\x69\x6f\xbe\xec\xb7\x56\x5d\x6f\xbd\xd8\xad\x76\xb2\xd8\x33\xed\x77\xea\xe7\x17\x88\xe9\xbc\x38\x69\xea\xd5\xf2\xce\xfa\xe8\x06\x1d\xee\xb4\x4c\x09\x16\x0f\x36\x32\x7a\x94\x76\xae\xdb\xba\xe9\x1e\x0c\x25\xb8\xff\x94\xe3\xfd\x59\xea\xbc\x6c\xcb\x50\xc1\xef\xde\xdc\x3d\xdd\x8d\x50\xeb\xed\xf3\x69\xb9\x3c\xbe\x56\xa1\xc5\x98\x42\x2b\xa1\xb9\x1a\xaf\x09\x9d\x85\xc2\xfe\x2b\x08\x0d\xc9\xa0\x4c\xd3\x80\x25\x4f\x31\xb0\xb2\x18\x0e\x69\xcc\x56\xdc\x8e\xc3\x8d\x6d\xcf\x07\xdf\xc2\xec\xd1\x76\xe7\xa8\x4a\x7f\xf3\x4b\x71\x2b\x4e\xf6\xa4\x91\x66\x54\xa2\xad\x74\x46\x98\x3f\xb2\xc8\x9c\x44\x4d\x90\xe3\x2c\x06\x97\x58\x8e\xbd\x30\x53\xc6\x70\x80\xc8\x1a\xa7\xbe\x18\x10\x63\x1a\x28\x13\x2e\x06\x7b\x4b\x4e\xf6\x61\x55\x5d\x73\x95\xb8\xfe\x5c\x4f\x9b\x8f\x0e\x98\xc1\x06\xf6\xe4\xc9\x2e\x12\xb7\x74\x3e\x91\x7b\xc6\xf9\x54\x62\x51\x5d\x6f\xa4\x32\x37\x96\x0c\xac\x71\xcc\xfe\x66\x92\x31\xf1\xc2\x35\x51\xe5\x26\x81\x3d\x26\x40\x9c\x6b\x1c\x04\xc7\x04\x48\x68\x26\x14\x1f\x2f\x5d\x9c\x00\x13\x44\x66\x09\xb7\x18\x49\xba\xfe\x4e\x24\x21\x12\xc5\x55\x0a\xd4\x2b\x89\x47\xe9\xd6\x34\xa5\xab\xcb\xea\x5b\x6f\x49\x13\x39\xfd\x37\x5a\xd2\x38\xd7\xa8\x97\x4e\x8e\x36\x1b\x6b\xa4\x19\xe7\x3a\x6b\x8f\x42\x05\x81\x68\xa1\x01\x2f\xa7\x11\xc7\x60\x81\x37\xd4\x68\x39\x7e\xaf\xc0\x8b\xaa\x51\xb7\xa3\xd9\x1c\x3e\x3c\x9a\xdd\xf7\x6b\xe4\x6d\xb4\xcd\x3c\xfb\xa9\xf8\x9f\x0f\x90\x54\x53\x3d\xca\x1f\xd3\x78\x43\xa0\xfb\xfc\x3d\xbe\xfc\x0d\x51\x81\xea\x20\xd4\x12\x00\x00")
+
+func projectTasksJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_projectTasksJson,
+		"project-tasks.json",
+	)
+}
+
+func projectTasksJson() (*asset, error) {
+	bytes, err := projectTasksJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "project-tasks.json", size: 4820, mode: os.FileMode(436), modTime: time.Unix(1792062479, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _roadmapJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x59\xcb\x6e\x1c\x45\x14\xfd\x95\x51\x6f\xd8\xb8\x44\xbd\x1f\xde\x85\x80\x90\x37\xc4\x82\x08\x84\xa2\x28\xaa\xc7\xad\xa4\xc5\x78\x7a\xd4\xdd\x13\xb0\x2c\xff\x01\x08\x16\x48\x11\x44\x22\x12\x08\x89\x25\x4b\xc4\xe7\xf0\x03\xf0\x09\xdc\x9a\xb1\x9d\xb1\xd3\x26\x95\x90\x10\x07\xd8\xf5\x6b\x6e\x55\x9f\x7b\xce\xb9\xf7\xf6\x1c\x35\xf7\xa1\x1f\xda\x6e\xd1\xec\xb2\x9d\x26\xf9\x11\xf0\x40\x53\x2b\xb8\xa2\x8e\x72\x86\x17\x47\x38\x58\xce\xf1\xc6\x87\x5b\x0f\x86\x79\x17\x3f\x19\x9a\xdd\x5b\x47\x4d\x9b\x9a\xdd\x26\x99\x6c\x85\xb1\x89\x58\x26\x15\x91\xd1\x72\x62\x65\xd2\xc4\xe7\x1c\x20\x98\x9c\x4c\xf2\xcd\x4e\xb3\xf4\x3d\x2c\xc6\xbd\xf2\x0b\x3c\xeb\xbb\x6e\x73\x5c\xf9\xeb\x21\xde\x83\x03\xbf\x5e\x7f\x3c\x5c\xe2\x46\x9b\xd0\xf9\x3e\xe1\x9d\xb1\x1d\xe7\xe5\xfc\xfd\xce\xa7\x03\xbf\xc4\x2b\xb9\x85\x79\xc2\x0d\x1e\x35\x11\x1f\xd9\xef\xbb\x25\xf4\x63\x0b\x5b\x5b\x56\x94\x31\x93\x14\x27\x21\x46\x43\xa4\x51\x94\x58\xee\x3d\xb1\x82\x79\xa1\x58\x94\xd2\x53\x0c\xb4\xf0\x07\x25\xf2\x07\xa3\x1f\x57\x03\x9e\x77\xcb\x11\x41\xd8\xc4\x89\xdd\xbc\xeb\xf1\xe6\x12\xc3\x5f\x2f\xc7\x6f\x43\xf6\xab\xf9\x88\x8f\xad\x97\xb0\x51\x29\x93\xb5\x23\x81\x42\x22\x52\x43\xc4\xe8\x5e\x10\x8b\x8f\x05\x97\xa4\x81\xac\xf0\xd9\xfb\x7e\xbe\x2a\x6b\xbc\xd7\x8d\x33\x5c\xa7\x1f\x21\x35\xc7\x3b\x13\xe1\x3f\x86\xf9\xbc\xfb\xf4\x34\x3a\x44\x9d\x78\x88\x8a\xa4\xcc\x03\x91\xd9\x18\x44\xcd\x38\x02\xca\x45\x08\x54\x38\x27\xf5\x56\xf4\xbd\xc5\x0c\x61\xb8\xdb\xc3\x30\x4c\x47\x7f\xb7\x07\x58\x9c\x6d\x5d\x3a\xa3\x75\xf0\x44\x69\x8f\x29\xb1\x89\x11\x6b\x75\x26\x9c\x69\xc3\xb3\x64\x4a\xb8\xed\xad\x5f\xef\x90\x23\x30\xc2\xec\x8f\x47\xdf\x7c\xde\x1c\xdf\x3e\xcb\xd0\x00\x73\x88\xe3\x7a\xc1\x75\x5c\x4e\x0d\x33\x3e\x09\xa2\x8c\x64\x44\x4a\xa6\x11\x92\xcc\x88\xce\x4c\x88\x9c\xb3\x48\x8c\x3d\x46\xfd\x66\x09\xf2\x14\xcc\xcf\x83\x22\xb9\x04\xaf\x40\x10\xe7\x3d\xc6\xa7\x46\x11\xcc\x42\x24\x94\x05\x29\x83\xd6\xa0\x85\xdc\xda\xf7\x3b\xcb\x36\xce\x7e\x7b\xf8\x73\x05\x22\x1a\xc0\x3b\x1d\x1d\xf2\x53\x63\x64\xa7\x2d\xb1\x4a\x49\x22\x83\x51\xc2\x80\xcd\x46\xda\xad\xc8\x37\xfd\xf0\x09\xa2\xf1\xf5\x4f\xd3\xa1\xdf\x87\x74\x1a\x98\xe5\x14\x82\x62\x8a\x40\x4a\x9c\x48\x9f\x71\xcb\x34\x47\x22\x31\x6a\xe2\x28\x41\x2f\xdd\x56\xe0\xb7\x56\x77\x31\xee\x57\xdf\xfd\x15\xca\x9a\x3a\xab\xb2\xd4\x44\x40\x41\xd9\x6a\x40\x6e\x33\x41\x78\x70\xd6\x48\x49\xc1\xb3\xb8\xc5\xed\x65\xdf\x2e\xc6\x67\xe4\x76\xa4\xc8\x04\x1d\x3d\x09\x3e\x64\x22\x95\x90\xc4\x06\x50\x24\x46\xe0\xdc\xa2\x60\xbd\x8e\x5b\xbb\xde\xac\x31\x63\xd3\x68\x5c\x88\x0d\x49\x7a\x0c\x48\x51\x32\xb4\xe8\x86\x05\x24\x9f\xb0\x84\x47\xa5\xc1\x02\x12\x3c\xc3\x93\xb1\x79\x55\x6c\x26\xad\xe3\xc2\x52\x82\x2a\x47\xb4\x79\x42\xd7\xa1\xb8\x79\x4f\x23\xe0\x95\x10\x0d\xe8\x27\x63\x8b\xbf\x42\x3b\x9b\x2c\x7c\x92\xe8\x24\x68\x1d\x98\xc0\x88\xf6\xc5\x32\x25\xdc\x82\xb7\x34\xaa\x20\x24\x3c\x46\x7b\xbf\x6f\xbb\xbe\x1d\x0f\x9f\x86\xf7\x16\x43\x62\xb0\x10\x93\x8f\x44\x20\xc4\x44\x26\x81\xa2\x31\x8c\x93\x28\x93\xb2\x31\x5a\x6f\x61\x9b\x21\xfb\xac\x10\xef\xc7\x2a\x0f\xd1\xde\x64\xee\xd0\xff\x64\x81\xdb\x58\x34\x27\x1f\x04\x11\xc8\xc2\xe0\x35\x8f\x7e\x2d\xc7\xb3\xc8\xfc\x32\xa1\xf8\xc3\xb3\xcd\x6a\xce\x0c\x07\x74\x8e\xe4\x31\x6e\x2a\xa6\xc7\x2c\x52\xd1\x70\x6d\x12\x66\xcf\x79\xb3\x1d\x72\x12\x5a\xbc\x92\x60\x88\x7d\xbb\x46\x68\x53\x2e\xda\xb8\x3e\xfa\xe3\xd1\x83\x5f\x7f\xff\xe5\xcb\x72\x61\xb8\x79\x52\x9a\x9a\xdd\xb1\x5f\xc1\xf1\x4e\x13\x7b\xc0\xd3\x6b\xe3\xba\x88\x19\xcd\xb9\xc2\x3a\x66\xe5\x4e\xb3\x5a\xa6\x73\x37\x04\xa7\x9c\x4a\x5a\x96\x29\xbe\x55\x6e\xd0\xb3\x84\x4a\xe7\xb2\x42\xc8\x49\x90\x88\x32\xd6\x23\xe4\x76\xcc\x92\x38\xe3\xb9\xd6\xd6\x19\xeb\xd3\xf9\x6a\x56\x59\xc1\xfe\x7e\xc5\xbb\xdf\xc2\xa7\x5b\x05\x0f\xcd\x60\xd8\xb8\xc1\xc5\x92\x77\xa3\x4f\x80\x39\xba\x85\x48\x62\xba\x56\x07\x8b\x8f\xda\x34\xde\x5b\xdf\xbe\x73\xe7\xe4\xe7\xdc\xe2\x3b\xe3\xef\xe6\x63\x79\xf4\xe8\xe4\xe8\x94\x8f\x8b\xd4\x9e\x80\xdf\x2e\xe2\x7c\x85\xf9\x28\xef\xbc\xa9\xa4\x87\x7b\xcf\x60\xe6\xeb\x54\x97\xa8\x75\x56\x77\xbb\x64\xbf\xac\xe2\x4f\x96\xf7\x8b\x52\x0f\x9b\x7b\x6d\x4a\xb0\xb8\xb1\x66\xc4\x5e\x79\xd1\xf2\x6e\x43\xd7\x8f\x37\xb6\x64\x74\x6e\x7f\x95\xc2\xec\xa1\xf4\x40\x28\xb7\xdd\xec\xe7\x03\x94\xe5\x0b\xcc\x37\x37\x88\x8f\x3e\xcc\xd7\x6e\xd3\x0e\x2d\x1e\x5d\x58\xff\xe4\xea\xfe\xd9\xb2\xeb\xf7\xac\xec\x2d\x2a\xf1\xab\x74\xf3\xaa\xb7\xbd\x3d\x21\x12\x29\x51\x26\xd6\x3c\x29\x12\x29\x51\xb3\xcc\x4c\x8b\x44\x01\x6e\x53\x05\x46\x38\x0f\x96\x48\x28\xb9\xe4\xdc\x13\x8f\x89\x14\x89\xb3\xc8\x29\x7d\x45\x22\x89\x17\xba\x42\x28\xe9\x9c\x5d\xdb\xdf\x9b\x21\x89\xdb\xbb\x8b\x73\x62\x39\xb3\x95\x87\x3f\x6c\x6c\x65\xb9\xd5\x2c\x1e\xd5\xe5\xa8\xba\xef\xa8\x22\x46\x75\xe3\x58\x45\x8c\xea\x92\x57\xc5\x9f\xca\x92\x71\x3c\xe9\xc5\xce\x51\x6b\x27\xbc\x98\x51\xab\xb8\x9c\xa6\x99\xb1\x2e\x58\x21\xb1\xc1\x65\x1c\x49\xa0\x22\x36\xa2\xc9\x67\x82\x45\x30\x68\x6c\x74\xb9\xe5\xea\x8a\x78\xf1\xe1\xec\xac\x93\xaa\xf5\xe2\x4b\xec\x77\xda\x00\xef\xf6\xdd\x6a\xf9\xd6\xc6\xda\x2a\x3d\xe1\x15\x79\xe6\xe9\x5c\xf6\x2c\x9e\x59\x69\x86\xcf\xed\x72\x82\x52\xa5\xc4\x24\xfd\xa4\x15\xda\x4e\xd3\xcf\x61\xa3\x90\x34\x12\x3d\x22\x0f\x90\x7e\x19\xd9\x1e\x39\x23\xc9\x63\xff\xc0\x0c\x44\xaa\xc2\xd5\x70\xb9\x3d\xf4\xb1\x61\x96\xba\xc5\x1b\xe3\x2c\xb5\x03\xf6\x45\x87\xd3\x3e\xf7\xc5\x83\xe7\x35\xb9\xca\x49\xe5\x95\x98\x5c\xe5\xcc\x50\x69\x72\x55\x1d\xf7\xa4\xc9\x39\x61\x91\x18\x93\x26\x87\xad\xa8\x9a\x66\x59\xb0\xd6\xe1\x78\xed\x49\xce\x16\x07\x4c\x10\xc5\xa1\xb3\x26\x8a\x3b\xd0\x06\x51\xcb\xce\x5e\x11\x96\x1d\x2c\xd1\x3d\x66\x6f\xce\xde\xf9\xac\x1c\x4c\x33\xec\xdb\xef\x9f\x97\x61\x95\x43\x76\x25\xc3\x2a\xbf\x90\x54\x32\xac\x72\xe2\x7d\xe9\x65\xb4\xbc\x82\x70\x93\x0c\x93\xca\x5c\x32\xd2\x84\x10\x93\x56\x06\xdb\x33\x84\x0e\xe5\x81\x66\x66\x0d\xc5\xcd\x7b\x27\x31\x20\xcf\x74\x3d\x42\x5e\x81\x32\x5a\x3e\x9c\x0c\x9b\x2f\x27\xaf\xc5\x4c\x53\x45\xd9\xff\x67\x9a\x7f\x7c\xa6\xb1\x4e\x0a\x35\x31\xd3\x28\x8a\x4e\xce\x2e\x51\x49\xd4\xc1\x4b\xac\xf1\x28\x24\x94\x38\xd6\x7c\x14\xa5\x73\x68\xcb\x3a\x60\x4b\x8f\x45\xdf\x87\xab\xa1\x92\xf2\xe1\x72\x58\x7f\xb9\x7c\x2d\x44\x52\x35\x1e\xbd\x38\x91\x54\xb2\xeb\xbf\x2e\x12\xc1\xb4\x30\x52\x4d\x0e\xfe\xd6\x89\x4b\x5a\xe2\x68\xb3\x54\x16\x9b\x2c\xa1\x02\x56\x2e\x21\x70\x22\x33\xd8\x2a\x45\xcb\x34\x26\xd5\x5b\x34\xc3\xab\x33\x91\x9d\xfe\x6f\x33\x2d\x92\xda\xfe\xbe\xb2\x41\xab\xfa\x26\xf2\x02\xa7\xc0\x4a\xba\xfd\xfb\xa6\xc0\x97\xad\x8c\xf2\xdd\x98\x89\xc9\x36\x9e\x4b\x43\x2f\x69\xb2\xc0\x46\x03\x92\x22\x99\x24\x26\x41\x72\x53\x26\x1a\xb0\xc4\x4b\xc3\x82\x02\xcb\xb8\xb7\x57\xa9\xc9\x0a\x4f\xd5\x47\x35\x57\xaf\x62\x73\xf5\x72\x94\xf2\xf7\x39\x5e\x29\xae\x2a\x8e\x4f\xf6\x3e\x8a\x6b\x2b\xc5\x54\xef\x23\x85\xd6\x17\xbe\xe7\xde\x3e\xfe\x13\x36\x4f\x5a\x6b\x04\x20\x00\x00")
+
+func roadmapJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_roadmapJson,
+		"roadmap.json",
+	)
+}
+
+func roadmapJson() (*asset, error) {
+	bytes, err := roadmapJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "roadmap.json", size: 8196, mode: os.FileMode(436), modTime: time.Unix(1792062479, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -149,18 +233,24 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"templates.json": templatesJson,
+	"meeting-notes.json":  meetingNotesJson,
+	"personal-goals.json": personalGoalsJson,
+	"personal-tasks.json": personalTasksJson,
+	"project-tasks.json":  projectTasksJson,
+	"roadmap.json":        roadmapJson,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -193,7 +283,11 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"templates.json": &bintree{templatesJson, map[string]*bintree{}},
+	"meeting-notes.json":  &bintree{meetingNotesJson, map[string]*bintree{}},
+	"personal-goals.json": &bintree{personalGoalsJson, map[string]*bintree{}},
+	"personal-tasks.json": &bintree{personalTasksJson, map[string]*bintree{}},
+	"project-tasks.json":  &bintree{projectTasksJson, map[string]*bintree{}},
+	"roadmap.json":        &bintree{roadmapJson, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -1,7 +1,10 @@
 package sqlstore
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/focalboard/server/model"
@@ -11,43 +14,160 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-// InitializeTemplates imports default templates if the blocks table is empty.
+const (
+	// templateSeedVersionPrefix is the prefix of the system settings
+	// recording the version of each seeded template.
+	templateSeedVersionPrefix = "TemplateSeedVersion_"
+	templateAssetSuffix       = ".json"
+
+	// legacyTemplateVersion is the version of the templates imported before
+	// seeding was versioned.
+	legacyTemplateVersion = 1
+)
+
+// templateArchive is an embedded template, with the version it is seeded at.
+type templateArchive struct {
+	model.Archive
+	TemplateVersion int `json:"templateVersion"`
+}
+
+// InitializeTemplates seeds the embedded templates in the global workspace.
+// Each template is recorded with its version, so that it is only imported
+// again when the embedded version is newer.
 func (s *SQLStore) InitializeTemplates() error {
-	isNeeded, err := s.isInitializationNeeded()
+	if s.disableTemplateSeeding {
+		s.logger.Info("Template seeding is disabled")
+		return nil
+	}
+
+	settings, err := s.GetSystemSettings()
 	if err != nil {
 		return err
 	}
 
-	if isNeeded {
-		return s.importInitialTemplates()
+	if !hasTemplateSeedVersions(settings) {
+		isNeeded, err := s.isInitializationNeeded()
+		if err != nil {
+			return err
+		}
+		if !isNeeded {
+			// the templates were imported before they were versioned.
+			return s.markLegacyTemplates()
+		}
+	}
+
+	for _, name := range s.templatesToSeed() {
+		version, _ := strconv.Atoi(settings[templateSeedVersionPrefix+name])
+		if err := s.seedTemplate(name, version); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (s *SQLStore) importInitialTemplates() error {
-	s.logger.Debug("importInitialTemplates")
-	blocksJSON := initializations.MustAsset("templates.json")
+// templatesToSeed returns the names of the embedded templates to seed, all
+// of them unless a set was configured.
+func (s *SQLStore) templatesToSeed() []string {
+	available := map[string]bool{}
+	names := []string{}
+	for _, asset := range initializations.AssetNames() {
+		name := strings.TrimSuffix(asset, templateAssetSuffix)
+		available[name] = true
+		names = append(names, name)
+	}
+
+	if len(s.seedTemplates) == 0 {
+		return names
+	}
+
+	names = []string{}
+	for _, name := range s.seedTemplates {
+		if !available[name] {
+			s.logger.Warn("Unknown template to seed", mlog.String("name", name))
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func (s *SQLStore) seedTemplate(name string, seededVersion int) error {
+	var archive templateArchive
+	err := json.Unmarshal(initializations.MustAsset(name+templateAssetSuffix), &archive)
+	if err != nil {
+		return err
+	}
+
+	if seededVersion >= archive.TemplateVersion {
+		return nil
+	}
+
+	s.logger.Debug("seedTemplate",
+		mlog.String("name", name),
+		mlog.Int("version", archive.TemplateVersion),
+		mlog.Int("block_count", len(archive.Blocks)),
+	)
+
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.importTemplate(tx, archive); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "seedTemplate"))
+		}
+		return err
+	}
 
-	var archive model.Archive
-	err := json.Unmarshal(blocksJSON, &archive)
+	err = s.setSystemSetting(tx, templateSeedVersionPrefix+name, strconv.Itoa(archive.TemplateVersion))
 	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "seedTemplate"))
+		}
 		return err
 	}
 
+	return tx.Commit()
+}
+
+// importTemplate upserts the blocks of a template, deleting the blocks of a
+// previous version that are gone from this one.
+func (s *SQLStore) importTemplate(db sq.BaseRunner, archive templateArchive) error {
 	globalContainer := store.Container{
 		WorkspaceID: "0",
 	}
 
-	s.logger.Debug("Inserting blocks", mlog.Int("block_count", len(archive.Blocks)))
+	blockIDs := map[string]bool{}
+	rootIDs := map[string]bool{}
+	for _, block := range archive.Blocks {
+		blockIDs[block.ID] = true
+		rootIDs[block.RootID] = true
+	}
+
+	for rootID := range rootIDs {
+		existing, err := s.getBlocksWithRootID(db, globalContainer, rootID)
+		if err != nil {
+			return err
+		}
+		for _, block := range existing {
+			if blockIDs[block.ID] {
+				continue
+			}
+			if err := s.deleteBlock(db, globalContainer, block.ID, "system"); err != nil {
+				return err
+			}
+		}
+	}
+
 	for i := range archive.Blocks {
 		s.logger.Trace("insert block",
 			mlog.String("blockID", archive.Blocks[i].ID),
 			mlog.String("block_type", archive.Blocks[i].Type.String()),
 			mlog.String("block_title", archive.Blocks[i].Title),
 		)
-		err := s.InsertBlock(globalContainer, &archive.Blocks[i], "system")
-		if err != nil {
+		if err := s.insertBlock(db, globalContainer, &archive.Blocks[i], "system"); err != nil {
 			return err
 		}
 	}
@@ -55,6 +175,29 @@ func (s *SQLStore) importInitialTemplates() error {
 	return nil
 }
 
+// markLegacyTemplates records the templates imported before seeding was
+// versioned, so that they aren't imported twice.
+func (s *SQLStore) markLegacyTemplates() error {
+	s.logger.Debug("markLegacyTemplates")
+	for _, asset := range initializations.AssetNames() {
+		name := strings.TrimSuffix(asset, templateAssetSuffix)
+		err := s.SetSystemSetting(templateSeedVersionPrefix+name, strconv.Itoa(legacyTemplateVersion))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasTemplateSeedVersions(settings map[string]string) bool {
+	for key := range settings {
+		if strings.HasPrefix(key, templateSeedVersionPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // isInitializationNeeded returns true if the blocks table is empty.
 func (s *SQLStore) isInitializationNeeded() (bool, error) {
 	query := s.getQueryBuilder(s.db).
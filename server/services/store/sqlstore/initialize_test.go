@@ -0,0 +1,94 @@
+package sqlstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	st "github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/stretchr/testify/require"
+)
+
+const roadmapTemplateBoardID = "d7f8378d-8145-4c82-84d6-affbeb7fd7da"
+
+func TestInitializeTemplates(t *testing.T) {
+	store, tearDown := SetupTests(t)
+	sqlStore := store.(*SQLStore)
+	defer tearDown()
+
+	container := st.Container{WorkspaceID: "0"}
+
+	boards, err := sqlStore.GetBlocksWithType(container, model.TypeBoard)
+	require.NoError(t, err)
+	require.Len(t, boards, len(sqlStore.templatesToSeed()))
+
+	version, err := sqlStore.GetSystemSetting(templateSeedVersionPrefix + "roadmap")
+	require.NoError(t, err)
+	require.Equal(t, "1", version)
+
+	t.Run("not seeded again on restart", func(t *testing.T) {
+		require.NoError(t, sqlStore.InitializeTemplates())
+
+		blocks, err := sqlStore.GetAllBlocks(container)
+		require.NoError(t, err)
+
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		require.NoError(t, sqlStore.DeleteBlock(container, roadmapTemplateBoardID, "user-id"))
+		require.NoError(t, sqlStore.InitializeTemplates())
+
+		after, err := sqlStore.GetAllBlocks(container)
+		require.NoError(t, err)
+		require.Len(t, after, len(blocks)-1)
+	})
+
+	t.Run("seeded again when the version is newer", func(t *testing.T) {
+		stray := model.Block{ID: "stray-block", RootID: roadmapTemplateBoardID, ParentID: roadmapTemplateBoardID, Type: model.TypeText}
+		require.NoError(t, sqlStore.InsertBlock(container, &stray, "user-id"))
+		require.NoError(t, sqlStore.SetSystemSetting(templateSeedVersionPrefix+"roadmap", "0"))
+
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		require.NoError(t, sqlStore.InitializeTemplates())
+
+		board, err := sqlStore.GetBlock(container, roadmapTemplateBoardID)
+		require.NoError(t, err)
+		require.NotNil(t, board)
+		block, err := sqlStore.GetBlock(container, stray.ID)
+		require.NoError(t, err)
+		require.Nil(t, block)
+
+		version, err := sqlStore.GetSystemSetting(templateSeedVersionPrefix + "roadmap")
+		require.NoError(t, err)
+		require.Equal(t, "1", version)
+	})
+
+	t.Run("seeding disabled", func(t *testing.T) {
+		sqlStore.disableTemplateSeeding = true
+		defer func() { sqlStore.disableTemplateSeeding = false }()
+
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
+		require.NoError(t, sqlStore.DeleteBlock(container, roadmapTemplateBoardID, "user-id"))
+		require.NoError(t, sqlStore.SetSystemSetting(templateSeedVersionPrefix+"roadmap", "0"))
+		require.NoError(t, sqlStore.InitializeTemplates())
+
+		board, err := sqlStore.GetBlock(container, roadmapTemplateBoardID)
+		require.NoError(t, err)
+		require.Nil(t, board)
+	})
+
+	t.Run("configured templates", func(t *testing.T) {
+		sqlStore.seedTemplates = []string{"roadmap", "unknown"}
+		defer func() { sqlStore.seedTemplates = nil }()
+
+		require.Equal(t, []string{"roadmap"}, sqlStore.templatesToSeed())
+	})
+}
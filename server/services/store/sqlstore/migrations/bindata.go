@@ -32,6 +32,18 @@
 // migrations_files/000015_blocks_history_no_nulls.up.sql
 // migrations_files/000016_subscriptions_table.down.sql
 // migrations_files/000016_subscriptions_table.up.sql
+// migrations_files/000017_notification_settings.down.sql
+// migrations_files/000017_notification_settings.up.sql
+// migrations_files/000018_board_api_keys.down.sql
+// migrations_files/000018_board_api_keys.up.sql
+// migrations_files/000019_card_mutes.down.sql
+// migrations_files/000019_card_mutes.up.sql
+// migrations_files/000020_board_acknowledgments.down.sql
+// migrations_files/000020_board_acknowledgments.up.sql
+// migrations_files/000021_blocks_history_fields_diff.down.sql
+// migrations_files/000021_blocks_history_fields_diff.up.sql
+// migrations_files/000022_board_api_keys_token_index.down.sql
+// migrations_files/000022_board_api_keys_token_index.up.sql
 package migrations
 
 import (
@@ -748,6 +760,246 @@ func _000016_subscriptions_tableUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __000017_notification_settingsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\xcd\xcb\x2f\xc9\x4c\xcb\x4c\x4e\x2c\xc9\xcc\xcf\x8b\x2f\x4e\x2d\x29\xc9\xcc\x4b\x2f\xb6\xe6\x72\x21\xac\x38\x25\x33\x3d\xb5\xb8\x04\xa8\x16\x00\xbd\xee\x21\x32\x59\x00\x00\x00")
+
+func _000017_notification_settingsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_notification_settingsDownSql,
+		"000017_notification_settings.down.sql",
+	)
+}
+
+func _000017_notification_settingsDownSql() (*asset, error) {
+	bytes, err := _000017_notification_settingsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_notification_settings.down.sql", size: 89, mode: os.FileMode(436), modTime: time.Unix(1792062028, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000017_notification_settingsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x90\xcd\x4e\xc3\x30\x10\x84\xcf\xcd\x53\xec\x31\x91\xaa\xaa\x08\x84\x90\x38\xb9\xc1\x05\x8b\x52\x90\x63\x50\x7b\x8a\xdc\x78\x53\x2c\x9a\x38\xd8\x2e\x3f\x8a\xfc\xee\xa4\x15\x70\x68\xe9\xa1\x5c\x67\x77\x67\x76\xbe\x94\x53\x22\x28\x08\x32\x9a\x50\x60\x63\x98\xde\x0b\xa0\x33\x96\x89\x0c\xda\x76\xd0\x58\x2c\xf5\x47\x08\xb5\xf1\xba\xd4\x85\xf4\xda\xd4\xb9\x43\xef\x75\xbd\x74\x10\x47\xbd\x85\x91\x56\xe5\x5a\xc1\x13\xe1\xe9\x0d\xe1\xf1\xe9\x79\xd2\x8f\x7a\xef\xc6\xbe\xb8\x46\x16\xb8\x3f\x5a\x3b\xb4\xfb\x6a\x65\x14\xfe\x4a\x27\xc3\xed\x62\xa3\xa4\xc7\x5c\x7a\x18\xb1\x6b\x36\x15\x9d\xf4\xc0\xd9\x1d\xe1\x73\xb8\xa5\x73\x88\x7f\xa2\xfb\xf0\x6d\x99\x44\x49\xf7\xb3\x2e\x61\x50\x7d\xba\xd7\x55\x08\x57\x74\x4c\x1e\x27\x02\x36\xa6\x24\x15\x94\x43\x46\x05\xac\x7d\x79\x51\x2d\xce\xda\x16\x6b\x15\xc2\x65\x14\xa5\xc7\x23\x50\x7a\x89\xce\x6f\x09\x1c\xd3\xfd\x00\xad\xbf\x91\x48\xef\x65\xf1\x5c\x61\xdd\xe5\x08\x3a\xdb\xf4\x2f\x2c\xee\x22\x51\xb8\xd2\x6f\xdd\xf9\x41\x4c\xff\xe6\xf2\x05\x3e\x3b\x7c\x96\x1a\x02\x00\x00")
+
+func _000017_notification_settingsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000017_notification_settingsUpSql,
+		"000017_notification_settings.up.sql",
+	)
+}
+
+func _000017_notification_settingsUpSql() (*asset, error) {
+	bytes, err := _000017_notification_settingsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000017_notification_settings.up.sql", size: 538, mode: os.FileMode(436), modTime: time.Unix(1792062028, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000018_board_api_keysDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\x4f\x2c\x4a\x89\x4f\x2c\xc8\x8c\xcf\x4e\xad\x2c\xb6\xe6\x02\x00\x46\x17\xba\xee\x26\x00\x00\x00")
+
+func _000018_board_api_keysDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_board_api_keysDownSql,
+		"000018_board_api_keys.down.sql",
+	)
+}
+
+func _000018_board_api_keysDownSql() (*asset, error) {
+	bytes, err := _000018_board_api_keysDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_board_api_keys.down.sql", size: 38, mode: os.FileMode(436), modTime: time.Unix(1792064907, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000018_board_api_keysUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\xcf\x5d\x0b\x82\x30\x18\x05\xe0\xeb\xfc\x15\xef\xa5\x42\x88\x51\x44\xd0\xd5\xb4\x55\xa3\x4f\xe6\x8a\xba\x1a\xb3\x4d\x18\xe6\x47\x6a\x94\xc8\xfe\x7b\x49\xd0\x45\xdd\x3e\x87\x73\xe0\x04\x14\x23\x86\x81\x21\x7f\x8d\x81\xcc\x61\xbb\x63\x80\x4f\x24\x64\x21\xb4\xad\x5b\x94\x2a\xd6\x4f\x63\xa2\x5c\x94\x92\x8b\x42\xf3\x44\x35\x15\xd8\x56\x4f\x4b\x38\x22\x1a\x2c\x11\xb5\x87\x63\xa7\x6f\xf5\x1e\x79\x99\x54\x85\xb8\x28\xfe\x17\x7d\xda\x7f\x9c\x89\x54\x7d\x69\xe0\x79\x9d\xd5\x79\xa2\xb2\x5f\xbc\x94\x4a\xd4\x4a\xf2\xa8\xf9\x59\xf8\x04\x5c\xd4\xe0\x93\x05\xd9\xb2\x37\xed\x29\xd9\x20\x7a\x86\x15\x3e\x83\xad\xa5\x63\x39\xef\x1f\x3a\x06\x37\x6d\xaa\xdb\xd5\x98\x19\x9e\xa3\xc3\x9a\x41\xb7\x82\x02\x86\x29\x84\x98\xc1\xbd\x8e\x27\x69\x34\x6a\x5b\x95\x49\x63\xa6\xd6\x0b\x55\xab\xa5\x1e\x16\x01\x00\x00")
+
+func _000018_board_api_keysUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000018_board_api_keysUpSql,
+		"000018_board_api_keys.up.sql",
+	)
+}
+
+func _000018_board_api_keysUpSql() (*asset, error) {
+	bytes, err := _000018_board_api_keysUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000018_board_api_keys.up.sql", size: 278, mode: os.FileMode(436), modTime: time.Unix(1792064907, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000019_card_mutesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\x4e\x2c\x4a\x89\xcf\x2d\x2d\x49\x2d\xb6\xe6\x02\x00\x46\x57\x60\x56\x22\x00\x00\x00")
+
+func _000019_card_mutesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_card_mutesDownSql,
+		"000019_card_mutes.down.sql",
+	)
+}
+
+func _000019_card_mutesDownSql() (*asset, error) {
+	bytes, err := _000019_card_mutesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_card_mutes.down.sql", size: 34, mode: os.FileMode(436), modTime: time.Unix(1792066654, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000019_card_mutesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8e\xc1\x0a\x82\x40\x18\x84\xcf\xfa\x14\xff\x51\x41\xbc\x14\x11\x74\x5a\x6d\xad\x25\xb3\x58\xb7\xc8\x93\xac\xba\x82\x94\x69\xab\x52\x21\xfb\xee\x59\x78\xb3\xe3\xcc\xf0\xcd\x8c\x4b\x31\x62\x18\x18\x72\x7c\x0c\xc4\x83\xe0\xc0\x00\x5f\x48\xc8\x42\xe8\x7b\xbb\x96\x22\x2f\x5e\x4a\xa5\x5c\x66\x71\xd9\xb5\xa2\x01\x43\xd7\x7e\xaa\xc8\xe0\x8c\xa8\xbb\x45\xd4\x98\x2d\x4c\x4b\xd7\x9e\x95\xbc\x36\x35\x4f\xc5\x34\x4a\xaa\xbf\x44\xd7\x08\x39\x75\x53\x29\x78\x2b\x62\xde\x82\x43\x36\x24\x60\x83\x75\xa4\x64\x8f\x68\x04\x3b\x1c\x81\x31\xae\x5b\x30\xe2\xa6\x6e\x0e\x57\x8b\x1c\xec\xf2\xdd\x3c\x6e\x4a\xad\xb1\x87\x4e\x3e\x83\x6f\x27\x72\x19\xa6\x10\x62\x06\x5d\x9b\x2f\xcb\x64\xde\xf7\xe2\x9e\x29\xb5\xd2\x3f\x3d\xe7\xd3\xdc\xf9\x00\x00\x00")
+
+func _000019_card_mutesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000019_card_mutesUpSql,
+		"000019_card_mutes.up.sql",
+	)
+}
+
+func _000019_card_mutesUpSql() (*asset, error) {
+	bytes, err := _000019_card_mutesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000019_card_mutes.up.sql", size: 249, mode: os.FileMode(436), modTime: time.Unix(1792066654, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000020_board_acknowledgmentsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\x4f\x2c\x4a\x89\x4f\x4c\xce\xce\xcb\x2f\xcf\x49\x4d\x49\xcf\x4d\xcd\x2b\x29\xb6\xe6\x02\x00\x85\x33\x5a\x50\x2d\x00\x00\x00")
+
+func _000020_board_acknowledgmentsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_board_acknowledgmentsDownSql,
+		"000020_board_acknowledgments.down.sql",
+	)
+}
+
+func _000020_board_acknowledgmentsDownSql() (*asset, error) {
+	bytes, err := _000020_board_acknowledgmentsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_board_acknowledgments.down.sql", size: 45, mode: os.FileMode(436), modTime: time.Unix(1792071524, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000020_board_acknowledgmentsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8f\xcb\x0a\xc2\x30\x14\x44\xd7\xf6\x2b\xee\xb2\x05\x71\xa3\x88\xe0\x2a\xd6\x54\x83\x4f\xd2\x28\x76\x25\xb1\x4d\x25\xf4\xa5\x49\x7c\x51\xf2\xef\x56\xd4\x85\xb8\x9d\x03\x73\x66\x7c\x8a\x11\xc3\xc0\xd0\x68\x8e\x81\x04\xb0\x5c\x31\xc0\x3b\x12\xb2\x10\xea\xba\x73\x52\x22\x95\x77\x6b\x0f\x15\x57\xc9\x9e\xc7\x59\x59\xdd\x72\x91\x1c\x0b\x51\x1a\x0d\xae\xd3\x7a\x03\x99\xc0\x16\x51\x7f\x8a\xa8\xdb\xed\x7b\x6d\xa7\x75\xab\x54\xa6\x4f\x3c\x16\xff\xe8\xa2\x85\xfa\x4f\xdf\x3d\x39\xd7\xa6\xb1\x18\x79\x95\xe6\xb1\xe7\x06\x46\x64\x42\x96\xac\xe1\xb1\x12\xdc\x88\x9f\x68\x4d\xc9\x02\xd1\x08\x66\x38\x02\xf7\xbb\xa3\x0d\x9f\x7e\xcf\xf1\x9a\x03\x32\x85\x4e\xf1\xd0\xe7\xdc\xda\x31\x0e\xd0\x66\xce\xe0\x25\x45\x3e\xc3\x14\x42\xcc\xe0\x62\xd2\x41\x71\xe8\xd5\xb5\x28\x13\x6b\x87\xce\x13\xac\x24\x2d\x7b\x0f\x01\x00\x00")
+
+func _000020_board_acknowledgmentsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000020_board_acknowledgmentsUpSql,
+		"000020_board_acknowledgments.up.sql",
+	)
+}
+
+func _000020_board_acknowledgmentsUpSql() (*asset, error) {
+	bytes, err := _000020_board_acknowledgmentsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000020_board_acknowledgments.up.sql", size: 271, mode: os.FileMode(436), modTime: time.Unix(1792071524, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000021_blocks_history_fields_diffDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\xc9\x4f\xce\x2e\x8e\xcf\xc8\x2c\x2e\xc9\x2f\xaa\xe4\x72\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xcb\x4c\xcd\x49\x29\x8e\x4f\xc9\x4c\x4b\xb3\xe6\x02\x00\x67\x8d\xc5\x81\x3f\x00\x00\x00")
+
+func _000021_blocks_history_fields_diffDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000021_blocks_history_fields_diffDownSql,
+		"000021_blocks_history_fields_diff.down.sql",
+	)
+}
+
+func _000021_blocks_history_fields_diffDownSql() (*asset, error) {
+	bytes, err := _000021_blocks_history_fields_diffDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000021_blocks_history_fields_diff.down.sql", size: 63, mode: os.FileMode(436), modTime: time.Unix(1792072162, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000021_blocks_history_fields_diffUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\xa8\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\xc9\x4f\xce\x2e\x8e\xcf\xc8\x2c\x2e\xc9\x2f\xaa\xe4\x72\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\x48\xcb\x4c\xcd\x49\x29\x8e\x4f\xc9\x4c\x4b\x53\x70\xf2\xf7\xf7\x71\x75\xf4\x53\x70\x71\x75\x73\x0c\xf5\x09\x51\x70\x73\xf4\x09\x76\xb5\xe6\x02\x00\xc6\x7c\xac\x35\x54\x00\x00\x00")
+
+func _000021_blocks_history_fields_diffUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000021_blocks_history_fields_diffUpSql,
+		"000021_blocks_history_fields_diff.up.sql",
+	)
+}
+
+func _000021_blocks_history_fields_diffUpSql() (*asset, error) {
+	bytes, err := _000021_blocks_history_fields_diffUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000021_blocks_history_fields_diff.up.sql", size: 84, mode: os.FileMode(436), modTime: time.Unix(1792072162, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000022_board_api_keys_token_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xc8\x4c\xa9\x88\xaf\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\x4f\x2c\x4a\x89\x4f\x2c\xc8\x8c\xcf\x4e\xad\x2c\x8e\x2f\xc9\xcf\x4e\xcd\xab\xae\xce\x4c\x53\xd0\xcb\xad\x2c\x2e\xcc\xa9\xad\x55\xf0\xf7\x53\xc0\xa9\xbe\xba\x3a\x35\x2f\xa5\xb6\xd6\x9a\x0b\x00\x98\x7d\x3c\xb6\x61\x00\x00\x00")
+
+func _000022_board_api_keys_token_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000022_board_api_keys_token_indexDownSql,
+		"000022_board_api_keys_token_index.down.sql",
+	)
+}
+
+func _000022_board_api_keys_token_indexDownSql() (*asset, error) {
+	bytes, err := _000022_board_api_keys_token_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000022_board_api_keys_token_index.down.sql", size: 97, mode: os.FileMode(436), modTime: time.Unix(1792076352, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var __000022_board_api_keys_token_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x0e\x72\x75\x0c\x71\x55\x08\xf5\xf3\x0c\x0c\x75\x55\xf0\xf4\x73\x71\x8d\x50\xc8\x4c\xa9\x88\xaf\xae\xd6\x2b\x28\x4a\x4d\xcb\xac\xa8\xad\x4d\xca\x4f\x2c\x4a\x89\x4f\x2c\xc8\x8c\xcf\x4e\xad\x2c\x8e\x2f\xc9\xcf\x4e\xcd\x53\xf0\xf7\x53\xc0\xa9\x44\x03\xac\x44\xd3\x9a\x0b\x00\x63\x84\xaa\xc5\x5d\x00\x00\x00")
+
+func _000022_board_api_keys_token_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__000022_board_api_keys_token_indexUpSql,
+		"000022_board_api_keys_token_index.up.sql",
+	)
+}
+
+func _000022_board_api_keys_token_indexUpSql() (*asset, error) {
+	bytes, err := _000022_board_api_keys_token_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "000022_board_api_keys_token_index.up.sql", size: 93, mode: os.FileMode(436), modTime: time.Unix(1792076352, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -800,49 +1052,63 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"000001_init.down.sql":                    _000001_initDownSql,
-	"000001_init.up.sql":                      _000001_initUpSql,
-	"000002_system_settings_table.down.sql":   _000002_system_settings_tableDownSql,
-	"000002_system_settings_table.up.sql":     _000002_system_settings_tableUpSql,
-	"000003_blocks_rootid.down.sql":           _000003_blocks_rootidDownSql,
-	"000003_blocks_rootid.up.sql":             _000003_blocks_rootidUpSql,
-	"000004_auth_table.down.sql":              _000004_auth_tableDownSql,
-	"000004_auth_table.up.sql":                _000004_auth_tableUpSql,
-	"000005_blocks_modifiedby.down.sql":       _000005_blocks_modifiedbyDownSql,
-	"000005_blocks_modifiedby.up.sql":         _000005_blocks_modifiedbyUpSql,
-	"000006_sharing_table.down.sql":           _000006_sharing_tableDownSql,
-	"000006_sharing_table.up.sql":             _000006_sharing_tableUpSql,
-	"000007_workspaces_table.down.sql":        _000007_workspaces_tableDownSql,
-	"000007_workspaces_table.up.sql":          _000007_workspaces_tableUpSql,
-	"000008_teams.down.sql":                   _000008_teamsDownSql,
-	"000008_teams.up.sql":                     _000008_teamsUpSql,
-	"000009_blocks_history.down.sql":          _000009_blocks_historyDownSql,
-	"000009_blocks_history.up.sql":            _000009_blocks_historyUpSql,
-	"000010_blocks_created_by.down.sql":       _000010_blocks_created_byDownSql,
-	"000010_blocks_created_by.up.sql":         _000010_blocks_created_byUpSql,
-	"000011_match_collation.down.sql":         _000011_match_collationDownSql,
-	"000011_match_collation.up.sql":           _000011_match_collationUpSql,
-	"000012_match_column_collation.down.sql":  _000012_match_column_collationDownSql,
-	"000012_match_column_collation.up.sql":    _000012_match_column_collationUpSql,
-	"000013_millisecond_timestamps.down.sql":  _000013_millisecond_timestampsDownSql,
-	"000013_millisecond_timestamps.up.sql":    _000013_millisecond_timestampsUpSql,
-	"000014_add_not_null_constraint.down.sql": _000014_add_not_null_constraintDownSql,
-	"000014_add_not_null_constraint.up.sql":   _000014_add_not_null_constraintUpSql,
-	"000015_blocks_history_no_nulls.down.sql": _000015_blocks_history_no_nullsDownSql,
-	"000015_blocks_history_no_nulls.up.sql":   _000015_blocks_history_no_nullsUpSql,
-	"000016_subscriptions_table.down.sql":     _000016_subscriptions_tableDownSql,
-	"000016_subscriptions_table.up.sql":       _000016_subscriptions_tableUpSql,
+	"000001_init.down.sql":                       _000001_initDownSql,
+	"000001_init.up.sql":                         _000001_initUpSql,
+	"000002_system_settings_table.down.sql":      _000002_system_settings_tableDownSql,
+	"000002_system_settings_table.up.sql":        _000002_system_settings_tableUpSql,
+	"000003_blocks_rootid.down.sql":              _000003_blocks_rootidDownSql,
+	"000003_blocks_rootid.up.sql":                _000003_blocks_rootidUpSql,
+	"000004_auth_table.down.sql":                 _000004_auth_tableDownSql,
+	"000004_auth_table.up.sql":                   _000004_auth_tableUpSql,
+	"000005_blocks_modifiedby.down.sql":          _000005_blocks_modifiedbyDownSql,
+	"000005_blocks_modifiedby.up.sql":            _000005_blocks_modifiedbyUpSql,
+	"000006_sharing_table.down.sql":              _000006_sharing_tableDownSql,
+	"000006_sharing_table.up.sql":                _000006_sharing_tableUpSql,
+	"000007_workspaces_table.down.sql":           _000007_workspaces_tableDownSql,
+	"000007_workspaces_table.up.sql":             _000007_workspaces_tableUpSql,
+	"000008_teams.down.sql":                      _000008_teamsDownSql,
+	"000008_teams.up.sql":                        _000008_teamsUpSql,
+	"000009_blocks_history.down.sql":             _000009_blocks_historyDownSql,
+	"000009_blocks_history.up.sql":               _000009_blocks_historyUpSql,
+	"000010_blocks_created_by.down.sql":          _000010_blocks_created_byDownSql,
+	"000010_blocks_created_by.up.sql":            _000010_blocks_created_byUpSql,
+	"000011_match_collation.down.sql":            _000011_match_collationDownSql,
+	"000011_match_collation.up.sql":              _000011_match_collationUpSql,
+	"000012_match_column_collation.down.sql":     _000012_match_column_collationDownSql,
+	"000012_match_column_collation.up.sql":       _000012_match_column_collationUpSql,
+	"000013_millisecond_timestamps.down.sql":     _000013_millisecond_timestampsDownSql,
+	"000013_millisecond_timestamps.up.sql":       _000013_millisecond_timestampsUpSql,
+	"000014_add_not_null_constraint.down.sql":    _000014_add_not_null_constraintDownSql,
+	"000014_add_not_null_constraint.up.sql":      _000014_add_not_null_constraintUpSql,
+	"000015_blocks_history_no_nulls.down.sql":    _000015_blocks_history_no_nullsDownSql,
+	"000015_blocks_history_no_nulls.up.sql":      _000015_blocks_history_no_nullsUpSql,
+	"000016_subscriptions_table.down.sql":        _000016_subscriptions_tableDownSql,
+	"000016_subscriptions_table.up.sql":          _000016_subscriptions_tableUpSql,
+	"000017_notification_settings.down.sql":      _000017_notification_settingsDownSql,
+	"000017_notification_settings.up.sql":        _000017_notification_settingsUpSql,
+	"000018_board_api_keys.down.sql":             _000018_board_api_keysDownSql,
+	"000018_board_api_keys.up.sql":               _000018_board_api_keysUpSql,
+	"000019_card_mutes.down.sql":                 _000019_card_mutesDownSql,
+	"000019_card_mutes.up.sql":                   _000019_card_mutesUpSql,
+	"000020_board_acknowledgments.down.sql":      _000020_board_acknowledgmentsDownSql,
+	"000020_board_acknowledgments.up.sql":        _000020_board_acknowledgmentsUpSql,
+	"000021_blocks_history_fields_diff.down.sql": _000021_blocks_history_fields_diffDownSql,
+	"000021_blocks_history_fields_diff.up.sql":   _000021_blocks_history_fields_diffUpSql,
+	"000022_board_api_keys_token_index.down.sql": _000022_board_api_keys_token_indexDownSql,
+	"000022_board_api_keys_token_index.up.sql":   _000022_board_api_keys_token_indexUpSql,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -875,38 +1141,50 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"000001_init.down.sql":                    &bintree{_000001_initDownSql, map[string]*bintree{}},
-	"000001_init.up.sql":                      &bintree{_000001_initUpSql, map[string]*bintree{}},
-	"000002_system_settings_table.down.sql":   &bintree{_000002_system_settings_tableDownSql, map[string]*bintree{}},
-	"000002_system_settings_table.up.sql":     &bintree{_000002_system_settings_tableUpSql, map[string]*bintree{}},
-	"000003_blocks_rootid.down.sql":           &bintree{_000003_blocks_rootidDownSql, map[string]*bintree{}},
-	"000003_blocks_rootid.up.sql":             &bintree{_000003_blocks_rootidUpSql, map[string]*bintree{}},
-	"000004_auth_table.down.sql":              &bintree{_000004_auth_tableDownSql, map[string]*bintree{}},
-	"000004_auth_table.up.sql":                &bintree{_000004_auth_tableUpSql, map[string]*bintree{}},
-	"000005_blocks_modifiedby.down.sql":       &bintree{_000005_blocks_modifiedbyDownSql, map[string]*bintree{}},
-	"000005_blocks_modifiedby.up.sql":         &bintree{_000005_blocks_modifiedbyUpSql, map[string]*bintree{}},
-	"000006_sharing_table.down.sql":           &bintree{_000006_sharing_tableDownSql, map[string]*bintree{}},
-	"000006_sharing_table.up.sql":             &bintree{_000006_sharing_tableUpSql, map[string]*bintree{}},
-	"000007_workspaces_table.down.sql":        &bintree{_000007_workspaces_tableDownSql, map[string]*bintree{}},
-	"000007_workspaces_table.up.sql":          &bintree{_000007_workspaces_tableUpSql, map[string]*bintree{}},
-	"000008_teams.down.sql":                   &bintree{_000008_teamsDownSql, map[string]*bintree{}},
-	"000008_teams.up.sql":                     &bintree{_000008_teamsUpSql, map[string]*bintree{}},
-	"000009_blocks_history.down.sql":          &bintree{_000009_blocks_historyDownSql, map[string]*bintree{}},
-	"000009_blocks_history.up.sql":            &bintree{_000009_blocks_historyUpSql, map[string]*bintree{}},
-	"000010_blocks_created_by.down.sql":       &bintree{_000010_blocks_created_byDownSql, map[string]*bintree{}},
-	"000010_blocks_created_by.up.sql":         &bintree{_000010_blocks_created_byUpSql, map[string]*bintree{}},
-	"000011_match_collation.down.sql":         &bintree{_000011_match_collationDownSql, map[string]*bintree{}},
-	"000011_match_collation.up.sql":           &bintree{_000011_match_collationUpSql, map[string]*bintree{}},
-	"000012_match_column_collation.down.sql":  &bintree{_000012_match_column_collationDownSql, map[string]*bintree{}},
-	"000012_match_column_collation.up.sql":    &bintree{_000012_match_column_collationUpSql, map[string]*bintree{}},
-	"000013_millisecond_timestamps.down.sql":  &bintree{_000013_millisecond_timestampsDownSql, map[string]*bintree{}},
-	"000013_millisecond_timestamps.up.sql":    &bintree{_000013_millisecond_timestampsUpSql, map[string]*bintree{}},
-	"000014_add_not_null_constraint.down.sql": &bintree{_000014_add_not_null_constraintDownSql, map[string]*bintree{}},
-	"000014_add_not_null_constraint.up.sql":   &bintree{_000014_add_not_null_constraintUpSql, map[string]*bintree{}},
-	"000015_blocks_history_no_nulls.down.sql": &bintree{_000015_blocks_history_no_nullsDownSql, map[string]*bintree{}},
-	"000015_blocks_history_no_nulls.up.sql":   &bintree{_000015_blocks_history_no_nullsUpSql, map[string]*bintree{}},
-	"000016_subscriptions_table.down.sql":     &bintree{_000016_subscriptions_tableDownSql, map[string]*bintree{}},
-	"000016_subscriptions_table.up.sql":       &bintree{_000016_subscriptions_tableUpSql, map[string]*bintree{}},
+	"000001_init.down.sql":                       &bintree{_000001_initDownSql, map[string]*bintree{}},
+	"000001_init.up.sql":                         &bintree{_000001_initUpSql, map[string]*bintree{}},
+	"000002_system_settings_table.down.sql":      &bintree{_000002_system_settings_tableDownSql, map[string]*bintree{}},
+	"000002_system_settings_table.up.sql":        &bintree{_000002_system_settings_tableUpSql, map[string]*bintree{}},
+	"000003_blocks_rootid.down.sql":              &bintree{_000003_blocks_rootidDownSql, map[string]*bintree{}},
+	"000003_blocks_rootid.up.sql":                &bintree{_000003_blocks_rootidUpSql, map[string]*bintree{}},
+	"000004_auth_table.down.sql":                 &bintree{_000004_auth_tableDownSql, map[string]*bintree{}},
+	"000004_auth_table.up.sql":                   &bintree{_000004_auth_tableUpSql, map[string]*bintree{}},
+	"000005_blocks_modifiedby.down.sql":          &bintree{_000005_blocks_modifiedbyDownSql, map[string]*bintree{}},
+	"000005_blocks_modifiedby.up.sql":            &bintree{_000005_blocks_modifiedbyUpSql, map[string]*bintree{}},
+	"000006_sharing_table.down.sql":              &bintree{_000006_sharing_tableDownSql, map[string]*bintree{}},
+	"000006_sharing_table.up.sql":                &bintree{_000006_sharing_tableUpSql, map[string]*bintree{}},
+	"000007_workspaces_table.down.sql":           &bintree{_000007_workspaces_tableDownSql, map[string]*bintree{}},
+	"000007_workspaces_table.up.sql":             &bintree{_000007_workspaces_tableUpSql, map[string]*bintree{}},
+	"000008_teams.down.sql":                      &bintree{_000008_teamsDownSql, map[string]*bintree{}},
+	"000008_teams.up.sql":                        &bintree{_000008_teamsUpSql, map[string]*bintree{}},
+	"000009_blocks_history.down.sql":             &bintree{_000009_blocks_historyDownSql, map[string]*bintree{}},
+	"000009_blocks_history.up.sql":               &bintree{_000009_blocks_historyUpSql, map[string]*bintree{}},
+	"000010_blocks_created_by.down.sql":          &bintree{_000010_blocks_created_byDownSql, map[string]*bintree{}},
+	"000010_blocks_created_by.up.sql":            &bintree{_000010_blocks_created_byUpSql, map[string]*bintree{}},
+	"000011_match_collation.down.sql":            &bintree{_000011_match_collationDownSql, map[string]*bintree{}},
+	"000011_match_collation.up.sql":              &bintree{_000011_match_collationUpSql, map[string]*bintree{}},
+	"000012_match_column_collation.down.sql":     &bintree{_000012_match_column_collationDownSql, map[string]*bintree{}},
+	"000012_match_column_collation.up.sql":       &bintree{_000012_match_column_collationUpSql, map[string]*bintree{}},
+	"000013_millisecond_timestamps.down.sql":     &bintree{_000013_millisecond_timestampsDownSql, map[string]*bintree{}},
+	"000013_millisecond_timestamps.up.sql":       &bintree{_000013_millisecond_timestampsUpSql, map[string]*bintree{}},
+	"000014_add_not_null_constraint.down.sql":    &bintree{_000014_add_not_null_constraintDownSql, map[string]*bintree{}},
+	"000014_add_not_null_constraint.up.sql":      &bintree{_000014_add_not_null_constraintUpSql, map[string]*bintree{}},
+	"000015_blocks_history_no_nulls.down.sql":    &bintree{_000015_blocks_history_no_nullsDownSql, map[string]*bintree{}},
+	"000015_blocks_history_no_nulls.up.sql":      &bintree{_000015_blocks_history_no_nullsUpSql, map[string]*bintree{}},
+	"000016_subscriptions_table.down.sql":        &bintree{_000016_subscriptions_tableDownSql, map[string]*bintree{}},
+	"000016_subscriptions_table.up.sql":          &bintree{_000016_subscriptions_tableUpSql, map[string]*bintree{}},
+	"000017_notification_settings.down.sql":      &bintree{_000017_notification_settingsDownSql, map[string]*bintree{}},
+	"000017_notification_settings.up.sql":        &bintree{_000017_notification_settingsUpSql, map[string]*bintree{}},
+	"000018_board_api_keys.down.sql":             &bintree{_000018_board_api_keysDownSql, map[string]*bintree{}},
+	"000018_board_api_keys.up.sql":               &bintree{_000018_board_api_keysUpSql, map[string]*bintree{}},
+	"000019_card_mutes.down.sql":                 &bintree{_000019_card_mutesDownSql, map[string]*bintree{}},
+	"000019_card_mutes.up.sql":                   &bintree{_000019_card_mutesUpSql, map[string]*bintree{}},
+	"000020_board_acknowledgments.down.sql":      &bintree{_000020_board_acknowledgmentsDownSql, map[string]*bintree{}},
+	"000020_board_acknowledgments.up.sql":        &bintree{_000020_board_acknowledgmentsUpSql, map[string]*bintree{}},
+	"000021_blocks_history_fields_diff.down.sql": &bintree{_000021_blocks_history_fields_diffDownSql, map[string]*bintree{}},
+	"000021_blocks_history_fields_diff.up.sql":   &bintree{_000021_blocks_history_fields_diffUpSql, map[string]*bintree{}},
+	"000022_board_api_keys_token_index.down.sql": &bintree{_000022_board_api_keys_token_indexDownSql, map[string]*bintree{}},
+	"000022_board_api_keys_token_index.up.sql":   &bintree{_000022_board_api_keys_token_indexUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
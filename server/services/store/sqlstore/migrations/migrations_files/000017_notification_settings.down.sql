@@ -0,0 +1,2 @@
+DROP TABLE {{.prefix}}notification_settings;
+DROP TABLE {{.prefix}}notification_digests;
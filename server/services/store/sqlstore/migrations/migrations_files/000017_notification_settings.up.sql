@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}notification_settings (
+	board_id VARCHAR(36),
+	workspace_id VARCHAR(36),
+	user_id VARCHAR(36),
+	mode VARCHAR(10),
+	update_at BIGINT,
+	PRIMARY KEY (board_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}notification_digests (
+	id VARCHAR(36),
+	workspace_id VARCHAR(36),
+	board_id VARCHAR(36),
+	user_id VARCHAR(36),
+	attachments TEXT,
+	create_at BIGINT,
+	deliver_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
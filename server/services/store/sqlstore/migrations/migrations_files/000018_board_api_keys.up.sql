@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_api_keys (
+	id VARCHAR(36),
+	workspace_id VARCHAR(36),
+	board_id VARCHAR(36),
+	name VARCHAR(100),
+	token VARCHAR(100),
+	created_by VARCHAR(36),
+	create_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_mutes (
+	card_id VARCHAR(36),
+	workspace_id VARCHAR(36),
+	board_id VARCHAR(36),
+	user_id VARCHAR(36),
+	create_at BIGINT,
+	PRIMARY KEY (card_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_acknowledgments (
+	board_id VARCHAR(36),
+	workspace_id VARCHAR(36),
+	user_id VARCHAR(36),
+	board_last_activity_at BIGINT,
+	create_at BIGINT,
+	PRIMARY KEY (board_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}blocks_history
+DROP COLUMN fields_diff;
@@ -0,0 +1,2 @@
+ALTER TABLE {{.prefix}}blocks_history
+ADD COLUMN fields_diff BOOLEAN DEFAULT FALSE;
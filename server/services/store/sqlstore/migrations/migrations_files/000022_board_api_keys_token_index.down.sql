@@ -0,0 +1 @@
+DROP INDEX idx_{{.prefix}}board_api_keys_token{{if .mysql}} ON {{.prefix}}board_api_keys{{end}};
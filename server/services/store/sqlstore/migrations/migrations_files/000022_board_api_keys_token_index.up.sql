@@ -0,0 +1 @@
+CREATE UNIQUE INDEX idx_{{.prefix}}board_api_keys_token ON {{.prefix}}board_api_keys(token);
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var notificationSettingsFields = []string{
+	"board_id",
+	"workspace_id",
+	"user_id",
+	"mode",
+	"update_at",
+}
+
+var notificationDigestItemFields = []string{
+	"id",
+	"workspace_id",
+	"board_id",
+	"user_id",
+	"attachments",
+	"create_at",
+	"deliver_at",
+}
+
+func (s *SQLStore) notificationSettingsFromRows(rows *sql.Rows) ([]*model.NotificationSettings, error) {
+	settings := []*model.NotificationSettings{}
+
+	for rows.Next() {
+		var setting model.NotificationSettings
+		err := rows.Scan(
+			&setting.BoardID,
+			&setting.WorkspaceID,
+			&setting.UserID,
+			&setting.Mode,
+			&setting.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, &setting)
+	}
+	return settings, nil
+}
+
+func (s *SQLStore) notificationDigestItemsFromRows(rows *sql.Rows) ([]*model.NotificationDigestItem, error) {
+	items := []*model.NotificationDigestItem{}
+
+	for rows.Next() {
+		var item model.NotificationDigestItem
+		err := rows.Scan(
+			&item.ID,
+			&item.WorkspaceID,
+			&item.BoardID,
+			&item.UserID,
+			&item.Attachments,
+			&item.CreateAt,
+			&item.DeliverAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// getNotificationSettings fetches the notification settings of a user for a board.
+func (s *SQLStore) getNotificationSettings(db sq.BaseRunner, c store.Container, boardID string, userID string) (*model.NotificationSettings, error) {
+	query := s.getQueryBuilder(db).
+		Select(notificationSettingsFields...).
+		From(s.tablePrefix + "notification_settings").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"workspace_id": c.WorkspaceID}).
+		Where(sq.Eq{"user_id": userID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch notification settings",
+			mlog.String("board_id", boardID),
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	settings, err := s.notificationSettingsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, store.NewErrNotFound(boardID + "," + userID)
+	}
+	return settings[0], nil
+}
+
+// upsertNotificationSettings creates or updates the notification settings of a user for a board.
+func (s *SQLStore) upsertNotificationSettings(db sq.BaseRunner, c store.Container, settings *model.NotificationSettings) (*model.NotificationSettings, error) {
+	settings.WorkspaceID = c.WorkspaceID
+
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	settings.UpdateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"notification_settings").
+		Columns(notificationSettingsFields...).
+		Values(settings.BoardID, settings.WorkspaceID, settings.UserID, settings.Mode, settings.UpdateAt)
+
+	if s.dbType == mysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE mode = ?, update_at = ?", settings.Mode, settings.UpdateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id,user_id) DO UPDATE SET mode = ?, update_at = ?", settings.Mode, settings.UpdateAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot upsert notification settings",
+			mlog.String("board_id", settings.BoardID),
+			mlog.String("user_id", settings.UserID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return settings, nil
+}
+
+// addNotificationDigestItem queues a notification for the next digest of a user.
+func (s *SQLStore) addNotificationDigestItem(db sq.BaseRunner, item *model.NotificationDigestItem) error {
+	if item.ID == "" {
+		item.ID = utils.NewID(utils.IDTypeNone)
+	}
+	item.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"notification_digests").
+		Columns(notificationDigestItemFields...).
+		Values(item.ID, item.WorkspaceID, item.BoardID, item.UserID, item.Attachments, item.CreateAt, item.DeliverAt)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot add notification digest item",
+			mlog.String("board_id", item.BoardID),
+			mlog.String("user_id", item.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getDueNotificationDigestItems fetches the queued notifications to deliver at or before
+// deliverAt, oldest first.
+func (s *SQLStore) getDueNotificationDigestItems(db sq.BaseRunner, deliverAt int64) ([]*model.NotificationDigestItem, error) {
+	query := s.getQueryBuilder(db).
+		Select(notificationDigestItemFields...).
+		From(s.tablePrefix+"notification_digests").
+		Where(sq.LtOrEq{"deliver_at": deliverAt}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due notification digest items", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.notificationDigestItemsFromRows(rows)
+}
+
+// deleteNotificationDigestItems deletes delivered notifications.
+func (s *SQLStore) deleteNotificationDigestItems(db sq.BaseRunner, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "notification_digests").
+		Where(sq.Eq{"id": ids})
+
+	_, err := query.Exec()
+	return err
+}
@@ -15,6 +15,19 @@ type Params struct {
 	DB               *sql.DB
 	IsPlugin         bool
 	NewMutexFn       MutexFactory
+
+	// DisableTemplateSeeding skips seeding the embedded templates.
+	DisableTemplateSeeding bool
+	// SeedTemplates are the names of the embedded templates to seed, all
+	// of them if empty.
+	SeedTemplates []string
+	// BlockUpdateCoalesceMS is the window in milliseconds, from the first
+	// of them, in which the successive updates of a block by the same user
+	// are recorded as a single history entry, 0 to record every update.
+	BlockUpdateCoalesceMS int64
+	// BlockHistoryDiffs records the updates of the blocks in their history
+	// as the fields they change instead of full snapshots.
+	BlockHistoryDiffs bool
 }
 
 func (p Params) CheckValid() error {
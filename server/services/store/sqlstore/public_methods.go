@@ -22,11 +22,21 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+func (s *SQLStore) AddNotificationDigestItem(item *model.NotificationDigestItem) error {
+	return s.addNotificationDigestItem(s.db, item)
+
+}
+
 func (s *SQLStore) CleanUpSessions(expireTime int64) error {
 	return s.cleanUpSessions(s.db, expireTime)
 
 }
 
+func (s *SQLStore) CreateBoardAPIKey(c store.Container, key *model.BoardAPIKey) error {
+	return s.createBoardAPIKey(s.db, c, key)
+
+}
+
 func (s *SQLStore) CreateSession(session *model.Session) error {
 	return s.createSession(s.db, session)
 
@@ -63,6 +73,37 @@ func (s *SQLStore) DeleteBlock(c store.Container, blockID string, modifiedBy str
 
 }
 
+func (s *SQLStore) DeleteBoardAPIKey(c store.Container, boardID string, keyID string) error {
+	return s.deleteBoardAPIKey(s.db, c, boardID, keyID)
+
+}
+
+func (s *SQLStore) DeleteBoardBlocks(c store.Container, boardID string, keepTypes []string, modifiedBy string) ([]model.Block, error) {
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.deleteBoardBlocks(tx, c, boardID, keepTypes, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardBlocks"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) DeleteNotificationDigestItems(ids []string) error {
+	return s.deleteNotificationDigestItems(s.db, ids)
+
+}
+
 func (s *SQLStore) DeleteNotificationHint(c store.Container, blockID string) error {
 	return s.deleteNotificationHint(s.db, c, blockID)
 
@@ -93,6 +134,16 @@ func (s *SQLStore) GetBlock(c store.Container, blockID string) (*model.Block, er
 
 }
 
+func (s *SQLStore) GetBlockCountWithRootID(c store.Container, rootID string) (int64, error) {
+	return s.getBlockCountWithRootID(s.db, c, rootID)
+
+}
+
+func (s *SQLStore) GetBlockCountWithRootIDAndType(c store.Container, rootID string, blockType string) (int64, error) {
+	return s.getBlockCountWithRootIDAndType(s.db, c, rootID, blockType)
+
+}
+
 func (s *SQLStore) GetBlockCountsByType() (map[string]int64, error) {
 	return s.getBlockCountsByType(s.db)
 
@@ -113,6 +164,16 @@ func (s *SQLStore) GetBlocksWithParentAndType(c store.Container, parentID string
 
 }
 
+func (s *SQLStore) GetBlocksWithParentAndTypePage(c store.Container, parentID string, blockType string, opts model.QueryPageOptions) ([]model.Block, error) {
+	return s.getBlocksWithParentAndTypePage(s.db, c, parentID, blockType, opts)
+
+}
+
+func (s *SQLStore) GetBlocksWithParentAndTypes(c store.Container, parentID string, blockTypes []string) ([]model.Block, error) {
+	return s.getBlocksWithParentAndTypes(s.db, c, parentID, blockTypes)
+
+}
+
 func (s *SQLStore) GetBlocksWithRootID(c store.Container, rootID string) ([]model.Block, error) {
 	return s.getBlocksWithRootID(s.db, c, rootID)
 
@@ -123,6 +184,26 @@ func (s *SQLStore) GetBlocksWithType(c store.Container, blockType string) ([]mod
 
 }
 
+func (s *SQLStore) GetBlocksWithTypes(c store.Container, blockTypes []string) ([]model.Block, error) {
+	return s.getBlocksWithTypes(s.db, c, blockTypes)
+
+}
+
+func (s *SQLStore) GetBoardAPIKeyByToken(token string) (*model.BoardAPIKey, error) {
+	return s.getBoardAPIKeyByToken(s.db, token)
+
+}
+
+func (s *SQLStore) GetBoardAPIKeys(c store.Container, boardID string) ([]*model.BoardAPIKey, error) {
+	return s.getBoardAPIKeys(s.db, c, boardID)
+
+}
+
+func (s *SQLStore) GetBoardAcknowledgments(c store.Container, boardID string) ([]*model.BoardAcknowledgment, error) {
+	return s.getBoardAcknowledgments(s.db, c, boardID)
+
+}
+
 func (s *SQLStore) GetBoardAndCard(c store.Container, block *model.Block) (*model.Block, *model.Block, error) {
 	return s.getBoardAndCard(s.db, c, block)
 
@@ -133,6 +214,56 @@ func (s *SQLStore) GetBoardAndCardByID(c store.Container, blockID string) (*mode
 
 }
 
+func (s *SQLStore) GetBoardCardStats(c store.Container, boardID string, opts model.CardStatsOptions) (*model.BoardStats, error) {
+	return s.getBoardCardStats(s.db, c, boardID, opts)
+
+}
+
+func (s *SQLStore) GetBoardHistory(c store.Container, boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
+	return s.getBoardHistory(s.db, c, boardID, opts)
+
+}
+
+func (s *SQLStore) GetBoardsSharingStatus(c store.Container, boardIDs []string) (map[string]model.SharingStatus, error) {
+	return s.getBoardsSharingStatus(s.db, c, boardIDs)
+
+}
+
+func (s *SQLStore) GetCardChildrenWithRootID(c store.Container, rootID string) ([]model.Block, error) {
+	return s.getCardChildrenWithRootID(s.db, c, rootID)
+
+}
+
+func (s *SQLStore) GetDueNotificationDigestItems(deliverAt int64) ([]*model.NotificationDigestItem, error) {
+	return s.getDueNotificationDigestItems(s.db, deliverAt)
+
+}
+
+func (s *SQLStore) GetEnabledSharingByToken(c store.Container, token string) (*model.Sharing, error) {
+	return s.getEnabledSharingByToken(s.db, c, token)
+
+}
+
+func (s *SQLStore) GetInactiveBoards(before int64) ([]model.Block, error) {
+	return s.getInactiveBoards(s.db, before)
+
+}
+
+func (s *SQLStore) GetLastActivityByRootIDs(c store.Container, rootIDs []string) (map[string]int64, error) {
+	return s.getLastActivityByRootIDs(s.db, c, rootIDs)
+
+}
+
+func (s *SQLStore) GetLastActivityWithRootID(c store.Container, rootID string) (int64, error) {
+	return s.getLastActivityWithRootID(s.db, c, rootID)
+
+}
+
+func (s *SQLStore) GetMutedCards(c store.Container, userID string) ([]*model.CardMute, error) {
+	return s.getMutedCards(s.db, c, userID)
+
+}
+
 func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
 	return s.getNextNotificationHint(s.db, remove)
 
@@ -143,6 +274,11 @@ func (s *SQLStore) GetNotificationHint(c store.Container, blockID string) (*mode
 
 }
 
+func (s *SQLStore) GetNotificationSettings(c store.Container, boardID string, userID string) (*model.NotificationSettings, error) {
+	return s.getNotificationSettings(s.db, c, boardID, userID)
+
+}
+
 func (s *SQLStore) GetParentID(c store.Container, blockID string) (string, error) {
 	return s.getParentID(s.db, c, blockID)
 
@@ -228,6 +364,11 @@ func (s *SQLStore) GetUserWorkspaces(userID string) ([]model.UserWorkspace, erro
 
 }
 
+func (s *SQLStore) GetUsersByIDs(userIDs []string) ([]*model.User, error) {
+	return s.getUsersByIDs(s.db, userIDs)
+
+}
+
 func (s *SQLStore) GetUsersByWorkspace(workspaceID string) ([]*model.User, error) {
 	return s.getUsersByWorkspace(s.db, workspaceID)
 
@@ -238,11 +379,21 @@ func (s *SQLStore) GetWorkspace(ID string) (*model.Workspace, error) {
 
 }
 
+func (s *SQLStore) GetWorkspaceBlockCountsByType(c store.Container) (map[string]int64, error) {
+	return s.getWorkspaceBlockCountsByType(s.db, c)
+
+}
+
 func (s *SQLStore) GetWorkspaceCount() (int64, error) {
 	return s.getWorkspaceCount(s.db)
 
 }
 
+func (s *SQLStore) GetWorkspaceMemberCount(workspaceID string) (int, error) {
+	return s.getWorkspaceMemberCount(s.db, workspaceID)
+
+}
+
 func (s *SQLStore) HasWorkspaceAccess(userID string, workspaceID string) (bool, error) {
 	return s.hasWorkspaceAccess(s.db, userID, workspaceID)
 
@@ -290,6 +441,11 @@ func (s *SQLStore) InsertBlocks(c store.Container, blocks []model.Block, userID
 
 }
 
+func (s *SQLStore) MuteCard(c store.Container, mute *model.CardMute) error {
+	return s.muteCard(s.db, c, mute)
+
+}
+
 func (s *SQLStore) PatchBlock(c store.Container, blockID string, blockPatch *model.BlockPatch, userID string) error {
 	tx, txErr := s.db.BeginTx(context.Background(), nil)
 	if txErr != nil {
@@ -332,6 +488,11 @@ func (s *SQLStore) PatchBlocks(c store.Container, blockPatches *model.BlockPatch
 
 }
 
+func (s *SQLStore) PruneBlockHistory(before int64, maxVersions int) (int64, error) {
+	return s.pruneBlockHistory(s.db, before, maxVersions)
+
+}
+
 func (s *SQLStore) RefreshSession(session *model.Session) error {
 	return s.refreshSession(s.db, session)
 
@@ -342,6 +503,11 @@ func (s *SQLStore) SetSystemSetting(key string, value string) error {
 
 }
 
+func (s *SQLStore) UnmuteCard(c store.Container, cardID string, userID string) error {
+	return s.unmuteCard(s.db, c, cardID, userID)
+
+}
+
 func (s *SQLStore) UpdateSession(session *model.Session) error {
 	return s.updateSession(s.db, session)
 
@@ -367,13 +533,39 @@ func (s *SQLStore) UpdateUserPasswordByID(userID string, password string) error
 
 }
 
+func (s *SQLStore) UpsertBoardAcknowledgment(c store.Container, ack *model.BoardAcknowledgment) error {
+	return s.upsertBoardAcknowledgment(s.db, c, ack)
+
+}
+
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
 	return s.upsertNotificationHint(s.db, hint, notificationFreq)
 
 }
 
-func (s *SQLStore) UpsertSharing(c store.Container, sharing model.Sharing) error {
-	return s.upsertSharing(s.db, c, sharing)
+func (s *SQLStore) UpsertNotificationSettings(c store.Container, settings *model.NotificationSettings) (*model.NotificationSettings, error) {
+	return s.upsertNotificationSettings(s.db, c, settings)
+
+}
+
+func (s *SQLStore) UpsertSharing(c store.Container, sharing model.Sharing) (*model.Sharing, error) {
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.upsertSharing(tx, c, sharing)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UpsertSharing"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 
 }
 
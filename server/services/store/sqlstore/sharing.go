@@ -1,14 +1,20 @@
 package sqlstore
 
 import (
+	"database/sql"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func (s *SQLStore) upsertSharing(db sq.BaseRunner, _ store.Container, sharing model.Sharing) error {
+// upsertSharing inserts or replaces the sharing of a root block in a single
+// statement, and returns the sharing as stored.
+func (s *SQLStore) upsertSharing(db sq.BaseRunner, c store.Container, sharing model.Sharing) (*model.Sharing, error) {
 	now := utils.GetMillis()
 
 	query := s.getQueryBuilder(db).
@@ -37,8 +43,11 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, _ store.Container, sharing mo
 		)
 	}
 
-	_, err := query.Exec()
-	return err
+	if _, err := query.Exec(); err != nil {
+		return nil, err
+	}
+
+	return s.getSharing(db, c, sharing.ID)
 }
 
 func (s *SQLStore) getSharing(db sq.BaseRunner, _ store.Container, rootID string) (*model.Sharing, error) {
@@ -68,3 +77,75 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, _ store.Container, rootID string
 
 	return &sharing, nil
 }
+
+// getEnabledSharingByToken returns the enabled sharing with the token, or
+// sql.ErrNoRows if there is none.
+func (s *SQLStore) getEnabledSharingByToken(db sq.BaseRunner, _ store.Container, token string) (*model.Sharing, error) {
+	query := s.getQueryBuilder(db).
+		Select(
+			"id",
+			"enabled",
+			"token",
+			"modified_by",
+			"update_at",
+		).
+		From(s.tablePrefix + "sharing").
+		Where(sq.Eq{"token": token}).
+		Where(sq.Eq{"enabled": true}).
+		Limit(1)
+	row := query.QueryRow()
+	sharing := model.Sharing{}
+
+	err := row.Scan(
+		&sharing.ID,
+		&sharing.Enabled,
+		&sharing.Token,
+		&sharing.ModifiedBy,
+		&sharing.UpdateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharing, nil
+}
+
+// getBoardsSharingStatus returns the sharing status of the boards of the
+// workspace among boardIDs. The IDs that aren't boards of the workspace are
+// left out.
+func (s *SQLStore) getBoardsSharingStatus(db sq.BaseRunner, c store.Container, boardIDs []string) (map[string]model.SharingStatus, error) {
+	statuses := make(map[string]model.SharingStatus, len(boardIDs))
+	if len(boardIDs) == 0 {
+		return statuses, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("b.id", "s.enabled", "s.token").
+		From(s.tablePrefix + "blocks AS b").
+		LeftJoin(s.tablePrefix + "sharing AS s ON s.id = b.id").
+		Where(sq.Eq{"b.id": boardIDs}).
+		Where(sq.Eq{"b.type": model.TypeBoard}).
+		Where(sq.Eq{"coalesce(b.workspace_id, '0')": c.WorkspaceID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsSharingStatus ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var boardID string
+		var enabled sql.NullBool
+		var token sql.NullString
+		if err := rows.Scan(&boardID, &enabled, &token); err != nil {
+			return nil, err
+		}
+		statuses[boardID] = model.SharingStatus{
+			Enabled:  enabled.Bool,
+			HasToken: token.String != "",
+		}
+	}
+
+	return statuses, rows.Err()
+}
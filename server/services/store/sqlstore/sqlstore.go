@@ -24,6 +24,11 @@ type SQLStore struct {
 	isPlugin         bool
 	logger           *mlog.Logger
 	NewMutexFn       MutexFactory
+
+	disableTemplateSeeding bool
+	seedTemplates          []string
+	blockUpdateCoalesceMS  int64
+	blockHistoryDiffs      bool
 }
 
 // MutexFactory is used by the store in plugin mode to generate
@@ -46,6 +51,11 @@ func New(params Params) (*SQLStore, error) {
 		logger:           params.Logger,
 		isPlugin:         params.IsPlugin,
 		NewMutexFn:       params.NewMutexFn,
+
+		disableTemplateSeeding: params.DisableTemplateSeeding,
+		seedTemplates:          params.SeedTemplates,
+		blockUpdateCoalesceMS:  params.BlockUpdateCoalesceMS,
+		blockHistoryDiffs:      params.BlockHistoryDiffs,
 	}
 
 	err := store.Migrate()
@@ -18,4 +18,8 @@ func TestBlocksStore(t *testing.T) {
 	t.Run("WorkspaceStore", func(t *testing.T) { storetests.StoreTestWorkspaceStore(t, SetupTests) })
 	t.Run("SubscriptionStore", func(t *testing.T) { storetests.StoreTestSubscriptionsStore(t, SetupTests) })
 	t.Run("NotificationHintStore", func(t *testing.T) { storetests.StoreTestNotificationHintsStore(t, SetupTests) })
+	t.Run("NotificationSettingsStore", func(t *testing.T) { storetests.StoreTestNotificationSettingsStore(t, SetupTests) })
+	t.Run("BoardAPIKeysStore", func(t *testing.T) { storetests.StoreTestBoardAPIKeysStore(t, SetupTests) })
+	t.Run("CardMutesStore", func(t *testing.T) { storetests.StoreTestCardMutesStore(t, SetupTests) })
+	t.Run("BoardAcknowledgmentsStore", func(t *testing.T) { storetests.StoreTestBoardAcknowledgmentsStore(t, SetupTests) })
 }
@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 
@@ -36,6 +37,12 @@ func (s *SQLStore) getRegisteredUserCount(db sq.BaseRunner) (int, error) {
 	return count, nil
 }
 
+// getWorkspaceMemberCount returns the number of registered users, as every
+// user is a member of the only workspace outside of plugin mode.
+func (s *SQLStore) getWorkspaceMemberCount(db sq.BaseRunner, _ string) (int, error) {
+	return s.getRegisteredUserCount(db)
+}
+
 func (s *SQLStore) getUserByCondition(db sq.BaseRunner, condition sq.Eq) (*model.User, error) {
 	users, err := s.getUsersByCondition(db, condition)
 	if err != nil {
@@ -200,6 +207,18 @@ func (s *SQLStore) getUsersByWorkspace(db sq.BaseRunner, _ string) ([]*model.Use
 	return s.getUsersByCondition(db, nil)
 }
 
+func (s *SQLStore) getUsersByIDs(db sq.BaseRunner, userIDs []string) ([]*model.User, error) {
+	if len(userIDs) == 0 {
+		return []*model.User{}, nil
+	}
+
+	users, err := s.getUsersByCondition(db, sq.Eq{"id": userIDs})
+	if errors.Is(err, sql.ErrNoRows) {
+		return []*model.User{}, nil
+	}
+	return users, err
+}
+
 func (s *SQLStore) usersFromRows(rows *sql.Rows) ([]*model.User, error) {
 	users := []*model.User{}
 
@@ -2,12 +2,16 @@ package sqlstore
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
+	"github.com/mattn/go-sqlite3"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -22,6 +26,33 @@ func (s *SQLStore) IsErrNotFound(err error) bool {
 	return store.IsErrNotFound(err)
 }
 
+const (
+	pqUniqueViolation   = "23505"
+	mysqlDuplicateEntry = 1062
+)
+
+// isUniqueViolation returns true if `err` is a primary key or unique
+// constraint violation reported by any of the supported database drivers.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqUniqueViolation
+	}
+
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey ||
+			sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+
+	return false
+}
+
 func PrepareNewTestDatabase() (dbType string, connectionString string, err error) {
 	dbType = strings.TrimSpace(os.Getenv("FB_STORE_TEST_DB_TYPE"))
 	if dbType == "" {
@@ -19,9 +19,13 @@ type Container struct {
 // Store represents the abstraction of the data storage.
 type Store interface {
 	GetBlocksWithParentAndType(c Container, parentID string, blockType string) ([]model.Block, error)
+	GetBlocksWithParentAndTypes(c Container, parentID string, blockTypes []string) ([]model.Block, error)
+	GetBlocksWithParentAndTypePage(c Container, parentID string, blockType string, opts model.QueryPageOptions) ([]model.Block, error)
 	GetBlocksWithParent(c Container, parentID string) ([]model.Block, error)
 	GetBlocksWithRootID(c Container, rootID string) ([]model.Block, error)
+	GetCardChildrenWithRootID(c Container, rootID string) ([]model.Block, error)
 	GetBlocksWithType(c Container, blockType string) ([]model.Block, error)
+	GetBlocksWithTypes(c Container, blockTypes []string) ([]model.Block, error)
 	GetSubTree2(c Container, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error)
 	GetSubTree3(c Container, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error)
 	GetAllBlocks(c Container) ([]model.Block, error)
@@ -33,11 +37,22 @@ type Store interface {
 	InsertBlocks(c Container, blocks []model.Block, userID string) error
 	// @withTransaction
 	DeleteBlock(c Container, blockID string, modifiedBy string) error
+	// @withTransaction
+	DeleteBoardBlocks(c Container, boardID string, keepTypes []string, modifiedBy string) ([]model.Block, error)
 	GetBlockCountsByType() (map[string]int64, error)
+	GetWorkspaceBlockCountsByType(c Container) (map[string]int64, error)
+	GetBlockCountWithRootID(c Container, rootID string) (int64, error)
+	GetBlockCountWithRootIDAndType(c Container, rootID string, blockType string) (int64, error)
+	GetLastActivityWithRootID(c Container, rootID string) (int64, error)
+	GetBoardCardStats(c Container, boardID string, opts model.CardStatsOptions) (*model.BoardStats, error)
+	GetLastActivityByRootIDs(c Container, rootIDs []string) (map[string]int64, error)
+	GetInactiveBoards(before int64) ([]model.Block, error)
 	GetBlock(c Container, blockID string) (*model.Block, error)
 	// @withTransaction
 	PatchBlock(c Container, blockID string, blockPatch *model.BlockPatch, userID string) error
 	GetBlockHistory(c Container, blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
+	GetBoardHistory(c Container, boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
+	PruneBlockHistory(before int64, maxVersions int) (int64, error)
 	GetBoardAndCardByID(c Container, blockID string) (board *model.Block, card *model.Block, err error)
 	GetBoardAndCard(c Container, block *model.Block) (board *model.Block, card *model.Block, err error)
 	// @withTransaction
@@ -58,6 +73,8 @@ type Store interface {
 	UpdateUserPassword(username, password string) error
 	UpdateUserPasswordByID(userID, password string) error
 	GetUsersByWorkspace(workspaceID string) ([]*model.User, error)
+	GetUsersByIDs(userIDs []string) ([]*model.User, error)
+	GetWorkspaceMemberCount(workspaceID string) (int, error)
 
 	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
 	GetSession(token string, expireTime int64) (*model.Session, error)
@@ -67,8 +84,11 @@ type Store interface {
 	DeleteSession(sessionID string) error
 	CleanUpSessions(expireTime int64) error
 
-	UpsertSharing(c Container, sharing model.Sharing) error
+	// @withTransaction
+	UpsertSharing(c Container, sharing model.Sharing) (*model.Sharing, error)
 	GetSharing(c Container, rootID string) (*model.Sharing, error)
+	GetEnabledSharingByToken(c Container, token string) (*model.Sharing, error)
+	GetBoardsSharingStatus(c Container, boardIDs []string) (map[string]model.SharingStatus, error)
 
 	UpsertWorkspaceSignupToken(workspace model.Workspace) error
 	UpsertWorkspaceSettings(workspace model.Workspace) error
@@ -90,6 +110,24 @@ type Store interface {
 	GetNotificationHint(c Container, blockID string) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
 
+	GetNotificationSettings(c Container, boardID string, userID string) (*model.NotificationSettings, error)
+	UpsertNotificationSettings(c Container, settings *model.NotificationSettings) (*model.NotificationSettings, error)
+	AddNotificationDigestItem(item *model.NotificationDigestItem) error
+	GetDueNotificationDigestItems(deliverAt int64) ([]*model.NotificationDigestItem, error)
+	DeleteNotificationDigestItems(ids []string) error
+
+	MuteCard(c Container, mute *model.CardMute) error
+	UnmuteCard(c Container, cardID string, userID string) error
+	GetMutedCards(c Container, userID string) ([]*model.CardMute, error)
+
+	UpsertBoardAcknowledgment(c Container, ack *model.BoardAcknowledgment) error
+	GetBoardAcknowledgments(c Container, boardID string) ([]*model.BoardAcknowledgment, error)
+
+	CreateBoardAPIKey(c Container, key *model.BoardAPIKey) error
+	GetBoardAPIKeys(c Container, boardID string) ([]*model.BoardAPIKey, error)
+	GetBoardAPIKeyByToken(token string) (*model.BoardAPIKey, error)
+	DeleteBoardAPIKey(c Container, boardID string, keyID string) error
+
 	IsErrNotFound(err error) bool
 }
 
@@ -118,3 +156,30 @@ func IsErrNotFound(err error) bool {
 	var nf *ErrNotFound
 	return errors.As(err, &nf)
 }
+
+// ErrConflict is an error type that can be returned by store APIs when a record can't be inserted because
+// another one with the same ID was created concurrently.
+type ErrConflict struct {
+	resource string
+}
+
+// NewErrConflict creates a new ErrConflict instance.
+func NewErrConflict(resource string) *ErrConflict {
+	return &ErrConflict{
+		resource: resource,
+	}
+}
+
+func (c *ErrConflict) Error() string {
+	return fmt.Sprintf("{%s} already exists", c.resource)
+}
+
+// IsErrConflict returns true if `err` is or wraps a ErrConflict.
+func IsErrConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var c *ErrConflict
+	return errors.As(err, &c)
+}
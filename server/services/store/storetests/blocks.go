@@ -1,6 +1,8 @@
 package storetests
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +33,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testInsertBlocks(t, store, container)
 	})
+	t.Run("InsertBlocksConcurrently", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertBlocksConcurrently(t, store, container)
+	})
 	t.Run("PatchBlock", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -56,6 +63,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetSubTree3(t, store, container)
 	})
+	t.Run("GetSubTreeOrdered", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetSubTreeOrdered(t, store, container)
+	})
+	t.Run("GetBlocksWithParentAndTypePage", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlocksWithParentAndTypePage(t, store, container)
+	})
+	t.Run("GetCardChildrenWithRootID", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardChildrenWithRootID(t, store, container)
+	})
 	t.Run("GetParentID", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -71,6 +93,46 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlock(t, store, container)
 	})
+	t.Run("GetBlockCountWithRootID", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockCountWithRootID(t, store, container)
+	})
+	t.Run("GetBlockCountWithRootIDAndType", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBlockCountWithRootIDAndType(t, store, container)
+	})
+	t.Run("GetWorkspaceBlockCountsByType", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetWorkspaceBlockCountsByType(t, store, container)
+	})
+	t.Run("GetLastActivityWithRootID", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetLastActivityWithRootID(t, store, container)
+	})
+	t.Run("GetLastActivityByRootIDs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetLastActivityByRootIDs(t, store, container)
+	})
+	t.Run("GetBoardHistory", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardHistory(t, store, container)
+	})
+	t.Run("GetInactiveBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetInactiveBoards(t, store, container)
+	})
+	t.Run("GetBoardCardStats", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardCardStats(t, store, container)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store, container store.Container) {
@@ -159,6 +221,7 @@ func testInsertBlock(t *testing.T, store store.Store, container store.Container)
 			ID:        "id-2",
 			RootID:    "root-id",
 			CreatedBy: "user-id-3",
+			CreateAt:  block.CreateAt + 1000000,
 			Title:     "New Title",
 		}
 		err = store.InsertBlock(container, &newBlock, "user-id-4")
@@ -166,6 +229,13 @@ func testInsertBlock(t *testing.T, store store.Store, container store.Container)
 		// created by is not altered for existing blocks
 		require.Equal(t, "user-id-3", newBlock.CreatedBy)
 		require.Equal(t, "New Title", newBlock.Title)
+
+		// create time is kept from the existing block, history included
+		require.Equal(t, block.CreateAt, newBlock.CreateAt)
+		history, err := store.GetBlockHistory(container, "id-2", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		require.Equal(t, block.CreateAt, history[1].CreateAt)
 	})
 
 	createdAt, err := time.Parse(time.RFC822, "01 Jan 90 01:00 IST")
@@ -231,6 +301,43 @@ func testInsertBlocks(t *testing.T, store store.Store, container store.Container
 	})
 }
 
+func testInsertBlocksConcurrently(t *testing.T, s store.Store, container store.Container) {
+	const workers = 10
+
+	// every worker saves the same card and text block, so each insert either
+	// updates the blocks saved by another worker or conflicts with one that
+	// was inserted after it checked for them
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			blocks := []model.Block{
+				{ID: "card", RootID: "board", ParentID: "board", Type: model.TypeCard, Title: fmt.Sprintf("worker %d", w)},
+				{ID: "text", RootID: "board", ParentID: "card", Type: model.TypeText, Title: fmt.Sprintf("worker %d", w)},
+			}
+			errs[w] = s.InsertBlocks(container, blocks, testUserID)
+		}(w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			require.True(t, store.IsErrConflict(err), "unexpected error: %v", err)
+		}
+	}
+
+	children, err := s.GetBlocksWithParent(container, "card")
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, "text", children[0].ID)
+
+	card, err := s.GetBlock(container, "card")
+	require.NoError(t, err)
+	require.NotNil(t, card)
+}
+
 func testPatchBlock(t *testing.T, store store.Store, container store.Container) {
 	userID := testUserID
 
@@ -369,6 +476,10 @@ func testPatchBlocks(t *testing.T, store store.Store, container store.Container)
 	require.NoError(t, err)
 
 	t.Run("successful updated existing blocks", func(t *testing.T) {
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+
 		title := "updatedTitle"
 		blockPatch := model.BlockPatch{
 			Title: &title,
@@ -530,6 +641,63 @@ func testGetSubTree3(t *testing.T, store store.Store, container store.Container)
 	})
 }
 
+func testGetSubTreeOrdered(t *testing.T, store store.Store, container store.Container) {
+	// the blocks are inserted in creation order, as the store sets create_at
+	blocks := []model.Block{
+		{ID: "parent", RootID: "parent", ModifiedBy: testUserID},
+		{ID: "child2", RootID: "parent", ParentID: "parent", ModifiedBy: testUserID},
+		{ID: "child3", RootID: "parent", ParentID: "parent", ModifiedBy: testUserID},
+		{ID: "child1", RootID: "parent", ParentID: "parent", ModifiedBy: testUserID},
+		{ID: "grandchild1", RootID: "parent", ParentID: "child1", ModifiedBy: testUserID},
+		{ID: "grandchild3", RootID: "parent", ParentID: "child2", ModifiedBy: testUserID},
+		{ID: "grandchild2", RootID: "parent", ParentID: "child2", ModifiedBy: testUserID},
+	}
+	for i := range blocks {
+		time.Sleep(2 * time.Millisecond)
+		require.NoError(t, store.InsertBlock(container, &blocks[i], "user-id-1"))
+	}
+	defer DeleteBlocks(t, store, container, blocks, "test")
+
+	blockIDs := func(blocks []model.Block) []string {
+		ids := []string{}
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	t.Run("ascending", func(t *testing.T) {
+		opts := model.QuerySubtreeOptions{OrderByCreateAt: true}
+		result, err := store.GetSubTree2(container, "parent", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"parent", "child2", "child3", "child1"}, blockIDs(result))
+	})
+
+	t.Run("descending", func(t *testing.T) {
+		opts := model.QuerySubtreeOptions{OrderByCreateAt: true, Descending: true}
+		result, err := store.GetSubTree3(container, "parent", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"grandchild2", "grandchild3", "grandchild1", "child1", "child3", "child2", "parent"}, blockIDs(result))
+	})
+
+	t.Run("limit per level", func(t *testing.T) {
+		opts := model.QuerySubtreeOptions{OrderByCreateAt: true, LimitPerLevel: 1}
+		result, err := store.GetSubTree3(container, "parent", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"parent", "child2", "grandchild3"}, blockIDs(result))
+
+		opts.LimitPerLevel = 2
+		result, err = store.GetSubTree3(container, "parent", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"parent", "child2", "child3", "grandchild3", "grandchild2"}, blockIDs(result))
+
+		opts = model.QuerySubtreeOptions{Descending: true, LimitPerLevel: 1}
+		result, err = store.GetSubTree3(container, "parent", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"grandchild1", "child1", "parent"}, blockIDs(result))
+	})
+}
+
 func testGetParents(t *testing.T, store store.Store, container store.Container) {
 	blocks, err := store.GetAllBlocks(container)
 	require.NoError(t, err)
@@ -634,6 +802,86 @@ func testDeleteBlock(t *testing.T, store store.Store, container store.Container)
 	})
 }
 
+func testGetBlocksWithParentAndTypePage(t *testing.T, store store.Store, container store.Container) {
+	// the blocks are inserted in creation order, as the store sets create_at
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "card3", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "view", RootID: "board", ParentID: "board", Type: model.TypeView, ModifiedBy: testUserID},
+		{ID: "card1", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "card2", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+	}
+	for i := range blocks {
+		time.Sleep(2 * time.Millisecond)
+		require.NoError(t, store.InsertBlock(container, &blocks[i], "user-id-1"))
+	}
+	defer DeleteBlocks(t, store, container, blocks, "test")
+
+	// this avoids triggering uniqueness constraint of
+	// id,insert_at on block history when deleting the blocks
+	time.Sleep(10 * time.Millisecond)
+
+	page, err := store.GetBlocksWithParentAndTypePage(container, "board", model.TypeCard, model.QueryPageOptions{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, "card3", page[0].ID)
+	require.Equal(t, "card1", page[1].ID)
+
+	page, err = store.GetBlocksWithParentAndTypePage(container, "board", model.TypeCard,
+		model.QueryPageOptions{Limit: 2, AfterCreateAt: page[1].CreateAt, AfterID: page[1].ID})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "card2", page[0].ID)
+
+	// the blocks created at the same time are sorted by id
+	page, err = store.GetBlocksWithParentAndTypePage(container, "board", model.TypeCard,
+		model.QueryPageOptions{AfterCreateAt: page[0].CreateAt, AfterID: "card0"})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, "card2", page[0].ID)
+
+	page, err = store.GetBlocksWithParentAndTypePage(container, "board", model.TypeCard, model.QueryPageOptions{})
+	require.NoError(t, err)
+	require.Len(t, page, 3)
+}
+
+func testGetCardChildrenWithRootID(t *testing.T, store store.Store, container store.Container) {
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "view", RootID: "board", ParentID: "board", Type: model.TypeView, ModifiedBy: testUserID},
+		{ID: "card1", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "card2", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "text1", RootID: "board", ParentID: "card1", Type: model.TypeText, ModifiedBy: testUserID},
+		{ID: "text2", RootID: "board", ParentID: "card2", Type: model.TypeText, ModifiedBy: testUserID},
+		{ID: "comment", RootID: "board", ParentID: "card2", Type: model.TypeComment, ModifiedBy: testUserID},
+		{ID: "other-board", RootID: "other-board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "other-card", RootID: "other-board", ParentID: "other-board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "other-text", RootID: "other-board", ParentID: "other-card", Type: model.TypeText, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, container, blocks, "user-id-1")
+	defer DeleteBlocks(t, store, container, blocks, "test")
+
+	// this avoids triggering uniqueness constraint of
+	// id,insert_at on block history when deleting the blocks
+	time.Sleep(10 * time.Millisecond)
+
+	blockIDs := func(blocks []model.Block) []string {
+		ids := []string{}
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	children, err := store.GetCardChildrenWithRootID(container, "board")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"text1", "text2", "comment"}, blockIDs(children))
+
+	children, err = store.GetCardChildrenWithRootID(container, "not-exists")
+	require.NoError(t, err)
+	require.Empty(t, children)
+}
+
 func testGetBlocks(t *testing.T, store store.Store, container store.Container) {
 	blocks, err := store.GetAllBlocks(container)
 	require.NoError(t, err)
@@ -699,6 +947,13 @@ func testGetBlocks(t *testing.T, store store.Store, container store.Container) {
 		require.Len(t, blocks, 2)
 	})
 
+	t.Run("valid parent and types", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithParentAndTypes(container, "block1", []string{"test", "test2"})
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+	})
+
 	t.Run("not existing parent", func(t *testing.T) {
 		time.Sleep(1 * time.Millisecond)
 		blocks, err = store.GetBlocksWithParent(container, "not-exists")
@@ -727,6 +982,13 @@ func testGetBlocks(t *testing.T, store store.Store, container store.Container) {
 		require.Len(t, blocks, 4)
 	})
 
+	t.Run("valid types", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		blocks, err = store.GetBlocksWithTypes(container, []string{"test", "test2", "not-exists"})
+		require.NoError(t, err)
+		require.Len(t, blocks, 5)
+	})
+
 	t.Run("not existing parent", func(t *testing.T) {
 		time.Sleep(1 * time.Millisecond)
 		blocks, err = store.GetBlocksWithRootID(container, "not-exists")
@@ -770,3 +1032,269 @@ func testGetBlock(t *testing.T, store store.Store, container store.Container) {
 		require.Nil(t, fetchedBlock)
 	})
 }
+
+func testGetBlockCountWithRootID(t *testing.T, store store.Store, container store.Container) {
+	InsertBlocks(t, store, container, subtreeSampleBlocks, "user-id-1")
+
+	t.Run("existing root", func(t *testing.T) {
+		count, err := store.GetBlockCountWithRootID(container, "parent")
+		require.NoError(t, err)
+		require.EqualValues(t, len(subtreeSampleBlocks), count)
+	})
+
+	t.Run("not existing root", func(t *testing.T) {
+		count, err := store.GetBlockCountWithRootID(container, "not-exists")
+		require.NoError(t, err)
+		require.EqualValues(t, 0, count)
+	})
+}
+
+func testGetBlockCountWithRootIDAndType(t *testing.T, store store.Store, container store.Container) {
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "card1", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "card2", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "text1", RootID: "board", ParentID: "card1", Type: model.TypeText, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, container, blocks, "user-id-1")
+
+	t.Run("existing root", func(t *testing.T) {
+		count, err := store.GetBlockCountWithRootIDAndType(container, "board", model.TypeCard)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, count)
+	})
+
+	t.Run("not existing root", func(t *testing.T) {
+		count, err := store.GetBlockCountWithRootIDAndType(container, "not-exists", model.TypeCard)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, count)
+	})
+}
+
+func testGetWorkspaceBlockCountsByType(t *testing.T, store store.Store, container store.Container) {
+	container.WorkspaceID = "stats-workspace"
+	otherContainer := container
+	otherContainer.WorkspaceID = "other-workspace"
+	emptyContainer := container
+	emptyContainer.WorkspaceID = "empty-workspace"
+
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "card1", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "card2", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, container, blocks, "user-id-1")
+
+	otherBlocks := []model.Block{
+		{ID: "other-board", RootID: "other-board", Type: model.TypeBoard, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, otherContainer, otherBlocks, "user-id-1")
+
+	counts, err := store.GetWorkspaceBlockCountsByType(container)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{model.TypeBoard: 1, model.TypeCard: 2}, counts)
+
+	counts, err = store.GetWorkspaceBlockCountsByType(emptyContainer)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
+func testGetLastActivityWithRootID(t *testing.T, store store.Store, container store.Container) {
+	InsertBlocks(t, store, container, subtreeSampleBlocks, "user-id-1")
+
+	t.Run("existing root", func(t *testing.T) {
+		blocks, err := store.GetBlocksWithRootID(container, "parent")
+		require.NoError(t, err)
+		var latest int64
+		for _, block := range blocks {
+			if block.UpdateAt > latest {
+				latest = block.UpdateAt
+			}
+		}
+
+		lastActivity, err := store.GetLastActivityWithRootID(container, "parent")
+		require.NoError(t, err)
+		require.Equal(t, latest, lastActivity)
+	})
+
+	t.Run("not existing root", func(t *testing.T) {
+		lastActivity, err := store.GetLastActivityWithRootID(container, "not-exists")
+		require.NoError(t, err)
+		require.Zero(t, lastActivity)
+	})
+
+	t.Run("changes of the system aren't activity", func(t *testing.T) {
+		lastActivity, err := store.GetLastActivityWithRootID(container, "parent")
+		require.NoError(t, err)
+
+		// this avoids triggering uniqueness constraint of
+		// id,insert_at on block history
+		time.Sleep(10 * time.Millisecond)
+		title := "archival notice"
+		require.NoError(t, store.PatchBlock(container, "parent", &model.BlockPatch{Title: &title}, model.SystemUserID))
+
+		systemActivity, err := store.GetLastActivityWithRootID(container, "parent")
+		require.NoError(t, err)
+		require.Equal(t, lastActivity, systemActivity)
+
+		lastActivities, err := store.GetLastActivityByRootIDs(container, []string{"parent"})
+		require.NoError(t, err)
+		require.Equal(t, lastActivity, lastActivities["parent"])
+	})
+}
+
+func testGetLastActivityByRootIDs(t *testing.T, store store.Store, container store.Container) {
+	InsertBlocks(t, store, container, subtreeSampleBlocks, "user-id-1")
+
+	blocks, err := store.GetBlocksWithRootID(container, "parent")
+	require.NoError(t, err)
+	var latest int64
+	for _, block := range blocks {
+		if block.UpdateAt > latest {
+			latest = block.UpdateAt
+		}
+	}
+
+	t.Run("existing and not existing roots", func(t *testing.T) {
+		lastActivities, err := store.GetLastActivityByRootIDs(container, []string{"parent", "not-exists"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"parent": latest}, lastActivities)
+	})
+
+	t.Run("no roots", func(t *testing.T) {
+		lastActivities, err := store.GetLastActivityByRootIDs(container, nil)
+		require.NoError(t, err)
+		require.Empty(t, lastActivities)
+	})
+}
+
+func testGetBoardHistory(t *testing.T, store store.Store, container store.Container) {
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", ModifiedBy: testUserID, Type: model.TypeBoard},
+		{ID: "card", RootID: "board", ParentID: "board", ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "other", RootID: "other", ModifiedBy: testUserID, Type: model.TypeBoard},
+	}
+	InsertBlocks(t, store, container, blocks, testUserID)
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBlock(container, "card", testUserID))
+
+	historyIDs := func(history []model.Block) []string {
+		ids := []string{}
+		for _, block := range history {
+			ids = append(ids, block.ID)
+		}
+		return ids
+	}
+
+	all, err := store.GetBoardHistory(container, "board", model.QueryBlockHistoryOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"board", "card", "card"}, historyIDs(all))
+	require.Zero(t, all[1].DeleteAt)
+	require.NotZero(t, all[2].DeleteAt)
+
+	t.Run("time range", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{AfterUpdateAt: all[1].UpdateAt, BeforeUpdateAt: all[2].UpdateAt + 1}
+		history, err := store.GetBoardHistory(container, "board", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"card"}, historyIDs(history))
+		require.NotZero(t, history[0].DeleteAt)
+	})
+
+	t.Run("page", func(t *testing.T) {
+		opts := model.QueryBlockHistoryOptions{Limit: 1, Offset: 1}
+		history, err := store.GetBoardHistory(container, "board", opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{"card"}, historyIDs(history))
+		require.Zero(t, history[0].DeleteAt)
+	})
+
+	t.Run("not existing board", func(t *testing.T) {
+		history, err := store.GetBoardHistory(container, "not-exists", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+}
+
+func testGetInactiveBoards(t *testing.T, store store.Store, container store.Container) {
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "card", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, container, blocks, testUserID)
+
+	card, err := store.GetBlock(container, "card")
+	require.NoError(t, err)
+
+	t.Run("active boards", func(t *testing.T) {
+		boards, err := store.GetInactiveBoards(card.UpdateAt)
+		require.NoError(t, err)
+		for _, board := range boards {
+			require.NotEqual(t, "board", board.ID)
+		}
+	})
+
+	t.Run("inactive boards", func(t *testing.T) {
+		boards, err := store.GetInactiveBoards(card.UpdateAt + 1)
+		require.NoError(t, err)
+		var board *model.Block
+		for i := range boards {
+			if boards[i].ID == "board" {
+				board = &boards[i]
+			}
+		}
+		require.NotNil(t, board)
+		require.Equal(t, card.UpdateAt, board.LastActivityAt)
+	})
+}
+
+func testGetBoardCardStats(t *testing.T, store store.Store, container store.Container) {
+	past := fmt.Sprintf(`{"from":%d}`, utils.GetMillis()-100000)
+	future := fmt.Sprintf(`{"from":%d}`, utils.GetMillis()+100000)
+	blocks := []model.Block{
+		{ID: "board", RootID: "board", Type: model.TypeBoard, ModifiedBy: testUserID},
+		{ID: "card-1", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "assignee": "user-id-1", "due": past},
+		}},
+		{ID: "card-2", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "assignee": "", "due": future},
+		}},
+		{ID: "card-3", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done", "reviewer": "user-id-2", "start": past},
+		}},
+		{ID: "card-4", RootID: "board", ParentID: "board", Type: model.TypeCard, ModifiedBy: testUserID},
+		{ID: "text", RootID: "board", ParentID: "card-1", Type: model.TypeText, ModifiedBy: testUserID},
+		{ID: "other-card", RootID: "other-board", ParentID: "other-board", Type: model.TypeCard, ModifiedBy: testUserID},
+	}
+	InsertBlocks(t, store, container, blocks, testUserID)
+
+	opts := model.CardStatsOptions{
+		GroupPropertyID:   "status",
+		PersonPropertyIDs: []string{"assignee", "reviewer"},
+		DatePropertyIDs:   []string{"due", "start"},
+		Now:               utils.GetMillis(),
+	}
+
+	t.Run("grouped", func(t *testing.T) {
+		stats, err := store.GetBoardCardStats(container, "board", opts)
+		require.NoError(t, err)
+		require.Equal(t, 4, stats.CardCount)
+		require.Equal(t, map[string]int{"todo": 2, "done": 1, "": 1}, stats.CardsByGroup)
+		require.Equal(t, 2, stats.UnassignedCardCount)
+		require.Equal(t, 2, stats.OverdueCardCount)
+	})
+
+	t.Run("not grouped", func(t *testing.T) {
+		stats, err := store.GetBoardCardStats(container, "board", model.CardStatsOptions{Now: opts.Now})
+		require.NoError(t, err)
+		require.Equal(t, 4, stats.CardCount)
+		require.Empty(t, stats.CardsByGroup)
+		require.Equal(t, 4, stats.UnassignedCardCount)
+		require.Zero(t, stats.OverdueCardCount)
+	})
+
+	t.Run("not existing board", func(t *testing.T) {
+		stats, err := store.GetBoardCardStats(container, "not-exists", opts)
+		require.NoError(t, err)
+		require.Zero(t, stats.CardCount)
+	})
+}
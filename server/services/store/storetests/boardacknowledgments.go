@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardAcknowledgmentsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("UpsertBoardAcknowledgment", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpsertBoardAcknowledgment(t, store, container)
+	})
+}
+
+func testUpsertBoardAcknowledgment(t *testing.T, store store.Store, container store.Container) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("no acknowledgments", func(t *testing.T) {
+		acks, err := store.GetBoardAcknowledgments(container, boardID)
+		require.NoError(t, err)
+		assert.Empty(t, acks)
+	})
+
+	t.Run("acknowledge a board", func(t *testing.T) {
+		require.NoError(t, store.UpsertBoardAcknowledgment(container, &model.BoardAcknowledgment{BoardID: boardID, UserID: userID, BoardLastActivityAt: 10}))
+		require.NoError(t, store.UpsertBoardAcknowledgment(container, &model.BoardAcknowledgment{BoardID: boardID, UserID: otherUserID, BoardLastActivityAt: 10}))
+
+		acks, err := store.GetBoardAcknowledgments(container, boardID)
+		require.NoError(t, err)
+		require.Len(t, acks, 2)
+		assert.ElementsMatch(t, []string{userID, otherUserID}, []string{acks[0].UserID, acks[1].UserID})
+		assert.Equal(t, container.WorkspaceID, acks[0].WorkspaceID)
+		assert.Equal(t, int64(10), acks[0].BoardLastActivityAt)
+		assert.NotZero(t, acks[0].CreateAt)
+	})
+
+	t.Run("acknowledge a board again", func(t *testing.T) {
+		require.NoError(t, store.UpsertBoardAcknowledgment(container, &model.BoardAcknowledgment{BoardID: boardID, UserID: userID, BoardLastActivityAt: 20}))
+
+		acks, err := store.GetBoardAcknowledgments(container, boardID)
+		require.NoError(t, err)
+		require.Len(t, acks, 2)
+		for _, ack := range acks {
+			if ack.UserID == userID {
+				assert.Equal(t, int64(20), ack.BoardLastActivityAt)
+			}
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestBoardAPIKeysStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("CreateAndGetBoardAPIKeys", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetBoardAPIKeys(t, store, container)
+	})
+
+	t.Run("DeleteBoardAPIKey", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardAPIKey(t, store, container)
+	})
+}
+
+func testCreateAndGetBoardAPIKeys(t *testing.T, store store.Store, container store.Container) {
+	key1 := &model.BoardAPIKey{ID: "key-1", BoardID: "board-1", Name: "first", Token: "token-1", CreatedBy: testUserID, CreateAt: 1}
+	key2 := &model.BoardAPIKey{ID: "key-2", BoardID: "board-1", Name: "second", Token: "token-2", CreatedBy: testUserID, CreateAt: 2}
+	key3 := &model.BoardAPIKey{ID: "key-3", BoardID: "board-2", Name: "other", Token: "token-3", CreatedBy: testUserID, CreateAt: 3}
+	for _, key := range []*model.BoardAPIKey{key1, key2, key3} {
+		require.NoError(t, store.CreateBoardAPIKey(container, key))
+	}
+
+	t.Run("keys of a board", func(t *testing.T) {
+		keys, err := store.GetBoardAPIKeys(container, "board-1")
+		require.NoError(t, err)
+		require.Equal(t, []*model.BoardAPIKey{key1, key2}, keys)
+	})
+
+	t.Run("keys of another workspace", func(t *testing.T) {
+		other := container
+		other.WorkspaceID = "other"
+		keys, err := store.GetBoardAPIKeys(other, "board-1")
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("key by token", func(t *testing.T) {
+		key, err := store.GetBoardAPIKeyByToken("token-3")
+		require.NoError(t, err)
+		require.Equal(t, key3, key)
+	})
+
+	t.Run("duplicate token", func(t *testing.T) {
+		duplicate := &model.BoardAPIKey{ID: "key-4", BoardID: "board-2", Name: "duplicate", Token: "token-3", CreatedBy: testUserID, CreateAt: 4}
+		require.Error(t, store.CreateBoardAPIKey(container, duplicate))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		key, err := store.GetBoardAPIKeyByToken("unknown")
+		require.True(t, store.IsErrNotFound(err))
+		require.Nil(t, key)
+	})
+}
+
+func testDeleteBoardAPIKey(t *testing.T, store store.Store, container store.Container) {
+	key := &model.BoardAPIKey{ID: "key-1", BoardID: "board-1", Name: "first", Token: "token-1", CreatedBy: testUserID, CreateAt: 1}
+	require.NoError(t, store.CreateBoardAPIKey(container, key))
+
+	t.Run("from another board", func(t *testing.T) {
+		err := store.DeleteBoardAPIKey(container, "board-2", key.ID)
+		require.True(t, store.IsErrNotFound(err))
+	})
+
+	t.Run("existing key", func(t *testing.T) {
+		require.NoError(t, store.DeleteBoardAPIKey(container, "board-1", key.ID))
+		_, err := store.GetBoardAPIKeyByToken(key.Token)
+		require.True(t, store.IsErrNotFound(err))
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		err := store.DeleteBoardAPIKey(container, "board-1", key.ID)
+		require.True(t, store.IsErrNotFound(err))
+	})
+}
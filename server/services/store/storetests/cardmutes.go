@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardMutesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("MuteCard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMuteCard(t, store, container)
+	})
+}
+
+func testMuteCard(t *testing.T, store store.Store, container store.Container) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	cardID1 := utils.NewID(utils.IDTypeCard)
+	cardID2 := utils.NewID(utils.IDTypeCard)
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("no muted cards", func(t *testing.T) {
+		mutes, err := store.GetMutedCards(container, userID)
+		require.NoError(t, err)
+		assert.Empty(t, mutes)
+	})
+
+	t.Run("mute cards", func(t *testing.T) {
+		require.NoError(t, store.MuteCard(container, &model.CardMute{CardID: cardID1, BoardID: boardID, UserID: userID}))
+		require.NoError(t, store.MuteCard(container, &model.CardMute{CardID: cardID2, BoardID: boardID, UserID: userID}))
+		require.NoError(t, store.MuteCard(container, &model.CardMute{CardID: cardID1, BoardID: boardID, UserID: otherUserID}))
+
+		mutes, err := store.GetMutedCards(container, userID)
+		require.NoError(t, err)
+		require.Len(t, mutes, 2)
+		cardIDs := []string{mutes[0].CardID, mutes[1].CardID}
+		assert.ElementsMatch(t, []string{cardID1, cardID2}, cardIDs)
+		assert.Equal(t, boardID, mutes[0].BoardID)
+		assert.Equal(t, container.WorkspaceID, mutes[0].WorkspaceID)
+		assert.NotZero(t, mutes[0].CreateAt)
+	})
+
+	t.Run("mute a card again", func(t *testing.T) {
+		require.NoError(t, store.MuteCard(container, &model.CardMute{CardID: cardID1, BoardID: boardID, UserID: userID}))
+
+		mutes, err := store.GetMutedCards(container, userID)
+		require.NoError(t, err)
+		assert.Len(t, mutes, 2)
+	})
+
+	t.Run("unmute card", func(t *testing.T) {
+		require.NoError(t, store.UnmuteCard(container, cardID1, userID))
+
+		mutes, err := store.GetMutedCards(container, userID)
+		require.NoError(t, err)
+		require.Len(t, mutes, 1)
+		assert.Equal(t, cardID2, mutes[0].CardID)
+
+		mutes, err = store.GetMutedCards(container, otherUserID)
+		require.NoError(t, err)
+		assert.Len(t, mutes, 1)
+	})
+}
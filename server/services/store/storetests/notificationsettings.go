@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestNotificationSettingsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	container := store.Container{
+		WorkspaceID: "0",
+	}
+
+	t.Run("UpsertNotificationSettings", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpsertNotificationSettings(t, store, container)
+	})
+
+	t.Run("NotificationDigestItems", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testNotificationDigestItems(t, store)
+	})
+}
+
+func testUpsertNotificationSettings(t *testing.T, store store.Store, container store.Container) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	userID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("no settings", func(t *testing.T) {
+		settings, err := store.GetNotificationSettings(container, boardID, userID)
+		assert.True(t, store.IsErrNotFound(err), "should be ErrNotFound")
+		assert.Nil(t, settings)
+	})
+
+	t.Run("create settings", func(t *testing.T) {
+		settings := &model.NotificationSettings{
+			BoardID: boardID,
+			UserID:  userID,
+			Mode:    model.NotifyModeHourly,
+		}
+		_, err := store.UpsertNotificationSettings(container, settings)
+		require.NoError(t, err)
+
+		settings, err = store.GetNotificationSettings(container, boardID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, model.NotifyMode(model.NotifyModeHourly), settings.Mode)
+		assert.Equal(t, container.WorkspaceID, settings.WorkspaceID)
+		assert.NotZero(t, settings.UpdateAt)
+	})
+
+	t.Run("update settings", func(t *testing.T) {
+		settings := &model.NotificationSettings{
+			BoardID: boardID,
+			UserID:  userID,
+			Mode:    model.NotifyModeOff,
+		}
+		_, err := store.UpsertNotificationSettings(container, settings)
+		require.NoError(t, err)
+
+		settings, err = store.GetNotificationSettings(container, boardID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, model.NotifyMode(model.NotifyModeOff), settings.Mode)
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		settings := &model.NotificationSettings{
+			BoardID: boardID,
+			UserID:  userID,
+			Mode:    "weekly",
+		}
+		_, err := store.UpsertNotificationSettings(container, settings)
+		require.ErrorAs(t, err, &model.ErrInvalidNotificationSettings{})
+	})
+}
+
+func testNotificationDigestItems(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	boardID := utils.NewID(utils.IDTypeBoard)
+
+	due := &model.NotificationDigestItem{
+		WorkspaceID: "0",
+		BoardID:     boardID,
+		UserID:      userID,
+		Attachments: "[]",
+		DeliverAt:   100,
+	}
+	require.NoError(t, store.AddNotificationDigestItem(due))
+	require.NotEmpty(t, due.ID)
+
+	later := &model.NotificationDigestItem{
+		WorkspaceID: "0",
+		BoardID:     boardID,
+		UserID:      userID,
+		Attachments: "[]",
+		DeliverAt:   200,
+	}
+	require.NoError(t, store.AddNotificationDigestItem(later))
+
+	items, err := store.GetDueNotificationDigestItems(150)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, due.ID, items[0].ID)
+	assert.Equal(t, "[]", items[0].Attachments)
+
+	require.NoError(t, store.DeleteNotificationDigestItems([]string{due.ID}))
+
+	items, err = store.GetDueNotificationDigestItems(250)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, later.ID, items[0].ID)
+}
@@ -1,6 +1,9 @@
 package storetests
 
 import (
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -18,6 +21,18 @@ func StoreTestSharingStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		defer tearDown()
 		testUpsertSharingAndGetSharing(t, store, container)
 	})
+
+	t.Run("GetEnabledSharingByToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetEnabledSharingByToken(t, store, container)
+	})
+
+	t.Run("GetBoardsSharingStatus", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsSharingStatus(t, store, container)
+	})
 }
 
 func testUpsertSharingAndGetSharing(t *testing.T, store store.Store, container store.Container) {
@@ -29,10 +44,12 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store, container s
 			ModifiedBy: testUserID,
 		}
 
-		err := store.UpsertSharing(container, sharing)
+		stored, err := store.UpsertSharing(container, sharing)
 		require.NoError(t, err)
+		require.NotZero(t, stored.UpdateAt)
 		newSharing, err := store.GetSharing(container, "sharing-id")
 		require.NoError(t, err)
+		require.Equal(t, stored, newSharing)
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
 	})
@@ -49,15 +66,108 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store, container s
 		newSharing.UpdateAt = 0
 		require.NotEqual(t, sharing, *newSharing)
 
-		err = store.UpsertSharing(container, sharing)
+		_, err = store.UpsertSharing(container, sharing)
 		require.NoError(t, err)
 		newSharing, err = store.GetSharing(container, "sharing-id")
 		require.NoError(t, err)
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
 	})
+	t.Run("Concurrent upserts", func(t *testing.T) {
+		const writers = 10
+		var wg sync.WaitGroup
+		results := make([]*model.Sharing, writers)
+		errs := make([]error, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = store.UpsertSharing(container, model.Sharing{
+					ID:         "concurrent-id",
+					Enabled:    true,
+					Token:      fmt.Sprintf("token-%d", i),
+					ModifiedBy: fmt.Sprintf("user-%d", i),
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		for i := 0; i < writers; i++ {
+			require.NoError(t, errs[i])
+			// each writer gets back a consistent row
+			require.Equal(t, strings.TrimPrefix(results[i].Token, "token-"), strings.TrimPrefix(results[i].ModifiedBy, "user-"))
+		}
+
+		stored, err := store.GetSharing(container, "concurrent-id")
+		require.NoError(t, err)
+		require.Equal(t, strings.TrimPrefix(stored.Token, "token-"), strings.TrimPrefix(stored.ModifiedBy, "user-"))
+	})
+
 	t.Run("Get not existing sharing", func(t *testing.T) {
 		_, err := store.GetSharing(container, "not-existing")
 		require.Error(t, err)
 	})
 }
+
+func testGetEnabledSharingByToken(t *testing.T, store store.Store, container store.Container) {
+	sharings := []model.Sharing{
+		{ID: "shared-board", Enabled: true, Token: "token", ModifiedBy: testUserID},
+		{ID: "disabled-board", Enabled: false, Token: "disabled-token", ModifiedBy: testUserID},
+	}
+	for _, sharing := range sharings {
+		_, err := store.UpsertSharing(container, sharing)
+		require.NoError(t, err)
+	}
+
+	sharing, err := store.GetEnabledSharingByToken(container, "token")
+	require.NoError(t, err)
+	require.Equal(t, "shared-board", sharing.ID)
+
+	_, err = store.GetEnabledSharingByToken(container, "disabled-token")
+	require.Error(t, err)
+
+	_, err = store.GetEnabledSharingByToken(container, "not-existing")
+	require.Error(t, err)
+}
+
+func testGetBoardsSharingStatus(t *testing.T, store store.Store, container store.Container) {
+	otherContainer := container
+	otherContainer.WorkspaceID = "other-workspace"
+
+	blocks := []model.Block{
+		{ID: "shared-board", RootID: "shared-board", Type: model.TypeBoard},
+		{ID: "disabled-board", RootID: "disabled-board", Type: model.TypeBoard},
+		{ID: "private-board", RootID: "private-board", Type: model.TypeBoard},
+		{ID: "card", RootID: "shared-board", Type: model.TypeCard},
+	}
+	InsertBlocks(t, store, container, blocks, testUserID)
+	InsertBlocks(t, store, otherContainer, []model.Block{{ID: "other-board", RootID: "other-board", Type: model.TypeBoard}}, testUserID)
+
+	sharings := []model.Sharing{
+		{ID: "shared-board", Enabled: true, Token: "token", ModifiedBy: testUserID},
+		{ID: "disabled-board", Enabled: false, Token: "token", ModifiedBy: testUserID},
+		{ID: "card", Enabled: true, Token: "token", ModifiedBy: testUserID},
+		{ID: "other-board", Enabled: true, Token: "token", ModifiedBy: testUserID},
+	}
+	for _, sharing := range sharings {
+		_, err := store.UpsertSharing(container, sharing)
+		require.NoError(t, err)
+	}
+
+	t.Run("boards of the workspace", func(t *testing.T) {
+		ids := []string{"shared-board", "disabled-board", "private-board", "card", "other-board", "not-existing"}
+		statuses, err := store.GetBoardsSharingStatus(container, ids)
+		require.NoError(t, err)
+		require.Equal(t, map[string]model.SharingStatus{
+			"shared-board":   {Enabled: true, HasToken: true},
+			"disabled-board": {Enabled: false, HasToken: true},
+			"private-board":  {Enabled: false, HasToken: false},
+		}, statuses)
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		statuses, err := store.GetBoardsSharingStatus(container, nil)
+		require.NoError(t, err)
+		require.Empty(t, statuses)
+	})
+}
@@ -7,10 +7,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// these system settings are created when running the data migrations,
-// so they will be present after the tests setup.
+// these system settings are created when running the data migrations
+// and seeding the templates, so they will be present after the tests setup.
 var dataMigrationSystemSettings = map[string]string{
-	"UniqueIDsMigrationComplete": "true",
+	"UniqueIDsMigrationComplete":         "true",
+	"TemplateSeedVersion_meeting-notes":  "1",
+	"TemplateSeedVersion_personal-goals": "1",
+	"TemplateSeedVersion_personal-tasks": "1",
+	"TemplateSeedVersion_project-tasks":  "1",
+	"TemplateSeedVersion_roadmap":        "1",
 }
 
 func addBaseSettings(m map[string]string) map[string]string {
@@ -39,6 +39,58 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testCreateAndGetRegisteredUserCount(t, store)
 	})
+
+	t.Run("GetUsersByIDs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetUsersByIDs(t, store)
+	})
+
+	t.Run("GetWorkspaceMemberCount", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetWorkspaceMemberCount(t, store)
+	})
+}
+
+func testGetWorkspaceMemberCount(t *testing.T, store store.Store) {
+	count, err := store.GetWorkspaceMemberCount("0")
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	err = store.CreateUser(&model.User{
+		ID:       utils.NewID(utils.IDTypeUser),
+		Username: "han",
+	})
+	require.NoError(t, err)
+
+	count, err = store.GetWorkspaceMemberCount("0")
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func testGetUsersByIDs(t *testing.T, store store.Store) {
+	users, err := store.GetUsersByIDs([]string{})
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	userIDs := []string{}
+	for _, username := range []string{"luke", "leia"} {
+		user := &model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: username,
+		}
+		require.NoError(t, store.CreateUser(user))
+		userIDs = append(userIDs, user.ID)
+	}
+
+	users, err = store.GetUsersByIDs(append(userIDs, "unknown-user"))
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	users, err = store.GetUsersByIDs([]string{"unknown-user"})
+	require.NoError(t, err)
+	require.Empty(t, users)
 }
 
 func testGetWorkspaceUsers(t *testing.T, store store.Store) {
@@ -0,0 +1,224 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrDestinationNotAllowed is returned when a webhook URL, or the address it
+// resolves to, is denied by the webhook_allowed_hosts and
+// webhook_denied_hosts settings, or is a private address that isn't
+// explicitly allowed.
+var ErrDestinationNotAllowed = errors.New("webhook destination not allowed")
+
+// privateNets are the loopback, private, link-local, shared, unspecified,
+// multicast and reserved ranges, and the NAT64 prefix that maps to IPv4
+// addresses, which webhooks can't be sent to unless they are allowed
+// explicitly.
+var privateNets = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = ipNet
+	}
+	return nets
+}
+
+// hostList is a list of host names, IP addresses and CIDR ranges. A host
+// name starting with *. matches the subdomains of the rest of the name.
+type hostList struct {
+	names []string
+	nets  []*net.IPNet
+}
+
+func parseHostList(setting string, entries []string) (hostList, error) {
+	list := hostList{}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			list.nets = append(list.nets, ipNet)
+			continue
+		}
+		if ip := net.ParseIP(entry); ip != nil {
+			list.nets = append(list.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		if strings.ContainsAny(entry, "/:") {
+			return hostList{}, fmt.Errorf("invalid %s entry %q, must be a host name, an IP address or a CIDR range", setting, entry)
+		}
+		list.names = append(list.names, entry)
+	}
+	return list, nil
+}
+
+func (l hostList) empty() bool {
+	return len(l.names) == 0 && len(l.nets) == 0
+}
+
+func (l hostList) matchesName(host string) bool {
+	for _, name := range l.names {
+		if host == name || (strings.HasPrefix(name, "*.") && strings.HasSuffix(host, name[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l hostList) matchesIP(ip net.IP) bool {
+	for _, ipNet := range l.nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPrivateIP(ip net.IP) bool {
+	for _, ipNet := range privateNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+// hostFilter decides which hosts webhooks can be sent to. The denied hosts
+// are always rejected. When allowed hosts are set, only they are accepted.
+// Private addresses are rejected unless they or their host name are allowed.
+type hostFilter struct {
+	allowed hostList
+	denied  hostList
+}
+
+func newHostFilter(allowed, denied []string) (*hostFilter, error) {
+	allowedList, err := parseHostList("webhook_allowed_hosts", allowed)
+	if err != nil {
+		return nil, err
+	}
+	deniedList, err := parseHostList("webhook_denied_hosts", denied)
+	if err != nil {
+		return nil, err
+	}
+	return &hostFilter{allowed: allowedList, denied: deniedList}, nil
+}
+
+// checkURL checks a webhook URL without resolving its host, so that the
+// configured URLs can be rejected up front. Host names are checked against
+// the host name entries only, their addresses are checked when dialing.
+func (f *hostFilter) checkURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL %q: %w", rawURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid webhook URL %q, the scheme must be http or https", rawURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("invalid webhook URL %q, the host is missing", rawURL)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return f.checkIP(host, ip)
+	}
+	if f.denied.matchesName(host) {
+		return fmt.Errorf("%w: %s is in webhook_denied_hosts", ErrDestinationNotAllowed, host)
+	}
+	allowedByName := f.allowed.matchesName(host)
+	if !allowedByName && len(f.allowed.names) > 0 && len(f.allowed.nets) == 0 {
+		return fmt.Errorf("%w: %s is not in webhook_allowed_hosts", ErrDestinationNotAllowed, host)
+	}
+	if !allowedByName && (host == "localhost" || strings.HasSuffix(host, ".localhost")) {
+		return fmt.Errorf("%w: %s is a private address, add it to webhook_allowed_hosts to allow it", ErrDestinationNotAllowed, host)
+	}
+	return nil
+}
+
+// checkIP checks an address host resolved to.
+func (f *hostFilter) checkIP(host string, ip net.IP) error {
+	if f.denied.matchesName(host) || f.denied.matchesIP(ip) {
+		return fmt.Errorf("%w: %s (%s) is in webhook_denied_hosts", ErrDestinationNotAllowed, host, ip)
+	}
+	allowed := f.allowed.matchesName(host) || f.allowed.matchesIP(ip)
+	if !allowed && !f.allowed.empty() {
+		return fmt.Errorf("%w: %s (%s) is not in webhook_allowed_hosts", ErrDestinationNotAllowed, host, ip)
+	}
+	if !allowed && isPrivateIP(ip) {
+		return fmt.Errorf("%w: %s (%s) is a private address, add it to webhook_allowed_hosts to allow it", ErrDestinationNotAllowed, host, ip)
+	}
+	return nil
+}
+
+// dialContext resolves the host of address and dials the first of its
+// addresses, failing if any of them isn't allowed. Dialing the checked
+// address itself keeps the host from resolving to another address between
+// the check and the connection.
+func (f *hostFilter) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	host = strings.ToLower(host)
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address found for %s", host)
+	}
+	for _, addr := range addrs {
+		if err := f.checkIP(host, addr.IP); err != nil {
+			return nil, err
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return dialer.DialContext(ctx, network, net.JoinHostPort(addrs[0].IP.String(), port))
+}
+
+// transport returns a transport dialing through the filter. Proxies are
+// ignored, as the destination couldn't be checked through them.
+func (f *hostFilter) transport() *http.Transport {
+	return &http.Transport{
+		Proxy:                 nil,
+		DialContext:           f.dialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestHostFilterCheckURL(t *testing.T) {
+	testCases := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		url     string
+		allow   bool
+	}{
+		{name: "public host", url: "https://hooks.example.com/update", allow: true},
+		{name: "public address", url: "http://93.184.216.34/update", allow: true},
+		{name: "loopback address", url: "http://127.0.0.1:8080/update"},
+		{name: "private address", url: "http://10.1.2.3/update"},
+		{name: "link-local address", url: "http://169.254.169.254/latest/meta-data"},
+		{name: "ipv6 loopback address", url: "http://[::1]/update"},
+		{name: "multicast address", url: "http://224.0.0.1/update"},
+		{name: "reserved address", url: "http://240.0.0.1/update"},
+		{name: "broadcast address", url: "http://255.255.255.255/update"},
+		{name: "benchmarking address", url: "http://198.18.0.1/update"},
+		{name: "ietf protocol address", url: "http://192.0.0.1/update"},
+		{name: "nat64 address", url: "http://[64:ff9b::a00:1]/update"},
+		{name: "ipv6 multicast address", url: "http://[ff02::1]/update"},
+		{name: "localhost", url: "http://localhost:8080/update"},
+		{name: "allowed private address", allowed: []string{"10.0.0.0/8"}, url: "http://10.1.2.3/update", allow: true},
+		{name: "allowed localhost", allowed: []string{"localhost"}, url: "http://localhost:8080/update", allow: true},
+		{name: "denied host", denied: []string{"hooks.example.com"}, url: "https://hooks.example.com/update"},
+		{name: "denied subdomain", denied: []string{"*.example.com"}, url: "https://hooks.example.com/update"},
+		{name: "denied range", denied: []string{"93.184.0.0/16"}, url: "http://93.184.216.34/update"},
+		{name: "denied over allowed", allowed: []string{"10.0.0.0/8"}, denied: []string{"10.1.2.3"}, url: "http://10.1.2.3/update"},
+		{name: "host not in the allowlist", allowed: []string{"hooks.example.com"}, url: "https://other.example.com/update"},
+		{name: "address not in the allowlist", allowed: []string{"hooks.example.com"}, url: "http://93.184.216.34/update"},
+		{name: "host in the allowlist", allowed: []string{"hooks.example.com"}, url: "https://hooks.example.com/update", allow: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := newHostFilter(tc.allowed, tc.denied)
+			require.NoError(t, err)
+
+			err = filter.checkURL(tc.url)
+			if tc.allow {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrDestinationNotAllowed)
+			}
+		})
+	}
+
+	t.Run("invalid urls", func(t *testing.T) {
+		filter, err := newHostFilter(nil, nil)
+		require.NoError(t, err)
+
+		for _, url := range []string{"ftp://hooks.example.com", "hooks.example.com/update", "http:///update"} {
+			err := filter.checkURL(url)
+			require.Error(t, err, url)
+			require.NotErrorIs(t, err, ErrDestinationNotAllowed, url)
+		}
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		_, err := newHostFilter([]string{"10.0.0.0/33"}, nil)
+		require.Error(t, err)
+
+		_, err = newHostFilter(nil, []string{"http://hooks.example.com"})
+		require.Error(t, err)
+	})
+}
+
+func TestHostFilterCheckIP(t *testing.T) {
+	filter, err := newHostFilter([]string{"internal.example.com"}, nil)
+	require.NoError(t, err)
+
+	// a host name resolving to a private address is allowed by its name only
+	require.NoError(t, filter.checkIP("internal.example.com", net.ParseIP("10.1.2.3")))
+	require.ErrorIs(t, filter.checkIP("rebound.example.com", net.ParseIP("10.1.2.3")), ErrDestinationNotAllowed)
+}
+
+func TestNewClientRejectsDisallowedURLs(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		require.NoError(t, err)
+	}()
+
+	_, err := NewClient(&config.Configuration{WebhookUpdate: []string{"http://169.254.169.254/update"}}, logger)
+	require.ErrorIs(t, err, ErrDestinationNotAllowed)
+
+	_, err = NewClient(&config.Configuration{
+		WebhookInsertValidation: map[string]config.InsertValidationWebhookConfig{
+			"workspace1": {URL: "http://10.1.2.3/validate"},
+		},
+	}, logger)
+	require.ErrorIs(t, err, ErrDestinationNotAllowed)
+}
+
+func TestClientChecksAddressesWhenDialing(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		require.NoError(t, err)
+	}()
+
+	var isNotified bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isNotified = true
+	}))
+	defer ts.Close()
+
+	// the addresses are checked again when dialing, so a URL set after the
+	// client is created can't reach a private address either
+	client, err := NewClient(&config.Configuration{}, logger)
+	require.NoError(t, err)
+	client.config.WebhookUpdate = []string{ts.URL}
+
+	client.NotifyUpdate(model.Block{})
+	require.False(t, isNotified)
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	defaultValidationTimeout = 5 * time.Second
+	maxRejectionMessageSize  = 4 * 1024
+)
+
+// ErrInsertRejected is returned when the insert validation webhook rejects
+// the blocks, or can't be reached and is configured to fail closed.
+type ErrInsertRejected struct {
+	Message string
+}
+
+func (e ErrInsertRejected) Error() string {
+	return e.Message
+}
+
+// IsErrInsertRejected returns true if `err` is or wraps an ErrInsertRejected.
+func IsErrInsertRejected(err error) bool {
+	var eir ErrInsertRejected
+	return errors.As(err, &eir)
+}
+
+type insertValidationRequest struct {
+	WorkspaceID string        `json:"workspaceId"`
+	Blocks      []model.Block `json:"blocks"`
+}
+
+// ValidateInsert calls the insert validation webhook of the workspace, if
+// any, with the blocks about to be inserted. A non-2xx response rejects the
+// insert, using the response body as the rejection message.
+func (wh *Client) ValidateInsert(workspaceID string, blocks []model.Block) error {
+	hook, ok := wh.config.WebhookInsertValidation[workspaceID]
+	if !ok || hook.URL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(insertValidationRequest{WorkspaceID: workspaceID, Blocks: blocks})
+	if err != nil {
+		return err
+	}
+
+	timeout := defaultValidationTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+	client := &http.Client{Timeout: timeout, Transport: wh.httpClient.Transport}
+
+	resp, err := client.Post(hook.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return wh.validationUnavailable(hook, err)
+	}
+	defer resp.Body.Close()
+
+	message, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRejectionMessageSize))
+	if err != nil {
+		return wh.validationUnavailable(hook, err)
+	}
+
+	wh.logger.Debug("webhook.ValidateInsert",
+		mlog.String("url", hook.URL),
+		mlog.Int("status", resp.StatusCode),
+	)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(message))
+		if msg == "" {
+			msg = fmt.Sprintf("insert rejected by validation webhook (status %d)", resp.StatusCode)
+		}
+		return ErrInsertRejected{Message: msg}
+	}
+
+	return nil
+}
+
+func (wh *Client) validationUnavailable(hook config.InsertValidationWebhookConfig, err error) error {
+	wh.logger.Error("webhook.ValidateInsert: webhook unavailable",
+		mlog.String("url", hook.URL),
+		mlog.Bool("fail_open", hook.FailOpen),
+		mlog.Err(err),
+	)
+	if hook.FailOpen {
+		return nil
+	}
+	return ErrInsertRejected{Message: "insert validation webhook unavailable"}
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func TestClientValidateInsert(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		require.NoError(t, err)
+	}()
+
+	blocks := []model.Block{{ID: "block1", Type: model.TypeCard}}
+
+	newClient := func(hook config.InsertValidationWebhookConfig) *Client {
+		cfg := &config.Configuration{
+			WebhookInsertValidation: map[string]config.InsertValidationWebhookConfig{"workspace1": hook},
+			WebhookAllowedHosts:     []string{"127.0.0.1", "localhost"},
+		}
+		client, err := NewClient(cfg, logger)
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("no webhook configured for the workspace", func(t *testing.T) {
+		client := newClient(config.InsertValidationWebhookConfig{URL: "http://localhost:0"})
+		require.NoError(t, client.ValidateInsert("workspace2", blocks))
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		client := newClient(config.InsertValidationWebhookConfig{URL: ts.URL})
+		require.NoError(t, client.ValidateInsert("workspace1", blocks))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte("cards need a cost center\n"))
+		}))
+		defer ts.Close()
+
+		client := newClient(config.InsertValidationWebhookConfig{URL: ts.URL})
+		err := client.ValidateInsert("workspace1", blocks)
+		require.True(t, IsErrInsertRejected(err))
+		require.Equal(t, "cards need a cost center", err.Error())
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := ts.URL
+		ts.Close()
+
+		client := newClient(config.InsertValidationWebhookConfig{URL: url, FailOpen: true})
+		require.NoError(t, client.ValidateInsert("workspace1", blocks))
+
+		client = newClient(config.InsertValidationWebhookConfig{URL: url, FailOpen: false})
+		require.True(t, IsErrInsertRejected(client.ValidateInsert("workspace1", blocks)))
+	})
+}
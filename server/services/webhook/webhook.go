@@ -23,7 +23,11 @@ func (wh *Client) NotifyUpdate(block model.Block) {
 		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
 	}
 	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
+		resp, err := wh.httpClient.Post(url, "application/json", bytes.NewBuffer(json))
+		if err != nil {
+			wh.logger.Error("webhook.NotifyUpdate", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
 		_, _ = ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 
@@ -31,16 +35,61 @@ func (wh *Client) NotifyUpdate(block model.Block) {
 	}
 }
 
+// NotifyBoardChange calls webhooks with the debounced changes of a board.
+func (wh *Client) NotifyBoardChange(change model.BoardChange) {
+	if len(wh.config.WebhookUpdate) < 1 {
+		return
+	}
+
+	json, err := json.Marshal(change)
+	if err != nil {
+		wh.logger.Error("NotifyBoardChange: json.Marshal", mlog.Err(err))
+		return
+	}
+	for _, url := range wh.config.WebhookUpdate {
+		resp, err := wh.httpClient.Post(url, "application/json", bytes.NewBuffer(json))
+		if err != nil {
+			wh.logger.Error("webhook.NotifyBoardChange", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
+		_, _ = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		wh.logger.Debug("webhook.NotifyBoardChange", mlog.String("url", url), mlog.String("boardID", change.BoardID))
+	}
+}
+
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger *mlog.Logger
+	config     *config.Configuration
+	logger     *mlog.Logger
+	httpClient *http.Client
 }
 
-// NewClient creates a new Client.
-func NewClient(config *config.Configuration, logger *mlog.Logger) *Client {
-	return &Client{
-		config: config,
-		logger: logger,
+// NewClient creates a new Client. It returns an error wrapping
+// ErrDestinationNotAllowed if a configured webhook URL isn't allowed by the
+// webhook_allowed_hosts and webhook_denied_hosts settings.
+func NewClient(config *config.Configuration, logger *mlog.Logger) (*Client, error) {
+	filter, err := newHostFilter(config.WebhookAllowedHosts, config.WebhookDeniedHosts)
+	if err != nil {
+		return nil, err
 	}
+
+	urls := append([]string{}, config.WebhookUpdate...)
+	for _, hook := range config.WebhookInsertValidation {
+		if hook.URL != "" {
+			urls = append(urls, hook.URL)
+		}
+	}
+	for _, url := range urls {
+		if err := filter.checkURL(url); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{
+		config:     config,
+		logger:     logger,
+		httpClient: &http.Client{Transport: filter.transport()},
+	}, nil
 }
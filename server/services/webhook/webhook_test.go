@@ -1,6 +1,7 @@
 package webhook
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
@@ -20,7 +22,8 @@ func TestClientUpdateNotify(t *testing.T) {
 	defer ts.Close()
 
 	cfg := &config.Configuration{
-		WebhookUpdate: []string{ts.URL},
+		WebhookUpdate:       []string{ts.URL},
+		WebhookAllowedHosts: []string{"127.0.0.1"},
 	}
 
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
@@ -29,7 +32,8 @@ func TestClientUpdateNotify(t *testing.T) {
 		assert.NoError(t, err)
 	}()
 
-	client := NewClient(cfg, logger)
+	client, err := NewClient(cfg, logger)
+	require.NoError(t, err)
 
 	client.NotifyUpdate(model.Block{})
 
@@ -37,3 +41,30 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestClientNotifyBoardChange(t *testing.T) {
+	var change model.BoardChange
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+	}))
+	defer ts.Close()
+
+	cfg := &config.Configuration{
+		WebhookUpdate:       []string{ts.URL},
+		WebhookAllowedHosts: []string{"127.0.0.1"},
+	}
+
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client, err := NewClient(cfg, logger)
+	require.NoError(t, err)
+
+	client.NotifyBoardChange(model.BoardChange{BoardID: "board-id", BlockIDs: []string{"card-1", "card-2"}})
+
+	assert.Equal(t, "board-id", change.BoardID)
+	assert.Equal(t, []string{"card-1", "card-2"}, change.BlockIDs)
+}
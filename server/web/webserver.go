@@ -27,17 +27,29 @@ type RoutedService interface {
 type Server struct {
 	http.Server
 
-	baseURL  string
-	rootPath string
-	port     int
-	ssl      bool
-	logger   *mlog.Logger
+	router      *mux.Router
+	baseURL     string
+	rootPath    string
+	routePrefix string
+	port        int
+	ssl         bool
+	logger      *mlog.Logger
 }
 
-// NewServer creates a new instance of the webserver.
-func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool, logger *mlog.Logger) *Server {
+// NewServer creates a new instance of the webserver. All the routes are
+// mounted under routePrefix if it isn't empty.
+func NewServer(rootPath string, serverRoot string, routePrefix string, port int, ssl, localOnly bool, logger *mlog.Logger) *Server {
 	r := mux.NewRouter()
 
+	routePrefix = strings.TrimRight(routePrefix, "/")
+	if routePrefix != "" && !strings.HasPrefix(routePrefix, "/") {
+		routePrefix = "/" + routePrefix
+	}
+	router := r
+	if routePrefix != "" {
+		router = r.PathPrefix(routePrefix).Subrouter()
+	}
+
 	var addr string
 	if localOnly {
 		addr = fmt.Sprintf(`localhost:%d`, port)
@@ -57,18 +69,22 @@ func NewServer(rootPath string, serverRoot string, port int, ssl, localOnly bool
 			Addr:    addr,
 			Handler: r,
 		},
-		baseURL:  baseURL,
-		rootPath: rootPath,
-		port:     port,
-		ssl:      ssl,
-		logger:   logger,
+		router:      router,
+		baseURL:     baseURL,
+		rootPath:    rootPath,
+		routePrefix: routePrefix,
+		port:        port,
+		ssl:         ssl,
+		logger:      logger,
 	}
 
 	return ws
 }
 
+// Router returns the router the routes are registered on, mounted under the
+// route prefix.
 func (ws *Server) Router() *mux.Router {
-	return ws.Server.Handler.(*mux.Router)
+	return ws.router
 }
 
 // AddRoutes allows services to register themself in the webserver router and provide new endpoints.
@@ -77,7 +93,7 @@ func (ws *Server) AddRoutes(rs RoutedService) {
 }
 
 func (ws *Server) registerRoutes() {
-	ws.Router().PathPrefix("/static").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir(filepath.Join(ws.rootPath, "static")))))
+	ws.Router().PathPrefix("/static").Handler(http.StripPrefix(ws.routePrefix+"/static/", http.FileServer(http.Dir(filepath.Join(ws.rootPath, "static")))))
 	ws.Router().PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		indexTemplate, err := template.New("index").ParseFiles(path.Join(ws.rootPath, "index.html"))
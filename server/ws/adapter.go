@@ -11,13 +11,20 @@ const (
 	websocketActionSubscribeBlocks      = "SUBSCRIBE_BLOCKS"
 	websocketActionUnsubscribeBlocks    = "UNSUBSCRIBE_BLOCKS"
 	websocketActionUpdateBlock          = "UPDATE_BLOCK"
+	websocketActionUpdateBoard          = "UPDATE_BOARD"
 	websocketActionUpdateConfig         = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateSubscription   = "UPDATE_SUBSCRIPTION"
+	websocketActionJoinBoard            = "JOIN_BOARD"
+	websocketActionLeaveBoard           = "LEAVE_BOARD"
+	websocketActionUpdatePresence       = "UPDATE_PRESENCE"
+	websocketActionUpdateCursor         = "UPDATE_CURSOR"
 )
 
 type Adapter interface {
 	BroadcastBlockChange(workspaceID string, block model.Block)
+	BroadcastBoardChange(workspaceID string, change model.BoardChange)
 	BroadcastBlockDelete(workspaceID, blockID, parentID string)
 	BroadcastConfigChange(clientConfig model.ClientConfig)
 	BroadcastSubscriptionChange(workspaceID string, subscription *model.Subscription)
+	GetBoardPresence(boardID string) []string
 }
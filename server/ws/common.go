@@ -1,6 +1,8 @@
 package ws
 
 import (
+	"encoding/json"
+
 	"github.com/mattermost/focalboard/server/model"
 )
 
@@ -10,6 +12,12 @@ type UpdateMsg struct {
 	Block  model.Block `json:"block"`
 }
 
+// UpdateBoardMsg is sent on the debounced block updates of a board.
+type UpdateBoardMsg struct {
+	Action      string            `json:"action"`
+	BoardChange model.BoardChange `json:"boardChange"`
+}
+
 // UpdateSubscription is sent on subscription updates.
 type UpdateSubscription struct {
 	Action       string              `json:"action"`
@@ -18,9 +26,11 @@ type UpdateSubscription struct {
 
 // WebsocketCommand is an incoming command from the client.
 type WebsocketCommand struct {
-	Action      string   `json:"action"`
-	WorkspaceID string   `json:"workspaceId"`
-	Token       string   `json:"token"`
-	ReadToken   string   `json:"readToken"`
-	BlockIDs    []string `json:"blockIds"`
+	Action      string          `json:"action"`
+	WorkspaceID string          `json:"workspaceId"`
+	Token       string          `json:"token"`
+	ReadToken   string          `json:"readToken"`
+	BlockIDs    []string        `json:"blockIds"`
+	BoardID     string          `json:"boardId"`
+	Cursor      json.RawMessage `json:"cursor"`
 }
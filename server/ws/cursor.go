@@ -0,0 +1,87 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// cursorMinInterval is the minimum time between two cursor updates
+	// of a listener, more frequent updates are dropped.
+	cursorMinInterval = 50 * time.Millisecond
+
+	// cursorMaxSize is the maximum size in bytes of the cursor data of
+	// an update.
+	cursorMaxSize = 1024
+)
+
+// CursorMsg is relayed to the viewers of a board when a user moves
+// their cursor or selection. It is not stored.
+type CursorMsg struct {
+	Action  string          `json:"action"`
+	BoardID string          `json:"boardId"`
+	UserID  string          `json:"userId"`
+	Cursor  json.RawMessage `json:"cursor"`
+}
+
+// allowCursorUpdate returns true if the session hasn't sent another
+// cursor update in the last cursorMinInterval.
+func (wss *websocketSession) allowCursorUpdate(now time.Time) bool {
+	if now.Sub(wss.lastCursorAt) < cursorMinInterval {
+		return false
+	}
+	wss.lastCursorAt = now
+	return true
+}
+
+// relayCursor sends the cursor of a user to the other viewers of a
+// board. The user must have joined the board, which checks its access
+// to it and that it is a board. Listeners subscribed through a read token
+// don't receive the cursors.
+func (ws *Server) relayCursor(wsSession *websocketSession, boardID string, cursor json.RawMessage) {
+	if len(cursor) > cursorMaxSize {
+		ws.logger.Debug("Dropped cursor update, too large",
+			mlog.Int("size", len(cursor)),
+			mlog.Stringer("client", wsSession.client.RemoteAddr()),
+		)
+		return
+	}
+
+	ws.mu.RLock()
+	joined := wsSession.client.hasJoinedBoard(boardID)
+	viewers := ws.getBoardViewers(boardID)
+	ws.mu.RUnlock()
+
+	if !joined {
+		ws.logger.Debug("Rejected cursor update for a board not joined",
+			mlog.String("boardID", boardID),
+			mlog.Stringer("client", wsSession.client.RemoteAddr()),
+		)
+		return
+	}
+
+	if !wsSession.allowCursorUpdate(time.Now()) {
+		return
+	}
+
+	message := CursorMsg{
+		Action:  websocketActionUpdateCursor,
+		BoardID: boardID,
+		UserID:  wsSession.userID,
+		Cursor:  cursor,
+	}
+
+	for _, listener := range viewers {
+		if listener == wsSession.client {
+			continue
+		}
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.Close()
+		}
+	}
+}
@@ -375,6 +375,21 @@ func (pa *PluginAdapter) BroadcastBlockChange(workspaceID string, block model.Bl
 	pa.sendWorkspaceMessage(websocketActionUpdateBlock, workspaceID, utils.StructToMap(message))
 }
 
+func (pa *PluginAdapter) BroadcastBoardChange(workspaceID string, change model.BoardChange) {
+	pa.api.LogInfo("BroadcastingBoardChange",
+		"workspaceID", workspaceID,
+		"boardID", change.BoardID,
+		"blockCount", len(change.BlockIDs),
+	)
+
+	message := UpdateBoardMsg{
+		Action:      websocketActionUpdateBoard,
+		BoardChange: change,
+	}
+
+	pa.sendWorkspaceMessage(websocketActionUpdateBoard, workspaceID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastBlockDelete(workspaceID, blockID, parentID string) {
 	now := utils.GetMillis()
 	block := model.Block{}
@@ -401,3 +416,9 @@ func (pa *PluginAdapter) BroadcastSubscriptionChange(workspaceID string, subscri
 
 	pa.sendWorkspaceMessage(websocketActionUpdateSubscription, workspaceID, utils.StructToMap(message))
 }
+
+func (pa *PluginAdapter) GetBoardPresence(boardID string) []string {
+	// not implemented for the plugin, the Mattermost server handles
+	// the websocket connections.
+	return []string{}
+}
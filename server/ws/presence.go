@@ -0,0 +1,175 @@
+package ws
+
+import (
+	"sort"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	presenceEventJoin  = "join"
+	presenceEventLeave = "leave"
+)
+
+// PresenceMsg is sent when a user starts or stops viewing a board.
+type PresenceMsg struct {
+	Action  string `json:"action"`
+	BoardID string `json:"boardId"`
+	UserID  string `json:"userId"`
+	Event   string `json:"event"`
+}
+
+func (c *wsClient) hasJoinedBoard(boardID string) bool {
+	for _, id := range c.boards {
+		if id == boardID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// joinBoard subscribes the listener to the board updates and marks the
+// user as viewing it. Other viewers are notified if it's the first
+// listener of the user on the board.
+func (ws *Server) joinBoard(client *wsClient, userID, boardID string) {
+	ws.subscribeListenerToBlocks(client, []string{boardID})
+
+	ws.mu.Lock()
+	joined := ws.addPresence(client, userID, boardID)
+	ws.mu.Unlock()
+
+	if joined {
+		ws.broadcastPresence(boardID, userID, presenceEventJoin)
+	}
+}
+
+// leaveBoard reverts joinBoard.
+func (ws *Server) leaveBoard(client *wsClient, boardID string) {
+	ws.unsubscribeListenerFromBlocks(client, []string{boardID})
+
+	ws.mu.Lock()
+	userID, left := ws.removePresence(client, boardID)
+	ws.mu.Unlock()
+
+	if left {
+		ws.broadcastPresence(boardID, userID, presenceEventLeave)
+	}
+}
+
+// getBoardViewers returns the listeners that joined the board. Listeners
+// subscribed to the board through a read token never join it, so they
+// don't receive what is only relayed to the viewers. The server lock must
+// be held.
+func (ws *Server) getBoardViewers(boardID string) []*wsClient {
+	viewers := make([]*wsClient, 0, len(ws.presenceByBoard[boardID]))
+	for client := range ws.presenceByBoard[boardID] {
+		viewers = append(viewers, client)
+	}
+	return viewers
+}
+
+// addPresence records the listener as viewing the board, and returns
+// true if the user wasn't viewing it through another listener. The
+// server lock must be held.
+func (ws *Server) addPresence(client *wsClient, userID, boardID string) bool {
+	if client.hasJoinedBoard(boardID) {
+		return false
+	}
+
+	viewers, ok := ws.presenceByBoard[boardID]
+	if !ok {
+		viewers = make(map[*wsClient]string)
+		ws.presenceByBoard[boardID] = viewers
+	}
+	joined := !containsValue(viewers, userID)
+
+	viewers[client] = userID
+	client.boards = append(client.boards, boardID)
+
+	return joined
+}
+
+// removePresence removes the listener from the board viewers, and
+// returns its user and true if the user isn't viewing the board
+// through another listener anymore. The server lock must be held.
+func (ws *Server) removePresence(client *wsClient, boardID string) (string, bool) {
+	if !client.hasJoinedBoard(boardID) {
+		return "", false
+	}
+
+	viewers := ws.presenceByBoard[boardID]
+	userID := viewers[client]
+	delete(viewers, client)
+	if len(viewers) == 0 {
+		delete(ws.presenceByBoard, boardID)
+	}
+
+	newClientBoards := []string{}
+	for _, id := range client.boards {
+		if id != boardID {
+			newClientBoards = append(newClientBoards, id)
+		}
+	}
+	client.boards = newClientBoards
+
+	return userID, !containsValue(viewers, userID)
+}
+
+// GetBoardPresence returns the IDs of the users viewing a board.
+func (ws *Server) GetBoardPresence(boardID string) []string {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	seen := map[string]bool{}
+	userIDs := []string{}
+	for _, userID := range ws.presenceByBoard[boardID] {
+		if !seen[userID] {
+			seen[userID] = true
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Strings(userIDs)
+
+	return userIDs
+}
+
+// broadcastPresence notifies the listeners of a board that a user
+// started or stopped viewing it.
+func (ws *Server) broadcastPresence(boardID, userID, event string) {
+	message := PresenceMsg{
+		Action:  websocketActionUpdatePresence,
+		BoardID: boardID,
+		UserID:  userID,
+		Event:   event,
+	}
+
+	ws.mu.RLock()
+	listeners := append([]*wsClient{}, ws.getListenersForBlock(boardID)...)
+	ws.mu.RUnlock()
+
+	for _, listener := range listeners {
+		ws.logger.Debug("Broadcast presence",
+			mlog.String("boardID", boardID),
+			mlog.String("userID", userID),
+			mlog.String("event", event),
+			mlog.Stringer("remoteAddr", listener.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.Close()
+		}
+	}
+}
+
+func containsValue(m map[*wsClient]string, value string) bool {
+	for _, v := range m {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -17,11 +18,23 @@ import (
 
 const singleUserID = "single-user-id"
 
+const (
+	// pongWait is the time allowed to read the next pong from the
+	// client, after which the connection is considered dead.
+	pongWait = 60 * time.Second
+
+	// pingPeriod is the interval between pings to the client, it must
+	// be less than pongWait.
+	pingPeriod = pongWait * 9 / 10
+)
+
 type wsClient struct {
 	*websocket.Conn
 	mu         sync.Mutex
 	workspaces []string
 	blocks     []string
+	boards     []string
+	userID     string
 }
 
 func (c *wsClient) WriteJSON(v interface{}) error {
@@ -51,14 +64,22 @@ func (c *wsClient) isSubscribedToBlock(blockID string) bool {
 	return false
 }
 
+// canSeeCard returns true if the client can receive the changes of the card,
+// which the clients subscribed with a read token can't if it's restricted.
+func (c *wsClient) canSeeCard(card *model.Block) bool {
+	return card == nil || card.IsVisibleTo(c.userID)
+}
+
 // Server is a WebSocket server.
 type Server struct {
 	upgrader             websocket.Upgrader
 	listeners            map[*wsClient]bool
 	listenersByWorkspace map[string][]*wsClient
 	listenersByBlock     map[string][]*wsClient
+	presenceByBoard      map[string]map[*wsClient]string
 	mu                   sync.RWMutex
 	auth                 *auth.Auth
+	store                store.Store
 	singleUserToken      string
 	isMattermostAuth     bool
 	logger               *mlog.Logger
@@ -71,8 +92,9 @@ type UpdateClientConfig struct {
 }
 
 type websocketSession struct {
-	client *wsClient
-	userID string
+	client       *wsClient
+	userID       string
+	lastCursorAt time.Time
 }
 
 func (wss *websocketSession) isAuthenticated() bool {
@@ -80,17 +102,19 @@ func (wss *websocketSession) isAuthenticated() bool {
 }
 
 // NewServer creates a new Server.
-func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger *mlog.Logger) *Server {
+func NewServer(auth *auth.Auth, store store.Store, singleUserToken string, isMattermostAuth bool, logger *mlog.Logger) *Server {
 	return &Server{
 		listeners:            make(map[*wsClient]bool),
 		listenersByWorkspace: make(map[string][]*wsClient),
 		listenersByBlock:     make(map[string][]*wsClient),
+		presenceByBoard:      make(map[string]map[*wsClient]string),
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
 				return true
 			},
 		},
 		auth:             auth,
+		store:            store,
 		singleUserToken:  singleUserToken,
 		isMattermostAuth: isMattermostAuth,
 		logger:           logger,
@@ -112,21 +136,32 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	// create an empty session with websocket client
 	wsSession := websocketSession{
-		client: &wsClient{client, sync.Mutex{}, []string{}, []string{}},
+		client: &wsClient{client, sync.Mutex{}, []string{}, []string{}, []string{}, ""},
 		userID: "",
 	}
 
 	if ws.isMattermostAuth {
 		wsSession.userID = r.Header.Get("Mattermost-User-Id")
+		wsSession.client.userID = wsSession.userID
 	}
 
 	ws.addListener(wsSession.client)
 
+	// Ping the client periodically, a client that doesn't answer in
+	// time fails the next read and is removed
+	_ = wsSession.client.SetReadDeadline(time.Now().Add(pongWait))
+	wsSession.client.SetPongHandler(func(string) error {
+		return wsSession.client.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	stopPing := make(chan struct{})
+	go ws.pingListener(wsSession.client, stopPing)
+
 	// Make sure we close the connection when the function returns
 	defer func() {
 		ws.logger.Debug("DISCONNECT WebSocket", mlog.Stringer("client", wsSession.client.RemoteAddr()))
 
 		// Remove client from listeners
+		close(stopPing)
 		ws.removeListener(wsSession.client)
 		wsSession.client.Close()
 	}()
@@ -221,19 +256,8 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.client.RemoteAddr()),
 			)
 
-			// if single user mode, check that the userID is valid and
-			// assume that the user has permission if so
-			if len(ws.singleUserToken) != 0 {
-				if wsSession.userID != singleUserID {
-					continue
-				}
-
-				// if not in single user mode validate that the session
-				// has permissions to the workspace
-			} else {
-				if !ws.auth.DoesUserHaveWorkspaceAccess(wsSession.userID, command.WorkspaceID) {
-					continue
-				}
+			if !ws.hasWorkspaceAccess(wsSession.userID, command.WorkspaceID) {
+				continue
 			}
 
 			ws.subscribeListenerToWorkspace(wsSession.client, command.WorkspaceID)
@@ -244,12 +268,82 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			)
 
 			ws.unsubscribeListenerFromWorkspace(wsSession.client, command.WorkspaceID)
+		case websocketActionJoinBoard:
+			ws.logger.Debug(`Command: JOIN_BOARD`,
+				mlog.String("workspaceID", command.WorkspaceID),
+				mlog.String("boardID", command.BoardID),
+				mlog.Stringer("client", wsSession.client.RemoteAddr()),
+			)
+
+			if command.BoardID == "" || !ws.hasWorkspaceAccess(wsSession.userID, command.WorkspaceID) {
+				continue
+			}
+
+			if !ws.isBoard(command.WorkspaceID, command.BoardID) {
+				ws.logger.Error(`Rejected JOIN_BOARD for a block that is not a board of the workspace`,
+					mlog.String("workspaceID", command.WorkspaceID),
+					mlog.String("boardID", command.BoardID),
+					mlog.Stringer("client", wsSession.client.RemoteAddr()),
+				)
+
+				continue
+			}
+
+			ws.joinBoard(wsSession.client, wsSession.userID, command.BoardID)
+		case websocketActionLeaveBoard:
+			ws.logger.Debug(`Command: LEAVE_BOARD`,
+				mlog.String("boardID", command.BoardID),
+				mlog.Stringer("client", wsSession.client.RemoteAddr()),
+			)
+
+			ws.leaveBoard(wsSession.client, command.BoardID)
+		case websocketActionUpdateCursor:
+			ws.relayCursor(&wsSession, command.BoardID, command.Cursor)
 		default:
 			ws.logger.Error(`ERROR webSocket command, invalid action`, mlog.String("action", command.Action))
 		}
 	}
 }
 
+// hasWorkspaceAccess checks the user permissions to a workspace, in
+// single user mode the user is assumed to have access to all of them.
+func (ws *Server) hasWorkspaceAccess(userID, workspaceID string) bool {
+	if len(ws.singleUserToken) != 0 {
+		return userID == singleUserID
+	}
+	return ws.auth.DoesUserHaveWorkspaceAccess(userID, workspaceID)
+}
+
+// isBoard checks that the block is a board of the workspace, so that
+// listeners can't join, and receive the changes of, any other block.
+func (ws *Server) isBoard(workspaceID, blockID string) bool {
+	block, err := ws.store.GetBlock(store.Container{WorkspaceID: workspaceID}, blockID)
+	if err != nil {
+		ws.logger.Error("isBoard ERROR", mlog.String("blockID", blockID), mlog.Err(err))
+		return false
+	}
+	return block != nil && block.Type == model.TypeBoard
+}
+
+// pingListener sends pings to the listener until stop is closed.
+func (ws *Server) pingListener(client *wsClient, stop <-chan struct{}) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			err := client.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingPeriod))
+			if err != nil {
+				ws.logger.Debug("ping error", mlog.Stringer("client", client.RemoteAddr()), mlog.Err(err))
+				return
+			}
+		}
+	}
+}
+
 // isCommandReadTokenValid ensures that a command contains a read
 // token and a set of block ids that said token is valid for.
 func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
@@ -287,10 +381,17 @@ func (ws *Server) addListener(client *wsClient) {
 // any, from the websockets server.
 func (ws *Server) removeListener(client *wsClient) {
 	ws.mu.Lock()
-	defer ws.mu.Unlock()
 
 	// remove the listener from its subscriptions, if any
 
+	// board presence
+	left := map[string]string{}
+	for _, boardID := range client.boards {
+		if userID, ok := ws.removePresence(client, boardID); ok {
+			left[boardID] = userID
+		}
+	}
+
 	// workspace subscriptions
 	for _, workspace := range client.workspaces {
 		ws.removeListenerFromWorkspace(client, workspace)
@@ -302,6 +403,12 @@ func (ws *Server) removeListener(client *wsClient) {
 	}
 
 	delete(ws.listeners, client)
+	ws.mu.Unlock()
+
+	// the remaining viewers are notified of the users that left
+	for boardID, userID := range left {
+		ws.broadcastPresence(boardID, userID, presenceEventLeave)
+	}
 }
 
 // subscribeListenerToWorkspace safely modifies the listener and the
@@ -447,6 +554,7 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 
 	// Authenticated
 	wsSession.userID = userID
+	wsSession.client.userID = userID
 	ws.logger.Debug("authenticateListener: Authenticated", mlog.String("userID", userID), mlog.Stringer("client", wsSession.client.RemoteAddr()))
 }
 
@@ -475,10 +583,18 @@ func (ws *Server) BroadcastBlockDelete(workspaceID, blockID, parentID string) {
 	ws.BroadcastBlockChange(workspaceID, block)
 }
 
-// BroadcastBlockChange broadcasts update messages to clients.
+// BroadcastBlockChange broadcasts update messages to clients. The changes
+// of a restricted card, and of the blocks in it, are only sent to the users
+// that can see the card.
 func (ws *Server) BroadcastBlockChange(workspaceID string, block model.Block) {
 	blockIDsToNotify := []string{block.ID, block.ParentID}
 
+	card, err := ws.cardOf(workspaceID, block)
+	if err != nil {
+		ws.logger.Error("broadcast error", mlog.String("blockID", block.ID), mlog.Err(err))
+		return
+	}
+
 	message := UpdateMsg{
 		Action: websocketActionUpdateBlock,
 		Block:  block,
@@ -499,6 +615,10 @@ func (ws *Server) BroadcastBlockChange(workspaceID string, block model.Block) {
 	}
 
 	for _, listener := range listeners {
+		if !listener.canSeeCard(card) {
+			continue
+		}
+
 		ws.logger.Debug("Broadcast change",
 			mlog.String("workspaceID", workspaceID),
 			mlog.String("blockID", block.ID),
@@ -513,6 +633,94 @@ func (ws *Server) BroadcastBlockChange(workspaceID string, block model.Block) {
 	}
 }
 
+// BroadcastBoardChange broadcasts a board update message to the clients
+// subscribed to the workspace, the board or any of the changed blocks. The
+// blocks of the restricted cards a client can't see are left out of its
+// message.
+func (ws *Server) BroadcastBoardChange(workspaceID string, change model.BoardChange) {
+	cards := make(map[string]*model.Block, len(change.BlockIDs))
+	for _, blockID := range change.BlockIDs {
+		card, err := ws.cardOfBlockID(workspaceID, blockID)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.String("blockID", blockID), mlog.Err(err))
+			return
+		}
+		cards[blockID] = card
+	}
+
+	listeners := ws.getListenersForWorkspace(workspaceID)
+	for _, blockID := range append([]string{change.BoardID}, change.BlockIDs...) {
+		listeners = append(listeners, ws.getListenersForBlock(blockID)...)
+	}
+
+	notified := map[*wsClient]bool{}
+	for _, listener := range listeners {
+		if notified[listener] {
+			continue
+		}
+		notified[listener] = true
+
+		blockIDs := make([]string, 0, len(change.BlockIDs))
+		for _, blockID := range change.BlockIDs {
+			if listener.canSeeCard(cards[blockID]) {
+				blockIDs = append(blockIDs, blockID)
+			}
+		}
+		if len(blockIDs) == 0 && len(change.BlockIDs) > 0 {
+			continue
+		}
+
+		ws.logger.Debug("Broadcast board change",
+			mlog.String("workspaceID", workspaceID),
+			mlog.String("boardID", change.BoardID),
+			mlog.Int("block_count", len(blockIDs)),
+			mlog.Stringer("remoteAddr", listener.RemoteAddr()),
+		)
+
+		message := UpdateBoardMsg{
+			Action:      websocketActionUpdateBoard,
+			BoardChange: model.BoardChange{BoardID: change.BoardID, BlockIDs: blockIDs},
+		}
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.Close()
+		}
+	}
+}
+
+// cardOf returns the card block is, or is in, or nil if it isn't in a card.
+// The restrictions aren't checked in single user mode, so nil is returned.
+func (ws *Server) cardOf(workspaceID string, block model.Block) (*model.Block, error) {
+	if ws.store == nil || len(ws.singleUserToken) > 0 {
+		return nil, nil
+	}
+	if block.Type == model.TypeCard {
+		return &block, nil
+	}
+	if block.ParentID == "" {
+		return nil, nil
+	}
+
+	parent, err := ws.store.GetBlock(store.Container{WorkspaceID: workspaceID}, block.ParentID)
+	if err != nil || parent == nil || parent.Type != model.TypeCard {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// cardOfBlockID returns the card the block with the ID is, or is in.
+func (ws *Server) cardOfBlockID(workspaceID, blockID string) (*model.Block, error) {
+	if ws.store == nil || len(ws.singleUserToken) > 0 {
+		return nil, nil
+	}
+	block, err := ws.store.GetBlock(store.Container{WorkspaceID: workspaceID}, blockID)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return ws.cardOf(workspaceID, *block)
+}
+
 // BroadcastConfigChange broadcasts update messages to clients.
 func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 	message := UpdateClientConfig{
@@ -3,18 +3,23 @@ package ws
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
+	"github.com/golang/mock/gomock"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
 
 func TestWorkspaceSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{})
-	client := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}}
+	server := NewServer(&auth.Auth{}, nil, "token", false, &mlog.Logger{})
+	client := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
 	session := &websocketSession{client: client}
 	workspaceID := "fake-workspace-id"
 
@@ -99,8 +104,8 @@ func TestWorkspaceSubscription(t *testing.T) {
 }
 
 func TestBlocksSubscription(t *testing.T) {
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{})
-	client := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}}
+	server := NewServer(&auth.Auth{}, nil, "token", false, &mlog.Logger{})
+	client := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
 	session := &websocketSession{client: client}
 	blockID1 := "block1"
 	blockID2 := "block2"
@@ -215,7 +220,7 @@ func TestBlocksSubscription(t *testing.T) {
 
 func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 	singleUserToken := "single-user-token"
-	server := NewServer(&auth.Auth{}, "token", false, &mlog.Logger{})
+	server := NewServer(&auth.Auth{}, nil, "token", false, &mlog.Logger{})
 	server.singleUserToken = singleUserToken
 
 	t.Run("Should return nothing if the token is empty", func(t *testing.T) {
@@ -230,3 +235,135 @@ func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 		require.Equal(t, singleUserID, server.getUserIDForToken(singleUserToken))
 	})
 }
+
+func TestBoardPresence(t *testing.T) {
+	server := NewServer(&auth.Auth{}, nil, "token", false, &mlog.Logger{})
+	client1 := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
+	client2 := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
+	boardID := "fake-board-id"
+
+	t.Run("Should add the first listener of a user", func(t *testing.T) {
+		require.True(t, server.addPresence(client1, "user-1", boardID))
+		require.Equal(t, []string{"user-1"}, server.GetBoardPresence(boardID))
+		require.True(t, client1.hasJoinedBoard(boardID))
+	})
+
+	t.Run("Joining again would have no effect", func(t *testing.T) {
+		require.False(t, server.addPresence(client1, "user-1", boardID))
+		require.Len(t, server.presenceByBoard[boardID], 1)
+		require.Len(t, client1.boards, 1)
+	})
+
+	t.Run("Another listener of the same user is not a new viewer", func(t *testing.T) {
+		require.False(t, server.addPresence(client2, "user-1", boardID))
+		require.Equal(t, []string{"user-1"}, server.GetBoardPresence(boardID))
+	})
+
+	t.Run("The user is still viewing until its last listener leaves", func(t *testing.T) {
+		_, left := server.removePresence(client1, boardID)
+		require.False(t, left)
+		require.Equal(t, []string{"user-1"}, server.GetBoardPresence(boardID))
+
+		userID, left := server.removePresence(client2, boardID)
+		require.True(t, left)
+		require.Equal(t, "user-1", userID)
+		require.Empty(t, server.GetBoardPresence(boardID))
+		require.Empty(t, server.presenceByBoard)
+		require.Empty(t, client2.boards)
+	})
+
+	t.Run("Removing a listener clears its presence", func(t *testing.T) {
+		server.addListener(client1)
+		require.True(t, server.addPresence(client1, "user-2", boardID))
+
+		server.removeListener(client1)
+		require.Empty(t, server.GetBoardPresence(boardID))
+		require.Empty(t, client1.boards)
+	})
+}
+
+func TestBoardViewers(t *testing.T) {
+	server := NewServer(&auth.Auth{}, nil, "token", false, &mlog.Logger{})
+	viewer := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
+	readTokenListener := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
+	boardID := "fake-board-id"
+
+	server.addPresence(viewer, "user-1", boardID)
+	server.subscribeListenerToBlocks(readTokenListener, []string{boardID})
+
+	require.Equal(t, []*wsClient{viewer}, server.getBoardViewers(boardID))
+	require.Empty(t, server.getBoardViewers("other-board-id"))
+}
+
+func TestIsBoard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mockstore.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, mockStore, "token", false, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+	container := store.Container{WorkspaceID: "workspace-id"}
+
+	mockStore.EXPECT().GetBlock(container, "board-id").Return(&model.Block{ID: "board-id", Type: model.TypeBoard}, nil)
+	require.True(t, server.isBoard("workspace-id", "board-id"))
+
+	mockStore.EXPECT().GetBlock(container, "card-id").Return(&model.Block{ID: "card-id", Type: model.TypeCard}, nil)
+	require.False(t, server.isBoard("workspace-id", "card-id"))
+
+	// a board of another workspace isn't found in this one
+	mockStore.EXPECT().GetBlock(container, "other-board-id").Return(nil, nil)
+	require.False(t, server.isBoard("workspace-id", "other-board-id"))
+}
+
+func TestCardOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mockstore.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, mockStore, "", false, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+	container := store.Container{WorkspaceID: "workspace-id"}
+	card := &model.Block{
+		ID:     "card-id",
+		Type:   model.TypeCard,
+		Fields: map[string]interface{}{model.RestrictedToField: []interface{}{"user-1"}},
+	}
+	member := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, "user-1"}
+	other := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, "user-2"}
+	readTokenListener := &wsClient{&websocket.Conn{}, sync.Mutex{}, []string{}, []string{}, []string{}, ""}
+
+	t.Run("card", func(t *testing.T) {
+		result, err := server.cardOf("workspace-id", *card)
+		require.NoError(t, err)
+		require.True(t, member.canSeeCard(result))
+		require.False(t, other.canSeeCard(result))
+		require.False(t, readTokenListener.canSeeCard(result))
+	})
+
+	t.Run("block in a card", func(t *testing.T) {
+		mockStore.EXPECT().GetBlock(container, "card-id").Return(card, nil)
+		result, err := server.cardOf("workspace-id", model.Block{ID: "text-id", ParentID: "card-id", Type: model.TypeText})
+		require.NoError(t, err)
+		require.Equal(t, card, result)
+	})
+
+	t.Run("block in a board", func(t *testing.T) {
+		mockStore.EXPECT().GetBlock(container, "board-id").Return(&model.Block{ID: "board-id", Type: model.TypeBoard}, nil)
+		result, err := server.cardOf("workspace-id", model.Block{ID: "view-id", ParentID: "board-id", Type: model.TypeView})
+		require.NoError(t, err)
+		require.Nil(t, result)
+		require.True(t, readTokenListener.canSeeCard(result))
+	})
+
+	t.Run("single user mode", func(t *testing.T) {
+		singleUserServer := NewServer(&auth.Auth{}, mockStore, "token", false, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+		result, err := singleUserServer.cardOf("workspace-id", *card)
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestCursorRateLimit(t *testing.T) {
+	session := &websocketSession{}
+	now := time.Now()
+
+	require.True(t, session.allowCursorUpdate(now))
+	require.False(t, session.allowCursorUpdate(now.Add(cursorMinInterval/2)))
+	require.True(t, session.allowCursorUpdate(now.Add(cursorMinInterval)))
+}
@@ -13,12 +13,19 @@ interface IPropertyOption {
     color: string
 }
 
+// How the values of a number property are displayed
+type PropertyFormat = {
+    decimals?: number
+    currency?: string
+}
+
 // A template for card properties attached to a board
 interface IPropertyTemplate {
     id: string
     name: string
     type: PropertyType
     options: IPropertyOption[]
+    format?: PropertyFormat
 }
 
 type BoardFields = {
@@ -55,6 +62,7 @@ function createBoard(block?: Block): Board {
                 name: o.name,
                 type: o.type,
                 options: o.options ? o.options.map((option) => ({...option})) : [],
+                ...(o.format ? {format: {...o.format}} : {}),
             }
         })
     }
@@ -78,4 +86,4 @@ type BoardGroup = {
     cards: Card[]
 }
 
-export {Board, PropertyType, IPropertyOption, IPropertyTemplate, BoardGroup, createBoard}
+export {Board, PropertyType, PropertyFormat, IPropertyOption, IPropertyTemplate, BoardGroup, createBoard}
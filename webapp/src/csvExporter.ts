@@ -11,6 +11,9 @@ import {IAppWindow} from './types'
 
 declare let window: IAppWindow
 
+// The most decimal places toFixed accepts, as on the server
+const maxDecimals = 20
+
 class CsvExporter {
     static exportTableCsv(board: Board, activeView: BoardView, cards: Card[], intl: IntlShape, view?: BoardView): void {
         const viewToExport = view ?? activeView
@@ -50,6 +53,25 @@ class CsvExporter {
         return text.replace(/"/g, '""')
     }
 
+    // Rounds a number to the decimal places of the format of the property
+    // and prefixes it with its currency symbol
+    private static formatNumber(value: string, template: IPropertyTemplate): string {
+        if (!value) {
+            return ''
+        }
+        const num = Number(value)
+        if (isNaN(num)) {
+            return `"${this.encodeText(value)}"`
+        }
+
+        const decimals = template.format?.decimals
+        const text = decimals === undefined ? num.toString() : num.toFixed(Math.min(Math.max(Math.trunc(decimals), 0), maxDecimals))
+        if (template.format?.currency) {
+            return `"${this.encodeText(template.format.currency + text)}"`
+        }
+        return text
+    }
+
     private static generateTableArray(board: Board, cards: Card[], viewToExport: BoardView, intl: IntlShape): string[][] {
         const rows: string[][] = []
         const visibleProperties = board.fields.cardProperties.filter((template: IPropertyTemplate) => viewToExport.fields.visiblePropertyIds.includes(template.id))
@@ -79,8 +101,7 @@ class CsvExporter {
                 const propertyValue = card.fields.properties[template.id]
                 const displayValue = (OctoUtils.propertyDisplayValue(card, propertyValue, template, intl) || '') as string
                 if (template.type === 'number') {
-                    const numericValue = propertyValue ? Number(propertyValue).toString() : ''
-                    row.push(numericValue)
+                    row.push(this.formatNumber(propertyValue as string, template))
                 } else if (template.type === 'multiSelect') {
                     const multiSelectValue = ((displayValue as unknown || []) as string[]).join('|')
                     row.push(multiSelectValue)
@@ -6,6 +6,7 @@ import {ClientConfig} from './config/clientConfig'
 import {Utils} from './utils'
 import {Block} from './blocks/block'
 import {OctoUtils} from './octoUtils'
+import octoClient from './octoClient'
 
 // These are outgoing commands to the server
 type WSCommand = {
@@ -19,9 +20,16 @@ type WSCommand = {
 type WSMessage = {
     action?: string
     block?: Block
+    boardChange?: BoardChange
     error?: string
 }
 
+// The blocks of a board changed during its change debounce
+type BoardChange = {
+    boardId: string
+    blockIds: string[]
+}
+
 type WSSubscriptionMsg = {
     action?: string
     subscription?: Subscription
@@ -40,6 +48,7 @@ export interface Subscription {
 }
 
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_WORKSPACE = 'SUBSCRIBE_WORKSPACE'
@@ -304,6 +313,9 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateBlockHandler(message)
                     break
+                case ACTION_UPDATE_BOARD:
+                    this.updateBoardHandler(message)
+                    break
                 default:
                     Utils.logError(`Unexpected action: ${message.action}`)
                 }
@@ -321,6 +333,18 @@ class WSClient {
         this.queueUpdateNotification(Utils.fixBlock(message.block!))
     }
 
+    // The debounced changes of a board only list the IDs of the changed
+    // blocks, so the blocks are fetched again
+    async updateBoardHandler(message: WSMessage): Promise<void> {
+        const blockIds = message.boardChange?.blockIds || []
+        const results = await Promise.all(blockIds.map((blockId) => octoClient.getBlocksWithBlockID(blockId)))
+        for (const blocks of results) {
+            for (const block of blocks) {
+                this.queueUpdateNotification(block)
+            }
+        }
+    }
+
     setOnFollowBlock(handler: FollowChangeHandler): void {
         this.onFollowBlock = handler
     }